@@ -0,0 +1,219 @@
+package cache
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// CapacityUnlimited, passed as a capacity, turns off size-based eviction.
+// An ExpiryCache with unlimited capacity only drops entries when they
+// expire.
+const CapacityUnlimited = -1
+
+var (
+	// ErrNoCapacity is returned by TryPut on a zero-capacity cache, which
+	// stores nothing.
+	ErrNoCapacity = errors.New("cache: cache has no capacity")
+	// ErrInvalidCapacity reports a negative capacity other than
+	// CapacityUnlimited. Constructors panic with it.
+	ErrInvalidCapacity = errors.New("cache: capacity must be non-negative or CapacityUnlimited")
+)
+
+// ValidateCapacity returns ErrInvalidCapacity if the constructors would
+// reject capacity, for callers that take capacities from configuration and
+// prefer an error to a panic.
+func ValidateCapacity(capacity int) error {
+	if capacity < 0 && capacity != CapacityUnlimited {
+		return ErrInvalidCapacity
+	}
+	return nil
+}
+
+// full reports whether a cache holding n entries must evict before
+// admitting another.
+func full(n, capacity int) bool {
+	return capacity != CapacityUnlimited && n >= capacity
+}
+
+// Cache is the common interface implemented by the caches in this package.
+type Cache[K comparable, V any] interface {
+	Get(key K) (V, bool)
+	Put(key K, value V)
+	Remove(key K)
+	Clear()
+	Len() int
+}
+
+// EvictionPolicy decides which key leaves a PolicyCache when it is full.
+// Policies are always called with the owning cache's lock held, so they do
+// not need to synchronize themselves.
+type EvictionPolicy[K comparable] interface {
+	// Admit records a key that has just been added to the cache.
+	Admit(key K)
+	// Touch records a read or update of a key already in the cache.
+	Touch(key K)
+	// Remove forgets a key that was removed from the cache explicitly.
+	Remove(key K)
+	// Evict selects a victim, forgets it and returns it.
+	Evict() (K, bool)
+	// Clear forgets every key.
+	Clear()
+}
+
+// PolicyCache is a bounded cache whose eviction order is delegated to an
+// EvictionPolicy. LRUCache and LFUCache are PolicyCaches with the built-in
+// policies; custom policies can be plugged in with NewPolicyCache.
+type PolicyCache[K comparable, V any] struct {
+	capacity   int
+	values     map[K]V
+	policy     EvictionPolicy[K]
+	hooks      hookSet[K, V]
+	id         atomic.Uint64
+	threadSafe bool
+	mu         sync.RWMutex
+}
+
+// NewPolicyCache creates a cache with the specified capacity and eviction
+// policy. A capacity of zero disables caching and CapacityUnlimited disables
+// eviction; any other negative capacity panics with ErrInvalidCapacity.
+func NewPolicyCache[K comparable, V any](capacity int, policy EvictionPolicy[K], threadSafe ...bool) *PolicyCache[K, V] {
+	if err := ValidateCapacity(capacity); err != nil {
+		panic(err)
+	}
+	isThreadSafe := true
+	if len(threadSafe) > 0 {
+		isThreadSafe = threadSafe[0]
+	}
+	return &PolicyCache[K, V]{
+		capacity:   capacity,
+		values:     make(map[K]V),
+		policy:     policy,
+		threadSafe: isThreadSafe,
+	}
+}
+
+// WithHooks installs hooks that observe Get, Put, Remove and Clear,
+// replacing any installed before, and returns the cache.
+func (c *PolicyCache[K, V]) WithHooks(hooks Hooks[K, V]) *PolicyCache[K, V] {
+	c.hooks.set(hooks)
+	return c
+}
+
+// Get retrieves a value from the cache and reports the access to the policy
+func (c *PolicyCache[K, V]) Get(key K) (value V, ok bool) {
+	if after := c.hooks.get(key); after != nil {
+		defer func() { after(value, ok) }()
+	}
+	if c.threadSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+	return c.get(key)
+}
+
+// get is Get without locking or hooks.
+func (c *PolicyCache[K, V]) get(key K) (V, bool) {
+	if value, exists := c.values[key]; exists {
+		c.policy.Touch(key)
+		return value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Put adds or updates a value in the cache, evicting a victim chosen by the
+// policy if the cache is full. Put on a zero-capacity cache does nothing.
+func (c *PolicyCache[K, V]) Put(key K, value V) {
+	if after := c.hooks.put(key, value); after != nil {
+		defer after()
+	}
+	if c.threadSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+	c.put(key, value)
+}
+
+// put is Put without locking or hooks.
+func (c *PolicyCache[K, V]) put(key K, value V) {
+	if c.capacity == 0 {
+		return
+	}
+	if _, exists := c.values[key]; exists {
+		c.policy.Touch(key)
+	} else {
+		if full(len(c.values), c.capacity) {
+			if victim, ok := c.policy.Evict(); ok {
+				delete(c.values, victim)
+			}
+		}
+		c.policy.Admit(key)
+	}
+	c.values[key] = value
+}
+
+// TryPut is Put for callers that need to know the value was stored. It
+// returns ErrNoCapacity instead of storing into a zero-capacity cache.
+func (c *PolicyCache[K, V]) TryPut(key K, value V) error {
+	if c.capacity == 0 {
+		return ErrNoCapacity
+	}
+	c.Put(key, value)
+	return nil
+}
+
+// Remove removes a key-value pair from the cache
+func (c *PolicyCache[K, V]) Remove(key K) {
+	c.TryRemove(key)
+}
+
+// TryRemove removes key and returns the value it held, or false if the key
+// was not cached.
+func (c *PolicyCache[K, V]) TryRemove(key K) (V, bool) {
+	if after := c.hooks.remove(key); after != nil {
+		defer after()
+	}
+	if c.threadSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+	return c.tryRemove(key)
+}
+
+// tryRemove is TryRemove without locking or hooks.
+func (c *PolicyCache[K, V]) tryRemove(key K) (V, bool) {
+	value, exists := c.values[key]
+	if exists {
+		c.policy.Remove(key)
+		delete(c.values, key)
+	}
+	return value, exists
+}
+
+// Clear removes all items from the cache
+func (c *PolicyCache[K, V]) Clear() {
+	if after := c.hooks.clear(); after != nil {
+		defer after()
+	}
+	if c.threadSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+	c.clear()
+}
+
+// clear is Clear without locking or hooks.
+func (c *PolicyCache[K, V]) clear() {
+	c.policy.Clear()
+	c.values = make(map[K]V)
+}
+
+// Len returns the current number of items in the cache
+func (c *PolicyCache[K, V]) Len() int {
+	if c.threadSafe {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+	}
+	return len(c.values)
+}
@@ -0,0 +1,150 @@
+package cache
+
+import (
+	"testing"
+)
+
+// fifoPolicy evicts keys in insertion order, ignoring accesses.
+type fifoPolicy[K comparable] struct {
+	order []K
+}
+
+func (p *fifoPolicy[K]) Admit(key K) { p.order = append(p.order, key) }
+func (p *fifoPolicy[K]) Touch(key K) {}
+func (p *fifoPolicy[K]) Remove(key K) {
+	for i, k := range p.order {
+		if k == key {
+			p.order = append(p.order[:i], p.order[i+1:]...)
+			return
+		}
+	}
+}
+func (p *fifoPolicy[K]) Evict() (K, bool) {
+	if len(p.order) == 0 {
+		var zero K
+		return zero, false
+	}
+	key := p.order[0]
+	p.order = p.order[1:]
+	return key, true
+}
+func (p *fifoPolicy[K]) Clear() { p.order = nil }
+
+func TestPolicyCacheCustomPolicy(t *testing.T) {
+	var c Cache[string, int] = NewPolicyCache[string, int](2, &fifoPolicy[string]{}, false)
+
+	c.Put("one", 1)
+	c.Put("two", 2)
+	// Access does not protect "one" under FIFO
+	c.Get("one")
+	c.Put("three", 3)
+
+	if _, exists := c.Get("one"); exists {
+		t.Error("Expected 'one' to be evicted")
+	}
+	if val, exists := c.Get("two"); !exists || val != 2 {
+		t.Error("Expected 'two' to be present")
+	}
+
+	c.Remove("two")
+	c.Put("four", 4)
+	if c.Len() != 2 {
+		t.Errorf("Expected length 2, got %d", c.Len())
+	}
+
+	c.Clear()
+	if c.Len() != 0 {
+		t.Errorf("Expected length 0, got %d", c.Len())
+	}
+}
+
+func TestCacheInterface(t *testing.T) {
+	caches := []Cache[int, int]{
+		NewLRUCache[int, int](2),
+		NewLFUCache[int, int](2),
+	}
+	for _, c := range caches {
+		c.Put(1, 1)
+		c.Put(2, 2)
+		c.Put(3, 3)
+		if c.Len() != 2 {
+			t.Errorf("Expected length 2, got %d", c.Len())
+		}
+	}
+}
+
+func TestLFUPolicyTouchAfterNodeEmpties(t *testing.T) {
+	cache := NewLFUCache[string, int](2, false)
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	// Move "a" to freq 2 and then freq 3, emptying the freq 2 node on the way
+	cache.Get("a")
+	cache.Get("a")
+	// Move "b" to freq 2 so the freq 2 node is recreated
+	cache.Get("b")
+	cache.Get("b")
+	cache.Get("b")
+	cache.Get("b")
+
+	// "a" (freq 3) must still be reachable for eviction
+	cache.Put("c", 3)
+	if _, exists := cache.Get("a"); exists {
+		t.Error("Expected 'a' to be evicted as least frequently used")
+	}
+	if _, exists := cache.Get("b"); !exists {
+		t.Error("Expected 'b' to be present")
+	}
+}
+
+func TestPolicyCacheTryVariants(t *testing.T) {
+	c := NewLRUCache[string, int](2)
+	if err := c.TryPut("a", 1); err != nil {
+		t.Fatalf("TryPut() error = %v", err)
+	}
+	if value, ok := c.TryRemove("a"); !ok || value != 1 {
+		t.Errorf("TryRemove(a) = %d, %v, want 1, true", value, ok)
+	}
+	if _, ok := c.TryRemove("a"); ok {
+		t.Error("TryRemove(a) again reported the key as cached")
+	}
+
+	empty := NewLRUCache[string, int](0)
+	if err := empty.TryPut("a", 1); err != ErrNoCapacity {
+		t.Errorf("TryPut() on zero capacity error = %v, want %v", err, ErrNoCapacity)
+	}
+	if empty.Len() != 0 {
+		t.Errorf("Len() after rejected TryPut = %d, want 0", empty.Len())
+	}
+}
+
+func TestPolicyCacheCapacity(t *testing.T) {
+	disabled := NewLRUCache[int, int](0)
+	disabled.Put(1, 1)
+	if disabled.Len() != 0 {
+		t.Errorf("Len() of a zero-capacity cache = %d, want 0", disabled.Len())
+	}
+
+	unlimited := NewLFUCache[int, int](CapacityUnlimited)
+	for i := 0; i < 1000; i++ {
+		unlimited.Put(i, i)
+	}
+	if unlimited.Len() != 1000 {
+		t.Errorf("Len() of an unlimited cache = %d, want 1000", unlimited.Len())
+	}
+
+	if err := ValidateCapacity(-2); err != ErrInvalidCapacity {
+		t.Errorf("ValidateCapacity(-2) = %v, want %v", err, ErrInvalidCapacity)
+	}
+	for _, capacity := range []int{0, 1, CapacityUnlimited} {
+		if err := ValidateCapacity(capacity); err != nil {
+			t.Errorf("ValidateCapacity(%d) = %v, want nil", capacity, err)
+		}
+	}
+	defer func() {
+		if r := recover(); r != ErrInvalidCapacity {
+			t.Errorf("NewLRUCache(-2) panicked with %v, want %v", r, ErrInvalidCapacity)
+		}
+	}()
+	NewLRUCache[int, int](-2)
+}
@@ -0,0 +1,181 @@
+package cache
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"dsgo/hashring"
+)
+
+// ShardStats reports the activity of one shard in a ShardedCacheCluster.
+type ShardStats struct {
+	Name   string
+	Hits   uint64
+	Misses uint64
+	Len    int
+	// Share is the fraction of the key space routed to the shard.
+	Share float64
+}
+
+type clusterShard[K comparable, V any] struct {
+	cache  Cache[K, V]
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+// ShardedCacheCluster routes keys across a set of named caches with a
+// consistent hash ring. Adding or removing a shard only reroutes the keys
+// adjacent to it on the ring; entries are not migrated, so rerouted keys
+// miss once and are then cached by their new shard. The shard caches must
+// be safe for concurrent use if the cluster is.
+type ShardedCacheCluster[K comparable, V any] struct {
+	ring    *hashring.Ring
+	shards  map[string]*clusterShard[K, V]
+	keyFunc func(K) string
+	mu      sync.RWMutex
+}
+
+// NewShardedCacheCluster creates an empty cluster placing each shard at
+// replicas points on the ring. keyFunc turns keys into the strings that are
+// hashed; if it is nil, keys are formatted with fmt.Sprint.
+func NewShardedCacheCluster[K comparable, V any](replicas int, keyFunc func(K) string) *ShardedCacheCluster[K, V] {
+	if keyFunc == nil {
+		keyFunc = func(key K) string { return fmt.Sprint(key) }
+	}
+	return &ShardedCacheCluster[K, V]{
+		ring:    hashring.NewRing(replicas, false),
+		shards:  make(map[string]*clusterShard[K, V]),
+		keyFunc: keyFunc,
+	}
+}
+
+// AddShard adds a cache under name, replacing any shard with that name.
+// Statistics are reset since the key distribution has changed.
+func (c *ShardedCacheCluster[K, V]) AddShard(name string, cache Cache[K, V]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.shards[name] = &clusterShard[K, V]{cache: cache}
+	c.ring.Add(name)
+	c.resetStats()
+}
+
+// RemoveShard removes the shard called name and returns its cache.
+// Statistics are reset since the key distribution has changed.
+func (c *ShardedCacheCluster[K, V]) RemoveShard(name string) (Cache[K, V], bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	shard, exists := c.shards[name]
+	if !exists {
+		return nil, false
+	}
+	delete(c.shards, name)
+	c.ring.Remove(name)
+	c.resetStats()
+	return shard.cache, true
+}
+
+// Shard returns the name of the shard that key is routed to
+func (c *ShardedCacheCluster[K, V]) Shard(key K) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ring.Get(c.keyFunc(key))
+}
+
+func (c *ShardedCacheCluster[K, V]) shardFor(key K) *clusterShard[K, V] {
+	name, ok := c.ring.Get(c.keyFunc(key))
+	if !ok {
+		return nil
+	}
+	return c.shards[name]
+}
+
+// Get retrieves a value from the shard that owns key
+func (c *ShardedCacheCluster[K, V]) Get(key K) (V, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	shard := c.shardFor(key)
+	if shard == nil {
+		var zero V
+		return zero, false
+	}
+	value, ok := shard.cache.Get(key)
+	if ok {
+		shard.hits.Add(1)
+	} else {
+		shard.misses.Add(1)
+	}
+	return value, ok
+}
+
+// Put stores a value in the shard that owns key. It is a no-op while the
+// cluster has no shards.
+func (c *ShardedCacheCluster[K, V]) Put(key K, value V) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if shard := c.shardFor(key); shard != nil {
+		shard.cache.Put(key, value)
+	}
+}
+
+// Remove removes key from the shard that owns it
+func (c *ShardedCacheCluster[K, V]) Remove(key K) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if shard := c.shardFor(key); shard != nil {
+		shard.cache.Remove(key)
+	}
+}
+
+// Clear clears every shard
+func (c *ShardedCacheCluster[K, V]) Clear() {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, shard := range c.shards {
+		shard.cache.Clear()
+	}
+}
+
+// Len returns the total number of items across all shards
+func (c *ShardedCacheCluster[K, V]) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	total := 0
+	for _, shard := range c.shards {
+		total += shard.cache.Len()
+	}
+	return total
+}
+
+// Stats returns per-shard statistics ordered by shard name
+func (c *ShardedCacheCluster[K, V]) Stats() []ShardStats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	shares := c.ring.Shares()
+	stats := make([]ShardStats, 0, len(c.shards))
+	for _, name := range c.ring.Nodes() {
+		shard := c.shards[name]
+		stats = append(stats, ShardStats{
+			Name:   name,
+			Hits:   shard.hits.Load(),
+			Misses: shard.misses.Load(),
+			Len:    shard.cache.Len(),
+			Share:  shares[name],
+		})
+	}
+	return stats
+}
+
+// ResetStats zeroes the hit and miss counters of every shard
+func (c *ShardedCacheCluster[K, V]) ResetStats() {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.resetStats()
+}
+
+func (c *ShardedCacheCluster[K, V]) resetStats() {
+	for _, shard := range c.shards {
+		shard.hits.Store(0)
+		shard.misses.Store(0)
+	}
+}
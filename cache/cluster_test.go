@@ -0,0 +1,109 @@
+package cache
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestShardedCacheCluster(t *testing.T) {
+	cluster := NewShardedCacheCluster[int, int](100, nil)
+	if _, ok := cluster.Get(1); ok {
+		t.Error("Get on empty cluster should miss")
+	}
+	cluster.Put(1, 1)
+
+	for i := 0; i < 3; i++ {
+		cluster.AddShard("shard"+strconv.Itoa(i), NewLRUCache[int, int](1000))
+	}
+	for i := 0; i < 900; i++ {
+		cluster.Put(i, i*10)
+	}
+	if cluster.Len() != 900 {
+		t.Errorf("Len() = %d, want 900", cluster.Len())
+	}
+	for i := 0; i < 900; i++ {
+		if val, ok := cluster.Get(i); !ok || val != i*10 {
+			t.Fatalf("Get(%d) = %d, %v, want %d, true", i, val, ok, i*10)
+		}
+	}
+
+	var hits uint64
+	for _, s := range cluster.Stats() {
+		if s.Len == 0 {
+			t.Errorf("shard %s received no keys", s.Name)
+		}
+		hits += s.Hits
+	}
+	if hits != 900 {
+		t.Errorf("total hits = %d, want 900", hits)
+	}
+
+	cluster.Remove(5)
+	if _, ok := cluster.Get(5); ok {
+		t.Error("Expected key 5 to be removed")
+	}
+	cluster.Clear()
+	if cluster.Len() != 0 {
+		t.Errorf("Len() after Clear = %d, want 0", cluster.Len())
+	}
+}
+
+func TestShardedCacheClusterRebalance(t *testing.T) {
+	cluster := NewShardedCacheCluster[string, int](100, func(key string) string { return key })
+	cluster.AddShard("a", NewLRUCache[string, int](10000))
+	cluster.AddShard("b", NewLRUCache[string, int](10000))
+	for i := 0; i < 1000; i++ {
+		cluster.Put(strconv.Itoa(i), i)
+	}
+
+	cluster.AddShard("c", NewLRUCache[string, int](10000))
+	for _, s := range cluster.Stats() {
+		if s.Hits != 0 || s.Misses != 0 {
+			t.Errorf("stats for %s should be reset after AddShard", s.Name)
+		}
+	}
+
+	misses := 0
+	for i := 0; i < 1000; i++ {
+		if _, ok := cluster.Get(strconv.Itoa(i)); !ok {
+			misses++
+		}
+	}
+	// Only keys rerouted to the new shard miss
+	if misses == 0 || misses > 500 {
+		t.Errorf("%d of 1000 keys missed after adding a shard", misses)
+	}
+
+	if _, ok := cluster.RemoveShard("c"); !ok {
+		t.Error("RemoveShard(c) should find the shard")
+	}
+	for i := 0; i < 1000; i++ {
+		if _, ok := cluster.Get(strconv.Itoa(i)); !ok {
+			t.Fatalf("key %d should route back to its original shard", i)
+		}
+	}
+}
+
+func TestShardedCacheClusterConcurrent(t *testing.T) {
+	cluster := NewShardedCacheCluster[int, int](50, nil)
+	cluster.AddShard("a", NewLRUCache[int, int](1000))
+	cluster.AddShard("b", NewLRUCache[int, int](1000))
+	var wg sync.WaitGroup
+
+	for i := 0; i < 1000; i++ {
+		wg.Add(1)
+		go func(val int) {
+			defer wg.Done()
+			cluster.Put(val, val)
+			cluster.Get(val)
+		}(i)
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		cluster.AddShard("c", NewLRUCache[int, int](1000))
+		cluster.RemoveShard("c")
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,80 @@
+package cache
+
+import (
+	"bytes"
+
+	"dsgo/codec"
+)
+
+const (
+	storedRaw byte = iota
+	storedCompressed
+)
+
+// CompressedCache stores string or byte slice values in an underlying byte
+// cache, compressing those larger than a threshold. Small values, and values
+// that do not shrink, are stored as is. Compression is transparent: Get
+// returns the original value.
+type CompressedCache[K comparable, V ~string | ~[]byte] struct {
+	inner      Cache[K, []byte]
+	compressor codec.Compressor
+	threshold  int
+}
+
+// WithCompression wraps inner so that values of at least threshold bytes are
+// compressed with compressor before they are stored.
+func WithCompression[K comparable, V ~string | ~[]byte](inner Cache[K, []byte], compressor codec.Compressor, threshold int) *CompressedCache[K, V] {
+	return &CompressedCache[K, V]{
+		inner:      inner,
+		compressor: compressor,
+		threshold:  threshold,
+	}
+}
+
+// Get retrieves and, if necessary, decompresses a value. A value that fails
+// to decompress is removed and reported as a miss. The value is the
+// caller's own: modifying a returned byte slice does not change the cache.
+func (c *CompressedCache[K, V]) Get(key K) (V, bool) {
+	var zero V
+	stored, ok := c.inner.Get(key)
+	if !ok || len(stored) == 0 {
+		return zero, false
+	}
+	data := stored[1:]
+	if stored[0] == storedCompressed {
+		var err error
+		if data, err = c.compressor.Decompress(data); err != nil {
+			c.inner.Remove(key)
+			return zero, false
+		}
+	} else {
+		// Raw values would otherwise alias the stored entry
+		data = bytes.Clone(data)
+	}
+	return V(data), true
+}
+
+// Put stores value, compressing it if it is at least threshold bytes long
+// and compression makes it smaller
+func (c *CompressedCache[K, V]) Put(key K, value V) {
+	data := []byte(value)
+	if len(data) >= c.threshold {
+		if compressed, err := c.compressor.Compress(data); err == nil && len(compressed) < len(data) {
+			c.inner.Put(key, append([]byte{storedCompressed}, compressed...))
+			return
+		}
+	}
+	c.inner.Put(key, append([]byte{storedRaw}, data...))
+}
+
+func (c *CompressedCache[K, V]) Remove(key K) {
+	c.inner.Remove(key)
+}
+
+func (c *CompressedCache[K, V]) Clear() {
+	c.inner.Clear()
+}
+
+func (c *CompressedCache[K, V]) Len() int {
+	return c.inner.Len()
+}
@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"dsgo/codec"
+)
+
+func TestCompressedCache(t *testing.T) {
+	inner := NewLRUCache[string, []byte](10)
+	c := WithCompression[string, string](inner, codec.Flate{}, 64)
+
+	small := "tiny"
+	large := strings.Repeat("abcdefgh", 100)
+	c.Put("small", small)
+	c.Put("large", large)
+
+	if val, ok := c.Get("small"); !ok || val != small {
+		t.Errorf("Get(small) = %q, %v", val, ok)
+	}
+	if val, ok := c.Get("large"); !ok || val != large {
+		t.Errorf("Get(large) returned %d bytes, %v", len(val), ok)
+	}
+
+	stored, _ := inner.Get("large")
+	if stored[0] != storedCompressed || len(stored) >= len(large) {
+		t.Errorf("large value stored in %d bytes, want it compressed", len(stored))
+	}
+	stored, _ = inner.Get("small")
+	if stored[0] != storedRaw {
+		t.Error("value below the threshold should be stored raw")
+	}
+
+	c.Remove("small")
+	if c.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", c.Len())
+	}
+}
+
+type failingCompressor struct{}
+
+func (failingCompressor) Compress(data []byte) ([]byte, error) {
+	return nil, errors.New("compress failed")
+}
+
+func (failingCompressor) Decompress(data []byte) ([]byte, error) {
+	return nil, errors.New("decompress failed")
+}
+
+func TestCompressedCacheFallback(t *testing.T) {
+	inner := NewLRUCache[int, []byte](10)
+	c := WithCompression[int, []byte](inner, failingCompressor{}, 1)
+
+	c.Put(1, []byte("stored raw when compression fails"))
+	if val, ok := c.Get(1); !ok || string(val) != "stored raw when compression fails" {
+		t.Errorf("Get(1) = %q, %v", val, ok)
+	}
+
+	inner.Put(2, []byte{storedCompressed, 1, 2, 3})
+	if _, ok := c.Get(2); ok {
+		t.Error("Get should miss on a value that fails to decompress")
+	}
+	if inner.Len() != 1 {
+		t.Error("undecodable value should be removed")
+	}
+}
+
+func TestCompressedCacheGetCopies(t *testing.T) {
+	c := WithCompression[string, []byte](NewLRUCache[string, []byte](10), codec.Flate{}, 64)
+	c.Put("k", []byte("raw"))
+	val, _ := c.Get("k")
+	val[0] = 'X'
+	if val, _ := c.Get("k"); string(val) != "raw" {
+		t.Errorf("Get() after modifying a returned value = %q, want %q", val, "raw")
+	}
+}
@@ -0,0 +1,152 @@
+package cache
+
+import (
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// DNSOptions configures a DNSCache.
+type DNSOptions[K comparable, V any] struct {
+	// Jitter shortens each TTL by a random fraction of up to Jitter, in
+	// [0, 1), so that entries cached together do not all expire together.
+	// TTLs are only ever shortened, so an entry is never served past the
+	// TTL it was stored with.
+	Jitter float64
+	// Refresh, if set, reloads an entry in the background when it is read
+	// within RefreshAhead of expiring, returning the new value and TTL. On
+	// error the old entry is kept until it expires.
+	Refresh func(key K) (V, time.Duration, error)
+	// RefreshAhead is how close to expiry a read triggers Refresh.
+	RefreshAhead time.Duration
+}
+
+// DNSCache is a cache for records with their own TTLs, such as DNS
+// answers. It composes an ExpiryCache, which bounds the cache by LRU and
+// tracks deadlines in its expiry heap, with TTL jitter and refresh-ahead:
+// a read of an entry about to expire returns it and reloads it in the
+// background, so hot keys do not miss when their TTL runs out. At most one
+// refresh per key runs at a time. It is always safe for concurrent use.
+type DNSCache[K comparable, V any] struct {
+	entries *ExpiryCache[K, V]
+	opts    DNSOptions[K, V]
+	random  func() float64
+	// refreshing holds the keys with a refresh in flight, mapped to whether
+	// a Put or Remove has since superseded it
+	refreshing map[K]bool
+	closed     bool
+	refreshes  sync.WaitGroup
+	mu         sync.Mutex
+}
+
+// NewDNSCache creates a DNS cache holding at most capacity entries, which
+// follows the rules of NewPolicyCache. It panics if opts.Jitter is not in
+// [0, 1).
+func NewDNSCache[K comparable, V any](capacity int, opts ...DNSOptions[K, V]) *DNSCache[K, V] {
+	var opt DNSOptions[K, V]
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.Jitter < 0 || opt.Jitter >= 1 {
+		panic("cache: jitter must be in [0, 1)")
+	}
+	return &DNSCache[K, V]{
+		entries:    NewExpiryCache[K, V](capacity, 0),
+		opts:       opt,
+		random:     rand.Float64,
+		refreshing: make(map[K]bool),
+	}
+}
+
+// Put caches value for up to ttl, less jitter. A ttl of zero or less means
+// the record must not be cached, and removes any cached entry for key.
+func (c *DNSCache[K, V]) Put(key K, value V, ttl time.Duration) {
+	c.supersede(key)
+	if ttl <= 0 {
+		c.entries.Remove(key)
+		return
+	}
+	c.entries.PutWithTTL(key, value, c.jitter(ttl))
+}
+
+// supersede marks a refresh in flight for key as out of date, so its result
+// does not overwrite a newer Put or bring back a removed entry.
+func (c *DNSCache[K, V]) supersede(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, running := c.refreshing[key]; running {
+		c.refreshing[key] = true
+	}
+}
+
+func (c *DNSCache[K, V]) jitter(ttl time.Duration) time.Duration {
+	if c.opts.Jitter == 0 {
+		return ttl
+	}
+	c.mu.Lock()
+	f := c.random()
+	c.mu.Unlock()
+	// Keep at least a nanosecond so the entry is still cached
+	return max(ttl-time.Duration(float64(ttl)*c.opts.Jitter*f), 1)
+}
+
+// Get returns the cached value for key if it has not expired, starting a
+// background refresh if it is within RefreshAhead of expiring.
+func (c *DNSCache[K, V]) Get(key K) (V, bool) {
+	value, deadline, ok := c.entries.getWithDeadline(key)
+	if ok && c.opts.Refresh != nil && deadline.Sub(c.entries.now()) <= c.opts.RefreshAhead {
+		c.refresh(key)
+	}
+	return value, ok
+}
+
+func (c *DNSCache[K, V]) refresh(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, running := c.refreshing[key]; running || c.closed {
+		return
+	}
+	c.refreshing[key] = false
+	c.refreshes.Add(1)
+	go func() {
+		defer c.refreshes.Done()
+		value, ttl, err := c.opts.Refresh(key)
+		if err == nil && ttl > 0 {
+			// Jittered before taking c.mu, which jitter also takes
+			ttl = c.jitter(ttl)
+		}
+		// The entry is written under c.mu so that a Put or Remove either
+		// supersedes the refresh first or lands after it
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if err == nil && !c.refreshing[key] {
+			if ttl <= 0 {
+				c.entries.Remove(key)
+			} else {
+				c.entries.PutWithTTL(key, value, ttl)
+			}
+		}
+		delete(c.refreshing, key)
+	}()
+}
+
+// Remove removes key from the cache.
+func (c *DNSCache[K, V]) Remove(key K) {
+	c.supersede(key)
+	c.entries.Remove(key)
+}
+
+// Len returns the number of cached entries, including expired entries that
+// have not been purged yet.
+func (c *DNSCache[K, V]) Len() int {
+	return c.entries.Len()
+}
+
+// Close stops starting new refreshes and waits for running ones to finish.
+// The cache can still be read and written after Close.
+func (c *DNSCache[K, V]) Close() {
+	c.mu.Lock()
+	c.closed = true
+	c.mu.Unlock()
+	c.refreshes.Wait()
+}
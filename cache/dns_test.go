@@ -0,0 +1,143 @@
+package cache
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestDNSCache(capacity int, opts DNSOptions[string, string]) (*DNSCache[string, string], *fakeClock) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	c := NewDNSCache(capacity, opts)
+	c.entries.now = clock.Now
+	return c, clock
+}
+
+func TestDNSCacheTTLAndJitter(t *testing.T) {
+	c, clock := newTestDNSCache(10, DNSOptions[string, string]{Jitter: 0.5})
+	c.random = func() float64 { return 0.5 }
+
+	// 0.5 jitter at 0.5 shortens a 100s TTL by 25s
+	c.Put("example.com", "93.184.216.34", 100*time.Second)
+	c.Put("nocache.example", "10.0.0.1", 0)
+	clock.Advance(74 * time.Second)
+	if v, ok := c.Get("example.com"); !ok || v != "93.184.216.34" {
+		t.Errorf("Get() before the jittered TTL = %q, %v, want a hit", v, ok)
+	}
+	clock.Advance(time.Second)
+	if _, ok := c.Get("example.com"); ok {
+		t.Error("Get() after the jittered TTL should miss")
+	}
+	if _, ok := c.Get("nocache.example"); ok {
+		t.Error("a record with TTL 0 should not be cached")
+	}
+}
+
+func TestDNSCacheLRUBound(t *testing.T) {
+	c, _ := newTestDNSCache(2, DNSOptions[string, string]{})
+	c.Put("a", "1", time.Hour)
+	c.Put("b", "2", time.Hour)
+	c.Get("a")
+	c.Put("c", "3", time.Hour)
+	if _, ok := c.Get("b"); ok {
+		t.Error("least recently used entry should have been evicted")
+	}
+	if c.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", c.Len())
+	}
+}
+
+func TestDNSCacheRefreshAhead(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	fail := atomic.Bool{}
+	opts := DNSOptions[string, string]{
+		RefreshAhead: 10 * time.Second,
+		Refresh: func(key string) (string, time.Duration, error) {
+			calls.Add(1)
+			<-release
+			if fail.Load() {
+				return "", 0, errors.New("resolver down")
+			}
+			return key + "-refreshed", time.Minute, nil
+		},
+	}
+	c, clock := newTestDNSCache(10, opts)
+	c.Put("host", "old", 30*time.Second)
+
+	c.Get("host")
+	if calls.Load() != 0 {
+		t.Fatal("a fresh entry should not be refreshed")
+	}
+
+	clock.Advance(25 * time.Second)
+	for i := 0; i < 5; i++ {
+		if v, ok := c.Get("host"); !ok || v != "old" {
+			t.Errorf("Get() during refresh = %q, %v, want the old value", v, ok)
+		}
+	}
+	close(release)
+	c.Close()
+	if calls.Load() != 1 {
+		t.Errorf("Refresh called %d times, want 1 for concurrent reads", calls.Load())
+	}
+	if v, _ := c.Get("host"); v != "host-refreshed" {
+		t.Errorf("Get() after refresh = %q, want host-refreshed", v)
+	}
+
+	// Reopen for refreshes; a failed refresh keeps the old entry
+	c.closed = false
+	fail.Store(true)
+	clock.Advance(55 * time.Second)
+	c.Get("host")
+	c.Close()
+	if v, ok := c.Get("host"); !ok || v != "host-refreshed" {
+		t.Errorf("Get() after a failed refresh = %q, %v, want the old entry", v, ok)
+	}
+	clock.Advance(5 * time.Second)
+	if _, ok := c.Get("host"); ok {
+		t.Error("entry should expire when refreshes keep failing")
+	}
+}
+
+func TestDNSCacheRefreshSuperseded(t *testing.T) {
+	release := make(chan struct{})
+	opts := DNSOptions[string, string]{
+		RefreshAhead: 10 * time.Second,
+		Refresh: func(key string) (string, time.Duration, error) {
+			<-release
+			return "stale", time.Minute, nil
+		},
+	}
+	c, clock := newTestDNSCache(10, opts)
+
+	// A Put made while the refresh runs wins
+	c.Put("a", "old", 15*time.Second)
+	clock.Advance(10 * time.Second)
+	c.Get("a")
+	c.Put("a", "new", time.Minute)
+
+	// So does a Remove
+	c.Put("b", "old", 15*time.Second)
+	c.Get("b")
+	c.Remove("b")
+
+	close(release)
+	c.Close()
+	if v, _ := c.Get("a"); v != "new" {
+		t.Errorf("Get(a) = %q, want the value Put during the refresh", v)
+	}
+	if v, ok := c.Get("b"); ok {
+		t.Errorf("Get(b) = %q, want the entry to stay removed", v)
+	}
+}
+
+func TestDNSCacheInvalidJitter(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("NewDNSCache() with jitter 1 did not panic")
+		}
+	}()
+	NewDNSCache[string, string](10, DNSOptions[string, string]{Jitter: 1})
+}
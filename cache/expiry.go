@@ -0,0 +1,259 @@
+package cache
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"dsgo/heaps"
+)
+
+type expiryEntry[V any] struct {
+	value    V
+	deadline time.Time
+}
+
+type expiryItem[K comparable] struct {
+	key      K
+	deadline time.Time
+}
+
+// ExpiryCache is a bounded cache with per-entry time-to-live. When the cache
+// is full, expired entries (tracked in a min-heap by deadline) are removed
+// first, and only if none have expired is the least recently used entry
+// evicted, so a live entry is never evicted while an expired one remains.
+// Expired entries are also never returned by Get.
+type ExpiryCache[K comparable, V any] struct {
+	capacity   int
+	defaultTTL time.Duration
+	entries    map[K]expiryEntry[V]
+	expiries   *heaps.MinHeap[expiryItem[K]]
+	lru        *LRUPolicy[K]
+	now        func() time.Time
+	hooks      hookSet[K, V]
+	id         atomic.Uint64
+	threadSafe bool
+	mu         sync.Mutex
+}
+
+// NewExpiryCache creates an expiry cache with the specified capacity. Put
+// uses defaultTTL; a TTL of zero or less means entries never expire. With
+// CapacityUnlimited, expired entries are purged on every Put instead of when
+// the cache is full. Other capacities follow the rules of NewPolicyCache.
+func NewExpiryCache[K comparable, V any](capacity int, defaultTTL time.Duration, threadSafe ...bool) *ExpiryCache[K, V] {
+	if err := ValidateCapacity(capacity); err != nil {
+		panic(err)
+	}
+	isThreadSafe := true
+	if len(threadSafe) > 0 {
+		isThreadSafe = threadSafe[0]
+	}
+	return &ExpiryCache[K, V]{
+		capacity:   capacity,
+		defaultTTL: defaultTTL,
+		entries:    make(map[K]expiryEntry[V]),
+		expiries:   newExpiryHeap[K](),
+		lru:        NewLRUPolicy[K](),
+		now:        time.Now,
+		threadSafe: isThreadSafe,
+	}
+}
+
+func newExpiryHeap[K comparable]() *heaps.MinHeap[expiryItem[K]] {
+	return heaps.NewMinHeap(func(a, b expiryItem[K]) bool {
+		return a.deadline.Before(b.deadline)
+	}, false)
+}
+
+// WithHooks installs hooks that observe Get, Put, PutWithTTL, Remove and
+// Clear, replacing any installed before, and returns the cache.
+func (c *ExpiryCache[K, V]) WithHooks(hooks Hooks[K, V]) *ExpiryCache[K, V] {
+	c.hooks.set(hooks)
+	return c
+}
+
+// Get retrieves a live value from the cache, marking it as recently used
+func (c *ExpiryCache[K, V]) Get(key K) (V, bool) {
+	after := c.hooks.get(key)
+	value, _, ok := c.getWithDeadline(key)
+	if after != nil {
+		after(value, ok)
+	}
+	return value, ok
+}
+
+// getWithDeadline is Get that also returns when the entry expires, or the
+// zero time if it never does.
+func (c *ExpiryCache[K, V]) getWithDeadline(key K) (V, time.Time, bool) {
+	if c.threadSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+	return c.get(key)
+}
+
+// get is getWithDeadline without locking.
+func (c *ExpiryCache[K, V]) get(key K) (V, time.Time, bool) {
+	entry, exists := c.entries[key]
+	if !exists {
+		var zero V
+		return zero, time.Time{}, false
+	}
+	if c.expired(entry, c.now()) {
+		c.remove(key)
+		var zero V
+		return zero, time.Time{}, false
+	}
+	c.lru.Touch(key)
+	return entry.value, entry.deadline, true
+}
+
+// Put adds or updates a value with the cache's default TTL
+func (c *ExpiryCache[K, V]) Put(key K, value V) {
+	c.PutWithTTL(key, value, c.defaultTTL)
+}
+
+// PutWithTTL adds or updates a value that expires after ttl. A ttl of zero or
+// less means the entry never expires.
+func (c *ExpiryCache[K, V]) PutWithTTL(key K, value V, ttl time.Duration) {
+	if after := c.hooks.put(key, value); after != nil {
+		defer after()
+	}
+	if c.threadSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+	c.put(key, value, ttl)
+}
+
+// put is PutWithTTL without locking or hooks.
+func (c *ExpiryCache[K, V]) put(key K, value V, ttl time.Duration) {
+	if c.capacity == 0 {
+		return
+	}
+	now := c.now()
+	var deadline time.Time
+	if ttl > 0 {
+		deadline = now.Add(ttl)
+	}
+	if c.capacity == CapacityUnlimited {
+		c.purge(now)
+	}
+
+	if _, exists := c.entries[key]; exists {
+		c.lru.Touch(key)
+	} else {
+		if full(len(c.entries), c.capacity) {
+			c.evict(now)
+		}
+		c.lru.Admit(key)
+	}
+	c.entries[key] = expiryEntry[V]{value: value, deadline: deadline}
+	if !deadline.IsZero() {
+		c.expiries.Push(expiryItem[K]{key: key, deadline: deadline})
+		c.compact()
+	}
+}
+
+// Remove removes a key-value pair from the cache
+func (c *ExpiryCache[K, V]) Remove(key K) {
+	if after := c.hooks.remove(key); after != nil {
+		defer after()
+	}
+	if c.threadSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+	c.remove(key)
+}
+
+// Purge removes every expired entry and returns how many were removed
+func (c *ExpiryCache[K, V]) Purge() int {
+	if c.threadSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+	return c.purge(c.now())
+}
+
+// Clear removes all items from the cache
+func (c *ExpiryCache[K, V]) Clear() {
+	if after := c.hooks.clear(); after != nil {
+		defer after()
+	}
+	if c.threadSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+	c.clear()
+}
+
+// clear is Clear without locking or hooks.
+func (c *ExpiryCache[K, V]) clear() {
+	c.entries = make(map[K]expiryEntry[V])
+	c.expiries = newExpiryHeap[K]()
+	c.lru.Clear()
+}
+
+// Len returns the number of entries in the cache, including expired entries
+// that have not been purged yet
+func (c *ExpiryCache[K, V]) Len() int {
+	if c.threadSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+	return len(c.entries)
+}
+
+func (c *ExpiryCache[K, V]) expired(entry expiryEntry[V], now time.Time) bool {
+	return !entry.deadline.IsZero() && !now.Before(entry.deadline)
+}
+
+func (c *ExpiryCache[K, V]) remove(key K) {
+	if _, exists := c.entries[key]; exists {
+		c.lru.Remove(key)
+		delete(c.entries, key)
+	}
+}
+
+// evict makes room for one entry, preferring expired entries over the least
+// recently used one.
+func (c *ExpiryCache[K, V]) evict(now time.Time) {
+	if c.purge(now) > 0 {
+		return
+	}
+	if victim, ok := c.lru.Evict(); ok {
+		delete(c.entries, victim)
+	}
+}
+
+// purge pops every heap item whose deadline has passed. Items left behind by
+// updates or removals no longer match their entry and are discarded.
+func (c *ExpiryCache[K, V]) purge(now time.Time) int {
+	removed := 0
+	for {
+		item, ok := c.expiries.Peek()
+		if !ok || now.Before(item.deadline) {
+			return removed
+		}
+		c.expiries.Pop()
+		if entry, exists := c.entries[item.key]; exists && entry.deadline.Equal(item.deadline) {
+			c.remove(item.key)
+			removed++
+		}
+	}
+}
+
+// compact rebuilds the heap once stale items outnumber live entries, so
+// repeatedly updating the same keys does not grow it without bound.
+func (c *ExpiryCache[K, V]) compact() {
+	if c.expiries.Size() <= 2*len(c.entries)+16 {
+		return
+	}
+	c.expiries = newExpiryHeap[K]()
+	for key, entry := range c.entries {
+		if !entry.deadline.IsZero() {
+			c.expiries.Push(expiryItem[K]{key: key, deadline: entry.deadline})
+		}
+	}
+}
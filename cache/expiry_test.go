@@ -0,0 +1,132 @@
+package cache
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newTestExpiryCache(capacity int, ttl time.Duration) (*ExpiryCache[string, int], *fakeClock) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	c := NewExpiryCache[string, int](capacity, ttl, false)
+	c.now = clock.Now
+	return c, clock
+}
+
+func TestExpiryCacheTTL(t *testing.T) {
+	c, clock := newTestExpiryCache(10, time.Minute)
+	c.Put("a", 1)
+	c.PutWithTTL("b", 2, 0)
+
+	clock.Advance(30 * time.Second)
+	if val, ok := c.Get("a"); !ok || val != 1 {
+		t.Errorf("Get(a) = %d, %v, want 1, true", val, ok)
+	}
+
+	clock.Advance(30 * time.Second)
+	if _, ok := c.Get("a"); ok {
+		t.Error("Get(a) should miss after its TTL")
+	}
+	if _, ok := c.Get("b"); !ok {
+		t.Error("Entry without TTL should not expire")
+	}
+	if c.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", c.Len())
+	}
+}
+
+func TestExpiryCacheEvictsExpiredFirst(t *testing.T) {
+	c, clock := newTestExpiryCache(3, 0)
+	c.PutWithTTL("short", 1, time.Second)
+	c.Put("x", 2)
+	c.Put("y", 3)
+
+	// "short" is the most recently used entry but has expired, so it is
+	// evicted instead of the LRU entry "x"
+	c.Get("short")
+	clock.Advance(2 * time.Second)
+	c.Put("z", 4)
+
+	if _, ok := c.Get("x"); !ok {
+		t.Error("Live LRU entry should survive while an expired entry exists")
+	}
+	if c.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", c.Len())
+	}
+
+	// With nothing expired, eviction falls back to LRU
+	c.Put("w", 5)
+	if _, ok := c.Get("y"); ok {
+		t.Error("Expected 'y' to be evicted as least recently used")
+	}
+}
+
+func TestExpiryCacheUpdateAndPurge(t *testing.T) {
+	c, clock := newTestExpiryCache(10, time.Second)
+	c.Put("a", 1)
+	c.PutWithTTL("a", 2, time.Hour)
+	c.Put("b", 3)
+	c.Put("c", 4)
+
+	clock.Advance(2 * time.Second)
+	if n := c.Purge(); n != 2 {
+		t.Errorf("Purge() = %d, want 2", n)
+	}
+	if val, ok := c.Get("a"); !ok || val != 2 {
+		t.Errorf("Updated entry should keep its new TTL, got %d, %v", val, ok)
+	}
+
+	for i := 0; i < 1000; i++ {
+		c.Put("a", i)
+	}
+	if c.expiries.Size() > 2*c.Len()+16 {
+		t.Errorf("expiry heap grew to %d items for %d entries", c.expiries.Size(), c.Len())
+	}
+}
+
+func TestExpiryCacheConcurrent(t *testing.T) {
+	c := NewExpiryCache[int, int](100, time.Millisecond)
+	var wg sync.WaitGroup
+
+	for i := 0; i < 1000; i++ {
+		wg.Add(1)
+		go func(val int) {
+			defer wg.Done()
+			c.Put(val, val)
+			c.Get(val - 1)
+		}(i)
+	}
+	wg.Wait()
+
+	if c.Len() > 100 {
+		t.Errorf("Len() = %d exceeds capacity 100", c.Len())
+	}
+}
+
+func TestExpiryCacheUnlimited(t *testing.T) {
+	c, clock := newTestExpiryCache(CapacityUnlimited, time.Minute)
+	for i := 0; i < 1000; i++ {
+		c.Put(strconv.Itoa(i), i)
+	}
+	if c.Len() != 1000 {
+		t.Fatalf("Len() = %d, want 1000 with no size-based eviction", c.Len())
+	}
+
+	clock.Advance(2 * time.Minute)
+	c.Put("fresh", 1)
+	if c.Len() != 1 {
+		t.Errorf("Len() after expiry = %d, want 1", c.Len())
+	}
+	if _, ok := c.Get("fresh"); !ok {
+		t.Error("Get(fresh) missed")
+	}
+}
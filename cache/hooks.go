@@ -0,0 +1,56 @@
+package cache
+
+import "sync/atomic"
+
+// Hooks are called around cache operations, for tracing, metrics or logging.
+// Each hook runs before the operation and may return a function that runs
+// after it, so a tracing span can be started in the hook and ended in the
+// returned function. Any hook, and any returned function, may be nil.
+//
+// Hooks are installed with the WithHooks method of PolicyCache, LRUCache,
+// LFUCache, ExpiryCache and SoftCache. They run outside the cache's lock, so
+// they may call back into the cache.
+type Hooks[K comparable, V any] struct {
+	OnGet    func(key K) func(value V, hit bool)
+	OnPut    func(key K, value V) func()
+	OnRemove func(key K) func()
+	OnClear  func() func()
+}
+
+// hookSet holds the Hooks installed on a cache. It is read on every
+// operation without the cache's lock, so the hooks are swapped atomically.
+type hookSet[K comparable, V any] struct {
+	hooks atomic.Pointer[Hooks[K, V]]
+}
+
+func (h *hookSet[K, V]) set(hooks Hooks[K, V]) {
+	h.hooks.Store(&hooks)
+}
+
+func (h *hookSet[K, V]) get(key K) func(V, bool) {
+	if hooks := h.hooks.Load(); hooks != nil && hooks.OnGet != nil {
+		return hooks.OnGet(key)
+	}
+	return nil
+}
+
+func (h *hookSet[K, V]) put(key K, value V) func() {
+	if hooks := h.hooks.Load(); hooks != nil && hooks.OnPut != nil {
+		return hooks.OnPut(key, value)
+	}
+	return nil
+}
+
+func (h *hookSet[K, V]) remove(key K) func() {
+	if hooks := h.hooks.Load(); hooks != nil && hooks.OnRemove != nil {
+		return hooks.OnRemove(key)
+	}
+	return nil
+}
+
+func (h *hookSet[K, V]) clear() func() {
+	if hooks := h.hooks.Load(); hooks != nil && hooks.OnClear != nil {
+		return hooks.OnClear()
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package cache
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCacheHooks(t *testing.T) {
+	var events []string
+	var c *LRUCache[string, int]
+	c = NewLRUCache[string, int](10).WithHooks(Hooks[string, int]{
+		OnGet: func(key string) func(int, bool) {
+			events = append(events, "start get "+key)
+			return func(value int, hit bool) {
+				events = append(events, fmt.Sprintf("end get %s %d %v", key, value, hit))
+			}
+		},
+		OnPut: func(key string, value int) func() {
+			events = append(events, fmt.Sprintf("put %s %d", key, value))
+			return nil
+		},
+		OnRemove: func(key string) func() {
+			return func() {
+				// Hooks run outside the lock, so they can use the cache
+				events = append(events, fmt.Sprintf("removed %s len %d", key, c.Len()))
+			}
+		},
+	})
+
+	c.Put("a", 1)
+	c.Get("a")
+	c.Get("b")
+	c.Remove("a")
+	c.Clear()
+
+	expected := []string{
+		"put a 1",
+		"start get a",
+		"end get a 1 true",
+		"start get b",
+		"end get b 0 false",
+		"removed a len 0",
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("events = %v, want %v", events, expected)
+	}
+	for i := range expected {
+		if events[i] != expected[i] {
+			t.Errorf("events[%d] = %q, want %q", i, events[i], expected[i])
+		}
+	}
+}
+
+func TestExpiryAndSoftCacheHooks(t *testing.T) {
+	var puts, clears int
+	hooks := Hooks[string, int]{
+		OnPut:   func(string, int) func() { puts++; return nil },
+		OnClear: func() func() { return func() { clears++ } },
+	}
+	caches := []Cache[string, int]{
+		NewExpiryCache[string, int](10, 0).WithHooks(hooks),
+		NewSoftCache[string, int]().WithHooks(hooks),
+	}
+	for _, c := range caches {
+		c.Put("a", 1)
+		c.Clear()
+	}
+	if puts != 2 || clears != 2 {
+		t.Errorf("puts, clears = %d, %d, want 2, 2", puts, clears)
+	}
+}
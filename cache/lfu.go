@@ -1,9 +1,5 @@
 package cache
 
-import (
-	"sync"
-)
-
 type frequencyNode[K comparable] struct {
 	freq  int
 	items map[K]struct{}
@@ -12,60 +8,61 @@ type frequencyNode[K comparable] struct {
 }
 
 type LFUCache[K comparable, V any] struct {
-	capacity   int
-	cache      map[K]*frequencyNode[K]
-	freqList   *frequencyNode[K]
-	values     map[K]V
-	threadSafe bool
-	mu         sync.RWMutex
+	*PolicyCache[K, V]
 }
 
-// NewLFUCache creates a new LFU cache with the specified capacity
+// NewLFUCache creates a new LFU cache with the specified capacity, which
+// follows the rules of NewPolicyCache
 func NewLFUCache[K comparable, V any](capacity int, threadSafe ...bool) *LFUCache[K, V] {
-	isThreadSafe := true
-	if len(threadSafe) > 0 {
-		isThreadSafe = threadSafe[0]
-	}
 	return &LFUCache[K, V]{
-		capacity:   capacity,
-		cache:      make(map[K]*frequencyNode[K]),
-		freqList:   nil,
-		values:     make(map[K]V),
-		threadSafe: isThreadSafe,
+		PolicyCache: NewPolicyCache[K, V](capacity, NewLFUPolicy[K](), threadSafe...),
 	}
 }
 
-// Get retrieves a value from the cache and increments its frequency
-func (c *LFUCache[K, V]) Get(key K) (V, bool) {
-	if c.threadSafe {
-		c.mu.Lock()
-		defer c.mu.Unlock()
-	}
+// WithHooks installs hooks on the underlying PolicyCache and returns c.
+func (c *LFUCache[K, V]) WithHooks(hooks Hooks[K, V]) *LFUCache[K, V] {
+	c.PolicyCache.WithHooks(hooks)
+	return c
+}
 
-	if node, exists := c.cache[key]; exists {
-		c.updateFrequency(key, node)
-		return c.values[key], true
-	}
-	var zero V
-	return zero, false
+// LFUPolicy evicts the least frequently used key.
+type LFUPolicy[K comparable] struct {
+	nodes    map[K]*frequencyNode[K]
+	freqList *frequencyNode[K]
 }
 
-// updateFrequency moves a key to the next frequency node
-func (c *LFUCache[K, V]) updateFrequency(key K, node *frequencyNode[K]) {
-	// Remove from current frequency node
-	delete(node.items, key)
+// NewLFUPolicy creates an empty least-frequently-used eviction policy
+func NewLFUPolicy[K comparable]() *LFUPolicy[K] {
+	return &LFUPolicy[K]{
+		nodes: make(map[K]*frequencyNode[K]),
+	}
+}
 
-	// If node becomes empty and it's not the head, remove it
-	if len(node.items) == 0 && node != c.freqList {
-		if node.prev != nil {
-			node.prev.next = node.next
+// Admit adds the key to the frequency 1 node
+func (p *LFUPolicy[K]) Admit(key K) {
+	if p.freqList == nil || p.freqList.freq != 1 {
+		p.freqList = &frequencyNode[K]{
+			freq:  1,
+			items: make(map[K]struct{}),
+			next:  p.freqList,
 		}
-		if node.next != nil {
-			node.next.prev = node.prev
+		if p.freqList.next != nil {
+			p.freqList.next.prev = p.freqList
 		}
 	}
+	p.freqList.items[key] = struct{}{}
+	p.nodes[key] = p.freqList
+}
 
-	// Create or get next frequency node
+// Touch moves a key to the next frequency node
+func (p *LFUPolicy[K]) Touch(key K) {
+	node, exists := p.nodes[key]
+	if !exists {
+		return
+	}
+
+	// Create or get next frequency node before unlinking the current one,
+	// so a new node is never attached to a node that has left the list
 	nextFreq := node.freq + 1
 	var nextNode *frequencyNode[K]
 
@@ -84,105 +81,59 @@ func (c *LFUCache[K, V]) updateFrequency(key K, node *frequencyNode[K]) {
 		node.next = nextNode
 	}
 
-	// Add to next frequency node
+	// Move from current to next frequency node
+	p.unlink(key, node)
 	nextNode.items[key] = struct{}{}
-	c.cache[key] = nextNode
+	p.nodes[key] = nextNode
 }
 
-// Put adds or updates a value in the cache
-func (c *LFUCache[K, V]) Put(key K, value V) {
-	if c.threadSafe {
-		c.mu.Lock()
-		defer c.mu.Unlock()
+func (p *LFUPolicy[K]) Remove(key K) {
+	if node, exists := p.nodes[key]; exists {
+		p.unlink(key, node)
+		delete(p.nodes, key)
 	}
-
-	// If key exists, update it
-	if node, exists := c.cache[key]; exists {
-		c.updateFrequency(key, node)
-	} else {
-		// If cache is full, remove the least frequently used item
-		if len(c.values) >= c.capacity {
-			// Find the first non-empty frequency node
-			current := c.freqList
-			for current != nil && len(current.items) == 0 {
-				current = current.next
-			}
-
-			if current != nil {
-				// Get any key from the items map
-				var keyToRemove K
-				for k := range current.items {
-					keyToRemove = k
-					break
-				}
-
-				// Remove the least frequently used item
-				delete(current.items, keyToRemove)
-				delete(c.cache, keyToRemove)
-				delete(c.values, keyToRemove)
-			}
-		}
-
-		// Add to frequency 1 node
-		if c.freqList == nil || c.freqList.freq != 1 {
-			c.freqList = &frequencyNode[K]{
-				freq:  1,
-				items: make(map[K]struct{}),
-				next:  c.freqList,
-			}
-			if c.freqList.next != nil {
-				c.freqList.next.prev = c.freqList
-			}
-		}
-		c.freqList.items[key] = struct{}{}
-		c.cache[key] = c.freqList
-	}
-
-	c.values[key] = value
 }
 
-// Remove removes a key-value pair from the cache
-func (c *LFUCache[K, V]) Remove(key K) {
-	if c.threadSafe {
-		c.mu.Lock()
-		defer c.mu.Unlock()
+// Evict removes and returns a key from the lowest non-empty frequency node
+func (p *LFUPolicy[K]) Evict() (K, bool) {
+	// Find the first non-empty frequency node
+	current := p.freqList
+	for current != nil && len(current.items) == 0 {
+		current = current.next
 	}
 
-	if node, exists := c.cache[key]; exists {
-		delete(node.items, key)
-
-		// If node becomes empty and it's not the head, remove it
-		if len(node.items) == 0 && node != c.freqList {
-			if node.prev != nil {
-				node.prev.next = node.next
-			}
-			if node.next != nil {
-				node.next.prev = node.prev
-			}
-		}
-
-		delete(c.cache, key)
-		delete(c.values, key)
+	if current == nil {
+		var zero K
+		return zero, false
 	}
-}
 
-// Clear removes all items from the cache
-func (c *LFUCache[K, V]) Clear() {
-	if c.threadSafe {
-		c.mu.Lock()
-		defer c.mu.Unlock()
+	// Get any key from the items map
+	var keyToRemove K
+	for k := range current.items {
+		keyToRemove = k
+		break
 	}
+	delete(current.items, keyToRemove)
+	delete(p.nodes, keyToRemove)
+	return keyToRemove, true
+}
 
-	c.freqList = nil
-	c.cache = make(map[K]*frequencyNode[K])
-	c.values = make(map[K]V)
+func (p *LFUPolicy[K]) Clear() {
+	p.freqList = nil
+	p.nodes = make(map[K]*frequencyNode[K])
 }
 
-// Len returns the current number of items in the cache
-func (c *LFUCache[K, V]) Len() int {
-	if c.threadSafe {
-		c.mu.RLock()
-		defer c.mu.RUnlock()
+// unlink removes key from node, dropping node from the list if it becomes
+// empty and is not the head
+func (p *LFUPolicy[K]) unlink(key K, node *frequencyNode[K]) {
+	delete(node.items, key)
+
+	if len(node.items) == 0 && node != p.freqList {
+		if node.prev != nil {
+			node.prev.next = node.next
+		}
+		if node.next != nil {
+			node.next.prev = node.prev
+		}
 	}
-	return len(c.values)
 }
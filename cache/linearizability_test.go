@@ -0,0 +1,125 @@
+package cache
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"dsgo/concurrencytest"
+)
+
+// lruModel is a sequential LRU cache: keys are ordered most recently used
+// first, and a capacity of CapacityUnlimited never evicts.
+type lruModel struct {
+	capacity int
+	keys     []int
+	values   map[int]int
+}
+
+func cloneLRU(m *lruModel) *lruModel {
+	c := &lruModel{capacity: m.capacity, keys: slices.Clone(m.keys), values: make(map[int]int, len(m.values))}
+	for k, v := range m.values {
+		c.values[k] = v
+	}
+	return c
+}
+
+func (m *lruModel) touch(key int) {
+	i := slices.Index(m.keys, key)
+	m.keys = slices.Insert(slices.Delete(m.keys, i, i+1), 0, key)
+}
+
+// cacheOps generates Get, Put, Remove and Len over more keys than the
+// capacity, so that evictions race with reads.
+func cacheOps[S Cache[int, int]](r *rand.Rand) concurrencytest.Op[S, *lruModel] {
+	key, value := r.Intn(4), r.Intn(100)
+	switch r.Intn(4) {
+	case 0:
+		return concurrencytest.Op[S, *lruModel]{
+			Name: fmt.Sprintf("Get(%d)", key),
+			Run: func(c S) any {
+				v, ok := c.Get(key)
+				return [2]any{v, ok}
+			},
+			Model: func(m *lruModel) any {
+				v, ok := m.values[key]
+				if ok {
+					m.touch(key)
+				}
+				return [2]any{v, ok}
+			},
+		}
+	case 1:
+		return concurrencytest.Op[S, *lruModel]{
+			Name: fmt.Sprintf("Put(%d, %d)", key, value),
+			Run:  func(c S) any { c.Put(key, value); return nil },
+			Model: func(m *lruModel) any {
+				if _, ok := m.values[key]; ok {
+					m.touch(key)
+				} else {
+					if full(len(m.keys), m.capacity) {
+						victim := m.keys[len(m.keys)-1]
+						m.keys = m.keys[:len(m.keys)-1]
+						delete(m.values, victim)
+					}
+					m.keys = slices.Insert(m.keys, 0, key)
+				}
+				m.values[key] = value
+				return nil
+			},
+		}
+	case 2:
+		return concurrencytest.Op[S, *lruModel]{
+			Name: fmt.Sprintf("Remove(%d)", key),
+			Run:  func(c S) any { c.Remove(key); return nil },
+			Model: func(m *lruModel) any {
+				if i := slices.Index(m.keys, key); i >= 0 {
+					m.keys = slices.Delete(m.keys, i, i+1)
+					delete(m.values, key)
+				}
+				return nil
+			},
+		}
+	default:
+		return concurrencytest.Op[S, *lruModel]{
+			Name:  "Len()",
+			Run:   func(c S) any { return c.Len() },
+			Model: func(m *lruModel) any { return len(m.values) },
+		}
+	}
+}
+
+func runCacheScript[S Cache[int, int]](t *testing.T, capacity int, newCache func() S) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		concurrencytest.Run(t, concurrencytest.Script[S, *lruModel]{
+			New: newCache,
+			NewModel: func() *lruModel {
+				return &lruModel{capacity: capacity, values: map[int]int{}}
+			},
+			Clone:   cloneLRU,
+			Threads: concurrencytest.RandomThreads(r, 4, 6, cacheOps[S]),
+			Runs:    10,
+		})
+	}
+}
+
+func TestLRUCacheLinearizable(t *testing.T) {
+	runCacheScript(t, 2, func() *LRUCache[int, int] { return NewLRUCache[int, int](2) })
+}
+
+// Without TTLs an ExpiryCache evicts exactly like an LRU cache.
+func TestExpiryCacheLinearizable(t *testing.T) {
+	runCacheScript(t, 2, func() *ExpiryCache[int, int] { return NewExpiryCache[int, int](2, 0) })
+}
+
+// LFU eviction depends on access counts the LRU model does not track, so
+// the LFU cache is checked without eviction.
+func TestLFUCacheLinearizable(t *testing.T) {
+	runCacheScript(t, CapacityUnlimited, func() *LFUCache[int, int] { return NewLFUCache[int, int](CapacityUnlimited) })
+}
+
+func TestSoftCacheLinearizable(t *testing.T) {
+	runCacheScript(t, CapacityUnlimited, func() *SoftCache[int, int] { return NewSoftCache[int, int]() })
+}
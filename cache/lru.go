@@ -1,116 +1,66 @@
 package cache
 
 import (
-	"sync"
-
 	"dsgo/linkedlist"
 )
 
 type LRUCache[K comparable, V any] struct {
-	capacity   int
-	cache      map[K]*linkedlist.DNode[K]
-	list       *linkedlist.DoubleLinkedList[K]
-	values     map[K]V
-	threadSafe bool
-	mu         sync.RWMutex
+	*PolicyCache[K, V]
 }
 
-// NewLRUCache creates a new LRU cache with the specified capacity
+// NewLRUCache creates a new LRU cache with the specified capacity, which
+// follows the rules of NewPolicyCache
 func NewLRUCache[K comparable, V any](capacity int, threadSafe ...bool) *LRUCache[K, V] {
-	isThreadSafe := true
-	if len(threadSafe) > 0 {
-		isThreadSafe = threadSafe[0]
-	}
 	return &LRUCache[K, V]{
-		capacity:   capacity,
-		cache:      make(map[K]*linkedlist.DNode[K]),
-		list:       linkedlist.NewDoubleLinkedList[K](isThreadSafe),
-		values:     make(map[K]V),
-		threadSafe: isThreadSafe,
+		PolicyCache: NewPolicyCache[K, V](capacity, NewLRUPolicy[K](), threadSafe...),
 	}
 }
 
-// Get retrieves a value from the cache and marks it as most recently used
-func (c *LRUCache[K, V]) Get(key K) (V, bool) {
-	if c.threadSafe {
-		c.mu.Lock()
-		defer c.mu.Unlock()
-	}
-
-	if _, exists := c.cache[key]; exists {
-		// Remove the node from its current position
-		c.list.Remove(key)
-		// Add it to the front (most recently used)
-		c.list.PushFront(key)
-		// Update the cache map with the new node
-		if front, err := c.list.Front(); err == nil {
-			c.cache[key] = front
-		}
-		return c.values[key], true
-	}
-	var zero V
-	return zero, false
+// WithHooks installs hooks on the underlying PolicyCache and returns c.
+func (c *LRUCache[K, V]) WithHooks(hooks Hooks[K, V]) *LRUCache[K, V] {
+	c.PolicyCache.WithHooks(hooks)
+	return c
 }
 
-// Put adds or updates a value in the cache
-func (c *LRUCache[K, V]) Put(key K, value V) {
-	if c.threadSafe {
-		c.mu.Lock()
-		defer c.mu.Unlock()
-	}
+// LRUPolicy evicts the least recently used key.
+type LRUPolicy[K comparable] struct {
+	list *linkedlist.DoubleLinkedList[K]
+}
 
-	// If key exists, update it
-	if _, exists := c.cache[key]; exists {
-		c.list.Remove(key)
-	} else if c.list.Len() >= c.capacity {
-		// If cache is full, remove the least recently used item
-		if tail, err := c.list.Back(); err == nil {
-			oldKey := tail.GetValue()
-			c.list.Remove(oldKey)
-			delete(c.cache, oldKey)
-			delete(c.values, oldKey)
-		}
+// NewLRUPolicy creates an empty least-recently-used eviction policy
+func NewLRUPolicy[K comparable]() *LRUPolicy[K] {
+	return &LRUPolicy[K]{
+		list: linkedlist.NewDoubleLinkedList[K](false),
 	}
+}
 
-	// Add the new key to the front
-	c.list.PushFront(key)
-	if front, err := c.list.Front(); err == nil {
-		c.cache[key] = front
-	}
-	c.values[key] = value
+// Admit adds the key to the front (most recently used)
+func (p *LRUPolicy[K]) Admit(key K) {
+	p.list.PushFront(key)
 }
 
-// Remove removes a key-value pair from the cache
-func (c *LRUCache[K, V]) Remove(key K) {
-	if c.threadSafe {
-		c.mu.Lock()
-		defer c.mu.Unlock()
-	}
+// Touch moves the key to the front (most recently used)
+func (p *LRUPolicy[K]) Touch(key K) {
+	p.list.Remove(key)
+	p.list.PushFront(key)
+}
 
-	if _, exists := c.cache[key]; exists {
-		c.list.Remove(key)
-		delete(c.cache, key)
-		delete(c.values, key)
-	}
+func (p *LRUPolicy[K]) Remove(key K) {
+	p.list.Remove(key)
 }
 
-// Clear removes all items from the cache
-func (c *LRUCache[K, V]) Clear() {
-	if c.threadSafe {
-		c.mu.Lock()
-		defer c.mu.Unlock()
+// Evict removes and returns the least recently used key
+func (p *LRUPolicy[K]) Evict() (K, bool) {
+	tail, err := p.list.Back()
+	if err != nil {
+		var zero K
+		return zero, false
 	}
-
-	c.list.Clear()
-	c.cache = make(map[K]*linkedlist.DNode[K])
-	c.values = make(map[K]V)
+	key := tail.GetValue()
+	p.list.Remove(key)
+	return key, true
 }
 
-// Len returns the current number of items in the cache
-func (c *LRUCache[K, V]) Len() int {
-	if c.threadSafe {
-		c.mu.RLock()
-		defer c.mu.RUnlock()
-	}
-	return c.list.Len()
+func (p *LRUPolicy[K]) Clear() {
+	p.list.Clear()
 }
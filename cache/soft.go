@@ -0,0 +1,216 @@
+package cache
+
+import (
+	"math"
+	"runtime"
+	"runtime/debug"
+	"runtime/metrics"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SoftOptions configures the memory-pressure trimming of a SoftCache.
+type SoftOptions struct {
+	// AutoTrim checks heap usage after every garbage collection and trims
+	// the cache while it is above HeapLimit.
+	AutoTrim bool
+	// HeapLimit is the live heap size, in bytes, above which AutoTrim
+	// releases entries. Zero uses the runtime memory limit set with
+	// debug.SetMemoryLimit or GOMEMLIMIT; if neither is set AutoTrim does
+	// nothing.
+	HeapLimit uint64
+	// TrimFraction is the fraction of entries released per trim, least
+	// recently used first. Zero means 0.25.
+	TrimFraction float64
+}
+
+type softEntry[V any] struct {
+	value      V
+	lastAccess time.Time
+}
+
+// SoftCache is an unbounded cache whose entries can be reclaimed when they
+// go idle or the process runs short of memory, in the spirit of soft
+// references. Idle entries are released explicitly with ReleaseIdle; with
+// AutoTrim, a finalizer re-armed on every GC cycle trims the least recently
+// used entries whenever the heap is above the limit. A SoftCache with
+// AutoTrim stays reachable from the GC hook until Close is called.
+type SoftCache[K comparable, V any] struct {
+	entries map[K]*softEntry[V]
+	opts    SoftOptions
+	now     func() time.Time
+	hooks   hookSet[K, V]
+	closed  atomic.Bool
+	mu      sync.Mutex
+}
+
+// NewSoftCache creates an empty soft cache.
+func NewSoftCache[K comparable, V any](opts ...SoftOptions) *SoftCache[K, V] {
+	var opt SoftOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.TrimFraction <= 0 || opt.TrimFraction > 1 {
+		opt.TrimFraction = 0.25
+	}
+	c := &SoftCache[K, V]{
+		entries: make(map[K]*softEntry[V]),
+		opts:    opt,
+		now:     time.Now,
+	}
+	if opt.AutoTrim {
+		onGC(func() bool {
+			if c.closed.Load() {
+				return false
+			}
+			// Finalizers run on a single goroutine; keep the hook cheap
+			go c.trimIfPressured()
+			return true
+		})
+	}
+	return c
+}
+
+// gcSentinel is an unreachable object whose finalizer runs once per GC cycle
+// and re-arms itself for the next one while fn returns true.
+type gcSentinel struct {
+	fn func() bool
+}
+
+func onGC(fn func() bool) {
+	runtime.SetFinalizer(&gcSentinel{fn: fn}, func(s *gcSentinel) {
+		if s.fn() {
+			onGC(s.fn)
+		}
+	})
+}
+
+// WithHooks installs hooks that observe Get, Put, Remove and Clear,
+// replacing any installed before, and returns the cache. Entries dropped by
+// trimming are not reported.
+func (c *SoftCache[K, V]) WithHooks(hooks Hooks[K, V]) *SoftCache[K, V] {
+	c.hooks.set(hooks)
+	return c
+}
+
+// Get retrieves a value and marks it as recently used
+func (c *SoftCache[K, V]) Get(key K) (value V, ok bool) {
+	if after := c.hooks.get(key); after != nil {
+		defer func() { after(value, ok) }()
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, exists := c.entries[key]
+	if !exists {
+		var zero V
+		return zero, false
+	}
+	entry.lastAccess = c.now()
+	return entry.value, true
+}
+
+// Put adds or updates a value
+func (c *SoftCache[K, V]) Put(key K, value V) {
+	if after := c.hooks.put(key, value); after != nil {
+		defer after()
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = &softEntry[V]{value: value, lastAccess: c.now()}
+}
+
+// Remove removes a key-value pair from the cache
+func (c *SoftCache[K, V]) Remove(key K) {
+	if after := c.hooks.remove(key); after != nil {
+		defer after()
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
+
+// Clear removes all items from the cache
+func (c *SoftCache[K, V]) Clear() {
+	if after := c.hooks.clear(); after != nil {
+		defer after()
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[K]*softEntry[V])
+}
+
+// Len returns the current number of items in the cache
+func (c *SoftCache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// ReleaseIdle removes every entry not accessed within olderThan and returns
+// how many were removed
+func (c *SoftCache[K, V]) ReleaseIdle(olderThan time.Duration) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cutoff := c.now().Add(-olderThan)
+	released := 0
+	for key, entry := range c.entries {
+		if entry.lastAccess.Before(cutoff) {
+			delete(c.entries, key)
+			released++
+		}
+	}
+	return released
+}
+
+// Trim removes the given fraction of entries, least recently used first,
+// and returns how many were removed
+func (c *SoftCache[K, V]) Trim(fraction float64) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n := int(math.Ceil(float64(len(c.entries)) * fraction))
+	if n <= 0 {
+		return 0
+	}
+
+	keys := make([]K, 0, len(c.entries))
+	for key := range c.entries {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b K) int {
+		return c.entries[a].lastAccess.Compare(c.entries[b].lastAccess)
+	})
+	for _, key := range keys[:min(n, len(keys))] {
+		delete(c.entries, key)
+	}
+	return min(n, len(keys))
+}
+
+// Close stops automatic trimming so the cache can be garbage collected
+func (c *SoftCache[K, V]) Close() {
+	c.closed.Store(true)
+}
+
+func (c *SoftCache[K, V]) trimIfPressured() {
+	limit := c.opts.HeapLimit
+	if limit == 0 {
+		// A negative argument reads the limit without changing it
+		if l := debug.SetMemoryLimit(-1); l != math.MaxInt64 {
+			limit = uint64(l)
+		}
+	}
+	if limit == 0 || heapInUse() <= limit {
+		return
+	}
+	c.Trim(c.opts.TrimFraction)
+}
+
+func heapInUse() uint64 {
+	sample := []metrics.Sample{{Name: "/memory/classes/heap/objects:bytes"}}
+	metrics.Read(sample)
+	if sample[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return sample[0].Value.Uint64()
+}
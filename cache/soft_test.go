@@ -0,0 +1,70 @@
+package cache
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestSoftCacheReleaseIdle(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	c := NewSoftCache[string, int]()
+	c.now = clock.Now
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+	clock.Advance(time.Minute)
+	c.Get("a")
+	c.Put("c", 3)
+	clock.Advance(30 * time.Second)
+
+	if n := c.ReleaseIdle(45 * time.Second); n != 1 {
+		t.Errorf("ReleaseIdle() = %d, want 1", n)
+	}
+	if _, ok := c.Get("b"); ok {
+		t.Error("Expected idle entry 'b' to be released")
+	}
+	if val, ok := c.Get("a"); !ok || val != 1 {
+		t.Errorf("Get(a) = %d, %v, want 1, true", val, ok)
+	}
+}
+
+func TestSoftCacheTrim(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	c := NewSoftCache[int, int]()
+	c.now = clock.Now
+
+	for i := 0; i < 8; i++ {
+		c.Put(i, i)
+		clock.Advance(time.Second)
+	}
+	if n := c.Trim(0.25); n != 2 {
+		t.Errorf("Trim(0.25) = %d, want 2", n)
+	}
+	for i := 0; i < 2; i++ {
+		if _, ok := c.Get(i); ok {
+			t.Errorf("Expected least recently used entry %d to be trimmed", i)
+		}
+	}
+	if c.Len() != 6 {
+		t.Errorf("Len() = %d, want 6", c.Len())
+	}
+}
+
+func TestSoftCacheAutoTrim(t *testing.T) {
+	// A one-byte limit means the heap is always under pressure
+	c := NewSoftCache[int, []byte](SoftOptions{AutoTrim: true, HeapLimit: 1, TrimFraction: 0.5})
+	defer c.Close()
+	for i := 0; i < 100; i++ {
+		c.Put(i, make([]byte, 1024))
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for c.Len() == 100 {
+		if time.Now().After(deadline) {
+			t.Fatal("cache was not trimmed after garbage collection")
+		}
+		runtime.GC()
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,180 @@
+package cache
+
+import (
+	"sync"
+
+	"dsgo/syncx"
+)
+
+// ErrorPolicy decides what a read-through or write-through cache does when
+// its backing store fails.
+type ErrorPolicy int
+
+const (
+	// FailClosed surfaces backing store errors to the caller. A failed write
+	// leaves the cache untouched.
+	FailClosed ErrorPolicy = iota
+	// FailOpen hides backing store errors from the caller: a failed load is
+	// treated as a miss and a failed write still updates the cache. Errors
+	// are reported to OnError.
+	FailOpen
+)
+
+// ThroughOptions configures WithReadThrough and WithWriteThrough.
+type ThroughOptions struct {
+	Policy ErrorPolicy
+	// OnError, if set, is called with every backing store error, whatever
+	// the policy.
+	OnError func(err error)
+}
+
+func throughOptions(opts []ThroughOptions) ThroughOptions {
+	if len(opts) > 0 {
+		return opts[0]
+	}
+	return ThroughOptions{}
+}
+
+func (o ThroughOptions) report(err error) {
+	if o.OnError != nil {
+		o.OnError(err)
+	}
+}
+
+// ReadThroughCache loads missing keys from a backing store and caches them.
+// Concurrent misses on the same key share a single load. A load that
+// finishes after a Put or Remove of its key is returned to its callers but
+// not cached, so it cannot overwrite the newer entry.
+type ReadThroughCache[K comparable, V any] struct {
+	Cache[K, V]
+	loader  func(key K) (V, error)
+	opts    ThroughOptions
+	loading syncx.KeyedMutex[K]
+	// mu guards pending, which marks the loads in flight that a Put or
+	// Remove has made stale
+	mu      sync.Mutex
+	pending map[K]*bool
+}
+
+// WithReadThrough wraps c so that misses are filled by loader.
+func WithReadThrough[K comparable, V any](c Cache[K, V], loader func(key K) (V, error), opts ...ThroughOptions) *ReadThroughCache[K, V] {
+	return &ReadThroughCache[K, V]{
+		Cache:   c,
+		loader:  loader,
+		opts:    throughOptions(opts),
+		pending: make(map[K]*bool),
+	}
+}
+
+// Load returns the cached value for key, loading and caching it on a miss.
+// Under FailOpen a load error is reported to OnError and Load returns the
+// zero value with a nil error.
+func (c *ReadThroughCache[K, V]) Load(key K) (V, error) {
+	value, err := c.load(key)
+	if err != nil && c.opts.Policy == FailOpen {
+		return value, nil
+	}
+	return value, err
+}
+
+// Get is Load without the error: it reports false if key could not be loaded
+func (c *ReadThroughCache[K, V]) Get(key K) (V, bool) {
+	value, err := c.load(key)
+	return value, err == nil
+}
+
+func (c *ReadThroughCache[K, V]) load(key K) (V, error) {
+	if value, ok := c.Cache.Get(key); ok {
+		return value, nil
+	}
+
+	c.loading.Lock(key)
+	defer c.loading.Unlock(key)
+	// Another goroutine may have loaded key while we waited
+	if value, ok := c.Cache.Get(key); ok {
+		return value, nil
+	}
+
+	stale := new(bool)
+	c.mu.Lock()
+	c.pending[key] = stale
+	c.mu.Unlock()
+	value, err := c.loader(key)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.pending, key)
+	if err != nil {
+		c.opts.report(err)
+		var zero V
+		return zero, err
+	}
+	if !*stale {
+		c.Cache.Put(key, value)
+	}
+	return value, nil
+}
+
+// Put caches value, superseding any load of key still in flight.
+func (c *ReadThroughCache[K, V]) Put(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.invalidate(key)
+	c.Cache.Put(key, value)
+}
+
+// Remove drops key, superseding any load of key still in flight.
+func (c *ReadThroughCache[K, V]) Remove(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.invalidate(key)
+	c.Cache.Remove(key)
+}
+
+// invalidate marks a load of key in flight as stale. The caller must hold
+// c.mu.
+func (c *ReadThroughCache[K, V]) invalidate(key K) {
+	if stale, ok := c.pending[key]; ok {
+		*stale = true
+	}
+}
+
+// WriteThroughCache writes every Put to a backing store before caching it.
+// Writes to the same key are serialized, so the store and the cache end up
+// holding the same value.
+type WriteThroughCache[K comparable, V any] struct {
+	Cache[K, V]
+	writer  func(key K, value V) error
+	opts    ThroughOptions
+	writing syncx.KeyedMutex[K]
+}
+
+// WithWriteThrough wraps c so that every Put is first written with writer.
+func WithWriteThrough[K comparable, V any](c Cache[K, V], writer func(key K, value V) error, opts ...ThroughOptions) *WriteThroughCache[K, V] {
+	return &WriteThroughCache[K, V]{
+		Cache:  c,
+		writer: writer,
+		opts:   throughOptions(opts),
+	}
+}
+
+// Store writes value to the backing store and then caches it. Under
+// FailClosed a write error is returned and the cache is left untouched;
+// under FailOpen the value is cached anyway and Store returns nil.
+func (c *WriteThroughCache[K, V]) Store(key K, value V) error {
+	c.writing.Lock(key)
+	defer c.writing.Unlock(key)
+	if err := c.writer(key, value); err != nil {
+		c.opts.report(err)
+		if c.opts.Policy == FailClosed {
+			return err
+		}
+	}
+	c.Cache.Put(key, value)
+	return nil
+}
+
+// Put is Store without the error; failures are only visible through OnError
+func (c *WriteThroughCache[K, V]) Put(key K, value V) {
+	c.Store(key, value)
+}
@@ -0,0 +1,188 @@
+package cache
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var errBackend = errors.New("backend unavailable")
+
+func TestReadThrough(t *testing.T) {
+	var loads atomic.Int32
+	store := map[string]int{"a": 1}
+	c := WithReadThrough[string, int](NewLRUCache[string, int](10), func(key string) (int, error) {
+		loads.Add(1)
+		if value, ok := store[key]; ok {
+			return value, nil
+		}
+		return 0, errBackend
+	})
+
+	if val, err := c.Load("a"); err != nil || val != 1 {
+		t.Errorf("Load(a) = %d, %v, want 1, nil", val, err)
+	}
+	if val, ok := c.Get("a"); !ok || val != 1 {
+		t.Errorf("Get(a) = %d, %v, want 1, true", val, ok)
+	}
+	if loads.Load() != 1 {
+		t.Errorf("loader called %d times, want 1", loads.Load())
+	}
+
+	if _, err := c.Load("missing"); err != errBackend {
+		t.Errorf("Load(missing) error = %v, want errBackend", err)
+	}
+	if _, ok := c.Get("missing"); ok {
+		t.Error("Get(missing) should report false")
+	}
+}
+
+func TestReadThroughFailOpen(t *testing.T) {
+	var reported []error
+	c := WithReadThrough[string, int](NewLRUCache[string, int](10), func(key string) (int, error) {
+		return 0, errBackend
+	}, ThroughOptions{Policy: FailOpen, OnError: func(err error) { reported = append(reported, err) }})
+
+	if val, err := c.Load("a"); err != nil || val != 0 {
+		t.Errorf("Load(a) = %d, %v, want 0, nil", val, err)
+	}
+	if len(reported) != 1 || reported[0] != errBackend {
+		t.Errorf("OnError received %v, want [errBackend]", reported)
+	}
+}
+
+func TestReadThroughSharesLoads(t *testing.T) {
+	var loads atomic.Int32
+	c := WithReadThrough[int, int](NewLRUCache[int, int](10), func(key int) (int, error) {
+		loads.Add(1)
+		return key * 2, nil
+	})
+	var wg sync.WaitGroup
+
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.Get(7)
+		}()
+	}
+	wg.Wait()
+
+	if loads.Load() != 1 {
+		t.Errorf("loader called %d times, want 1", loads.Load())
+	}
+}
+
+func TestWriteThrough(t *testing.T) {
+	store := make(map[string]int)
+	fail := false
+	writer := func(key string, value int) error {
+		if fail {
+			return errBackend
+		}
+		store[key] = value
+		return nil
+	}
+
+	closed := WithWriteThrough[string, int](NewLRUCache[string, int](10), writer)
+	if err := closed.Store("a", 1); err != nil || store["a"] != 1 {
+		t.Errorf("Store(a) = %v, backing store has %d", err, store["a"])
+	}
+	fail = true
+	if err := closed.Store("b", 2); err != errBackend {
+		t.Errorf("Store(b) error = %v, want errBackend", err)
+	}
+	if _, ok := closed.Get("b"); ok {
+		t.Error("FailClosed should not cache a value that failed to write")
+	}
+
+	open := WithWriteThrough[string, int](NewLRUCache[string, int](10), writer, ThroughOptions{Policy: FailOpen})
+	open.Put("c", 3)
+	if val, ok := open.Get("c"); !ok || val != 3 {
+		t.Errorf("FailOpen should cache the value anyway, got %d, %v", val, ok)
+	}
+}
+
+func TestReadWriteThroughComposition(t *testing.T) {
+	store := map[string]int{"a": 1}
+	var c Cache[string, int] = NewLRUCache[string, int](10)
+	c = WithReadThrough(c, func(key string) (int, error) {
+		value, ok := store[key]
+		if !ok {
+			return 0, errBackend
+		}
+		return value, nil
+	})
+	c = WithWriteThrough(c, func(key string, value int) error {
+		store[key] = value
+		return nil
+	})
+
+	c.Put("b", 2)
+	if store["b"] != 2 {
+		t.Error("Put should write through to the backing store")
+	}
+	if val, ok := c.Get("a"); !ok || val != 1 {
+		t.Errorf("Get(a) = %d, %v, want 1, true", val, ok)
+	}
+}
+
+func TestReadThroughLoadSupersededByPut(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	inner := NewLRUCache[string, int](10)
+	c := WithReadThrough[string, int](inner, func(key string) (int, error) {
+		close(started)
+		<-release
+		return 1, nil
+	})
+	done := make(chan int)
+	go func() {
+		value, _ := c.Get("a")
+		done <- value
+	}()
+	<-started
+	c.Put("a", 2)
+	close(release)
+	if value := <-done; value != 1 {
+		t.Errorf("Get(a) = %d, want the loaded 1", value)
+	}
+	if value, _ := inner.Get("a"); value != 2 {
+		t.Errorf("cached a = %d after a slow load, want the newer Put's 2", value)
+	}
+}
+
+func TestWriteThroughSerializesPuts(t *testing.T) {
+	var mu sync.Mutex
+	store := make(map[string]int)
+	second := make(chan struct{})
+	inner := NewLRUCache[string, int](10)
+	c := WithWriteThrough[string, int](inner, func(key string, value int) error {
+		mu.Lock()
+		store[key] = value
+		mu.Unlock()
+		if value == 1 {
+			// Give the second Put the chance to overtake this one
+			select {
+			case <-second:
+			case <-time.After(50 * time.Millisecond):
+			}
+		} else {
+			close(second)
+		}
+		return nil
+	})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		c.Put("a", 1)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	c.Put("a", 2)
+	wg.Wait()
+	if cached, _ := inner.Get("a"); cached != store["a"] {
+		t.Errorf("cache holds %d but the store holds %d", cached, store["a"])
+	}
+}
@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"sync/atomic"
+
+	"dsgo/syncx"
+)
+
+// TierStats counts the lookups answered by one tier of a TieredCache.
+type TierStats struct {
+	Hits   uint64
+	Misses uint64
+}
+
+// TieredStats holds the statistics of both tiers of a TieredCache.
+type TieredStats struct {
+	L1 TierStats
+	L2 TierStats
+}
+
+type tierCounters struct {
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+func (c *tierCounters) record(hit bool) {
+	if hit {
+		c.hits.Add(1)
+	} else {
+		c.misses.Add(1)
+	}
+}
+
+func (c *tierCounters) stats() TierStats {
+	return TierStats{Hits: c.hits.Load(), Misses: c.misses.Load()}
+}
+
+// TieredCache layers a small, fast L1 cache over a larger L2 cache. Gets
+// check L1 first and promote L2 hits into L1; Puts write through to both
+// tiers. Both tiers must be safe for concurrent use if the tiered cache is.
+type TieredCache[K comparable, V any] struct {
+	l1, l2 Cache[K, V]
+	l1Stat tierCounters
+	l2Stat tierCounters
+	keys   syncx.KeyedMutex[K]
+}
+
+// NewTieredCache creates a tiered cache over l1 and l2
+func NewTieredCache[K comparable, V any](l1, l2 Cache[K, V]) *TieredCache[K, V] {
+	return &TieredCache[K, V]{l1: l1, l2: l2}
+}
+
+// Get looks key up in L1, then in L2, promoting an L2 hit into L1
+func (c *TieredCache[K, V]) Get(key K) (V, bool) {
+	if value, ok := c.l1.Get(key); ok {
+		c.l1Stat.record(true)
+		return value, true
+	}
+	c.l1Stat.record(false)
+
+	// Hold the key while promoting so a concurrent Put cannot be overwritten
+	// in L1 by the older value read from L2
+	c.keys.Lock(key)
+	defer c.keys.Unlock(key)
+	value, ok := c.l2.Get(key)
+	c.l2Stat.record(ok)
+	if ok {
+		c.l1.Put(key, value)
+	}
+	return value, ok
+}
+
+// Put writes value to both tiers
+func (c *TieredCache[K, V]) Put(key K, value V) {
+	c.keys.Lock(key)
+	defer c.keys.Unlock(key)
+	c.l2.Put(key, value)
+	c.l1.Put(key, value)
+}
+
+// Remove removes key from both tiers
+func (c *TieredCache[K, V]) Remove(key K) {
+	c.keys.Lock(key)
+	defer c.keys.Unlock(key)
+	c.l1.Remove(key)
+	c.l2.Remove(key)
+}
+
+// Clear empties both tiers
+func (c *TieredCache[K, V]) Clear() {
+	c.l1.Clear()
+	c.l2.Clear()
+}
+
+// Len returns the number of items in L2, which holds every entry written
+// through the tiered cache unless L2 has since evicted it
+func (c *TieredCache[K, V]) Len() int {
+	return c.l2.Len()
+}
+
+// Stats returns the hit and miss counts of each tier. L2 is only consulted
+// on an L1 miss, so L2 lookups equal L1 misses.
+func (c *TieredCache[K, V]) Stats() TieredStats {
+	return TieredStats{L1: c.l1Stat.stats(), L2: c.l2Stat.stats()}
+}
@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestTieredCache(t *testing.T) {
+	l1 := NewLRUCache[string, int](2)
+	l2 := NewLRUCache[string, int](10)
+	c := NewTieredCache[string, int](l1, l2)
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Put("c", 3)
+	if l1.Len() != 2 || l2.Len() != 3 {
+		t.Errorf("tier lengths = %d, %d, want 2, 3", l1.Len(), l2.Len())
+	}
+
+	// "a" was evicted from L1 but is still in L2
+	if val, ok := c.Get("a"); !ok || val != 1 {
+		t.Errorf("Get(a) = %d, %v, want 1, true", val, ok)
+	}
+	if _, ok := l1.Get("a"); !ok {
+		t.Error("L2 hit should be promoted into L1")
+	}
+	c.Get("a")
+	c.Get("missing")
+
+	stats := c.Stats()
+	expected := TieredStats{L1: TierStats{Hits: 1, Misses: 2}, L2: TierStats{Hits: 1, Misses: 1}}
+	if stats != expected {
+		t.Errorf("Stats() = %+v, want %+v", stats, expected)
+	}
+
+	c.Remove("a")
+	if _, ok := c.Get("a"); ok {
+		t.Error("Remove should remove the key from both tiers")
+	}
+	c.Clear()
+	if c.Len() != 0 || l1.Len() != 0 {
+		t.Error("Clear should empty both tiers")
+	}
+}
+
+func TestTieredCacheConcurrent(t *testing.T) {
+	c := NewTieredCache[int, int](NewLRUCache[int, int](10), NewLFUCache[int, int](100))
+	var wg sync.WaitGroup
+
+	for i := 0; i < 1000; i++ {
+		wg.Add(1)
+		go func(val int) {
+			defer wg.Done()
+			c.Put(val%50, val)
+			c.Get(val % 50)
+		}(i)
+	}
+	wg.Wait()
+
+	stats := c.Stats()
+	if stats.L1.Hits+stats.L1.Misses != 1000 {
+		t.Errorf("L1 lookups = %d, want 1000", stats.L1.Hits+stats.L1.Misses)
+	}
+}
@@ -0,0 +1,122 @@
+package cache
+
+import (
+	"time"
+
+	"dsgo/utils"
+)
+
+// LockID returns the cache's stable identifier, used by dsgo.Atomic to lock
+// structures in a canonical order.
+func (c *PolicyCache[K, V]) LockID() uint64 {
+	return utils.LazyID(&c.id)
+}
+
+// Lock takes the cache's write lock. It exists for dsgo.Atomic; prefer the
+// cache's own methods, which lock for you.
+func (c *PolicyCache[K, V]) Lock() {
+	c.mu.Lock()
+}
+
+// Unlock releases the lock taken by Lock.
+func (c *PolicyCache[K, V]) Unlock() {
+	c.mu.Unlock()
+}
+
+// InTx returns an unlocked view of the cache for use inside a dsgo.Atomic
+// callback that holds this cache's lock. It panics with utils.ErrNotInTx
+// otherwise. Hooks are not called for operations made through the view, and
+// the view must not be used after the callback returns.
+func (c *PolicyCache[K, V]) InTx(tx interface{ Holds(any) bool }) *PolicyCacheTx[K, V] {
+	if !tx.Holds(c) {
+		panic(utils.ErrNotInTx)
+	}
+	return &PolicyCacheTx[K, V]{c: c}
+}
+
+// PolicyCacheTx is the unlocked view of a PolicyCache returned by InTx.
+type PolicyCacheTx[K comparable, V any] struct {
+	c *PolicyCache[K, V]
+}
+
+func (t *PolicyCacheTx[K, V]) Get(key K) (V, bool) {
+	return t.c.get(key)
+}
+
+func (t *PolicyCacheTx[K, V]) Put(key K, value V) {
+	t.c.put(key, value)
+}
+
+func (t *PolicyCacheTx[K, V]) Remove(key K) {
+	t.c.tryRemove(key)
+}
+
+func (t *PolicyCacheTx[K, V]) TryRemove(key K) (V, bool) {
+	return t.c.tryRemove(key)
+}
+
+func (t *PolicyCacheTx[K, V]) Clear() {
+	t.c.clear()
+}
+
+func (t *PolicyCacheTx[K, V]) Len() int {
+	return len(t.c.values)
+}
+
+// LockID returns the cache's stable identifier, used by dsgo.Atomic to lock
+// structures in a canonical order.
+func (c *ExpiryCache[K, V]) LockID() uint64 {
+	return utils.LazyID(&c.id)
+}
+
+// Lock takes the cache's lock. It exists for dsgo.Atomic; prefer the
+// cache's own methods, which lock for you.
+func (c *ExpiryCache[K, V]) Lock() {
+	c.mu.Lock()
+}
+
+// Unlock releases the lock taken by Lock.
+func (c *ExpiryCache[K, V]) Unlock() {
+	c.mu.Unlock()
+}
+
+// InTx returns an unlocked view of the cache for use inside a dsgo.Atomic
+// callback that holds this cache's lock. It panics with utils.ErrNotInTx
+// otherwise. Hooks are not called for operations made through the view, and
+// the view must not be used after the callback returns.
+func (c *ExpiryCache[K, V]) InTx(tx interface{ Holds(any) bool }) *ExpiryCacheTx[K, V] {
+	if !tx.Holds(c) {
+		panic(utils.ErrNotInTx)
+	}
+	return &ExpiryCacheTx[K, V]{c: c}
+}
+
+// ExpiryCacheTx is the unlocked view of an ExpiryCache returned by InTx.
+type ExpiryCacheTx[K comparable, V any] struct {
+	c *ExpiryCache[K, V]
+}
+
+func (t *ExpiryCacheTx[K, V]) Get(key K) (V, bool) {
+	value, _, ok := t.c.get(key)
+	return value, ok
+}
+
+func (t *ExpiryCacheTx[K, V]) Put(key K, value V) {
+	t.c.put(key, value, t.c.defaultTTL)
+}
+
+func (t *ExpiryCacheTx[K, V]) PutWithTTL(key K, value V, ttl time.Duration) {
+	t.c.put(key, value, ttl)
+}
+
+func (t *ExpiryCacheTx[K, V]) Remove(key K) {
+	t.c.remove(key)
+}
+
+func (t *ExpiryCacheTx[K, V]) Clear() {
+	t.c.clear()
+}
+
+func (t *ExpiryCacheTx[K, V]) Len() int {
+	return len(t.c.entries)
+}
@@ -0,0 +1,178 @@
+package changelog
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"dsgo/codec"
+)
+
+// Op identifies the mutation a Record describes.
+type Op byte
+
+const (
+	OpSet Op = iota + 1
+	OpDelete
+	OpClear
+)
+
+// MaxFieldSize is the largest encoded key or value a record may hold.
+// Writer refuses larger fields and Reader treats a longer length prefix as
+// corruption instead of allocating it.
+const MaxFieldSize = 64 << 20
+
+var (
+	ErrCorrupt       = errors.New("changelog: corrupt record")
+	ErrFieldTooLarge = errors.New("changelog: field larger than MaxFieldSize")
+)
+
+// Record is a single mutation. Value is only meaningful for OpSet and Key is
+// unused for OpClear.
+type Record[K any, V any] struct {
+	Op    Op
+	Key   K
+	Value V
+}
+
+// Writer appends records to an io.Writer. Each record is encoded as
+//
+//	op byte | uvarint key length | key | uvarint value length | value
+//
+// with the key omitted for OpClear and the value only present for OpSet.
+// Writer does no buffering of its own; wrap the destination in a
+// bufio.Writer and flush it to trade durability for throughput.
+type Writer[K any, V any] struct {
+	w      io.Writer
+	keys   codec.Codec[K]
+	values codec.Codec[V]
+	buf    []byte
+}
+
+func NewWriter[K any, V any](w io.Writer, keys codec.Codec[K], values codec.Codec[V]) *Writer[K, V] {
+	return &Writer[K, V]{
+		w:      w,
+		keys:   keys,
+		values: values,
+	}
+}
+
+// Write encodes rec and writes it with a single call to the underlying writer.
+func (w *Writer[K, V]) Write(rec Record[K, V]) error {
+	buf := append(w.buf[:0], byte(rec.Op))
+	if rec.Op != OpClear {
+		key, err := w.keys.Encode(rec.Key)
+		if err != nil {
+			return err
+		}
+		if len(key) > MaxFieldSize {
+			return ErrFieldTooLarge
+		}
+		buf = binary.AppendUvarint(buf, uint64(len(key)))
+		buf = append(buf, key...)
+	}
+	if rec.Op == OpSet {
+		value, err := w.values.Encode(rec.Value)
+		if err != nil {
+			return err
+		}
+		if len(value) > MaxFieldSize {
+			return ErrFieldTooLarge
+		}
+		buf = binary.AppendUvarint(buf, uint64(len(value)))
+		buf = append(buf, value...)
+	}
+	w.buf = buf
+	_, err := w.w.Write(buf)
+	return err
+}
+
+// Reader decodes records written by a Writer.
+type Reader[K any, V any] struct {
+	r      *bufio.Reader
+	keys   codec.Codec[K]
+	values codec.Codec[V]
+}
+
+func NewReader[K any, V any](r io.Reader, keys codec.Codec[K], values codec.Codec[V]) *Reader[K, V] {
+	return &Reader[K, V]{
+		r:      bufio.NewReader(r),
+		keys:   keys,
+		values: values,
+	}
+}
+
+// Next returns the next record. It returns io.EOF when the log ends cleanly
+// and io.ErrUnexpectedEOF when it ends in the middle of a record, as happens
+// after a crash during a write.
+func (r *Reader[K, V]) Next() (Record[K, V], error) {
+	var rec Record[K, V]
+	op, err := r.r.ReadByte()
+	if err != nil {
+		return rec, err
+	}
+	rec.Op = Op(op)
+	if rec.Op < OpSet || rec.Op > OpClear {
+		return rec, ErrCorrupt
+	}
+
+	if rec.Op != OpClear {
+		data, err := r.readField()
+		if err != nil {
+			return rec, err
+		}
+		if rec.Key, err = r.keys.Decode(data); err != nil {
+			return rec, err
+		}
+	}
+	if rec.Op == OpSet {
+		data, err := r.readField()
+		if err != nil {
+			return rec, err
+		}
+		if rec.Value, err = r.values.Decode(data); err != nil {
+			return rec, err
+		}
+	}
+	return rec, nil
+}
+
+func (r *Reader[K, V]) readField() ([]byte, error) {
+	n, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		if err == io.EOF {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	if n > MaxFieldSize {
+		return nil, ErrCorrupt
+	}
+	data := make([]byte, n)
+	if _, err := io.ReadFull(r.r, data); err != nil {
+		if err == io.EOF {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+// Replay reads every record from r and passes it to apply, stopping at the
+// first error returned by either.
+func Replay[K any, V any](r io.Reader, keys codec.Codec[K], values codec.Codec[V], apply func(Record[K, V]) error) error {
+	reader := NewReader(r, keys, values)
+	for {
+		rec, err := reader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := apply(rec); err != nil {
+			return err
+		}
+	}
+}
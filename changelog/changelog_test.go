@@ -0,0 +1,83 @@
+package changelog
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+
+	"dsgo/codec"
+)
+
+func TestWriterReaderRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, codec.Default[string](), codec.Default[int]())
+
+	records := []Record[string, int]{
+		{Op: OpSet, Key: "a", Value: 1},
+		{Op: OpSet, Key: "b", Value: -2},
+		{Op: OpDelete, Key: "a"},
+		{Op: OpClear},
+	}
+	for _, rec := range records {
+		if err := w.Write(rec); err != nil {
+			t.Fatalf("Write(%v) error: %v", rec, err)
+		}
+	}
+
+	r := NewReader(&buf, codec.Default[string](), codec.Default[int]())
+	for i, want := range records {
+		got, err := r.Next()
+		if err != nil {
+			t.Fatalf("Next() #%d error: %v", i, err)
+		}
+		if got != want {
+			t.Errorf("Next() #%d = %v, want %v", i, got, want)
+		}
+	}
+	if _, err := r.Next(); err != io.EOF {
+		t.Errorf("Next() at end = %v, want io.EOF", err)
+	}
+}
+
+func TestReaderTruncatedAndCorrupt(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, codec.Default[string](), codec.Default[string]())
+	w.Write(Record[string, string]{Op: OpSet, Key: "key", Value: "value"})
+
+	truncated := buf.Bytes()[:buf.Len()-2]
+	r := NewReader(bytes.NewReader(truncated), codec.Default[string](), codec.Default[string]())
+	if _, err := r.Next(); err != io.ErrUnexpectedEOF {
+		t.Errorf("Next() on truncated log = %v, want io.ErrUnexpectedEOF", err)
+	}
+
+	r = NewReader(bytes.NewReader([]byte{42}), codec.Default[string](), codec.Default[string]())
+	if _, err := r.Next(); err != ErrCorrupt {
+		t.Errorf("Next() on unknown op = %v, want ErrCorrupt", err)
+	}
+
+	// A forged key length is rejected before it is allocated
+	forged := binary.AppendUvarint([]byte{byte(OpDelete)}, 1<<62)
+	r = NewReader(bytes.NewReader(forged), codec.Default[string](), codec.Default[string]())
+	if _, err := r.Next(); err != ErrCorrupt {
+		t.Errorf("Next() on forged length = %v, want ErrCorrupt", err)
+	}
+}
+
+func TestReplayStopsOnApplyError(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, codec.Default[int](), codec.Default[int]())
+	w.Write(Record[int, int]{Op: OpSet, Key: 1, Value: 1})
+	w.Write(Record[int, int]{Op: OpSet, Key: 2, Value: 2})
+
+	stop := errors.New("stop")
+	applied := 0
+	err := Replay(&buf, codec.Default[int](), codec.Default[int](), func(Record[int, int]) error {
+		applied++
+		return stop
+	})
+	if err != stop || applied != 1 {
+		t.Errorf("Replay() = %v after %d records, want stop after 1", err, applied)
+	}
+}
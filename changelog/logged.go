@@ -0,0 +1,192 @@
+package changelog
+
+import (
+	"io"
+	"sync"
+
+	"dsgo/cache"
+	"dsgo/codec"
+)
+
+// Map is the subset of the map API a LoggedMap mirrors. OrderedMap,
+// SortedMap, SafeSortedMap and ConcurrentSortedMap all satisfy it.
+type Map[K comparable, V any] interface {
+	Get(key K) (V, bool)
+	Set(key K, value V)
+	Delete(key K)
+	Len() int
+	Keys() []K
+}
+
+// LoggedMap wraps a map and appends every Set and Delete to a changelog.
+// Mutations are serialized so the log order always matches the order in
+// which they were applied. Reads go straight to the wrapped map.
+type LoggedMap[K comparable, V any] struct {
+	Map[K, V]
+	log *Writer[K, V]
+	mu  sync.Mutex
+	err error
+}
+
+// WithChangelog wraps m so that its mutations are recorded to w.
+func WithChangelog[K comparable, V any](m Map[K, V], w io.Writer) *LoggedMap[K, V] {
+	return &LoggedMap[K, V]{
+		Map: m,
+		log: NewWriter(w, codec.Default[K](), codec.Default[V]()),
+	}
+}
+
+// WithKeyCodec makes the changelog encode keys with c instead of
+// codec.Default. Call it before the first mutation, and pass the same codec
+// when reading the log back.
+func (m *LoggedMap[K, V]) WithKeyCodec(c codec.Codec[K]) *LoggedMap[K, V] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.log.keys = c
+	return m
+}
+
+// WithValueCodec makes the changelog encode values with c instead of
+// codec.Default. Call it before the first mutation, and pass the same codec
+// when reading the log back.
+func (m *LoggedMap[K, V]) WithValueCodec(c codec.Codec[V]) *LoggedMap[K, V] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.log.values = c
+	return m
+}
+
+func (m *LoggedMap[K, V]) Set(key K, value V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Map.Set(key, value)
+	m.record(Record[K, V]{Op: OpSet, Key: key, Value: value})
+}
+
+func (m *LoggedMap[K, V]) Delete(key K) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Map.Delete(key)
+	m.record(Record[K, V]{Op: OpDelete, Key: key})
+}
+
+// Err returns the first error encountered while writing the changelog.
+func (m *LoggedMap[K, V]) Err() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.err
+}
+
+// Replay applies a changelog read from r to the wrapped map without
+// recording the replayed mutations again.
+func (m *LoggedMap[K, V]) Replay(r io.Reader) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return Replay(r, m.log.keys, m.log.values, func(rec Record[K, V]) error {
+		switch rec.Op {
+		case OpSet:
+			m.Map.Set(rec.Key, rec.Value)
+		case OpDelete:
+			m.Map.Delete(rec.Key)
+		case OpClear:
+			for _, key := range m.Map.Keys() {
+				m.Map.Delete(key)
+			}
+		}
+		return nil
+	})
+}
+
+func (m *LoggedMap[K, V]) record(rec Record[K, V]) {
+	if err := m.log.Write(rec); err != nil && m.err == nil {
+		m.err = err
+	}
+}
+
+// LoggedCache wraps a cache and appends every Put, Remove and Clear to a
+// changelog. Evictions are not logged: replaying into a cache of the same
+// capacity re-runs them, although read-driven recency may differ.
+type LoggedCache[K comparable, V any] struct {
+	cache.Cache[K, V]
+	log *Writer[K, V]
+	mu  sync.Mutex
+	err error
+}
+
+// WithCacheChangelog wraps c so that its mutations are recorded to w.
+func WithCacheChangelog[K comparable, V any](c cache.Cache[K, V], w io.Writer) *LoggedCache[K, V] {
+	return &LoggedCache[K, V]{
+		Cache: c,
+		log:   NewWriter(w, codec.Default[K](), codec.Default[V]()),
+	}
+}
+
+// WithKeyCodec makes the changelog encode keys with kc instead of
+// codec.Default. Call it before the first mutation.
+func (c *LoggedCache[K, V]) WithKeyCodec(kc codec.Codec[K]) *LoggedCache[K, V] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.log.keys = kc
+	return c
+}
+
+// WithValueCodec makes the changelog encode values with vc instead of
+// codec.Default. Call it before the first mutation.
+func (c *LoggedCache[K, V]) WithValueCodec(vc codec.Codec[V]) *LoggedCache[K, V] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.log.values = vc
+	return c
+}
+
+func (c *LoggedCache[K, V]) Put(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Cache.Put(key, value)
+	c.record(Record[K, V]{Op: OpSet, Key: key, Value: value})
+}
+
+func (c *LoggedCache[K, V]) Remove(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Cache.Remove(key)
+	c.record(Record[K, V]{Op: OpDelete, Key: key})
+}
+
+func (c *LoggedCache[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Cache.Clear()
+	c.record(Record[K, V]{Op: OpClear})
+}
+
+// Err returns the first error encountered while writing the changelog.
+func (c *LoggedCache[K, V]) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+// Replay applies a changelog read from r to the wrapped cache without
+// recording the replayed mutations again.
+func (c *LoggedCache[K, V]) Replay(r io.Reader) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return Replay(r, c.log.keys, c.log.values, func(rec Record[K, V]) error {
+		switch rec.Op {
+		case OpSet:
+			c.Cache.Put(rec.Key, rec.Value)
+		case OpDelete:
+			c.Cache.Remove(rec.Key)
+		case OpClear:
+			c.Cache.Clear()
+		}
+		return nil
+	})
+}
+
+func (c *LoggedCache[K, V]) record(rec Record[K, V]) {
+	if err := c.log.Write(rec); err != nil && c.err == nil {
+		c.err = err
+	}
+}
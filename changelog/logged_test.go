@@ -0,0 +1,128 @@
+package changelog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"dsgo/cache"
+	"dsgo/codec"
+	"dsgo/maps"
+)
+
+func TestLoggedMapReplay(t *testing.T) {
+	var log bytes.Buffer
+	m := WithChangelog[string, int](maps.NewOrderedMap[string, int](), &log)
+
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("a", 10)
+	m.Delete("b")
+	m.Set("c", 3)
+	if err := m.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+
+	// Rebuild into a different map type after a "restart"
+	var mirrorLog bytes.Buffer
+	rebuilt := WithChangelog[string, int](maps.NewSortedMap[string, int](), &mirrorLog)
+	if err := rebuilt.Replay(bytes.NewReader(log.Bytes())); err != nil {
+		t.Fatalf("Replay() error: %v", err)
+	}
+	if mirrorLog.Len() != 0 {
+		t.Error("Replay should not re-record mutations")
+	}
+
+	if rebuilt.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", rebuilt.Len())
+	}
+	if v, _ := rebuilt.Get("a"); v != 10 {
+		t.Errorf("Get(a) = %d, want 10", v)
+	}
+	if _, ok := rebuilt.Get("b"); ok {
+		t.Error("Get(b) should be absent after replaying the delete")
+	}
+}
+
+func TestLoggedCacheReplay(t *testing.T) {
+	var log bytes.Buffer
+	c := WithCacheChangelog[int, string](cache.NewLRUCache[int, string](2), &log)
+
+	c.Put(1, "one")
+	c.Put(2, "two")
+	c.Clear()
+	c.Put(3, "three")
+	c.Put(4, "four")
+	c.Remove(3)
+	c.Put(5, "five")
+
+	rebuilt := WithCacheChangelog[int, string](cache.NewLRUCache[int, string](2), &bytes.Buffer{})
+	if err := rebuilt.Replay(&log); err != nil {
+		t.Fatalf("Replay() error: %v", err)
+	}
+
+	for key, want := range map[int]string{4: "four", 5: "five"} {
+		if v, ok := rebuilt.Get(key); !ok || v != want {
+			t.Errorf("Get(%d) = %q, %v; want %q, true", key, v, ok, want)
+		}
+	}
+	if rebuilt.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", rebuilt.Len())
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestLoggedMapWriteError(t *testing.T) {
+	m := WithChangelog[int, int](maps.NewOrderedMap[int, int](), failingWriter{})
+	m.Set(1, 1)
+
+	if m.Err() == nil {
+		t.Error("Err() should report the write failure")
+	}
+	if v, ok := m.Get(1); !ok || v != 1 {
+		t.Error("The mutation should still be applied to the map")
+	}
+}
+
+func TestLoggedMapCustomCodecs(t *testing.T) {
+	type point struct{ X, Y int }
+	points := codec.Funcs[point]{
+		EncodeFunc: func(p point) ([]byte, error) { return json.Marshal(p) },
+		DecodeFunc: func(data []byte) (point, error) {
+			var p point
+			err := json.Unmarshal(data, &p)
+			return p, err
+		},
+	}
+	upper := codec.Funcs[string]{
+		EncodeFunc: func(s string) ([]byte, error) { return []byte(strings.ToUpper(s)), nil },
+		DecodeFunc: func(data []byte) (string, error) { return strings.ToLower(string(data)), nil },
+	}
+
+	var log bytes.Buffer
+	m := WithChangelog[string, point](maps.NewOrderedMap[string, point](), &log).
+		WithKeyCodec(upper).
+		WithValueCodec(points)
+	m.Set("origin", point{0, 0})
+	m.Set("corner", point{3, 4})
+	if !bytes.Contains(log.Bytes(), []byte(`CORNER`)) || !bytes.Contains(log.Bytes(), []byte(`{"X":3,"Y":4}`)) {
+		t.Errorf("changelog did not use the custom codecs: %q", log.Bytes())
+	}
+
+	rebuilt := WithChangelog[string, point](maps.NewSortedMap[string, point](), &bytes.Buffer{}).
+		WithKeyCodec(upper).
+		WithValueCodec(points)
+	if err := rebuilt.Replay(&log); err != nil {
+		t.Fatalf("Replay() error: %v", err)
+	}
+	if p, _ := rebuilt.Get("corner"); p != (point{3, 4}) {
+		t.Errorf("Get(corner) = %v, want {3 4}", p)
+	}
+}
@@ -0,0 +1,184 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"math"
+	"slices"
+)
+
+var ErrInvalidData = errors.New("codec: invalid encoded data")
+
+// Codec converts values to and from bytes. The persistence features of the
+// library (changelogs, files, sketches) take a Codec so callers can plug in
+// protobuf, msgpack or any other encoding without the library depending on it.
+type Codec[T any] interface {
+	Encode(v T) ([]byte, error)
+	Decode(data []byte) (T, error)
+}
+
+// Funcs adapts a pair of functions into a Codec.
+type Funcs[T any] struct {
+	EncodeFunc func(v T) ([]byte, error)
+	DecodeFunc func(data []byte) (T, error)
+}
+
+func (f Funcs[T]) Encode(v T) ([]byte, error) {
+	return f.EncodeFunc(v)
+}
+
+func (f Funcs[T]) Decode(data []byte) (T, error) {
+	return f.DecodeFunc(data)
+}
+
+// Default returns a compact codec for the built-in scalar types, strings and
+// byte slices, falling back to encoding/gob for everything else.
+func Default[T any]() Codec[T] {
+	return defaultCodec[T]{}
+}
+
+type defaultCodec[T any] struct{}
+
+// compact reports whether T is one of the types with a compact encoding.
+// It looks at T itself rather than at a value, so that an interface type
+// goes through gob in both directions whatever it holds.
+func (defaultCodec[T]) compact() bool {
+	var zero T
+	switch any(zero).(type) {
+	case string, []byte, bool,
+		int, int8, int16, int32, int64,
+		uint, uint8, uint16, uint32, uint64,
+		float32, float64:
+		return true
+	}
+	return false
+}
+
+func (c defaultCodec[T]) Encode(v T) ([]byte, error) {
+	if !c.compact() {
+		return gobEncode(v)
+	}
+	switch x := any(v).(type) {
+	case string:
+		return []byte(x), nil
+	case []byte:
+		return slices.Clone(x), nil
+	case bool:
+		if x {
+			return []byte{1}, nil
+		}
+		return []byte{0}, nil
+	case int:
+		return binary.AppendVarint(nil, int64(x)), nil
+	case int8:
+		return binary.AppendVarint(nil, int64(x)), nil
+	case int16:
+		return binary.AppendVarint(nil, int64(x)), nil
+	case int32:
+		return binary.AppendVarint(nil, int64(x)), nil
+	case int64:
+		return binary.AppendVarint(nil, x), nil
+	case uint:
+		return binary.AppendUvarint(nil, uint64(x)), nil
+	case uint8:
+		return binary.AppendUvarint(nil, uint64(x)), nil
+	case uint16:
+		return binary.AppendUvarint(nil, uint64(x)), nil
+	case uint32:
+		return binary.AppendUvarint(nil, uint64(x)), nil
+	case uint64:
+		return binary.AppendUvarint(nil, x), nil
+	case float32:
+		return binary.LittleEndian.AppendUint32(nil, math.Float32bits(x)), nil
+	case float64:
+		return binary.LittleEndian.AppendUint64(nil, math.Float64bits(x)), nil
+	default:
+		return gobEncode(v)
+	}
+}
+
+func gobEncode[T any](v T) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decode returns ErrInvalidData for a number that does not fit in T.
+func (defaultCodec[T]) Decode(data []byte) (T, error) {
+	var zero T
+	var out any
+	switch any(zero).(type) {
+	case string:
+		out = string(data)
+	case []byte:
+		out = slices.Clone(data)
+	case bool:
+		if len(data) != 1 {
+			return zero, ErrInvalidData
+		}
+		out = data[0] == 1
+	case int, int8, int16, int32, int64:
+		n, size := binary.Varint(data)
+		if size <= 0 || size != len(data) {
+			return zero, ErrInvalidData
+		}
+		var fits bool
+		switch any(zero).(type) {
+		case int:
+			out, fits = int(n), int64(int(n)) == n
+		case int8:
+			out, fits = int8(n), int64(int8(n)) == n
+		case int16:
+			out, fits = int16(n), int64(int16(n)) == n
+		case int32:
+			out, fits = int32(n), int64(int32(n)) == n
+		default:
+			out, fits = n, true
+		}
+		if !fits {
+			return zero, ErrInvalidData
+		}
+	case uint, uint8, uint16, uint32, uint64:
+		n, size := binary.Uvarint(data)
+		if size <= 0 || size != len(data) {
+			return zero, ErrInvalidData
+		}
+		var fits bool
+		switch any(zero).(type) {
+		case uint:
+			out, fits = uint(n), uint64(uint(n)) == n
+		case uint8:
+			out, fits = uint8(n), uint64(uint8(n)) == n
+		case uint16:
+			out, fits = uint16(n), uint64(uint16(n)) == n
+		case uint32:
+			out, fits = uint32(n), uint64(uint32(n)) == n
+		default:
+			out, fits = n, true
+		}
+		if !fits {
+			return zero, ErrInvalidData
+		}
+	case float32:
+		if len(data) != 4 {
+			return zero, ErrInvalidData
+		}
+		out = math.Float32frombits(binary.LittleEndian.Uint32(data))
+	case float64:
+		if len(data) != 8 {
+			return zero, ErrInvalidData
+		}
+		out = math.Float64frombits(binary.LittleEndian.Uint64(data))
+	default:
+		var v T
+		if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&v); err != nil {
+			return zero, err
+		}
+		return v, nil
+	}
+	return out.(T), nil
+}
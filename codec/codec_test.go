@@ -0,0 +1,112 @@
+package codec
+
+import (
+	"bytes"
+	"compress/flate"
+	"strconv"
+	"testing"
+)
+
+func roundTrip[T comparable](t *testing.T, v T) {
+	t.Helper()
+	c := Default[T]()
+	data, err := c.Encode(v)
+	if err != nil {
+		t.Fatalf("Encode(%v) error: %v", v, err)
+	}
+	got, err := c.Decode(data)
+	if err != nil {
+		t.Fatalf("Decode(%v) error: %v", v, err)
+	}
+	if got != v {
+		t.Errorf("round trip of %v returned %v", v, got)
+	}
+}
+
+type point struct {
+	X, Y int
+}
+
+func TestDefaultCodecRoundTrip(t *testing.T) {
+	roundTrip(t, "hello")
+	roundTrip(t, "")
+	roundTrip(t, true)
+	roundTrip(t, -42)
+	roundTrip(t, int8(-8))
+	roundTrip(t, int16(300))
+	roundTrip(t, int32(-70000))
+	roundTrip(t, int64(1<<40))
+	roundTrip(t, uint(7))
+	roundTrip(t, uint8(255))
+	roundTrip(t, uint16(65535))
+	roundTrip(t, uint32(1<<31))
+	roundTrip(t, uint64(1<<63))
+	roundTrip(t, float32(1.5))
+	roundTrip(t, 3.25)
+	roundTrip(t, point{X: 1, Y: -2})
+	roundTrip[any](t, "held in an interface")
+	roundTrip[any](t, 42)
+}
+
+func TestDefaultCodecBytes(t *testing.T) {
+	c := Default[[]byte]()
+	in := []byte{1, 2, 3}
+	data, _ := c.Encode(in)
+	in[0] = 9
+	got, err := c.Decode(data)
+	if err != nil || !bytes.Equal(got, []byte{1, 2, 3}) {
+		t.Errorf("Decode() = %v, %v; want [1 2 3]", got, err)
+	}
+}
+
+func TestDefaultCodecInvalidData(t *testing.T) {
+	if _, err := Default[float64]().Decode([]byte{1, 2}); err != ErrInvalidData {
+		t.Errorf("Decode(short float) error = %v, want ErrInvalidData", err)
+	}
+	if _, err := Default[int]().Decode(nil); err != ErrInvalidData {
+		t.Errorf("Decode(empty int) error = %v, want ErrInvalidData", err)
+	}
+	wide, _ := Default[int64]().Encode(300)
+	if _, err := Default[int8]().Decode(wide); err != ErrInvalidData {
+		t.Errorf("Decode(300) as int8 error = %v, want ErrInvalidData", err)
+	}
+	wide, _ = Default[uint64]().Encode(1 << 40)
+	if _, err := Default[uint32]().Decode(wide); err != ErrInvalidData {
+		t.Errorf("Decode(1<<40) as uint32 error = %v, want ErrInvalidData", err)
+	}
+}
+
+func TestFuncs(t *testing.T) {
+	c := Funcs[int]{
+		EncodeFunc: func(v int) ([]byte, error) { return []byte(strconv.Itoa(v)), nil },
+		DecodeFunc: func(data []byte) (int, error) { return strconv.Atoi(string(data)) },
+	}
+	data, _ := c.Encode(123)
+	if string(data) != "123" {
+		t.Errorf("Encode(123) = %q, want \"123\"", data)
+	}
+	if v, err := c.Decode(data); err != nil || v != 123 {
+		t.Errorf("Decode() = %d, %v; want 123, nil", v, err)
+	}
+}
+
+func TestFlateRoundTrip(t *testing.T) {
+	data := bytes.Repeat([]byte("compressible "), 100)
+	for _, c := range []Flate{{}, {Level: flate.BestSpeed}} {
+		compressed, err := c.Compress(data)
+		if err != nil {
+			t.Fatalf("Compress() error = %v", err)
+		}
+		if len(compressed) >= len(data) {
+			t.Errorf("Compress() produced %d bytes from %d", len(compressed), len(data))
+		}
+		out, err := c.Decompress(compressed)
+		if err != nil || !bytes.Equal(out, data) {
+			t.Errorf("Decompress() = %d bytes, %v", len(out), err)
+		}
+	}
+
+	if _, err := (Flate{}).Decompress([]byte{0xff, 0xff}); err != ErrInvalidData {
+		t.Errorf("Decompress(garbage) error = %v, want ErrInvalidData", err)
+	}
+}
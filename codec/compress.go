@@ -0,0 +1,50 @@
+package codec
+
+import (
+	"bytes"
+	"compress/flate"
+	"io"
+)
+
+// Compressor compresses and decompresses byte slices. Snappy, zstd or any
+// other algorithm can be plugged in by implementing it; Flate is provided so
+// the library itself needs nothing outside the standard library.
+type Compressor interface {
+	Compress(data []byte) ([]byte, error)
+	Decompress(data []byte) ([]byte, error)
+}
+
+// Flate is a Compressor using DEFLATE at the given compression level.
+// The zero value uses flate.DefaultCompression.
+type Flate struct {
+	Level int
+}
+
+func (f Flate) Compress(data []byte) ([]byte, error) {
+	level := f.Level
+	if level == 0 {
+		level = flate.DefaultCompression
+	}
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, level)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (f Flate) Decompress(data []byte) ([]byte, error) {
+	r := flate.NewReader(bytes.NewReader(data))
+	defer r.Close()
+	out, err := io.ReadAll(r)
+	if err != nil {
+		return nil, ErrInvalidData
+	}
+	return out, nil
+}
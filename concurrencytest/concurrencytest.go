@@ -0,0 +1,151 @@
+// Package concurrencytest checks thread-safe structures for linearizability.
+//
+// A Script gives each goroutine a list of operations. The goroutines run in
+// lockstep rounds: every goroutine performs its next operation at the same
+// time, and a barrier separates one round from the next. Each operation is
+// also described against a sequential model, and after the run the results
+// must be explained by some ordering of each round's operations applied to
+// the model one at a time. Because rounds are barrier-separated only the
+// operations inside a round may be reordered, which keeps the search small
+// and the schedule reproducible.
+package concurrencytest
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Op is one operation in a script.
+type Op[S, M any] struct {
+	// Name describes the operation in failure messages.
+	Name string
+	// Run performs the operation on the structure under test and returns
+	// its observable result.
+	Run func(s S) any
+	// Model performs the operation on the sequential model and returns the
+	// result Run must produce when linearized at that point.
+	Model func(m M) any
+}
+
+// Script describes a concurrent test.
+type Script[S, M any] struct {
+	// New returns a fresh structure under test.
+	New func() S
+	// NewModel returns a fresh model in the same initial state.
+	NewModel func() M
+	// Clone copies a model so alternative orderings can be explored.
+	Clone func(m M) M
+	// Threads lists, per goroutine, the operation it performs in each
+	// round. Goroutines with fewer operations sit out later rounds.
+	Threads [][]Op[S, M]
+	// Runs is how many times the script is executed. Zero means once.
+	Runs int
+}
+
+type outcome[S, M any] struct {
+	thread int
+	op     Op[S, M]
+	result any
+}
+
+// Run executes the script and fails t if a run's results cannot be
+// linearized against the model.
+func Run[S, M any](t testing.TB, script Script[S, M]) {
+	t.Helper()
+	rounds := 0
+	for _, ops := range script.Threads {
+		rounds = max(rounds, len(ops))
+	}
+	runs := max(script.Runs, 1)
+	for run := 0; run < runs; run++ {
+		history := execute(script, rounds)
+		if !linearizable(script, history, 0, script.NewModel()) {
+			t.Fatalf("run %d: results are not linearizable:\n%s", run, describe(history))
+		}
+	}
+}
+
+// execute runs every round behind a barrier and records the results.
+func execute[S, M any](script Script[S, M], rounds int) [][]outcome[S, M] {
+	s := script.New()
+	history := make([][]outcome[S, M], rounds)
+	for r := 0; r < rounds; r++ {
+		start := make(chan struct{})
+		var wg sync.WaitGroup
+		results := make([]outcome[S, M], len(script.Threads))
+		for g, ops := range script.Threads {
+			if r >= len(ops) {
+				continue
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-start
+				results[g] = outcome[S, M]{thread: g, op: ops[r], result: ops[r].Run(s)}
+			}()
+		}
+		close(start)
+		wg.Wait()
+		for g, ops := range script.Threads {
+			if r < len(ops) {
+				history[r] = append(history[r], results[g])
+			}
+		}
+	}
+	return history
+}
+
+// linearizable searches for an order of each round's operations, from
+// round r onwards, under which the model reproduces every recorded result.
+func linearizable[S, M any](script Script[S, M], history [][]outcome[S, M], r int, model M) bool {
+	if r == len(history) {
+		return true
+	}
+	var try func(pending []outcome[S, M], model M) bool
+	try = func(pending []outcome[S, M], model M) bool {
+		if len(pending) == 0 {
+			return linearizable(script, history, r+1, model)
+		}
+		for i, o := range pending {
+			next := script.Clone(model)
+			if !reflect.DeepEqual(o.op.Model(next), o.result) {
+				continue
+			}
+			rest := append(append([]outcome[S, M](nil), pending[:i]...), pending[i+1:]...)
+			if try(rest, next) {
+				return true
+			}
+		}
+		return false
+	}
+	return try(history[r], model)
+}
+
+func describe[S, M any](history [][]outcome[S, M]) string {
+	var b strings.Builder
+	for r, round := range history {
+		fmt.Fprintf(&b, "  round %d:", r)
+		for _, o := range round {
+			fmt.Fprintf(&b, " [g%d %s = %v]", o.thread, o.op.Name, o.result)
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// RandomThreads builds a script's Threads from gen, giving each of the
+// given number of goroutines ops operations. Seeding r makes the script
+// reproducible.
+func RandomThreads[S, M any](r *rand.Rand, goroutines, ops int, gen func(r *rand.Rand) Op[S, M]) [][]Op[S, M] {
+	threads := make([][]Op[S, M], goroutines)
+	for g := range threads {
+		for i := 0; i < ops; i++ {
+			threads[g] = append(threads[g], gen(r))
+		}
+	}
+	return threads
+}
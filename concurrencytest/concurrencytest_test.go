@@ -0,0 +1,79 @@
+package concurrencytest
+
+import (
+	"fmt"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+)
+
+type counterModel struct{ n int }
+
+func counterScript(newCounter func() func() int) Script[func() int, *counterModel] {
+	incr := Op[func() int, *counterModel]{
+		Name:  "Incr",
+		Run:   func(incr func() int) any { return incr() },
+		Model: func(m *counterModel) any { m.n++; return m.n },
+	}
+	return Script[func() int, *counterModel]{
+		New:      newCounter,
+		NewModel: func() *counterModel { return &counterModel{} },
+		Clone:    func(m *counterModel) *counterModel { c := *m; return &c },
+		Threads: [][]Op[func() int, *counterModel]{
+			{incr, incr, incr},
+			{incr, incr},
+			{incr, incr, incr, incr},
+		},
+		Runs: 20,
+	}
+}
+
+func TestRunAcceptsLinearizable(t *testing.T) {
+	Run(t, counterScript(func() func() int {
+		var n atomic.Int64
+		return func() int { return int(n.Add(1)) }
+	}))
+}
+
+// recorder captures failures instead of stopping the test.
+type recorder struct {
+	testing.TB
+	failed string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.failed = fmt.Sprintf(format, args...)
+}
+
+func TestRunRejectsLostUpdates(t *testing.T) {
+	// A counter that loses every increment after the first
+	script := counterScript(func() func() int {
+		return func() int { return 1 }
+	})
+	script.Runs = 1
+	r := &recorder{TB: t}
+	Run(r, script)
+	if r.failed == "" {
+		t.Fatal("Run() accepted non-linearizable results")
+	}
+}
+
+func TestRandomThreads(t *testing.T) {
+	gen := func(r *rand.Rand) Op[int, int] {
+		return Op[int, int]{Name: fmt.Sprint(r.Intn(100))}
+	}
+	a := RandomThreads(rand.New(rand.NewSource(7)), 3, 4, gen)
+	b := RandomThreads(rand.New(rand.NewSource(7)), 3, 4, gen)
+	if len(a) != 3 || len(a[2]) != 4 {
+		t.Fatalf("RandomThreads() shape = %d x %d, want 3 x 4", len(a), len(a[2]))
+	}
+	for g := range a {
+		for i := range a[g] {
+			if a[g][i].Name != b[g][i].Name {
+				t.Fatal("RandomThreads() is not reproducible for a fixed seed")
+			}
+		}
+	}
+}
@@ -0,0 +1,166 @@
+// Package epoch implements epoch-based reclamation for lock-free structures.
+//
+// Readers Pin a Domain for the duration of an operation; writers Retire
+// objects they have unlinked. A retired object's callback runs only once
+// every reader that was pinned when it was unlinked has unpinned, so the
+// callback may recycle or reset the object without a reader observing it.
+// The garbage collector already keeps unlinked objects alive while they are
+// referenced; what epochs add is knowing when nobody can reference them any
+// more, which is what makes reuse (pooling, resetting fields) safe.
+package epoch
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// collectEvery is how many retirements trigger an automatic collection.
+const collectEvery = 64
+
+// record is a participant slot. state is zero when unpinned and
+// epoch<<1|1 while pinned.
+type record struct {
+	inUse atomic.Bool
+	state atomic.Uint64
+	// Keep records on separate cache lines so pinning readers do not
+	// contend with each other
+	_ [48]byte
+}
+
+type retired struct {
+	epoch uint64
+	fn    func()
+}
+
+// Domain is an independent reclamation scope, usually one per structure.
+// The zero value is ready to use.
+type Domain struct {
+	global  atomic.Uint64
+	records atomic.Pointer[[]*record]
+	regMu   sync.Mutex
+
+	retireMu sync.Mutex
+	pending  []retired
+	sinceGC  int
+}
+
+// Guard marks an in-progress read. Objects reachable while the guard is
+// pinned are not reclaimed until it is unpinned.
+type Guard struct {
+	r *record
+}
+
+// Pin starts a read-side critical section. Every Pin must be paired with an
+// Unpin on the returned guard, and guards must not be shared between
+// goroutines.
+func (d *Domain) Pin() Guard {
+	r := d.acquire()
+	// Publish the epoch we saw. Objects retired before this load were
+	// unlinked before we could reach them; later ones carry an epoch at
+	// least this large and so wait for us
+	r.state.Store(d.global.Load()<<1 | 1)
+	return Guard{r: r}
+}
+
+// Unpin ends the critical section. Pointers obtained while pinned must not
+// be used afterwards.
+func (g Guard) Unpin() {
+	g.r.state.Store(0)
+	g.r.inUse.Store(false)
+}
+
+func (d *Domain) acquire() *record {
+	if records := d.records.Load(); records != nil {
+		for _, r := range *records {
+			if !r.inUse.Load() && r.inUse.CompareAndSwap(false, true) {
+				return r
+			}
+		}
+	}
+
+	d.regMu.Lock()
+	defer d.regMu.Unlock()
+	r := &record{}
+	r.inUse.Store(true)
+	var grown []*record
+	if records := d.records.Load(); records != nil {
+		grown = append(grown, *records...)
+	}
+	grown = append(grown, r)
+	d.records.Store(&grown)
+	return r
+}
+
+// Retire schedules fn to run once no guard pinned before this call remains
+// pinned. Call it after the object has been unlinked, so that new readers
+// cannot reach it. fn runs on whichever goroutine triggers the collection.
+func (d *Domain) Retire(fn func()) {
+	d.retireMu.Lock()
+	d.pending = append(d.pending, retired{epoch: d.global.Load(), fn: fn})
+	d.sinceGC++
+	var ready []func()
+	if d.sinceGC >= collectEvery {
+		ready = d.collectLocked()
+	}
+	d.retireMu.Unlock()
+	for _, fn := range ready {
+		fn()
+	}
+}
+
+// Collect tries to advance the epoch and runs the callbacks that have
+// become safe. It returns the number of objects still pending.
+func (d *Domain) Collect() int {
+	d.retireMu.Lock()
+	ready := d.collectLocked()
+	remaining := len(d.pending)
+	d.retireMu.Unlock()
+	for _, fn := range ready {
+		fn()
+	}
+	return remaining
+}
+
+// Pending returns the number of retired objects not yet reclaimed.
+func (d *Domain) Pending() int {
+	d.retireMu.Lock()
+	defer d.retireMu.Unlock()
+	return len(d.pending)
+}
+
+func (d *Domain) collectLocked() []func() {
+	d.sinceGC = 0
+	// With no readers in the way two steps free everything retired so far
+	if d.tryAdvance() {
+		d.tryAdvance()
+	}
+	// An object retired in epoch e may still be held by a reader pinned in
+	// e; once the global epoch is e+2 every such reader has unpinned
+	global := d.global.Load()
+	var ready []func()
+	kept := d.pending[:0]
+	for _, r := range d.pending {
+		if r.epoch+2 <= global {
+			ready = append(ready, r.fn)
+		} else {
+			kept = append(kept, r)
+		}
+	}
+	clear(d.pending[len(kept):])
+	d.pending = kept
+	return ready
+}
+
+// tryAdvance moves the global epoch forward if every pinned participant
+// has observed the current one, and reports whether it did.
+func (d *Domain) tryAdvance() bool {
+	global := d.global.Load()
+	if records := d.records.Load(); records != nil {
+		for _, r := range *records {
+			if state := r.state.Load(); state&1 == 1 && state>>1 != global {
+				return false
+			}
+		}
+	}
+	return d.global.CompareAndSwap(global, global+1)
+}
@@ -0,0 +1,108 @@
+package epoch
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRetireWaitsForPinnedReaders(t *testing.T) {
+	var d Domain
+	g := d.Pin()
+
+	var freed atomic.Bool
+	d.Retire(func() { freed.Store(true) })
+	for i := 0; i < 5; i++ {
+		d.Collect()
+	}
+	if freed.Load() {
+		t.Fatal("object reclaimed while a reader pinned before Retire was active")
+	}
+	if d.Pending() != 1 {
+		t.Errorf("Pending() = %d, want 1", d.Pending())
+	}
+
+	g.Unpin()
+	if remaining := d.Collect(); remaining != 0 || !freed.Load() {
+		t.Errorf("Collect() left %d pending, freed = %v; want 0, true", remaining, freed.Load())
+	}
+}
+
+func TestLaterReadersDoNotBlock(t *testing.T) {
+	var d Domain
+	var freed atomic.Bool
+	d.Retire(func() { freed.Store(true) })
+
+	// A reader pinned after the unlink cannot have seen the object, but it
+	// still holds the epoch back by one step until it unpins
+	g := d.Pin()
+	d.Collect()
+	g.Unpin()
+	d.Collect()
+	if !freed.Load() {
+		t.Error("object not reclaimed after every reader unpinned")
+	}
+}
+
+func TestAutomaticCollection(t *testing.T) {
+	var d Domain
+	var freed atomic.Int64
+	for i := 0; i < collectEvery*3; i++ {
+		d.Retire(func() { freed.Add(1) })
+	}
+	if freed.Load() == 0 {
+		t.Error("Retire never triggered a collection")
+	}
+}
+
+func TestConcurrentPinRetire(t *testing.T) {
+	var d Domain
+	type object struct{ live atomic.Bool }
+	var current atomic.Pointer[object]
+	first := &object{}
+	first.live.Store(true)
+	current.Store(first)
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	var violations atomic.Int64
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				g := d.Pin()
+				obj := current.Load()
+				for j := 0; j < 10; j++ {
+					if !obj.live.Load() {
+						violations.Add(1)
+					}
+				}
+				g.Unpin()
+			}
+		}()
+	}
+
+	for i := 0; i < 5000; i++ {
+		next := &object{}
+		next.live.Store(true)
+		old := current.Swap(next)
+		d.Retire(func() { old.live.Store(false) })
+	}
+	close(stop)
+	wg.Wait()
+	d.Collect()
+	d.Collect()
+
+	if violations.Load() != 0 {
+		t.Errorf("readers observed %d reclaimed objects", violations.Load())
+	}
+	if d.Pending() != 0 {
+		t.Errorf("Pending() = %d after quiescence, want 0", d.Pending())
+	}
+}
@@ -0,0 +1,221 @@
+// Package extsort sorts sequences that do not fit in memory. Values are
+// buffered into runs, each run is sorted and spilled to a temporary file,
+// and the runs are merged lazily with a k-way merge when the result is read.
+package extsort
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"iter"
+	"os"
+	"slices"
+
+	"dsgo/codec"
+	"dsgo/sortx"
+	"dsgo/utils"
+)
+
+// DefaultRunSize is the number of values held in memory per run when
+// Options.RunSize is not set.
+const DefaultRunSize = 100_000
+
+var ErrFinished = errors.New("extsort: sorter already finished")
+
+type Options struct {
+	// RunSize is the number of values buffered before a run is spilled.
+	// Memory use is roughly RunSize values plus one decoded value per run
+	// during the merge.
+	RunSize int
+	// TempDir is where runs are written. Empty means os.TempDir().
+	TempDir string
+}
+
+// Sorter accumulates values and yields them in sorted order. Values are
+// written to run files with codec, framed as a uvarint length followed by
+// the encoded bytes. The sort is stable. A Sorter is not safe for concurrent
+// use; call Close to remove its run files.
+type Sorter[T any] struct {
+	cmp     utils.CompareFunc[T]
+	codec   codec.Codec[T]
+	runSize int
+	dir     string
+	buf     []T
+	runs    []string
+	err     error
+	done    bool
+}
+
+func NewSorter[T any](cmp utils.CompareFunc[T], c codec.Codec[T], opts ...Options) *Sorter[T] {
+	var o Options
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	if o.RunSize <= 0 {
+		o.RunSize = DefaultRunSize
+	}
+	return &Sorter[T]{
+		cmp:     cmp,
+		codec:   c,
+		runSize: o.RunSize,
+		dir:     o.TempDir,
+	}
+}
+
+// Sort feeds every value of input to a new Sorter. On error the Sorter has
+// already been closed.
+func Sort[T any](input iter.Seq[T], cmp utils.CompareFunc[T], c codec.Codec[T], opts ...Options) (*Sorter[T], error) {
+	s := NewSorter(cmp, c, opts...)
+	for v := range input {
+		if err := s.Add(v); err != nil {
+			s.Close()
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Add buffers v, spilling a sorted run to disk once the buffer is full.
+func (s *Sorter[T]) Add(v T) error {
+	if s.done {
+		return ErrFinished
+	}
+	if s.err != nil {
+		return s.err
+	}
+	s.buf = append(s.buf, v)
+	if len(s.buf) >= s.runSize {
+		s.err = s.spill()
+	}
+	return s.err
+}
+
+func (s *Sorter[T]) spill() error {
+	slices.SortStableFunc(s.buf, s.cmp)
+	f, err := os.CreateTemp(s.dir, "extsort-*.run")
+	if err != nil {
+		return err
+	}
+	s.runs = append(s.runs, f.Name())
+
+	w := bufio.NewWriter(f)
+	var frame []byte
+	for _, v := range s.buf {
+		data, err := s.codec.Encode(v)
+		if err != nil {
+			f.Close()
+			return err
+		}
+		frame = binary.AppendUvarint(frame[:0], uint64(len(data)))
+		frame = append(frame, data...)
+		if _, err := w.Write(frame); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	clear(s.buf)
+	s.buf = s.buf[:0]
+	return f.Close()
+}
+
+// Sorted finishes the input and returns the values in sorted order. The
+// sequence can be ranged over more than once until Close. If reading a run
+// fails the sequence ends early and Err reports why.
+func (s *Sorter[T]) Sorted() iter.Seq[T] {
+	if !s.done {
+		s.done = true
+		slices.SortStableFunc(s.buf, s.cmp)
+	}
+	sources := make([]iter.Seq[T], 0, len(s.runs)+1)
+	for _, name := range s.runs {
+		sources = append(sources, s.readRun(name))
+	}
+	// The in-memory tail came last, so it goes last to keep the sort stable
+	sources = append(sources, slices.Values(s.buf))
+	return sortx.MergeSortedFunc(s.cmp, sources...)
+}
+
+func (s *Sorter[T]) readRun(name string) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		f, err := os.Open(name)
+		if err != nil {
+			s.setErr(err)
+			return
+		}
+		defer f.Close()
+		info, err := f.Stat()
+		if err != nil {
+			s.setErr(err)
+			return
+		}
+		// remaining bounds each length prefix, so a corrupt one fails the
+		// read instead of allocating whatever it claims
+		remaining := uint64(info.Size())
+		r := bufio.NewReader(f)
+		var prefix [binary.MaxVarintLen64]byte
+		for {
+			n, err := binary.ReadUvarint(r)
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				s.setErr(err)
+				return
+			}
+			remaining -= uint64(binary.PutUvarint(prefix[:], n))
+			if n > remaining {
+				s.setErr(io.ErrUnexpectedEOF)
+				return
+			}
+			remaining -= n
+			data := make([]byte, n)
+			if _, err := io.ReadFull(r, data); err != nil {
+				s.setErr(io.ErrUnexpectedEOF)
+				return
+			}
+			v, err := s.codec.Decode(data)
+			if err != nil {
+				s.setErr(err)
+				return
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+func (s *Sorter[T]) setErr(err error) {
+	if s.err == nil {
+		s.err = err
+	}
+}
+
+// Err returns the first error from spilling or reading runs.
+func (s *Sorter[T]) Err() error {
+	return s.err
+}
+
+// Runs returns the number of runs spilled to disk so far.
+func (s *Sorter[T]) Runs() int {
+	return len(s.runs)
+}
+
+// Close removes the run files. The sorter cannot be used afterwards.
+func (s *Sorter[T]) Close() error {
+	s.done = true
+	var errs []error
+	for _, name := range s.runs {
+		if err := os.Remove(name); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	s.runs = nil
+	s.buf = nil
+	return errors.Join(errs...)
+}
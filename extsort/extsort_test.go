@@ -0,0 +1,109 @@
+package extsort
+
+import (
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/rand"
+	"os"
+	"slices"
+	"testing"
+
+	"dsgo/codec"
+	"dsgo/utils"
+)
+
+func TestSorter(t *testing.T) {
+	dir := t.TempDir()
+	rng := rand.New(rand.NewSource(1))
+	input := make([]int, 10_000)
+	for i := range input {
+		input[i] = rng.Intn(1000)
+	}
+
+	s, err := Sort(slices.Values(input), utils.Natural[int](), codec.Default[int](), Options{RunSize: 512, TempDir: dir})
+	if err != nil {
+		t.Fatalf("Sort() error = %v", err)
+	}
+	if s.Runs() != len(input)/512 {
+		t.Errorf("Runs() = %d, want %d", s.Runs(), len(input)/512)
+	}
+
+	want := slices.Clone(input)
+	slices.Sort(want)
+	for pass := 0; pass < 2; pass++ {
+		got := slices.Collect(s.Sorted())
+		if err := s.Err(); err != nil {
+			t.Fatalf("Err() = %v", err)
+		}
+		if !slices.Equal(got, want) {
+			t.Fatalf("pass %d: Sorted() differs from slices.Sort", pass)
+		}
+	}
+
+	if err := s.Add(1); !errors.Is(err, ErrFinished) {
+		t.Errorf("Add() after Sorted error = %v, want ErrFinished", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("Close() left %d run files", len(entries))
+	}
+}
+
+func TestSorterStable(t *testing.T) {
+	type rec struct {
+		Key int
+		Seq int
+	}
+	var input []rec
+	for i := 0; i < 100; i++ {
+		input = append(input, rec{Key: i % 3, Seq: i})
+	}
+	byKey := func(a, b rec) int { return cmp.Compare(a.Key, b.Key) }
+
+	s, err := Sort(slices.Values(input), byKey, codec.Default[rec](), Options{RunSize: 7, TempDir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("Sort() error = %v", err)
+	}
+	defer s.Close()
+
+	got := slices.Collect(s.Sorted())
+	want := slices.Clone(input)
+	slices.SortStableFunc(want, byKey)
+	if !slices.Equal(got, want) {
+		t.Errorf("Sorted() is not stable")
+	}
+}
+
+func TestSorterEncodeError(t *testing.T) {
+	errBoom := errors.New("boom")
+	failing := codec.Funcs[int]{
+		EncodeFunc: func(int) ([]byte, error) { return nil, errBoom },
+		DecodeFunc: func([]byte) (int, error) { return 0, nil },
+	}
+	_, err := Sort(slices.Values([]int{3, 2, 1}), utils.Natural[int](), failing, Options{RunSize: 2, TempDir: t.TempDir()})
+	if !errors.Is(err, errBoom) {
+		t.Errorf("Sort() error = %v, want the codec's error", err)
+	}
+}
+
+func TestSorterForgedLength(t *testing.T) {
+	s, err := Sort(slices.Values([]int{3, 2, 1}), utils.Natural[int](), codec.Default[int](), Options{RunSize: 2, TempDir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("Sort() error = %v", err)
+	}
+	defer s.Close()
+	// A length prefix far past the end of the file must not be allocated
+	forged := binary.AppendUvarint(nil, 1<<60)
+	if err := os.WriteFile(s.runs[0], forged, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for range s.Sorted() {
+	}
+	if !errors.Is(s.Err(), io.ErrUnexpectedEOF) {
+		t.Errorf("Err() = %v, want %v", s.Err(), io.ErrUnexpectedEOF)
+	}
+}
@@ -0,0 +1,293 @@
+package graphs
+
+import (
+	"context"
+	"runtime"
+)
+
+// checkInterval is how many units of work an algorithm does between context
+// checks and progress reports.
+const checkInterval = 256
+
+// AlgorithmOptions configures the *Ctx algorithm variants.
+type AlgorithmOptions struct {
+	// Progress, if set, is called periodically with the units of work done
+	// so far and the total expected, or -1 when the total is unknown.
+	Progress func(done, total int)
+	// Parallelism is the number of goroutines used by algorithms that can
+	// run in parallel. Zero means runtime.GOMAXPROCS(0).
+	Parallelism int
+}
+
+// WithParallelism returns options that run parallel algorithms on n
+// goroutines.
+func WithParallelism(n int) AlgorithmOptions {
+	return AlgorithmOptions{Parallelism: n}
+}
+
+// mergeOptions folds opts into one, with later options overriding the
+// fields they set.
+func mergeOptions(opts []AlgorithmOptions) AlgorithmOptions {
+	var merged AlgorithmOptions
+	for _, o := range opts {
+		if o.Progress != nil {
+			merged.Progress = o.Progress
+		}
+		if o.Parallelism != 0 {
+			merged.Parallelism = o.Parallelism
+		}
+	}
+	if merged.Parallelism <= 0 {
+		merged.Parallelism = runtime.GOMAXPROCS(0)
+	}
+	return merged
+}
+
+// tracker counts work done by an algorithm, checking the context and
+// reporting progress every checkInterval steps.
+type tracker struct {
+	ctx      context.Context
+	progress func(done, total int)
+	done     int
+	total    int
+}
+
+func newTracker(ctx context.Context, total int, opts []AlgorithmOptions) *tracker {
+	return &tracker{ctx: ctx, total: total, progress: mergeOptions(opts).Progress}
+}
+
+// step records n units of work and returns the context's error once it is
+// cancelled.
+func (t *tracker) step(n int) error {
+	before := t.done / checkInterval
+	t.done += n
+	if t.done/checkInterval == before {
+		return nil
+	}
+	if err := t.ctx.Err(); err != nil {
+		return err
+	}
+	if t.progress != nil {
+		t.progress(t.done, t.total)
+	}
+	return nil
+}
+
+// finish reports final progress and returns the context's error, if any.
+func (t *tracker) finish() error {
+	if err := t.ctx.Err(); err != nil {
+		return err
+	}
+	if t.progress != nil {
+		t.progress(t.done, t.done)
+	}
+	return nil
+}
+
+// StronglyConnectedComponents returns the strongly connected components of
+// the graph using Tarjan's algorithm. Edges to keys that were never added as
+// nodes are ignored.
+func (g *Graph[K, V]) StronglyConnectedComponents() [][]K {
+	components, _ := g.StronglyConnectedComponentsCtx(context.Background())
+	return components
+}
+
+// StronglyConnectedComponentsCtx is StronglyConnectedComponents with
+// cancellation. Progress is measured in nodes visited.
+func (g *Graph[K, V]) StronglyConnectedComponentsCtx(ctx context.Context, opts ...AlgorithmOptions) ([][]K, error) {
+	if g.threadSafe && !g.sealed.Load() {
+		g.mu.RLock()
+		defer g.mu.RUnlock()
+	}
+	t := newTracker(ctx, len(g.nodes), opts)
+
+	type frame struct {
+		node K
+		succ []K
+		next int
+	}
+	index := make(map[K]int, len(g.nodes))
+	low := make(map[K]int, len(g.nodes))
+	onStack := make(map[K]bool)
+	var stack []K
+	var components [][]K
+
+	visit := func(node K) *frame {
+		index[node] = len(index)
+		low[node] = index[node]
+		stack = append(stack, node)
+		onStack[node] = true
+		f := &frame{node: node}
+		for to := range g.edges[node] {
+			if _, exists := g.nodes[to]; exists {
+				f.succ = append(f.succ, to)
+			}
+		}
+		return f
+	}
+
+	for root := range g.nodes {
+		if _, seen := index[root]; seen {
+			continue
+		}
+		frames := []*frame{visit(root)}
+		if err := t.step(1); err != nil {
+			return nil, err
+		}
+		for len(frames) > 0 {
+			f := frames[len(frames)-1]
+			if f.next < len(f.succ) {
+				to := f.succ[f.next]
+				f.next++
+				if _, seen := index[to]; !seen {
+					frames = append(frames, visit(to))
+					if err := t.step(1); err != nil {
+						return nil, err
+					}
+				} else if onStack[to] {
+					low[f.node] = min(low[f.node], index[to])
+				}
+				continue
+			}
+
+			frames = frames[:len(frames)-1]
+			if len(frames) > 0 {
+				parent := frames[len(frames)-1].node
+				low[parent] = min(low[parent], low[f.node])
+			}
+			if low[f.node] != index[f.node] {
+				continue
+			}
+			var component []K
+			for {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[top] = false
+				component = append(component, top)
+				if top == f.node {
+					break
+				}
+			}
+			components = append(components, component)
+		}
+	}
+	return components, t.finish()
+}
+
+// PageRank runs the given number of power iterations and returns each node's
+// rank. Ranks sum to 1; the rank of dangling nodes is spread evenly over all
+// nodes. Edges to keys that were never added as nodes are ignored.
+func (g *Graph[K, V]) PageRank(damping float64, iterations int) map[K]float64 {
+	ranks, _ := g.PageRankCtx(context.Background(), damping, iterations)
+	return ranks
+}
+
+// PageRankCtx is PageRank with cancellation. Each iteration pulls rank
+// along in-edges, split across Parallelism goroutines. Progress is measured
+// in edges relaxed.
+func (g *Graph[K, V]) PageRankCtx(ctx context.Context, damping float64, iterations int, opts ...AlgorithmOptions) (map[K]float64, error) {
+	if g.threadSafe && !g.sealed.Load() {
+		g.mu.RLock()
+		defer g.mu.RUnlock()
+	}
+	n := len(g.nodes)
+	if n == 0 {
+		return map[K]float64{}, nil
+	}
+	idx := g.index()
+	workers := mergeOptions(opts).Parallelism
+
+	t := newTracker(ctx, idx.edges*iterations, opts)
+	ranks := make([]float64, n)
+	for i := range ranks {
+		ranks[i] = 1 / float64(n)
+	}
+	next := make([]float64, n)
+	share := make([]float64, n)
+	for iter := 0; iter < iterations; iter++ {
+		dangling := 0.0
+		for i, targets := range idx.out {
+			if len(targets) == 0 {
+				dangling += ranks[i]
+				share[i] = 0
+			} else {
+				share[i] = ranks[i] / float64(len(targets))
+			}
+		}
+		base := (1-damping)/float64(n) + damping*dangling/float64(n)
+		parallelFor(n, workers, func(lo, hi int) {
+			for j := lo; j < hi; j++ {
+				sum := 0.0
+				for _, i := range idx.in[j] {
+					sum += share[i]
+				}
+				next[j] = base + damping*sum
+			}
+		})
+		ranks, next = next, ranks
+		if err := t.step(idx.edges); err != nil {
+			return nil, err
+		}
+	}
+
+	result := make(map[K]float64, n)
+	for i, key := range idx.keys {
+		result[key] = ranks[i]
+	}
+	return result, t.finish()
+}
+
+// AllSimplePaths returns every path from 'from' to 'to' that visits no node
+// twice. The number of paths can grow exponentially with the graph size.
+func (g *Graph[K, V]) AllSimplePaths(from, to K) [][]K {
+	paths, _ := g.AllSimplePathsCtx(context.Background(), from, to)
+	return paths
+}
+
+// AllSimplePathsCtx is AllSimplePaths with cancellation, which bounds the
+// exponential search on untrusted graphs. Progress is measured in nodes
+// expanded, and the total is reported as -1 until the search completes.
+func (g *Graph[K, V]) AllSimplePathsCtx(ctx context.Context, from, to K, opts ...AlgorithmOptions) ([][]K, error) {
+	if g.threadSafe && !g.sealed.Load() {
+		g.mu.RLock()
+		defer g.mu.RUnlock()
+	}
+	if _, exists := g.nodes[from]; !exists {
+		return nil, nil
+	}
+	if _, exists := g.nodes[to]; !exists {
+		return nil, nil
+	}
+	t := newTracker(ctx, -1, opts)
+
+	var paths [][]K
+	path := []K{from}
+	onPath := map[K]bool{from: true}
+	var walk func(node K) error
+	walk = func(node K) error {
+		if err := t.step(1); err != nil {
+			return err
+		}
+		if node == to {
+			paths = append(paths, append([]K(nil), path...))
+			return nil
+		}
+		for next := range g.edges[node] {
+			if _, exists := g.nodes[next]; !exists || onPath[next] {
+				continue
+			}
+			onPath[next] = true
+			path = append(path, next)
+			if err := walk(next); err != nil {
+				return err
+			}
+			path = path[:len(path)-1]
+			onPath[next] = false
+		}
+		return nil
+	}
+	if err := walk(from); err != nil {
+		return nil, err
+	}
+	return paths, t.finish()
+}
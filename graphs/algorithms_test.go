@@ -0,0 +1,124 @@
+package graphs
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func buildGraph(edges ...[2]string) *Graph[string, int] {
+	g := NewGraph[string, int](false)
+	for _, e := range edges {
+		g.AddNode(e[0], 0)
+		g.AddNode(e[1], 0)
+		g.AddEdge(e[0], e[1])
+	}
+	return g
+}
+
+func TestStronglyConnectedComponents(t *testing.T) {
+	g := buildGraph(
+		[2]string{"A", "B"}, [2]string{"B", "C"}, [2]string{"C", "A"},
+		[2]string{"C", "D"}, [2]string{"D", "E"}, [2]string{"E", "D"},
+		[2]string{"E", "F"},
+	)
+	var got []string
+	for _, component := range g.StronglyConnectedComponents() {
+		sort.Strings(component)
+		got = append(got, strings.Join(component, ""))
+	}
+	sort.Strings(got)
+	want := []string{"ABC", "DE", "F"}
+	if !slices.Equal(got, want) {
+		t.Errorf("StronglyConnectedComponents() = %v, want %v", got, want)
+	}
+}
+
+func TestPageRank(t *testing.T) {
+	g := buildGraph([2]string{"A", "B"}, [2]string{"B", "C"}, [2]string{"C", "A"}, [2]string{"D", "C"})
+	ranks := g.PageRank(0.85, 50)
+	sum := 0.0
+	for _, r := range ranks {
+		sum += r
+	}
+	if math.Abs(sum-1) > 1e-9 {
+		t.Errorf("PageRank() ranks sum to %v, want 1", sum)
+	}
+	if ranks["C"] <= ranks["B"] || ranks["D"] >= ranks["A"] {
+		t.Errorf("PageRank() = %v; C should outrank B and D should rank lowest", ranks)
+	}
+}
+
+func TestAllSimplePaths(t *testing.T) {
+	g := buildGraph(
+		[2]string{"A", "B"}, [2]string{"A", "C"}, [2]string{"B", "D"},
+		[2]string{"C", "D"}, [2]string{"D", "A"}, [2]string{"B", "C"},
+	)
+	var got []string
+	for _, path := range g.AllSimplePaths("A", "D") {
+		got = append(got, strings.Join(path, ""))
+	}
+	sort.Strings(got)
+	want := []string{"ABCD", "ABD", "ACD"}
+	if !slices.Equal(got, want) {
+		t.Errorf("AllSimplePaths(A, D) = %v, want %v", got, want)
+	}
+}
+
+func TestAlgorithmsCtxCancellation(t *testing.T) {
+	// A complete graph has factorially many simple paths
+	g := NewGraph[int, int](false)
+	for i := 0; i < 12; i++ {
+		g.AddNode(i, i)
+	}
+	for i := 0; i < 12; i++ {
+		for j := 0; j < 12; j++ {
+			if i != j {
+				g.AddEdge(i, j)
+			}
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	reports := 0
+	opts := AlgorithmOptions{Progress: func(done, total int) {
+		reports++
+		if done >= 10*checkInterval {
+			cancel()
+		}
+	}}
+	if _, err := g.AllSimplePathsCtx(ctx, 0, 11, opts); !errors.Is(err, context.Canceled) {
+		t.Errorf("AllSimplePathsCtx() error = %v, want context.Canceled", err)
+	}
+	if reports == 0 {
+		t.Error("AllSimplePathsCtx() never reported progress")
+	}
+
+	if _, err := g.PageRankCtx(ctx, 0.85, 10); !errors.Is(err, context.Canceled) {
+		t.Errorf("PageRankCtx() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestAlgorithmsProgressCompletes(t *testing.T) {
+	g := NewGraph[string, int](false)
+	for i := 0; i < 1000; i++ {
+		g.AddNode(strconv.Itoa(i), i)
+		if i > 0 {
+			g.AddEdge(strconv.Itoa(i-1), strconv.Itoa(i))
+		}
+	}
+	var lastDone, lastTotal int
+	opts := AlgorithmOptions{Progress: func(done, total int) { lastDone, lastTotal = done, total }}
+	components, err := g.StronglyConnectedComponentsCtx(context.Background(), opts)
+	if err != nil || len(components) != 1000 {
+		t.Fatalf("StronglyConnectedComponentsCtx() = %d components, %v", len(components), err)
+	}
+	if lastDone != 1000 || lastTotal != 1000 {
+		t.Errorf("final progress = %d/%d, want 1000/1000", lastDone, lastTotal)
+	}
+}
@@ -0,0 +1,111 @@
+package graphs
+
+import "errors"
+
+// ErrCycle is returned by DAG algorithms when the graph contains a cycle.
+var ErrCycle = errors.New("graphs: graph contains a cycle")
+
+// topoOrder returns node indices in topological order using Kahn's
+// algorithm, or ErrCycle.
+func (idx *indexedGraph[K]) topoOrder() ([]int, error) {
+	indegree := make([]int, len(idx.keys))
+	for j := range idx.in {
+		indegree[j] = len(idx.in[j])
+	}
+	order := make([]int, 0, len(idx.keys))
+	for i, d := range indegree {
+		if d == 0 {
+			order = append(order, i)
+		}
+	}
+	for head := 0; head < len(order); head++ {
+		for _, j := range idx.out[order[head]] {
+			indegree[j]--
+			if indegree[j] == 0 {
+				order = append(order, j)
+			}
+		}
+	}
+	if len(order) != len(idx.keys) {
+		return nil, ErrCycle
+	}
+	return order, nil
+}
+
+// depths returns, for each node, the number of edges on the longest path
+// ending at it, along with the predecessor on that path or -1.
+func (idx *indexedGraph[K]) depths(order []int) (depth, prev []int) {
+	depth = make([]int, len(idx.keys))
+	prev = make([]int, len(idx.keys))
+	for i := range prev {
+		prev[i] = -1
+	}
+	for _, i := range order {
+		for _, j := range idx.out[i] {
+			if depth[i]+1 > depth[j] {
+				depth[j] = depth[i] + 1
+				prev[j] = i
+			}
+		}
+	}
+	return depth, prev
+}
+
+// Layers assigns every node of a DAG to a layer: sources are in layer 0 and
+// every other node sits one layer below its deepest predecessor, so edges
+// always point to a later layer. Nodes in the same layer have no path between
+// them and can be scheduled together. Returns ErrCycle if the graph is not
+// acyclic.
+func (g *Graph[K, V]) Layers() ([][]K, error) {
+	if g.threadSafe && !g.sealed.Load() {
+		g.mu.RLock()
+		defer g.mu.RUnlock()
+	}
+	idx := g.index()
+	order, err := idx.topoOrder()
+	if err != nil {
+		return nil, err
+	}
+	depth, _ := idx.depths(order)
+	var layers [][]K
+	for _, i := range order {
+		for depth[i] >= len(layers) {
+			layers = append(layers, nil)
+		}
+		layers[depth[i]] = append(layers[depth[i]], idx.keys[i])
+	}
+	return layers, nil
+}
+
+// LongestPath returns a path with the most edges in a DAG, such as the
+// critical path of a build graph. Returns ErrCycle if the graph is not
+// acyclic.
+func (g *Graph[K, V]) LongestPath() ([]K, error) {
+	if g.threadSafe && !g.sealed.Load() {
+		g.mu.RLock()
+		defer g.mu.RUnlock()
+	}
+	idx := g.index()
+	order, err := idx.topoOrder()
+	if err != nil {
+		return nil, err
+	}
+	if len(order) == 0 {
+		return nil, nil
+	}
+	depth, prev := idx.depths(order)
+	end := order[0]
+	for i, d := range depth {
+		if d > depth[end] {
+			end = i
+		}
+	}
+	var path []K
+	for i := end; i != -1; i = prev[i] {
+		path = append(path, idx.keys[i])
+	}
+	for l, r := 0, len(path)-1; l < r; l, r = l+1, r-1 {
+		path[l], path[r] = path[r], path[l]
+	}
+	return path, nil
+}
@@ -0,0 +1,58 @@
+package graphs
+
+import (
+	"errors"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestLayers(t *testing.T) {
+	// compile -> link -> package, with test depending on compile and a
+	// lint step that nothing depends on
+	g := buildGraph(
+		[2]string{"compile", "link"}, [2]string{"link", "package"},
+		[2]string{"compile", "test"}, [2]string{"test", "package"},
+	)
+	g.AddNode("lint", 0)
+
+	layers, err := g.Layers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, layer := range layers {
+		sort.Strings(layer)
+	}
+	want := [][]string{{"compile", "lint"}, {"link", "test"}, {"package"}}
+	if !slices.EqualFunc(layers, want, slices.Equal[[]string]) {
+		t.Errorf("Layers() = %v, want %v", layers, want)
+	}
+}
+
+func TestLongestPath(t *testing.T) {
+	g := buildGraph(
+		[2]string{"A", "B"}, [2]string{"B", "C"}, [2]string{"C", "D"},
+		[2]string{"A", "D"}, [2]string{"E", "D"},
+	)
+	path, err := g.LongestPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"A", "B", "C", "D"}; !slices.Equal(path, want) {
+		t.Errorf("LongestPath() = %v, want %v", path, want)
+	}
+
+	if path, err := NewGraph[string, int](false).LongestPath(); err != nil || path != nil {
+		t.Errorf("LongestPath() on empty graph = %v, %v", path, err)
+	}
+}
+
+func TestDAGCycle(t *testing.T) {
+	g := buildGraph([2]string{"A", "B"}, [2]string{"B", "C"}, [2]string{"C", "A"})
+	if _, err := g.Layers(); !errors.Is(err, ErrCycle) {
+		t.Errorf("Layers() error = %v, want ErrCycle", err)
+	}
+	if _, err := g.LongestPath(); !errors.Is(err, ErrCycle) {
+		t.Errorf("LongestPath() error = %v, want ErrCycle", err)
+	}
+}
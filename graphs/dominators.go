@@ -0,0 +1,89 @@
+package graphs
+
+// Dominators returns the immediate dominator of every node reachable from
+// root: the closest node through which every path from root must pass. The
+// root itself and unreachable nodes have no entry. It uses the iterative
+// algorithm of Cooper, Harvey and Kennedy, which is quadratic in the worst
+// case but fast on the shallow graphs seen in practice.
+func (g *Graph[K, V]) Dominators(root K) map[K]K {
+	if g.threadSafe && !g.sealed.Load() {
+		g.mu.RLock()
+		defer g.mu.RUnlock()
+	}
+	if _, exists := g.nodes[root]; !exists {
+		return nil
+	}
+	idx := g.index()
+	start := idx.pos[root]
+
+	// Number reachable nodes in postorder with an iterative DFS
+	post := make([]int, len(idx.keys))
+	for i := range post {
+		post[i] = -1
+	}
+	var order []int
+	visited := make([]bool, len(idx.keys))
+	type frame struct{ node, next int }
+	stack := []frame{{node: start}}
+	visited[start] = true
+	for len(stack) > 0 {
+		f := &stack[len(stack)-1]
+		if f.next < len(idx.out[f.node]) {
+			to := idx.out[f.node][f.next]
+			f.next++
+			if !visited[to] {
+				visited[to] = true
+				stack = append(stack, frame{node: to})
+			}
+			continue
+		}
+		post[f.node] = len(order)
+		order = append(order, f.node)
+		stack = stack[:len(stack)-1]
+	}
+
+	idom := make([]int, len(idx.keys))
+	for i := range idom {
+		idom[i] = -1
+	}
+	idom[start] = start
+	intersect := func(a, b int) int {
+		for a != b {
+			for post[a] < post[b] {
+				a = idom[a]
+			}
+			for post[b] < post[a] {
+				b = idom[b]
+			}
+		}
+		return a
+	}
+	for changed := true; changed; {
+		changed = false
+		// Reverse postorder, skipping the root which comes last in order
+		for k := len(order) - 2; k >= 0; k-- {
+			node := order[k]
+			dom := -1
+			for _, pred := range idx.in[node] {
+				if idom[pred] == -1 {
+					continue
+				}
+				if dom == -1 {
+					dom = pred
+				} else {
+					dom = intersect(pred, dom)
+				}
+			}
+			if dom != idom[node] {
+				idom[node] = dom
+				changed = true
+			}
+		}
+	}
+
+	result := make(map[K]K, len(order)-1)
+	for _, node := range order[:len(order)-1] {
+		result[idx.keys[node]] = idx.keys[idom[node]]
+	}
+	return result
+}
@@ -0,0 +1,36 @@
+package graphs
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestDominators(t *testing.T) {
+	// A control-flow graph with a diamond and a loop:
+	//   entry -> cond -> then -> join -> loop -> join
+	//                \-> else -/           \-> exit
+	g := buildGraph(
+		[2]string{"entry", "cond"},
+		[2]string{"cond", "then"}, [2]string{"cond", "else"},
+		[2]string{"then", "join"}, [2]string{"else", "join"},
+		[2]string{"join", "loop"}, [2]string{"loop", "join"},
+		[2]string{"loop", "exit"},
+	)
+	g.AddNode("dead", 0)
+	g.AddEdge("dead", "exit")
+
+	want := map[string]string{
+		"cond": "entry",
+		"then": "cond",
+		"else": "cond",
+		"join": "cond",
+		"loop": "join",
+		"exit": "loop",
+	}
+	if got := g.Dominators("entry"); !maps.Equal(got, want) {
+		t.Errorf("Dominators(entry) = %v, want %v", got, want)
+	}
+	if got := g.Dominators("missing"); got != nil {
+		t.Errorf("Dominators(missing) = %v, want nil", got)
+	}
+}
@@ -0,0 +1,138 @@
+package graphs
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+)
+
+// ErrDependencyFailed is recorded by Execute for nodes that were not run
+// because a node they depend on failed.
+var ErrDependencyFailed = errors.New("graphs: dependency failed")
+
+// ExecuteOptions configures Execute.
+type ExecuteOptions struct {
+	// ContinueOnError keeps running nodes that do not depend on a failed
+	// node. By default no new node is started after the first failure.
+	ContinueOnError bool
+}
+
+// ExecuteError reports the nodes that failed during Execute. Errors maps
+// each failed node to the error its worker returned, and each node skipped
+// because of a failure upstream to ErrDependencyFailed.
+type ExecuteError[K comparable] struct {
+	Errors map[K]error
+}
+
+func (e *ExecuteError[K]) Error() string {
+	skipped := 0
+	for _, err := range e.Errors {
+		if err == ErrDependencyFailed {
+			skipped++
+		}
+	}
+	return fmt.Sprintf("graphs: %d nodes failed and %d were skipped", len(e.Errors)-skipped, skipped)
+}
+
+// Unwrap returns the node errors, so errors.Is and errors.As look through
+// an ExecuteError.
+func (e *ExecuteError[K]) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// Execute runs worker on every node of a DAG, each only after every node
+// with an edge to it has finished successfully, with up to parallelism
+// workers at a time. Zero or less means runtime.GOMAXPROCS(0). The graph is
+// snapshotted before the first worker starts, so workers may read or modify
+// it. Execute returns ErrCycle if the graph is not acyclic, an
+// *ExecuteError if any worker failed, and nil otherwise. Edges to keys that
+// were never added as nodes are ignored.
+func Execute[K comparable, V any](g *Graph[K, V], worker func(key K, value V) error, parallelism int, opts ...ExecuteOptions) error {
+	var opt ExecuteOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if parallelism <= 0 {
+		parallelism = runtime.GOMAXPROCS(0)
+	}
+
+	if g.threadSafe && !g.sealed.Load() {
+		g.mu.RLock()
+	}
+	idx := g.index()
+	values := make([]V, len(idx.keys))
+	for i, key := range idx.keys {
+		values[i] = g.nodes[key]
+	}
+	if g.threadSafe && !g.sealed.Load() {
+		g.mu.RUnlock()
+	}
+	if _, err := idx.topoOrder(); err != nil {
+		return err
+	}
+
+	type result struct {
+		node int
+		err  error
+	}
+	results := make(chan result)
+	waiting := make([]int, len(idx.keys))
+	blocked := make([]bool, len(idx.keys))
+	var ready []int
+	for i := range idx.keys {
+		waiting[i] = len(idx.in[i])
+		if waiting[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+	errs := make(map[K]error)
+
+	// finish releases the successors of a node that is done. A failed node
+	// blocks its successors, which are skipped, along with theirs, once
+	// their other dependencies are done.
+	var finish func(node int, failed bool)
+	finish = func(node int, failed bool) {
+		for _, next := range idx.out[node] {
+			blocked[next] = blocked[next] || failed
+			waiting[next]--
+			if waiting[next] > 0 {
+				continue
+			}
+			if blocked[next] {
+				errs[idx.keys[next]] = ErrDependencyFailed
+				finish(next, true)
+			} else {
+				ready = append(ready, next)
+			}
+		}
+	}
+
+	running := 0
+	stopped := false
+	for running > 0 || (len(ready) > 0 && !stopped) {
+		for !stopped && len(ready) > 0 && running < parallelism {
+			node := ready[0]
+			ready = ready[1:]
+			running++
+			go func() {
+				results <- result{node, worker(idx.keys[node], values[node])}
+			}()
+		}
+		r := <-results
+		running--
+		if r.err != nil {
+			errs[idx.keys[r.node]] = r.err
+			stopped = !opt.ContinueOnError
+		}
+		finish(r.node, r.err != nil)
+	}
+
+	if len(errs) > 0 {
+		return &ExecuteError[K]{Errors: errs}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package graphs
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// buildExecuteGraph returns a diamond a -> {b, c} -> d, plus e -> f independent of it.
+func buildExecuteGraph() *Graph[string, int] {
+	g := NewGraph[string, int]()
+	for i, key := range []string{"a", "b", "c", "d", "e", "f"} {
+		g.AddNode(key, i)
+	}
+	g.AddEdge("a", "b")
+	g.AddEdge("a", "c")
+	g.AddEdge("b", "d")
+	g.AddEdge("c", "d")
+	g.AddEdge("e", "f")
+	return g
+}
+
+func TestExecuteOrderAndParallelism(t *testing.T) {
+	g := buildExecuteGraph()
+	var mu sync.Mutex
+	done := make(map[string]bool)
+	var running, peak atomic.Int32
+
+	err := Execute(g, func(key string, value int) error {
+		now := running.Add(1)
+		defer running.Add(-1)
+		for {
+			old := peak.Load()
+			if now <= old || peak.CompareAndSwap(old, now) {
+				break
+			}
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		for _, pred := range g.Predecessors(key) {
+			if !done[pred] {
+				t.Errorf("%s ran before its dependency %s", key, pred)
+			}
+		}
+		done[key] = true
+		return nil
+	}, 2)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(done) != 6 {
+		t.Errorf("ran %d nodes, want 6", len(done))
+	}
+	if peak.Load() > 2 {
+		t.Errorf("peak parallelism = %d, want at most 2", peak.Load())
+	}
+}
+
+func TestExecuteErrors(t *testing.T) {
+	boom := errors.New("boom")
+	run := func(opts ...ExecuteOptions) (map[string]bool, error) {
+		var mu sync.Mutex
+		ran := make(map[string]bool)
+		err := Execute(buildExecuteGraph(), func(key string, _ int) error {
+			mu.Lock()
+			ran[key] = true
+			mu.Unlock()
+			if key == "b" {
+				return boom
+			}
+			return nil
+		}, 1, opts...)
+		return ran, err
+	}
+
+	ran, err := run(ExecuteOptions{ContinueOnError: true})
+	var execErr *ExecuteError[string]
+	if !errors.As(err, &execErr) {
+		t.Fatalf("Execute() error = %v, want an *ExecuteError", err)
+	}
+	if !errors.Is(err, boom) {
+		t.Error("errors.Is(err, boom) = false")
+	}
+	if execErr.Errors["b"] != boom || execErr.Errors["d"] != ErrDependencyFailed || len(execErr.Errors) != 2 {
+		t.Errorf("Errors = %v, want b failed and d skipped", execErr.Errors)
+	}
+	if ran["d"] || !ran["c"] || !ran["f"] {
+		t.Errorf("ran %v, want every node but d", ran)
+	}
+
+	ran, err = run()
+	if !errors.Is(err, boom) {
+		t.Fatalf("fail-fast Execute() error = %v, want boom", err)
+	}
+	if ran["d"] {
+		t.Error("fail-fast Execute() ran d after its dependency failed")
+	}
+}
+
+func TestExecuteCycle(t *testing.T) {
+	g := NewGraph[int, int]()
+	g.AddNode(1, 1)
+	g.AddNode(2, 2)
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 1)
+	err := Execute(g, func(int, int) error {
+		t.Error("worker ran on a cyclic graph")
+		return nil
+	}, 1)
+	if err != ErrCycle {
+		t.Errorf("Execute() error = %v, want %v", err, ErrCycle)
+	}
+}
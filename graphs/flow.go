@@ -0,0 +1,332 @@
+package graphs
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"sync/atomic"
+
+	"dsgo/utils"
+)
+
+var (
+	// ErrNegativeCapacity is returned when an arc is given a negative capacity.
+	ErrNegativeCapacity = errors.New("graphs: negative arc capacity")
+	// ErrUnknownNode is returned when an operation names a node that is not
+	// in the graph or, for flows, has no arcs.
+	ErrUnknownNode = errors.New("graphs: unknown node")
+	// ErrUnknownEdge is returned when removing an edge that does not exist.
+	ErrUnknownEdge = errors.New("graphs: unknown edge")
+	// ErrSameEndpoints is returned when the source and sink are the same node.
+	ErrSameEndpoints = errors.New("graphs: source and sink are the same node")
+	// ErrFlowOverflow is returned when the capacities leaving the source and
+	// those entering the sink both add up to more than an int64 holds, so
+	// the flow value could overflow.
+	ErrFlowOverflow = errors.New("graphs: flow value may overflow int64")
+)
+
+// ResidualArc is an arc of a flow network's residual graph.
+type ResidualArc[K comparable] struct {
+	From, To K
+	Capacity int64
+}
+
+type flowArc struct {
+	to       int
+	capacity int64
+	flow     int64
+}
+
+// FlowNetwork is a directed graph whose arcs carry integer capacities. It
+// computes maximum flows with Dinic's algorithm and keeps the last flow so
+// the residual graph and minimum cut can be inspected.
+type FlowNetwork[K comparable] struct {
+	threadSafe bool
+	mu         sync.RWMutex
+	sealed     atomic.Bool
+	pos        map[K]int
+	keys       []K
+	// arcs holds every arc followed by its reverse, so arc i pairs with i^1
+	arcs []flowArc
+	adj  [][]int
+}
+
+// NewFlowNetwork creates an empty flow network. If threadSafe is true, the
+// network will be safe for concurrent access.
+func NewFlowNetwork[K comparable](threadSafe ...bool) *FlowNetwork[K] {
+	isThreadSafe := true
+	if len(threadSafe) > 0 {
+		isThreadSafe = threadSafe[0]
+	}
+	return &FlowNetwork[K]{
+		threadSafe: isThreadSafe,
+		pos:        make(map[K]int),
+	}
+}
+
+func (f *FlowNetwork[K]) node(key K) int {
+	if i, exists := f.pos[key]; exists {
+		return i
+	}
+	f.pos[key] = len(f.keys)
+	f.keys = append(f.keys, key)
+	f.adj = append(f.adj, nil)
+	return len(f.keys) - 1
+}
+
+// AddArc adds an arc from 'from' to 'to' with the given capacity, creating
+// the endpoints as needed. Parallel arcs add up. Self-loops are accepted but
+// never carry flow.
+func (f *FlowNetwork[K]) AddArc(from, to K, capacity int64) error {
+	if f.threadSafe {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+	}
+	if f.sealed.Load() {
+		return utils.ErrSealed
+	}
+	if capacity < 0 {
+		return ErrNegativeCapacity
+	}
+	u, v := f.node(from), f.node(to)
+	f.adj[u] = append(f.adj[u], len(f.arcs))
+	f.arcs = append(f.arcs, flowArc{to: v, capacity: capacity})
+	f.adj[v] = append(f.adj[v], len(f.arcs))
+	f.arcs = append(f.arcs, flowArc{to: u})
+	return nil
+}
+
+// MaxFlow computes a maximum flow from source to sink and returns its value.
+// The flow replaces any previous one and is visible through Flow and
+// Residual. It returns ErrFlowOverflow rather than a wrapped value if the
+// flow could exceed math.MaxInt64.
+func (f *FlowNetwork[K]) MaxFlow(source, sink K) (int64, error) {
+	return f.MaxFlowCtx(context.Background(), source, sink)
+}
+
+// MaxFlowCtx is MaxFlow with cancellation. Progress is measured in
+// augmenting paths found, with an unknown total. If ctx is cancelled the
+// flow found so far, which is valid but not maximal, is left for Flow and
+// Residual.
+func (f *FlowNetwork[K]) MaxFlowCtx(ctx context.Context, source, sink K, opts ...AlgorithmOptions) (int64, error) {
+	if f.threadSafe {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+	}
+	if f.sealed.Load() {
+		return 0, utils.ErrSealed
+	}
+	s, t, err := f.prepare(source, sink)
+	if err != nil {
+		return 0, err
+	}
+	return f.maxFlow(s, t, newTracker(ctx, -1, opts))
+}
+
+// prepare resolves the endpoints of a flow computation and checks that its
+// value fits in an int64.
+func (f *FlowNetwork[K]) prepare(source, sink K) (int, int, error) {
+	s, t, err := f.endpoints(source, sink)
+	if err != nil {
+		return 0, 0, err
+	}
+	if !f.bounded(s, false) && !f.bounded(t, true) {
+		return 0, 0, ErrFlowOverflow
+	}
+	return s, t, nil
+}
+
+// bounded reports whether the capacities of the arcs leaving u, or
+// entering it if in is set, add up to at most math.MaxInt64. The flow
+// through u can then not overflow.
+func (f *FlowNetwork[K]) bounded(u int, in bool) bool {
+	var sum int64
+	for _, a := range f.adj[u] {
+		// Forward arcs are even, so an odd arc at u is the reverse of an arc
+		// into u and a&^1 is the forward arc of either
+		if (a%2 == 1) != in {
+			continue
+		}
+		c := f.arcs[a&^1].capacity
+		if c > math.MaxInt64-sum {
+			return false
+		}
+		sum += c
+	}
+	return true
+}
+
+// MinCut computes a maximum flow from source to sink and returns the arcs of
+// a minimum cut: saturated arcs leading from the nodes still reachable from
+// the source in the residual graph to the rest. Their capacities sum to the
+// maximum flow value. It returns ErrFlowOverflow as MaxFlow does.
+func (f *FlowNetwork[K]) MinCut(source, sink K) ([][2]K, error) {
+	if f.threadSafe {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+	}
+	if f.sealed.Load() {
+		return nil, utils.ErrSealed
+	}
+	s, t, err := f.prepare(source, sink)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.maxFlow(s, t, newTracker(context.Background(), -1, nil)); err != nil {
+		return nil, err
+	}
+	level := f.levels(s)
+	var cut [][2]K
+	for u, arcs := range f.adj {
+		if level[u] < 0 {
+			continue
+		}
+		for _, a := range arcs {
+			arc := f.arcs[a]
+			if a%2 == 0 && level[arc.to] < 0 {
+				cut = append(cut, [2]K{f.keys[u], f.keys[arc.to]})
+			}
+		}
+	}
+	return cut, nil
+}
+
+// Flow returns the flow on the arcs from 'from' to 'to' after the last
+// MaxFlow or MinCut.
+func (f *FlowNetwork[K]) Flow(from, to K) int64 {
+	if f.threadSafe && !f.sealed.Load() {
+		f.mu.RLock()
+		defer f.mu.RUnlock()
+	}
+	u, uok := f.pos[from]
+	v, vok := f.pos[to]
+	if !uok || !vok {
+		return 0
+	}
+	var total int64
+	for _, a := range f.adj[u] {
+		if a%2 == 0 && f.arcs[a].to == v {
+			total += f.arcs[a].flow
+		}
+	}
+	return total
+}
+
+// Residual returns the arcs of the residual graph of the last computed
+// flow: forward arcs with spare capacity and backward arcs along which flow
+// could be cancelled. Before any flow is computed it is the network itself.
+func (f *FlowNetwork[K]) Residual() []ResidualArc[K] {
+	if f.threadSafe && !f.sealed.Load() {
+		f.mu.RLock()
+		defer f.mu.RUnlock()
+	}
+	var residual []ResidualArc[K]
+	for u, arcs := range f.adj {
+		for _, a := range arcs {
+			arc := f.arcs[a]
+			if spare := arc.capacity - arc.flow; spare > 0 {
+				residual = append(residual, ResidualArc[K]{From: f.keys[u], To: f.keys[arc.to], Capacity: spare})
+			}
+		}
+	}
+	return residual
+}
+
+func (f *FlowNetwork[K]) endpoints(source, sink K) (int, int, error) {
+	s, sok := f.pos[source]
+	t, tok := f.pos[sink]
+	if !sok || !tok {
+		return 0, 0, ErrUnknownNode
+	}
+	if s == t {
+		return 0, 0, ErrSameEndpoints
+	}
+	return s, t, nil
+}
+
+// maxFlow runs Dinic's algorithm from s to t, starting from zero flow, and
+// stops early once tr's context is cancelled. The caller must hold the
+// write lock.
+func (f *FlowNetwork[K]) maxFlow(s, t int, tr *tracker) (int64, error) {
+	for i := range f.arcs {
+		f.arcs[i].flow = 0
+	}
+	var total int64
+	for {
+		level := f.levels(s)
+		if level[t] < 0 {
+			return total, tr.finish()
+		}
+		next := make([]int, len(f.adj))
+		for {
+			pushed := f.augment(s, t, math.MaxInt64, level, next)
+			if pushed == 0 {
+				break
+			}
+			total += pushed
+			if err := tr.step(1); err != nil {
+				return total, err
+			}
+		}
+	}
+}
+
+// levels returns each node's BFS distance from s in the residual graph, or
+// -1 for unreachable nodes.
+func (f *FlowNetwork[K]) levels(s int) []int {
+	level := make([]int, len(f.adj))
+	for i := range level {
+		level[i] = -1
+	}
+	level[s] = 0
+	queue := []int{s}
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		for _, a := range f.adj[u] {
+			arc := f.arcs[a]
+			if arc.capacity > arc.flow && level[arc.to] < 0 {
+				level[arc.to] = level[u] + 1
+				queue = append(queue, arc.to)
+			}
+		}
+	}
+	return level
+}
+
+// augment pushes up to limit units along a path of increasing level from u
+// to t, resuming each node's arc scan at next[u] so dead ends are skipped.
+func (f *FlowNetwork[K]) augment(u, t int, limit int64, level, next []int) int64 {
+	if u == t {
+		return limit
+	}
+	for ; next[u] < len(f.adj[u]); next[u]++ {
+		a := f.adj[u][next[u]]
+		arc := &f.arcs[a]
+		if arc.capacity <= arc.flow || level[arc.to] != level[u]+1 {
+			continue
+		}
+		if pushed := f.augment(arc.to, t, min(limit, arc.capacity-arc.flow), level, next); pushed > 0 {
+			arc.flow += pushed
+			f.arcs[a^1].flow -= pushed
+			return pushed
+		}
+	}
+	return 0
+}
+
+// Seal makes the network read-only. AddArc, MaxFlow and MinCut return
+// utils.ErrSealed afterwards. Reads on a sealed network skip locking.
+func (f *FlowNetwork[K]) Seal() {
+	if f.threadSafe {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+	}
+	f.sealed.Store(true)
+}
+
+// IsSealed reports whether Seal has been called.
+func (f *FlowNetwork[K]) IsSealed() bool {
+	return f.sealed.Load()
+}
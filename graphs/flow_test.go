@@ -0,0 +1,132 @@
+package graphs
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"dsgo/utils"
+)
+
+// clrsNetwork is the flow network from CLRS figure 26.1, with a maximum
+// flow of 23.
+func clrsNetwork() *FlowNetwork[string] {
+	f := NewFlowNetwork[string](false)
+	arcs := []struct {
+		from, to string
+		capacity int64
+	}{
+		{"s", "v1", 16}, {"s", "v2", 13}, {"v2", "v1", 4}, {"v1", "v3", 12},
+		{"v3", "v2", 9}, {"v2", "v4", 14}, {"v4", "v3", 7}, {"v3", "t", 20},
+		{"v4", "t", 4},
+	}
+	for _, a := range arcs {
+		if err := f.AddArc(a.from, a.to, a.capacity); err != nil {
+			panic(err)
+		}
+	}
+	return f
+}
+
+func TestFlowNetworkMaxFlow(t *testing.T) {
+	f := clrsNetwork()
+	flow, err := f.MaxFlow("s", "t")
+	if err != nil || flow != 23 {
+		t.Fatalf("MaxFlow(s, t) = %d, %v, want 23", flow, err)
+	}
+	if in := f.Flow("v3", "t") + f.Flow("v4", "t"); in != 23 {
+		t.Errorf("flow into sink = %d, want 23", in)
+	}
+	for _, arc := range f.Residual() {
+		if arc.Capacity <= 0 {
+			t.Errorf("Residual() contains non-positive arc %v", arc)
+		}
+		if arc.From == "s" && arc.To == "v1" && arc.Capacity > 16 {
+			t.Errorf("Residual() arc %v exceeds its capacity", arc)
+		}
+	}
+}
+
+func TestFlowNetworkMinCut(t *testing.T) {
+	f := clrsNetwork()
+	cut, err := f.MinCut("s", "t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	capacity := map[[2]string]int64{
+		{"v1", "v3"}: 12, {"v4", "v3"}: 7, {"v4", "t"}: 4,
+	}
+	var total int64
+	for _, arc := range cut {
+		c, ok := capacity[arc]
+		if !ok {
+			t.Errorf("MinCut() contains unexpected arc %v", arc)
+		}
+		total += c
+	}
+	if total != 23 {
+		t.Errorf("MinCut() = %v with capacity %d, want 23", cut, total)
+	}
+}
+
+func TestFlowNetworkValidation(t *testing.T) {
+	f := NewFlowNetwork[int](false)
+	if err := f.AddArc(1, 2, -1); !errors.Is(err, ErrNegativeCapacity) {
+		t.Errorf("AddArc() with negative capacity error = %v, want ErrNegativeCapacity", err)
+	}
+	f.AddArc(1, 2, 5)
+	if _, err := f.MaxFlow(1, 3); !errors.Is(err, ErrUnknownNode) {
+		t.Errorf("MaxFlow() to unknown node error = %v, want ErrUnknownNode", err)
+	}
+	if _, err := f.MaxFlow(1, 1); !errors.Is(err, ErrSameEndpoints) {
+		t.Errorf("MaxFlow(1, 1) error = %v, want ErrSameEndpoints", err)
+	}
+	if flow, _ := f.MaxFlow(2, 1); flow != 0 {
+		t.Errorf("MaxFlow(2, 1) = %d, want 0", flow)
+	}
+
+	f.Seal()
+	if err := f.AddArc(2, 3, 1); err != utils.ErrSealed {
+		t.Errorf("AddArc() on sealed network error = %v, want ErrSealed", err)
+	}
+}
+
+func TestFlowNetworkMaxFlowCtx(t *testing.T) {
+	// Each of 300 disjoint paths needs its own augmentation, which is
+	// enough for the context to be checked
+	f := NewFlowNetwork[int](false)
+	for i := 2; i < 302; i++ {
+		f.AddArc(0, i, 1)
+		f.AddArc(i, 1, 1)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := f.MaxFlowCtx(ctx, 0, 1); !errors.Is(err, context.Canceled) {
+		t.Errorf("MaxFlowCtx() error = %v, want context.Canceled", err)
+	}
+	if flow, err := f.MaxFlowCtx(context.Background(), 0, 1); err != nil || flow != 300 {
+		t.Errorf("MaxFlowCtx() = %d, %v, want 300, nil", flow, err)
+	}
+}
+
+func TestFlowNetworkOverflow(t *testing.T) {
+	f := NewFlowNetwork[int](false)
+	f.AddArc(0, 1, math.MaxInt64)
+	f.AddArc(0, 2, 1)
+	f.AddArc(1, 3, math.MaxInt64)
+	f.AddArc(2, 3, 1)
+	if _, err := f.MaxFlow(0, 3); !errors.Is(err, ErrFlowOverflow) {
+		t.Errorf("MaxFlow() error = %v, want ErrFlowOverflow", err)
+	}
+	parallel := NewFlowNetwork[int](false)
+	parallel.AddArc(0, 1, math.MaxInt64)
+	parallel.AddArc(0, 1, math.MaxInt64)
+	if cut, err := parallel.MinCut(0, 1); !errors.Is(err, ErrFlowOverflow) {
+		t.Errorf("MinCut() over parallel arcs = %v, %v, want ErrFlowOverflow", cut, err)
+	}
+	// The capacities into 2 are bounded, so so is the flow
+	if flow, err := f.MaxFlow(0, 2); err != nil || flow != 1 {
+		t.Errorf("MaxFlow(0, 2) = %d, %v, want 1, nil", flow, err)
+	}
+}
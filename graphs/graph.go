@@ -4,13 +4,36 @@ import (
 	"fmt"
 	"sort"
 	"sync"
+	"sync/atomic"
+
+	"dsgo/cache"
+	"dsgo/maps"
+	"dsgo/utils"
 )
 
 type Graph[K comparable, V any] struct {
 	threadSafe bool
 	mu         sync.RWMutex
+	sealed     atomic.Bool
 	nodes      map[K]V
 	edges      map[K]map[K]struct{}
+	// inEdges mirrors edges in reverse so predecessors can be found without
+	// scanning every adjacency map
+	inEdges map[K]map[K]struct{}
+	// gen counts mutations that can change paths through the graph: node
+	// membership, edges and edge weights
+	gen   uint64
+	paths *cache.LRUCache[[2]K, cachedPath[K]]
+	// nodeOrder and edgeOrder record insertion order once WithStableOrder
+	// has been called, and are nil otherwise
+	nodeOrder *maps.OrderedMap[K, struct{}]
+	edgeOrder *maps.OrderedMap[[2]K, struct{}]
+	// meta holds the display metadata set by SetNodeMeta, and is nil
+	// until it is first used
+	meta map[K]NodeMeta
+	// weights holds edge weights that differ from the default of 1, and is
+	// nil until a weight is set
+	weights map[[2]K]float64
 }
 
 // NewGraph creates a new graph. If threadSafe is true, the graph will be safe for concurrent access.
@@ -23,6 +46,7 @@ func NewGraph[K comparable, V any](threadSafe ...bool) *Graph[K, V] {
 		threadSafe: isThreadSafe,
 		nodes:      make(map[K]V),
 		edges:      make(map[K]map[K]struct{}),
+		inEdges:    make(map[K]map[K]struct{}),
 	}
 }
 
@@ -32,7 +56,15 @@ func (g *Graph[K, V]) AddNode(key K, value V) {
 		g.mu.Lock()
 		defer g.mu.Unlock()
 	}
+	if g.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
+	if _, exists := g.nodes[key]; !exists {
+		// A new node can complete paths that were cached as missing
+		g.gen++
+	}
 	g.nodes[key] = value
+	g.recordNode(key)
 }
 
 // AddEdge adds a directed edge from 'from' to 'to'.
@@ -41,15 +73,18 @@ func (g *Graph[K, V]) AddEdge(from, to K) {
 		g.mu.Lock()
 		defer g.mu.Unlock()
 	}
-	if _, exists := g.edges[from]; !exists {
-		g.edges[from] = make(map[K]struct{})
+	if g.sealed.Load() {
+		panic(utils.ErrSealed)
 	}
-	g.edges[from][to] = struct{}{}
+	addAdjacent(g.edges, from, to)
+	addAdjacent(g.inEdges, to, from)
+	g.recordEdge(from, to)
+	g.gen++
 }
 
 // HasNode checks if a node with the given key exists.
 func (g *Graph[K, V]) HasNode(key K) bool {
-	if g.threadSafe {
+	if g.threadSafe && !g.sealed.Load() {
 		g.mu.RLock()
 		defer g.mu.RUnlock()
 	}
@@ -59,7 +94,7 @@ func (g *Graph[K, V]) HasNode(key K) bool {
 
 // HasEdge checks if an edge exists from 'from' to 'to'.
 func (g *Graph[K, V]) HasEdge(from, to K) bool {
-	if g.threadSafe {
+	if g.threadSafe && !g.sealed.Load() {
 		g.mu.RLock()
 		defer g.mu.RUnlock()
 	}
@@ -70,18 +105,33 @@ func (g *Graph[K, V]) HasEdge(from, to K) bool {
 	return false
 }
 
-// RemoveNode removes a node and all its associated edges.
+// RemoveNode removes a node and all its associated edges in O(degree).
 func (g *Graph[K, V]) RemoveNode(key K) {
 	if g.threadSafe {
 		g.mu.Lock()
 		defer g.mu.Unlock()
 	}
-	delete(g.nodes, key)
-	delete(g.edges, key)
-	// Remove all edges pointing to this node
-	for _, neighbors := range g.edges {
-		delete(neighbors, key)
+	if g.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
+	g.removeNode(key)
+}
+
+// TryRemoveNode is RemoveNode for callers that need to know the node
+// existed. It returns ErrUnknownNode, leaving the graph unchanged, otherwise.
+func (g *Graph[K, V]) TryRemoveNode(key K) error {
+	if g.threadSafe {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+	}
+	if g.sealed.Load() {
+		return utils.ErrSealed
+	}
+	if _, exists := g.nodes[key]; !exists {
+		return ErrUnknownNode
 	}
+	g.removeNode(key)
+	return nil
 }
 
 // RemoveEdge removes the edge from 'from' to 'to'.
@@ -90,14 +140,77 @@ func (g *Graph[K, V]) RemoveEdge(from, to K) {
 		g.mu.Lock()
 		defer g.mu.Unlock()
 	}
-	if neighbors, exists := g.edges[from]; exists {
-		delete(neighbors, to)
+	if g.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
+	g.removeEdge(from, to)
+}
+
+// TryRemoveEdge is RemoveEdge for callers that need to know the edge
+// existed. It returns ErrUnknownEdge, leaving the graph unchanged, otherwise.
+func (g *Graph[K, V]) TryRemoveEdge(from, to K) error {
+	if g.threadSafe {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+	}
+	if g.sealed.Load() {
+		return utils.ErrSealed
+	}
+	if _, exists := g.edges[from][to]; !exists {
+		return ErrUnknownEdge
+	}
+	g.removeEdge(from, to)
+	return nil
+}
+
+func (g *Graph[K, V]) removeNode(key K) {
+	delete(g.nodes, key)
+	delete(g.meta, key)
+	g.forgetNode(key)
+	g.forgetNodeEdges(key)
+	for to := range g.edges[key] {
+		removeAdjacent(g.inEdges, to, key)
+		delete(g.weights, [2]K{key, to})
+	}
+	for from := range g.inEdges[key] {
+		removeAdjacent(g.edges, from, key)
+		delete(g.weights, [2]K{from, key})
+	}
+	delete(g.edges, key)
+	delete(g.inEdges, key)
+	g.gen++
+}
+
+func (g *Graph[K, V]) removeEdge(from, to K) {
+	removeAdjacent(g.edges, from, to)
+	removeAdjacent(g.inEdges, to, from)
+	g.forgetEdge(from, to)
+	delete(g.weights, [2]K{from, to})
+	g.gen++
+}
+
+// addAdjacent records b in a's entry of adj, creating the entry if needed.
+func addAdjacent[K comparable](adj map[K]map[K]struct{}, a, b K) {
+	if _, exists := adj[a]; !exists {
+		adj[a] = make(map[K]struct{})
+	}
+	adj[a][b] = struct{}{}
+}
+
+// removeAdjacent drops b from a's entry of adj, releasing the entry once it
+// is empty.
+func removeAdjacent[K comparable](adj map[K]map[K]struct{}, a, b K) {
+	if neighbors, exists := adj[a]; exists {
+		delete(neighbors, b)
+		if len(neighbors) == 0 {
+			delete(adj, a)
+		}
 	}
 }
 
 // GetNeighbors returns all neighbors of the given node.
 func (g *Graph[K, V]) GetNeighbors(key K) []K {
-	if g.threadSafe {
+	if g.threadSafe && !g.sealed.Load() {
 		g.mu.RLock()
 		defer g.mu.RUnlock()
 	}
@@ -111,12 +224,91 @@ func (g *Graph[K, V]) GetNeighbors(key K) []K {
 	return nil
 }
 
-// GetNodes returns all node keys in the graph.
+// Predecessors returns all nodes with an edge pointing to the given node.
+func (g *Graph[K, V]) Predecessors(key K) []K {
+	if g.threadSafe && !g.sealed.Load() {
+		g.mu.RLock()
+		defer g.mu.RUnlock()
+	}
+	if preds, exists := g.inEdges[key]; exists {
+		result := make([]K, 0, len(preds))
+		for pred := range preds {
+			result = append(result, pred)
+		}
+		return result
+	}
+	return nil
+}
+
+// InEdges returns all edges pointing to the given node as [from, to] pairs.
+func (g *Graph[K, V]) InEdges(key K) [][2]K {
+	if g.threadSafe && !g.sealed.Load() {
+		g.mu.RLock()
+		defer g.mu.RUnlock()
+	}
+	edges := make([][2]K, 0, len(g.inEdges[key]))
+	for from := range g.inEdges[key] {
+		edges = append(edges, [2]K{from, key})
+	}
+	return edges
+}
+
+// InDegree returns the number of edges pointing to the given node.
+func (g *Graph[K, V]) InDegree(key K) int {
+	if g.threadSafe && !g.sealed.Load() {
+		g.mu.RLock()
+		defer g.mu.RUnlock()
+	}
+	return len(g.inEdges[key])
+}
+
+// OutDegree returns the number of edges leaving the given node.
+func (g *Graph[K, V]) OutDegree(key K) int {
+	if g.threadSafe && !g.sealed.Load() {
+		g.mu.RLock()
+		defer g.mu.RUnlock()
+	}
+	return len(g.edges[key])
+}
+
+// AppendNeighbors appends the neighbors of the given node to dst and
+// returns the extended slice, letting callers reuse a buffer across calls.
+func (g *Graph[K, V]) AppendNeighbors(dst []K, key K) []K {
+	if g.threadSafe && !g.sealed.Load() {
+		g.mu.RLock()
+		defer g.mu.RUnlock()
+	}
+	for neighbor := range g.edges[key] {
+		dst = append(dst, neighbor)
+	}
+	return dst
+}
+
+// AppendNodes appends all node keys to dst and returns the extended slice.
+func (g *Graph[K, V]) AppendNodes(dst []K) []K {
+	if g.threadSafe && !g.sealed.Load() {
+		g.mu.RLock()
+		defer g.mu.RUnlock()
+	}
+	if g.nodeOrder != nil {
+		return g.nodeOrder.AppendKeys(dst)
+	}
+	for node := range g.nodes {
+		dst = append(dst, node)
+	}
+	return dst
+}
+
+// GetNodes returns all node keys in the graph, in insertion order if
+// WithStableOrder has been called.
 func (g *Graph[K, V]) GetNodes() []K {
-	if g.threadSafe {
+	if g.threadSafe && !g.sealed.Load() {
 		g.mu.RLock()
 		defer g.mu.RUnlock()
 	}
+	if g.nodeOrder != nil {
+		return g.nodeOrder.Keys()
+	}
 	nodes := make([]K, 0, len(g.nodes))
 	for node := range g.nodes {
 		nodes = append(nodes, node)
@@ -124,12 +316,16 @@ func (g *Graph[K, V]) GetNodes() []K {
 	return nodes
 }
 
-// GetEdges returns all edges in the graph as pairs of [from, to] keys.
+// GetEdges returns all edges in the graph as pairs of [from, to] keys, in
+// insertion order if WithStableOrder has been called.
 func (g *Graph[K, V]) GetEdges() [][2]K {
-	if g.threadSafe {
+	if g.threadSafe && !g.sealed.Load() {
 		g.mu.RLock()
 		defer g.mu.RUnlock()
 	}
+	if g.edgeOrder != nil {
+		return g.edgeOrder.Keys()
+	}
 	edges := make([][2]K, 0)
 	for from, neighbors := range g.edges {
 		for to := range neighbors {
@@ -141,7 +337,7 @@ func (g *Graph[K, V]) GetEdges() [][2]K {
 
 // GetNodeValue returns the value associated with a node key.
 func (g *Graph[K, V]) GetNodeValue(key K) (V, bool) {
-	if g.threadSafe {
+	if g.threadSafe && !g.sealed.Load() {
 		g.mu.RLock()
 		defer g.mu.RUnlock()
 	}
@@ -149,9 +345,50 @@ func (g *Graph[K, V]) GetNodeValue(key K) (V, bool) {
 	return value, exists
 }
 
+// UpdateNodeValue replaces the value of key with fn applied to it, holding
+// the graph's write lock across the read and the write so concurrent
+// updates to the same node cannot interleave. It returns ErrUnknownNode if
+// key is not in the graph. fn must not call back into the graph.
+func (g *Graph[K, V]) UpdateNodeValue(key K, fn func(V) V) error {
+	if g.threadSafe {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+	}
+	if g.sealed.Load() {
+		return utils.ErrSealed
+	}
+	value, exists := g.nodes[key]
+	if !exists {
+		return ErrUnknownNode
+	}
+	g.nodes[key] = fn(value)
+	return nil
+}
+
+// RangeNodes calls fn with every node and its value until fn returns false,
+// in insertion order if WithStableOrder has been called. The read lock is
+// held throughout, so fn sees a consistent graph but must not modify it.
+func (g *Graph[K, V]) RangeNodes(fn func(key K, value V) bool) {
+	if g.threadSafe && !g.sealed.Load() {
+		g.mu.RLock()
+		defer g.mu.RUnlock()
+	}
+	if g.nodeOrder != nil {
+		g.nodeOrder.Range(func(key K, _ struct{}) bool {
+			return fn(key, g.nodes[key])
+		})
+		return
+	}
+	for key, value := range g.nodes {
+		if !fn(key, value) {
+			return
+		}
+	}
+}
+
 // BFS performs a breadth-first search starting from the given node.
 func (g *Graph[K, V]) BFS(start K) []K {
-	if g.threadSafe {
+	if g.threadSafe && !g.sealed.Load() {
 		g.mu.RLock()
 		defer g.mu.RUnlock()
 	}
@@ -164,13 +401,18 @@ func (g *Graph[K, V]) BFS(start K) []K {
 	result := make([]K, 0)
 	visited[start] = true
 
+	// neighbors is scratch space reused for every node
+	var neighbors []K
 	for len(queue) > 0 {
 		node := queue[0]
 		queue = queue[1:]
 		result = append(result, node)
 
 		// Get neighbors and sort them to ensure consistent order
-		neighbors := g.GetNeighbors(node)
+		neighbors = neighbors[:0]
+		for neighbor := range g.edges[node] {
+			neighbors = append(neighbors, neighbor)
+		}
 		sort.Slice(neighbors, func(i, j int) bool {
 			return fmt.Sprintf("%v", neighbors[i]) < fmt.Sprintf("%v", neighbors[j])
 		})
@@ -187,7 +429,7 @@ func (g *Graph[K, V]) BFS(start K) []K {
 
 // DFS performs a depth-first search starting from the given node.
 func (g *Graph[K, V]) DFS(start K) []K {
-	if g.threadSafe {
+	if g.threadSafe && !g.sealed.Load() {
 		g.mu.RLock()
 		defer g.mu.RUnlock()
 	}
@@ -217,3 +459,18 @@ func (g *Graph[K, V]) DFS(start K) []K {
 	dfs(start)
 	return result
 }
+
+// Seal makes the graph read-only. Mutations after Seal panic with
+// utils.ErrSealed. Reads on a sealed graph skip locking.
+func (g *Graph[K, V]) Seal() {
+	if g.threadSafe {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+	}
+	g.sealed.Store(true)
+}
+
+// IsSealed reports whether Seal has been called.
+func (g *Graph[K, V]) IsSealed() bool {
+	return g.sealed.Load()
+}
@@ -1,7 +1,11 @@
 package graphs
 
 import (
+	"slices"
+	"sort"
 	"testing"
+
+	"dsgo/utils"
 )
 
 func TestNewGraph(t *testing.T) {
@@ -125,6 +129,51 @@ func TestGraphGetNodesAndEdges(t *testing.T) {
 	}
 }
 
+func TestGraphPredecessors(t *testing.T) {
+	g := NewGraph[string, int](false)
+	for i, key := range []string{"A", "B", "C", "D"} {
+		g.AddNode(key, i)
+	}
+	g.AddEdge("A", "C")
+	g.AddEdge("B", "C")
+	g.AddEdge("C", "D")
+	g.AddEdge("C", "C")
+
+	preds := g.Predecessors("C")
+	sort.Strings(preds)
+	if want := []string{"A", "B", "C"}; !slices.Equal(preds, want) {
+		t.Errorf("Predecessors(C) = %v, want %v", preds, want)
+	}
+	if got := g.InDegree("C"); got != 3 {
+		t.Errorf("InDegree(C) = %d, want 3", got)
+	}
+	if got := g.OutDegree("C"); got != 2 {
+		t.Errorf("OutDegree(C) = %d, want 2", got)
+	}
+	for _, edge := range g.InEdges("D") {
+		if edge != [2]string{"C", "D"} {
+			t.Errorf("InEdges(D) contains %v", edge)
+		}
+	}
+
+	g.RemoveNode("C")
+	if g.HasEdge("A", "C") || g.HasEdge("B", "C") {
+		t.Error("RemoveNode should remove edges pointing to the node")
+	}
+	if preds := g.Predecessors("D"); preds != nil {
+		t.Errorf("Predecessors(D) = %v after removing C, want nil", preds)
+	}
+	if len(g.edges) != 0 || len(g.inEdges) != 0 {
+		t.Errorf("adjacency maps not released: edges %v, inEdges %v", g.edges, g.inEdges)
+	}
+
+	g.AddEdge("A", "B")
+	g.RemoveEdge("A", "B")
+	if g.InDegree("B") != 0 || len(g.inEdges) != 0 {
+		t.Error("RemoveEdge should drop the reverse edge")
+	}
+}
+
 func TestSafeGraphConcurrent(t *testing.T) {
 	sg := NewGraph[string, int](true)
 
@@ -180,3 +229,120 @@ func TestGraphEdgeCases(t *testing.T) {
 	g.RemoveNode("X")      // Should not panic
 	g.RemoveEdge("X", "Y") // Should not panic
 }
+
+func TestGraphSeal(t *testing.T) {
+	g := NewGraph[string, int]()
+	g.AddNode("A", 1)
+	g.AddNode("B", 2)
+	g.AddEdge("A", "B")
+	g.Seal()
+
+	if bfs := g.BFS("A"); len(bfs) != 2 {
+		t.Errorf("BFS on sealed graph returned %v", bfs)
+	}
+	defer func() {
+		if r := recover(); r != utils.ErrSealed {
+			t.Errorf("AddEdge on sealed graph should panic with ErrSealed, got %v", r)
+		}
+	}()
+	g.AddEdge("B", "A")
+}
+
+func TestGraphAppendNodes(t *testing.T) {
+	g := NewGraph[string, int]()
+	g.AddNode("A", 1)
+	g.AddNode("B", 2)
+	g.AddEdge("A", "B")
+
+	nodes := g.AppendNodes([]string{"x"})
+	sort.Strings(nodes[1:])
+	if want := []string{"x", "A", "B"}; !slices.Equal(nodes, want) {
+		t.Errorf("AppendNodes() = %v, want %v", nodes, want)
+	}
+	buf := make([]string, 0, 4)
+	if allocs := testing.AllocsPerRun(100, func() { buf = g.AppendNeighbors(buf[:0], "A") }); allocs != 0 || len(buf) != 1 {
+		t.Errorf("AppendNeighbors() = %v with %v allocations", buf, allocs)
+	}
+}
+
+func TestGraphTryRemove(t *testing.T) {
+	g := NewGraph[string, int](false)
+	g.AddNode("a", 1)
+	g.AddNode("b", 2)
+	g.AddEdge("a", "b")
+
+	if err := g.TryRemoveEdge("b", "a"); err != ErrUnknownEdge {
+		t.Errorf("TryRemoveEdge(b, a) error = %v, want %v", err, ErrUnknownEdge)
+	}
+	if err := g.TryRemoveEdge("a", "b"); err != nil {
+		t.Errorf("TryRemoveEdge(a, b) error = %v", err)
+	}
+	if g.HasEdge("a", "b") {
+		t.Error("edge a->b still present after TryRemoveEdge")
+	}
+	if err := g.TryRemoveNode("c"); err != ErrUnknownNode {
+		t.Errorf("TryRemoveNode(c) error = %v, want %v", err, ErrUnknownNode)
+	}
+	if err := g.TryRemoveNode("a"); err != nil || g.HasNode("a") {
+		t.Errorf("TryRemoveNode(a) error = %v, HasNode(a) = %v", err, g.HasNode("a"))
+	}
+
+	g.Seal()
+	if err := g.TryRemoveNode("b"); err != utils.ErrSealed {
+		t.Errorf("TryRemoveNode() on sealed graph error = %v, want %v", err, utils.ErrSealed)
+	}
+}
+
+func TestGraphUpdateNodeValue(t *testing.T) {
+	g := NewGraph[string, int](true)
+	g.AddNode("counter", 0)
+
+	done := make(chan bool)
+	for i := 0; i < 50; i++ {
+		go func() {
+			if err := g.UpdateNodeValue("counter", func(v int) int { return v + 1 }); err != nil {
+				t.Errorf("UpdateNodeValue() error = %v", err)
+			}
+			done <- true
+		}()
+	}
+	for i := 0; i < 50; i++ {
+		<-done
+	}
+	if value, _ := g.GetNodeValue("counter"); value != 50 {
+		t.Errorf("GetNodeValue(counter) = %d, want 50", value)
+	}
+
+	if err := g.UpdateNodeValue("missing", func(v int) int { return v }); err != ErrUnknownNode {
+		t.Errorf("UpdateNodeValue(missing) error = %v, want %v", err, ErrUnknownNode)
+	}
+	g.Seal()
+	if err := g.UpdateNodeValue("counter", func(v int) int { return v }); err != utils.ErrSealed {
+		t.Errorf("UpdateNodeValue() on sealed graph error = %v, want %v", err, utils.ErrSealed)
+	}
+}
+
+func TestGraphRangeNodes(t *testing.T) {
+	g := NewGraph[string, int](false).WithStableOrder()
+	g.AddNode("c", 3)
+	g.AddNode("a", 1)
+	g.AddNode("b", 2)
+
+	var keys []string
+	sum := 0
+	g.RangeNodes(func(key string, value int) bool {
+		keys = append(keys, key)
+		sum += value
+		return true
+	})
+	if !slices.Equal(keys, []string{"c", "a", "b"}) || sum != 6 {
+		t.Errorf("RangeNodes visited %v with sum %d, want [c a b] and 6", keys, sum)
+	}
+
+	visited := 0
+	NewGraph[int, int]().RangeNodes(func(int, int) bool { visited++; return true })
+	g.RangeNodes(func(string, int) bool { visited++; return false })
+	if visited != 1 {
+		t.Errorf("RangeNodes made %d calls, want 1 after stopping early", visited)
+	}
+}
@@ -0,0 +1,101 @@
+package graphs
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"dsgo/concurrencytest"
+)
+
+// graphModel tracks which of three nodes exist and which edges between
+// them. As in Graph, an edge may be added before its endpoints.
+type graphModel struct {
+	nodes [3]bool
+	edges [3][3]bool
+}
+
+func cloneGraphModel(m *graphModel) *graphModel {
+	c := *m
+	return &c
+}
+
+func graphOp(r *rand.Rand) concurrencytest.Op[*Graph[int, int], *graphModel] {
+	a, b := r.Intn(3), r.Intn(3)
+	switch r.Intn(7) {
+	case 0:
+		return concurrencytest.Op[*Graph[int, int], *graphModel]{
+			Name:  fmt.Sprintf("AddNode(%d)", a),
+			Run:   func(g *Graph[int, int]) any { g.AddNode(a, 0); return nil },
+			Model: func(m *graphModel) any { m.nodes[a] = true; return nil },
+		}
+	case 1:
+		return concurrencytest.Op[*Graph[int, int], *graphModel]{
+			Name: fmt.Sprintf("RemoveNode(%d)", a),
+			Run:  func(g *Graph[int, int]) any { g.RemoveNode(a); return nil },
+			Model: func(m *graphModel) any {
+				m.nodes[a] = false
+				for i := range m.edges {
+					m.edges[a][i], m.edges[i][a] = false, false
+				}
+				return nil
+			},
+		}
+	case 2:
+		return concurrencytest.Op[*Graph[int, int], *graphModel]{
+			Name:  fmt.Sprintf("AddEdge(%d, %d)", a, b),
+			Run:   func(g *Graph[int, int]) any { g.AddEdge(a, b); return nil },
+			Model: func(m *graphModel) any { m.edges[a][b] = true; return nil },
+		}
+	case 3:
+		return concurrencytest.Op[*Graph[int, int], *graphModel]{
+			Name:  fmt.Sprintf("RemoveEdge(%d, %d)", a, b),
+			Run:   func(g *Graph[int, int]) any { g.RemoveEdge(a, b); return nil },
+			Model: func(m *graphModel) any { m.edges[a][b] = false; return nil },
+		}
+	case 4:
+		return concurrencytest.Op[*Graph[int, int], *graphModel]{
+			Name:  fmt.Sprintf("HasNode(%d)", a),
+			Run:   func(g *Graph[int, int]) any { return g.HasNode(a) },
+			Model: func(m *graphModel) any { return m.nodes[a] },
+		}
+	case 5:
+		return concurrencytest.Op[*Graph[int, int], *graphModel]{
+			Name:  fmt.Sprintf("HasEdge(%d, %d)", a, b),
+			Run:   func(g *Graph[int, int]) any { return g.HasEdge(a, b) },
+			Model: func(m *graphModel) any { return m.edges[a][b] },
+		}
+	default:
+		return concurrencytest.Op[*Graph[int, int], *graphModel]{
+			Name: "GetNodes()",
+			Run: func(g *Graph[int, int]) any {
+				nodes := append([]int{}, g.GetNodes()...)
+				slices.Sort(nodes)
+				return nodes
+			},
+			Model: func(m *graphModel) any {
+				nodes := []int{}
+				for node, exists := range m.nodes {
+					if exists {
+						nodes = append(nodes, node)
+					}
+				}
+				return nodes
+			},
+		}
+	}
+}
+
+func TestGraphLinearizable(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		concurrencytest.Run(t, concurrencytest.Script[*Graph[int, int], *graphModel]{
+			New:      func() *Graph[int, int] { return NewGraph[int, int]() },
+			NewModel: func() *graphModel { return &graphModel{} },
+			Clone:    cloneGraphModel,
+			Threads:  concurrencytest.RandomThreads(r, 4, 6, graphOp),
+			Runs:     10,
+		})
+	}
+}
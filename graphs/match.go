@@ -0,0 +1,253 @@
+package graphs
+
+// MatchOptions configures IsIsomorphic and FindSubgraphMatches.
+type MatchOptions[K comparable, V any] struct {
+	// NodeMatch, if set, must accept a pattern node's value and the value
+	// of the graph node it is mapped to.
+	NodeMatch func(patternValue, value V) bool
+	// EdgeMatch, if set, must accept every pattern edge and the graph edge
+	// it is mapped to.
+	EdgeMatch func(patternFrom, patternTo, from, to K) bool
+	// Limit caps the number of matches returned. Zero means no limit.
+	Limit int
+}
+
+// matcher holds the state of a VF2-style backtracking search mapping
+// pattern nodes onto graph nodes.
+type matcher[K comparable, V any] struct {
+	pattern, target *Graph[K, V]
+	opts            MatchOptions[K, V]
+	order           []K
+	mapping         map[K]K
+	used            map[K]bool
+	matches         []map[K]K
+}
+
+// IsIsomorphic reports whether other has the same shape as the graph: a
+// one-to-one mapping of nodes that preserves every edge and satisfies the
+// predicates in opts. The search is exponential in the worst case and is
+// meant for small graphs.
+func (g *Graph[K, V]) IsIsomorphic(other *Graph[K, V], opts ...MatchOptions[K, V]) bool {
+	var o MatchOptions[K, V]
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	o.Limit = 1
+	if g == other {
+		return len(g.FindSubgraphMatches(other, o)) == 1
+	}
+	// other is copied under its own lock before g is locked, as in
+	// trees.Diff, so g.IsIsomorphic(h) and h.IsIsomorphic(g) cannot deadlock
+	other = other.matchSnapshot()
+	if g.threadSafe && !g.sealed.Load() {
+		g.mu.RLock()
+		defer g.mu.RUnlock()
+	}
+	if len(g.nodes) != len(other.nodes) || g.edgeCount() != other.edgeCount() {
+		return false
+	}
+	return len(g.findMatches(other, o)) == 1
+}
+
+// FindSubgraphMatches returns the ways pattern embeds in the graph, each as
+// a map from pattern node to graph node. Distinct pattern nodes map to
+// distinct graph nodes and every pattern edge must map to a graph edge; the
+// graph may have extra edges between matched nodes. The search is
+// exponential in the worst case and is meant for small patterns.
+func (g *Graph[K, V]) FindSubgraphMatches(pattern *Graph[K, V], opts ...MatchOptions[K, V]) []map[K]K {
+	var o MatchOptions[K, V]
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	if pattern != g {
+		// Copied first for the same reason as in IsIsomorphic
+		pattern = pattern.matchSnapshot()
+	}
+	if g.threadSafe && !g.sealed.Load() {
+		g.mu.RLock()
+		defer g.mu.RUnlock()
+	}
+	return g.findMatches(pattern, o)
+}
+
+// matchSnapshot returns an unlocked copy of the nodes and edges of the
+// graph, taken under its read lock, for use as the other side of a match.
+func (g *Graph[K, V]) matchSnapshot() *Graph[K, V] {
+	if g.threadSafe && !g.sealed.Load() {
+		g.mu.RLock()
+		defer g.mu.RUnlock()
+	}
+	copyAdj := func(adj map[K]map[K]struct{}) map[K]map[K]struct{} {
+		out := make(map[K]map[K]struct{}, len(adj))
+		for node, set := range adj {
+			inner := make(map[K]struct{}, len(set))
+			for next := range set {
+				inner[next] = struct{}{}
+			}
+			out[node] = inner
+		}
+		return out
+	}
+	nodes := make(map[K]V, len(g.nodes))
+	for key, value := range g.nodes {
+		nodes[key] = value
+	}
+	return &Graph[K, V]{nodes: nodes, edges: copyAdj(g.edges), inEdges: copyAdj(g.inEdges)}
+}
+
+// edgeCount counts edges between nodes. The caller must hold the read lock.
+func (g *Graph[K, V]) edgeCount() int {
+	count := 0
+	for from, neighbors := range g.edges {
+		if _, exists := g.nodes[from]; !exists {
+			continue
+		}
+		for to := range neighbors {
+			if _, exists := g.nodes[to]; exists {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// findMatches runs the search. The caller must hold the read lock of g;
+// pattern is either g or a snapshot.
+func (g *Graph[K, V]) findMatches(pattern *Graph[K, V], opts MatchOptions[K, V]) []map[K]K {
+	if len(pattern.nodes) > len(g.nodes) {
+		return nil
+	}
+	m := &matcher[K, V]{
+		pattern: pattern,
+		target:  g,
+		opts:    opts,
+		mapping: make(map[K]K, len(pattern.nodes)),
+		used:    make(map[K]bool, len(pattern.nodes)),
+	}
+	m.order = pattern.matchOrder()
+	m.search(0)
+	return m.matches
+}
+
+// matchOrder lists the nodes so that each one, where possible, is adjacent
+// to an earlier one, letting the search draw candidates from the
+// neighbours of already-mapped nodes.
+func (g *Graph[K, V]) matchOrder() []K {
+	order := make([]K, 0, len(g.nodes))
+	seen := make(map[K]bool, len(g.nodes))
+	for root := range g.nodes {
+		if seen[root] {
+			continue
+		}
+		seen[root] = true
+		for queue := []K{root}; len(queue) > 0; queue = queue[1:] {
+			node := queue[0]
+			order = append(order, node)
+			for _, adj := range []map[K]struct{}{g.edges[node], g.inEdges[node]} {
+				for next := range adj {
+					if _, exists := g.nodes[next]; exists && !seen[next] {
+						seen[next] = true
+						queue = append(queue, next)
+					}
+				}
+			}
+		}
+	}
+	return order
+}
+
+func (m *matcher[K, V]) done() bool {
+	return m.opts.Limit > 0 && len(m.matches) >= m.opts.Limit
+}
+
+func (m *matcher[K, V]) search(depth int) {
+	if depth == len(m.order) {
+		match := make(map[K]K, len(m.mapping))
+		for k, v := range m.mapping {
+			match[k] = v
+		}
+		m.matches = append(m.matches, match)
+		return
+	}
+	p := m.order[depth]
+	for _, t := range m.candidates(p) {
+		if m.done() {
+			return
+		}
+		if m.used[t] || !m.feasible(p, t) {
+			continue
+		}
+		m.mapping[p] = t
+		m.used[t] = true
+		m.search(depth + 1)
+		delete(m.mapping, p)
+		delete(m.used, t)
+	}
+}
+
+// candidates returns the graph nodes p could map to: the neighbours of the
+// image of an already-mapped neighbour of p, or every node if p has none.
+func (m *matcher[K, V]) candidates(p K) []K {
+	var result []K
+	for q := range m.pattern.inEdges[p] {
+		if t, ok := m.mapping[q]; ok {
+			for next := range m.target.edges[t] {
+				result = append(result, next)
+			}
+			return result
+		}
+	}
+	for q := range m.pattern.edges[p] {
+		if t, ok := m.mapping[q]; ok {
+			for prev := range m.target.inEdges[t] {
+				result = append(result, prev)
+			}
+			return result
+		}
+	}
+	for node := range m.target.nodes {
+		result = append(result, node)
+	}
+	return result
+}
+
+// feasible reports whether mapping p to t keeps the partial mapping
+// consistent with every pattern edge between p and already-mapped nodes.
+func (m *matcher[K, V]) feasible(p, t K) bool {
+	pv, pok := m.pattern.nodes[p]
+	tv, tok := m.target.nodes[t]
+	if !pok || !tok {
+		return false
+	}
+	if len(m.pattern.edges[p]) > len(m.target.edges[t]) || len(m.pattern.inEdges[p]) > len(m.target.inEdges[t]) {
+		return false
+	}
+	if m.opts.NodeMatch != nil && !m.opts.NodeMatch(pv, tv) {
+		return false
+	}
+	image := func(q K) (K, bool) {
+		if q == p {
+			return t, true
+		}
+		mapped, ok := m.mapping[q]
+		return mapped, ok
+	}
+	for q := range m.pattern.edges[p] {
+		if mq, ok := image(q); ok && !m.hasEdge(p, q, t, mq) {
+			return false
+		}
+	}
+	for q := range m.pattern.inEdges[p] {
+		if mq, ok := image(q); ok && !m.hasEdge(q, p, mq, t) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *matcher[K, V]) hasEdge(pFrom, pTo, from, to K) bool {
+	if _, exists := m.target.edges[from][to]; !exists {
+		return false
+	}
+	return m.opts.EdgeMatch == nil || m.opts.EdgeMatch(pFrom, pTo, from, to)
+}
@@ -0,0 +1,91 @@
+package graphs
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestIsIsomorphic(t *testing.T) {
+	cycle := buildGraph([2]string{"A", "B"}, [2]string{"B", "C"}, [2]string{"C", "A"})
+	relabeled := buildGraph([2]string{"x", "z"}, [2]string{"z", "y"}, [2]string{"y", "x"})
+	path := buildGraph([2]string{"A", "B"}, [2]string{"B", "C"}, [2]string{"A", "C"})
+
+	if !cycle.IsIsomorphic(relabeled) {
+		t.Error("IsIsomorphic() = false for relabeled cycle")
+	}
+	if cycle.IsIsomorphic(path) {
+		t.Error("IsIsomorphic() = true for cycle and transitive triangle")
+	}
+	if !cycle.IsIsomorphic(cycle) {
+		t.Error("IsIsomorphic() = false for a graph and itself")
+	}
+
+	relabeled.AddNode("x", 1)
+	onlyZeros := MatchOptions[string, int]{NodeMatch: func(a, b int) bool { return a == b }}
+	if cycle.IsIsomorphic(relabeled, onlyZeros) {
+		t.Error("IsIsomorphic() ignored NodeMatch")
+	}
+}
+
+func TestFindSubgraphMatches(t *testing.T) {
+	// Two routers each feeding two hosts, plus a link between the routers
+	g := buildGraph(
+		[2]string{"r1", "h1"}, [2]string{"r1", "h2"},
+		[2]string{"r2", "h3"}, [2]string{"r2", "h4"},
+		[2]string{"r1", "r2"},
+	)
+	fanOut := buildGraph([2]string{"hub", "a"}, [2]string{"hub", "b"})
+
+	matches := g.FindSubgraphMatches(fanOut)
+	// r1 has three successors (6 ordered pairs), r2 has two (2 pairs)
+	if len(matches) != 8 {
+		t.Fatalf("FindSubgraphMatches() found %d matches, want 8", len(matches))
+	}
+	for _, match := range matches {
+		if !g.HasEdge(match["hub"], match["a"]) || !g.HasEdge(match["hub"], match["b"]) || match["a"] == match["b"] {
+			t.Errorf("invalid match %v", match)
+		}
+	}
+
+	noRouterLeaves := MatchOptions[string, int]{
+		EdgeMatch: func(_, _, from, to string) bool { return to[0] == 'h' },
+		Limit:     1,
+	}
+	matches = g.FindSubgraphMatches(fanOut, noRouterLeaves)
+	if len(matches) != 1 || matches[0]["a"][0] != 'h' || matches[0]["b"][0] != 'h' {
+		t.Errorf("FindSubgraphMatches() with EdgeMatch and Limit = %v", matches)
+	}
+
+	big := buildGraph([2]string{"1", "2"}, [2]string{"2", "3"}, [2]string{"3", "4"}, [2]string{"4", "5"}, [2]string{"5", "6"}, [2]string{"6", "7"})
+	if matches := fanOut.FindSubgraphMatches(big); matches != nil {
+		t.Errorf("FindSubgraphMatches() with a larger pattern = %v, want nil", matches)
+	}
+}
+
+func TestIsIsomorphicConcurrent(t *testing.T) {
+	path := func(nodes ...string) *Graph[string, int] {
+		g := NewGraph[string, int]()
+		for i, node := range nodes {
+			g.AddNode(node, 0)
+			if i > 0 {
+				g.AddEdge(nodes[i-1], node)
+			}
+		}
+		return g
+	}
+	a, b := path("A", "B", "C"), path("x", "y", "z")
+
+	// Comparing each way while both graphs take writes must not deadlock
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(4)
+		go func() { defer wg.Done(); a.IsIsomorphic(b) }()
+		go func() { defer wg.Done(); b.FindSubgraphMatches(a) }()
+		go func() { defer wg.Done(); a.AddNode("A", 0) }()
+		go func() { defer wg.Done(); b.AddNode("x", 0) }()
+	}
+	wg.Wait()
+	if !a.IsIsomorphic(b) {
+		t.Error("IsIsomorphic() = false for relabeled paths")
+	}
+}
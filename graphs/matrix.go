@@ -0,0 +1,94 @@
+package graphs
+
+// AdjacencyMatrix returns the graph as a dense matrix along with the node
+// keys that label its rows and columns: entry [i][j] is the weight of the
+// edge from keys[i] to keys[j], which is 1 unless it has been changed, and 0
+// if there is no such edge. Keys are in insertion order if WithStableOrder
+// has been called.
+func (g *Graph[K, V]) AdjacencyMatrix() ([][]float64, []K) {
+	if g.threadSafe && !g.sealed.Load() {
+		g.mu.RLock()
+		defer g.mu.RUnlock()
+	}
+	return g.adjacencyMatrix()
+}
+
+// DegreeMatrix returns the diagonal matrix of out-degrees, each the sum of
+// the node's outgoing edge weights, labelled as in AdjacencyMatrix. For an
+// undirected graph, stored as edges in both directions, this is the usual
+// degree matrix.
+func (g *Graph[K, V]) DegreeMatrix() ([][]float64, []K) {
+	if g.threadSafe && !g.sealed.Load() {
+		g.mu.RLock()
+		defer g.mu.RUnlock()
+	}
+	adj, keys := g.adjacencyMatrix()
+	deg := newMatrix(len(keys))
+	for i, row := range adj {
+		deg[i][i] = rowSum(row)
+	}
+	return deg, keys
+}
+
+// LaplacianMatrix returns L = D - A, the degree matrix minus the adjacency
+// matrix, labelled as in AdjacencyMatrix. Its spectrum is the input to
+// spectral clustering; for an undirected graph L is symmetric and the
+// number of zero eigenvalues is the number of connected components.
+func (g *Graph[K, V]) LaplacianMatrix() ([][]float64, []K) {
+	if g.threadSafe && !g.sealed.Load() {
+		g.mu.RLock()
+		defer g.mu.RUnlock()
+	}
+	lap, keys := g.adjacencyMatrix()
+	for i, row := range lap {
+		degree := rowSum(row)
+		for j := range row {
+			row[j] = -row[j]
+		}
+		row[i] += degree
+	}
+	return lap, keys
+}
+
+func (g *Graph[K, V]) adjacencyMatrix() ([][]float64, []K) {
+	var keys []K
+	if g.nodeOrder != nil {
+		keys = g.nodeOrder.Keys()
+	} else {
+		keys = make([]K, 0, len(g.nodes))
+		for key := range g.nodes {
+			keys = append(keys, key)
+		}
+	}
+	pos := make(map[K]int, len(keys))
+	for i, key := range keys {
+		pos[key] = i
+	}
+	adj := newMatrix(len(keys))
+	for i, key := range keys {
+		for to := range g.edges[key] {
+			if j, exists := pos[to]; exists {
+				adj[i][j] = g.edgeWeight(key, to)
+			}
+		}
+	}
+	return adj, keys
+}
+
+// newMatrix allocates an n by n zero matrix backed by one slice.
+func newMatrix(n int) [][]float64 {
+	cells := make([]float64, n*n)
+	m := make([][]float64, n)
+	for i := range m {
+		m[i] = cells[i*n : (i+1)*n : (i+1)*n]
+	}
+	return m
+}
+
+func rowSum(row []float64) float64 {
+	sum := 0.0
+	for _, v := range row {
+		sum += v
+	}
+	return sum
+}
@@ -0,0 +1,91 @@
+package graphs
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGraphMatrices(t *testing.T) {
+	// An undirected path a - b - c, stored as edges both ways, plus an
+	// isolated node d.
+	g := NewGraph[string, int](false).WithStableOrder()
+	for _, key := range []string{"a", "b", "c", "d"} {
+		g.AddNode(key, 0)
+	}
+	for _, e := range [][2]string{{"a", "b"}, {"b", "c"}} {
+		g.AddEdge(e[0], e[1])
+		g.AddEdge(e[1], e[0])
+	}
+	g.AddEdge("a", "zz")
+
+	adj, keys := g.AdjacencyMatrix()
+	if !slices.Equal(keys, []string{"a", "b", "c", "d"}) {
+		t.Fatalf("AdjacencyMatrix() keys = %v", keys)
+	}
+	wantAdj := [][]float64{
+		{0, 1, 0, 0},
+		{1, 0, 1, 0},
+		{0, 1, 0, 0},
+		{0, 0, 0, 0},
+	}
+	wantDeg := [][]float64{
+		{1, 0, 0, 0},
+		{0, 2, 0, 0},
+		{0, 0, 1, 0},
+		{0, 0, 0, 0},
+	}
+	wantLap := [][]float64{
+		{1, -1, 0, 0},
+		{-1, 2, -1, 0},
+		{0, -1, 1, 0},
+		{0, 0, 0, 0},
+	}
+	deg, _ := g.DegreeMatrix()
+	lap, _ := g.LaplacianMatrix()
+	for i := range keys {
+		if !slices.Equal(adj[i], wantAdj[i]) {
+			t.Errorf("AdjacencyMatrix() row %d = %v, want %v", i, adj[i], wantAdj[i])
+		}
+		if !slices.Equal(deg[i], wantDeg[i]) {
+			t.Errorf("DegreeMatrix() row %d = %v, want %v", i, deg[i], wantDeg[i])
+		}
+		if !slices.Equal(lap[i], wantLap[i]) {
+			t.Errorf("LaplacianMatrix() row %d = %v, want %v", i, lap[i], wantLap[i])
+		}
+	}
+
+	// Rows must not share storage past their length
+	adj[0] = append(adj[0], 9)
+	if adj[1][0] != 1 {
+		t.Error("appending to a row overwrote the next row")
+	}
+}
+
+func TestGraphMatricesUnordered(t *testing.T) {
+	g := NewGraph[int, int]()
+	g.AddNode(1, 0)
+	g.AddNode(2, 0)
+	g.AddEdge(1, 2)
+	adj, keys := g.AdjacencyMatrix()
+	i, j := slices.Index(keys, 1), slices.Index(keys, 2)
+	if adj[i][j] != 1 || adj[j][i] != 0 {
+		t.Errorf("AdjacencyMatrix() = %v for keys %v, want only 1->2", adj, keys)
+	}
+}
+
+func TestGraphMatricesWeighted(t *testing.T) {
+	g := NewGraph[string, int](false).WithStableOrder()
+	g.AddNode("a", 0)
+	g.AddNode("b", 0)
+	g.AddEdge("a", "b")
+	g.AddEdge("b", "a")
+	g.UpdateEdgeWeight("a", "b", func(float64) float64 { return 2.5 })
+
+	adj, _ := g.AdjacencyMatrix()
+	if want := [][]float64{{0, 2.5}, {1, 0}}; !slices.Equal(adj[0], want[0]) || !slices.Equal(adj[1], want[1]) {
+		t.Errorf("AdjacencyMatrix() = %v, want %v", adj, want)
+	}
+	if deg, _ := g.DegreeMatrix(); deg[0][0] != 2.5 {
+		t.Errorf("DegreeMatrix()[0][0] = %v, want 2.5", deg[0][0])
+	}
+}
@@ -0,0 +1,70 @@
+package graphs
+
+import (
+	"dsgo/maps"
+	"dsgo/utils"
+)
+
+// WithStableOrder makes GetNodes, AppendNodes and GetEdges return results in
+// insertion order rather than map order, so snapshots and exports are
+// reproducible. Nodes and edges already in the graph are recorded in an
+// arbitrary order, so call it on an empty graph. Removing a node or edge
+// from a stably ordered graph costs time linear in the graph's size. It
+// panics with utils.ErrSealed on a sealed graph.
+func (g *Graph[K, V]) WithStableOrder() *Graph[K, V] {
+	if g.threadSafe {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+	}
+	if g.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
+	if g.nodeOrder != nil {
+		return g
+	}
+	g.nodeOrder = maps.NewOrderedMap[K, struct{}](false)
+	g.edgeOrder = maps.NewOrderedMap[[2]K, struct{}](false)
+	for key := range g.nodes {
+		g.nodeOrder.Set(key, struct{}{})
+	}
+	for from, neighbors := range g.edges {
+		for to := range neighbors {
+			g.edgeOrder.Set([2]K{from, to}, struct{}{})
+		}
+	}
+	return g
+}
+
+func (g *Graph[K, V]) recordNode(key K) {
+	if g.nodeOrder != nil {
+		g.nodeOrder.Set(key, struct{}{})
+	}
+}
+
+func (g *Graph[K, V]) recordEdge(from, to K) {
+	if g.edgeOrder != nil {
+		g.edgeOrder.Set([2]K{from, to}, struct{}{})
+	}
+}
+
+func (g *Graph[K, V]) forgetNode(key K) {
+	if g.nodeOrder != nil {
+		g.nodeOrder.Delete(key)
+	}
+}
+
+func (g *Graph[K, V]) forgetEdge(from, to K) {
+	if g.edgeOrder != nil {
+		g.edgeOrder.Delete([2]K{from, to})
+	}
+}
+
+// forgetNodeEdges drops every edge into or out of key from the edge order
+// in a single pass, rather than one linear-time Delete per edge.
+func (g *Graph[K, V]) forgetNodeEdges(key K) {
+	if g.edgeOrder != nil {
+		g.edgeOrder.DeleteFunc(func(edge [2]K, _ struct{}) bool {
+			return edge[0] == key || edge[1] == key
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package graphs
+
+import (
+	"slices"
+	"testing"
+
+	"dsgo/utils"
+)
+
+func TestGraphStableOrder(t *testing.T) {
+	g := NewGraph[string, int](false).WithStableOrder()
+	for i, key := range []string{"e", "b", "d", "a", "c"} {
+		g.AddNode(key, i)
+	}
+	g.AddNode("b", 10)
+	g.AddEdge("d", "a")
+	g.AddEdge("b", "c")
+	g.AddEdge("e", "d")
+	g.AddEdge("a", "b")
+	g.AddEdge("d", "a")
+
+	wantNodes := []string{"e", "b", "d", "a", "c"}
+	if got := g.GetNodes(); !slices.Equal(got, wantNodes) {
+		t.Errorf("GetNodes() = %v, want %v", got, wantNodes)
+	}
+	if got := g.AppendNodes(nil); !slices.Equal(got, wantNodes) {
+		t.Errorf("AppendNodes() = %v, want %v", got, wantNodes)
+	}
+	wantEdges := [][2]string{{"d", "a"}, {"b", "c"}, {"e", "d"}, {"a", "b"}}
+	if got := g.GetEdges(); !slices.Equal(got, wantEdges) {
+		t.Errorf("GetEdges() = %v, want %v", got, wantEdges)
+	}
+
+	g.RemoveNode("d")
+	g.RemoveEdge("b", "c")
+	if got, want := g.GetNodes(), []string{"e", "b", "a", "c"}; !slices.Equal(got, want) {
+		t.Errorf("GetNodes() after removals = %v, want %v", got, want)
+	}
+	if got, want := g.GetEdges(), [][2]string{{"a", "b"}}; !slices.Equal(got, want) {
+		t.Errorf("GetEdges() after removals = %v, want %v", got, want)
+	}
+}
+
+func TestGraphStableOrderSealed(t *testing.T) {
+	g := NewGraph[string, int]()
+	g.Seal()
+	defer func() {
+		if r := recover(); r != utils.ErrSealed {
+			t.Errorf("WithStableOrder() on sealed graph panic = %v, want %v", r, utils.ErrSealed)
+		}
+	}()
+	g.WithStableOrder()
+}
@@ -0,0 +1,121 @@
+package graphs
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// indexedGraph is a snapshot of the graph's nodes numbered densely, with
+// adjacency in both directions as slices, so parallel algorithms can share
+// it without touching the maps.
+type indexedGraph[K comparable] struct {
+	keys  []K
+	pos   map[K]int
+	out   [][]int
+	in    [][]int
+	edges int
+}
+
+// index builds an indexedGraph. Edges to keys that were never added as nodes
+// are dropped. The caller must hold the read lock.
+func (g *Graph[K, V]) index() *indexedGraph[K] {
+	n := len(g.nodes)
+	idx := &indexedGraph[K]{
+		keys: make([]K, 0, n),
+		pos:  make(map[K]int, n),
+		out:  make([][]int, n),
+		in:   make([][]int, n),
+	}
+	for key := range g.nodes {
+		idx.pos[key] = len(idx.keys)
+		idx.keys = append(idx.keys, key)
+	}
+	for i, key := range idx.keys {
+		for to := range g.edges[key] {
+			if j, exists := idx.pos[to]; exists {
+				idx.out[i] = append(idx.out[i], j)
+				idx.in[j] = append(idx.in[j], i)
+			}
+		}
+		idx.edges += len(idx.out[i])
+	}
+	return idx
+}
+
+// parallelFor splits [0, n) into contiguous chunks and runs fn on each from
+// up to workers goroutines, returning once all chunks are done.
+func parallelFor(n, workers int, fn func(lo, hi int)) {
+	if workers <= 1 || n < 2*workers {
+		fn(0, n)
+		return
+	}
+	chunk := (n + workers - 1) / workers
+	var wg sync.WaitGroup
+	for lo := 0; lo < n; lo += chunk {
+		hi := min(lo+chunk, n)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fn(lo, hi)
+		}()
+	}
+	wg.Wait()
+}
+
+// ParallelBFS performs a breadth-first search from start, expanding each
+// frontier on a pool of goroutines. Nodes are returned level by level, but
+// unlike BFS the order within a level is unspecified.
+func (g *Graph[K, V]) ParallelBFS(start K, opts ...AlgorithmOptions) []K {
+	result, _ := g.ParallelBFSCtx(context.Background(), start, opts...)
+	return result
+}
+
+// ParallelBFSCtx is ParallelBFS with cancellation, checked between levels.
+// Progress is measured in nodes visited.
+func (g *Graph[K, V]) ParallelBFSCtx(ctx context.Context, start K, opts ...AlgorithmOptions) ([]K, error) {
+	if g.threadSafe && !g.sealed.Load() {
+		g.mu.RLock()
+		defer g.mu.RUnlock()
+	}
+	if _, exists := g.nodes[start]; !exists {
+		return nil, nil
+	}
+	idx := g.index()
+	workers := mergeOptions(opts).Parallelism
+	t := newTracker(ctx, len(idx.keys), opts)
+
+	visited := make([]atomic.Bool, len(idx.keys))
+	first := idx.pos[start]
+	visited[first].Store(true)
+	frontier := []int{first}
+	result := make([]K, 0, len(idx.keys))
+	for len(frontier) > 0 {
+		for _, i := range frontier {
+			result = append(result, idx.keys[i])
+		}
+		if err := t.step(len(frontier)); err != nil {
+			return nil, err
+		}
+
+		// Each worker claims unvisited neighbours of its slice of the
+		// frontier into a private list; the lists form the next frontier
+		var mu sync.Mutex
+		var next []int
+		parallelFor(len(frontier), workers, func(lo, hi int) {
+			var local []int
+			for _, i := range frontier[lo:hi] {
+				for _, j := range idx.out[i] {
+					if !visited[j].Load() && visited[j].CompareAndSwap(false, true) {
+						local = append(local, j)
+					}
+				}
+			}
+			mu.Lock()
+			next = append(next, local...)
+			mu.Unlock()
+		})
+		frontier = next
+	}
+	return result, t.finish()
+}
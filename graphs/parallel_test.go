@@ -0,0 +1,118 @@
+package graphs
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func randomGraph(nodes, edges int, seed int64) *Graph[int, struct{}] {
+	r := rand.New(rand.NewSource(seed))
+	g := NewGraph[int, struct{}](false)
+	for i := 0; i < nodes; i++ {
+		g.AddNode(i, struct{}{})
+	}
+	for i := 0; i < edges; i++ {
+		g.AddEdge(r.Intn(nodes), r.Intn(nodes))
+	}
+	return g
+}
+
+func TestParallelBFS(t *testing.T) {
+	g := randomGraph(2000, 6000, 1)
+	want := g.BFS(0)
+	level := make(map[int]int)
+	for _, node := range want {
+		if _, seen := level[node]; !seen {
+			level[node] = 0
+		}
+		for _, next := range g.GetNeighbors(node) {
+			if _, seen := level[next]; !seen {
+				level[next] = level[node] + 1
+			}
+		}
+	}
+
+	for _, workers := range []int{1, 4} {
+		got := g.ParallelBFS(0, WithParallelism(workers))
+		if len(got) != len(want) {
+			t.Fatalf("ParallelBFS() with %d workers visited %d nodes, want %d", workers, len(got), len(want))
+		}
+		for i := 1; i < len(got); i++ {
+			if level[got[i]] < level[got[i-1]] {
+				t.Fatalf("ParallelBFS() with %d workers visited %d (level %d) after %d (level %d)",
+					workers, got[i], level[got[i]], got[i-1], level[got[i-1]])
+			}
+		}
+		sorted := slices.Clone(got)
+		sort.Ints(sorted)
+		expected := slices.Clone(want)
+		sort.Ints(expected)
+		if !slices.Equal(sorted, expected) {
+			t.Errorf("ParallelBFS() with %d workers visited a different node set", workers)
+		}
+	}
+	if got := g.ParallelBFS(-1); got != nil {
+		t.Errorf("ParallelBFS() from a missing node = %v, want nil", got)
+	}
+}
+
+func TestParallelPageRankMatchesSequential(t *testing.T) {
+	g := randomGraph(1000, 5000, 2)
+	want := g.PageRank(0.85, 30)
+	got, err := g.PageRankCtx(context.Background(), 0.85, 30, WithParallelism(8))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key, rank := range want {
+		if math.Abs(got[key]-rank) > 1e-12 {
+			t.Fatalf("PageRankCtx() with 8 workers [%d] = %v, want %v", key, got[key], rank)
+		}
+	}
+}
+
+var (
+	benchGraphOnce sync.Once
+	benchGraph     *Graph[int, struct{}]
+)
+
+// millionEdgeGraph builds a random graph with 100k nodes and 1M edges once
+// per test binary.
+func millionEdgeGraph() *Graph[int, struct{}] {
+	benchGraphOnce.Do(func() {
+		benchGraph = randomGraph(100_000, 1_000_000, 42)
+	})
+	return benchGraph
+}
+
+func BenchmarkBFS(b *testing.B) {
+	g := millionEdgeGraph()
+	b.Run("sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			g.BFS(0)
+		}
+	})
+	for _, workers := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("parallel-%d", workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				g.ParallelBFS(0, WithParallelism(workers))
+			}
+		})
+	}
+}
+
+func BenchmarkPageRank(b *testing.B) {
+	g := millionEdgeGraph()
+	for _, workers := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("parallel-%d", workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				g.PageRankCtx(context.Background(), 0.85, 10, WithParallelism(workers))
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package graphs
+
+import (
+	"slices"
+
+	"dsgo/cache"
+)
+
+// cachedPath is a shortest-path result tagged with the graph generation it
+// was computed at.
+type cachedPath[K comparable] struct {
+	gen   uint64
+	path  []K
+	found bool
+}
+
+// Generation returns a counter that changes whenever a node is added or
+// removed or an edge is added, removed or reweighted, so callers can tell
+// whether results derived from the graph are still current.
+func (g *Graph[K, V]) Generation() uint64 {
+	if g.threadSafe && !g.sealed.Load() {
+		g.mu.RLock()
+		defer g.mu.RUnlock()
+	}
+	return g.gen
+}
+
+// EnablePathCache makes ShortestPath remember up to capacity results in an
+// LRU cache. Entries computed before a mutation are ignored once the graph's
+// generation moves on, so cached answers never go stale. A capacity of zero
+// or less disables the cache.
+func (g *Graph[K, V]) EnablePathCache(capacity int) {
+	if g.threadSafe {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+	}
+	if capacity <= 0 {
+		g.paths = nil
+		return
+	}
+	g.paths = cache.NewLRUCache[[2]K, cachedPath[K]](capacity)
+}
+
+// ShortestPath returns a path from 'from' to 'to' with the fewest edges, and
+// whether one exists. Results are served from the path cache when it is
+// enabled and the graph has not changed since they were computed.
+func (g *Graph[K, V]) ShortestPath(from, to K) ([]K, bool) {
+	if g.threadSafe && !g.sealed.Load() {
+		g.mu.RLock()
+		defer g.mu.RUnlock()
+	}
+	key := [2]K{from, to}
+	if g.paths != nil {
+		if cached, ok := g.paths.Get(key); ok && cached.gen == g.gen {
+			return slices.Clone(cached.path), cached.found
+		}
+	}
+	path, found := g.shortestPath(from, to)
+	if g.paths != nil {
+		g.paths.Put(key, cachedPath[K]{gen: g.gen, path: path, found: found})
+	}
+	return slices.Clone(path), found
+}
+
+// shortestPath runs a BFS from 'from' until it reaches 'to'. The caller
+// must hold the read lock.
+func (g *Graph[K, V]) shortestPath(from, to K) ([]K, bool) {
+	if _, exists := g.nodes[from]; !exists {
+		return nil, false
+	}
+	if _, exists := g.nodes[to]; !exists {
+		return nil, false
+	}
+	parent := map[K]K{from: from}
+	queue := []K{from}
+	_, reached := parent[to]
+	for len(queue) > 0 && !reached {
+		node := queue[0]
+		queue = queue[1:]
+		for next := range g.edges[node] {
+			if _, seen := parent[next]; seen {
+				continue
+			}
+			if _, exists := g.nodes[next]; !exists {
+				continue
+			}
+			parent[next] = node
+			queue = append(queue, next)
+			if next == to {
+				reached = true
+				break
+			}
+		}
+	}
+	if !reached {
+		return nil, false
+	}
+	path := []K{to}
+	for node := to; node != from; {
+		node = parent[node]
+		path = append(path, node)
+	}
+	slices.Reverse(path)
+	return path, true
+}
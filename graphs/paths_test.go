@@ -0,0 +1,72 @@
+package graphs
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestShortestPath(t *testing.T) {
+	g := buildGraph(
+		[2]string{"A", "B"}, [2]string{"B", "C"}, [2]string{"C", "D"},
+		[2]string{"A", "E"}, [2]string{"E", "D"},
+	)
+	path, ok := g.ShortestPath("A", "D")
+	if want := []string{"A", "E", "D"}; !ok || !slices.Equal(path, want) {
+		t.Errorf("ShortestPath(A, D) = %v, %v, want %v", path, ok, want)
+	}
+	if path, ok := g.ShortestPath("A", "A"); !ok || !slices.Equal(path, []string{"A"}) {
+		t.Errorf("ShortestPath(A, A) = %v, %v", path, ok)
+	}
+	if path, ok := g.ShortestPath("D", "A"); ok || path != nil {
+		t.Errorf("ShortestPath(D, A) = %v, %v, want no path", path, ok)
+	}
+}
+
+func TestShortestPathCache(t *testing.T) {
+	g := buildGraph([2]string{"A", "B"}, [2]string{"B", "C"}, [2]string{"C", "D"})
+	g.EnablePathCache(8)
+
+	path, _ := g.ShortestPath("A", "D")
+	if len(path) != 4 || g.paths.Len() != 1 {
+		t.Fatalf("ShortestPath(A, D) = %v with %d cached entries", path, g.paths.Len())
+	}
+	path[0] = "mutated"
+	if again, _ := g.ShortestPath("A", "D"); again[0] != "A" {
+		t.Error("ShortestPath() returned a slice shared with the cache")
+	}
+
+	gen := g.Generation()
+	g.AddEdge("A", "D")
+	if g.Generation() == gen {
+		t.Error("AddEdge() should advance the generation")
+	}
+	if path, _ := g.ShortestPath("A", "D"); !slices.Equal(path, []string{"A", "D"}) {
+		t.Errorf("ShortestPath(A, D) after AddEdge = %v, want [A D]", path)
+	}
+
+	g.RemoveNode("D")
+	if path, ok := g.ShortestPath("A", "D"); ok {
+		t.Errorf("ShortestPath(A, D) after RemoveNode = %v, want no path", path)
+	}
+}
+
+func TestShortestPathCacheAddNode(t *testing.T) {
+	g := NewGraph[int, int](false)
+	g.EnablePathCache(10)
+	g.AddNode(1, 0)
+	g.AddEdge(1, 2)
+	if _, ok := g.ShortestPath(1, 2); ok {
+		t.Fatal("ShortestPath(1, 2) found a path to a node that was never added")
+	}
+
+	g.AddNode(2, 0)
+	if path, ok := g.ShortestPath(1, 2); !ok || !slices.Equal(path, []int{1, 2}) {
+		t.Errorf("ShortestPath(1, 2) after AddNode(2) = %v, %v, want [1 2], true", path, ok)
+	}
+
+	gen := g.Generation()
+	g.AddNode(2, 5)
+	if g.Generation() != gen {
+		t.Error("AddNode() on an existing node should not advance the generation")
+	}
+}
@@ -0,0 +1,78 @@
+package graphs
+
+// TSPApprox returns a short tour visiting every node once and the tour's
+// total cost, including the return to the first node. The graph is treated
+// as complete: weight gives the cost of travelling between any two nodes
+// and is assumed symmetric, and the graph's own edges are ignored. The tour
+// is built by nearest neighbour and then improved with 2-opt moves until
+// none helps, which is usually within a few percent of optimal but carries
+// no guarantee.
+func (g *Graph[K, V]) TSPApprox(weight func(from, to K) float64) ([]K, float64) {
+	if g.threadSafe && !g.sealed.Load() {
+		g.mu.RLock()
+		defer g.mu.RUnlock()
+	}
+	n := len(g.nodes)
+	if n == 0 {
+		return nil, 0
+	}
+	keys := make([]K, 0, n)
+	for key := range g.nodes {
+		keys = append(keys, key)
+	}
+	dist := make([][]float64, n)
+	for i := range dist {
+		dist[i] = make([]float64, n)
+		for j := range dist[i] {
+			if i != j {
+				dist[i][j] = weight(keys[i], keys[j])
+			}
+		}
+	}
+
+	// Nearest neighbour from the first node
+	tour := make([]int, 0, n)
+	visited := make([]bool, n)
+	tour = append(tour, 0)
+	visited[0] = true
+	for len(tour) < n {
+		last := tour[len(tour)-1]
+		next := -1
+		for j := range dist {
+			if !visited[j] && (next == -1 || dist[last][j] < dist[last][next]) {
+				next = j
+			}
+		}
+		tour = append(tour, next)
+		visited[next] = true
+	}
+
+	// 2-opt: replace edges (a,b) and (c,d) with (a,c) and (b,d) by reversing
+	// the segment b..c whenever that shortens the tour
+	for improved := true; improved; {
+		improved = false
+		for i := 0; i < n-1; i++ {
+			for j := i + 2; j < n; j++ {
+				a, b := tour[i], tour[i+1]
+				c, d := tour[j], tour[(j+1)%n]
+				if a == d {
+					continue
+				}
+				if dist[a][c]+dist[b][d] < dist[a][b]+dist[c][d]-1e-12 {
+					for l, r := i+1, j; l < r; l, r = l+1, r-1 {
+						tour[l], tour[r] = tour[r], tour[l]
+					}
+					improved = true
+				}
+			}
+		}
+	}
+
+	result := make([]K, n)
+	cost := 0.0
+	for i, node := range tour {
+		result[i] = keys[node]
+		cost += dist[node][tour[(i+1)%n]]
+	}
+	return result, cost
+}
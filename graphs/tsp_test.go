@@ -0,0 +1,42 @@
+package graphs
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTSPApprox(t *testing.T) {
+	// Points on a circle: the optimal tour visits them in angular order
+	const n = 24
+	type point struct{ x, y float64 }
+	g := NewGraph[int, point](false)
+	for i := 0; i < n; i++ {
+		angle := 2 * math.Pi * float64((i*7)%n) / n
+		g.AddNode(i, point{math.Cos(angle), math.Sin(angle)})
+	}
+	weight := func(a, b int) float64 {
+		pa, _ := g.GetNodeValue(a)
+		pb, _ := g.GetNodeValue(b)
+		return math.Hypot(pa.x-pb.x, pa.y-pb.y)
+	}
+
+	tour, cost := g.TSPApprox(weight)
+	if len(tour) != n {
+		t.Fatalf("TSPApprox() tour has %d nodes, want %d", len(tour), n)
+	}
+	seen := make(map[int]bool)
+	for _, node := range tour {
+		if seen[node] {
+			t.Fatalf("TSPApprox() visits %d twice", node)
+		}
+		seen[node] = true
+	}
+	optimal := n * 2 * math.Sin(math.Pi/n)
+	if cost > optimal*1.0001 {
+		t.Errorf("TSPApprox() cost = %v, want the optimal %v", cost, optimal)
+	}
+
+	if tour, cost := NewGraph[int, int](false).TSPApprox(func(int, int) float64 { return 1 }); tour != nil || cost != 0 {
+		t.Errorf("TSPApprox() on empty graph = %v, %v", tour, cost)
+	}
+}
@@ -0,0 +1,178 @@
+package graphs
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"dsgo/utils"
+)
+
+// Point is a layout position hint.
+type Point struct {
+	X, Y float64
+}
+
+// NodeMeta is optional display metadata for a node, used by ToVisJSON.
+type NodeMeta struct {
+	// Label is shown on the node; empty means the node's ID.
+	Label string
+	// Group lets a viewer style related nodes alike.
+	Group string
+	// Position, if set, is where the viewer should place the node.
+	Position *Point
+}
+
+// SetNodeMeta attaches display metadata to a node, replacing any it had.
+// The metadata is dropped when the node is removed. It returns
+// ErrUnknownNode if the node does not exist.
+func (g *Graph[K, V]) SetNodeMeta(key K, meta NodeMeta) error {
+	if g.threadSafe {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+	}
+	if g.sealed.Load() {
+		return utils.ErrSealed
+	}
+	if _, exists := g.nodes[key]; !exists {
+		return ErrUnknownNode
+	}
+	if g.meta == nil {
+		g.meta = make(map[K]NodeMeta)
+	}
+	g.meta[key] = meta
+	return nil
+}
+
+// NodeMeta returns the display metadata of a node, if any was set.
+func (g *Graph[K, V]) NodeMeta(key K) (NodeMeta, bool) {
+	if g.threadSafe && !g.sealed.Load() {
+		g.mu.RLock()
+		defer g.mu.RUnlock()
+	}
+	meta, ok := g.meta[key]
+	return meta, ok
+}
+
+type visNode struct {
+	ID    string   `json:"id"`
+	Label string   `json:"label"`
+	Group string   `json:"group,omitempty"`
+	X     *float64 `json:"x,omitempty"`
+	Y     *float64 `json:"y,omitempty"`
+}
+
+type visEdge struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Arrows string `json:"arrows"`
+}
+
+type visGraph struct {
+	Nodes []visNode `json:"nodes"`
+	Edges []visEdge `json:"edges"`
+}
+
+// ToVisJSON exports the graph as {"nodes": [...], "edges": [...]} in the
+// shape vis-network's DataSet expects and other JS viewers such as
+// Cytoscape or force-graph read with a trivial mapping. A node's ID is its
+// key formatted with fmt.Sprint, which stays the same across exports, so a
+// live view can be updated in place. Keys that print alike fall back to
+// %#v, and then to a "#n" suffix, so that IDs stay unique. Edges to keys
+// that are not nodes are left out, since viewers reject dangling edges.
+// Nodes and edges are in insertion order if WithStableOrder has been
+// called and sorted by ID otherwise.
+func (g *Graph[K, V]) ToVisJSON() ([]byte, error) {
+	if g.threadSafe && !g.sealed.Load() {
+		g.mu.RLock()
+		defer g.mu.RUnlock()
+	}
+
+	out := visGraph{Nodes: []visNode{}, Edges: []visEdge{}}
+	var keys []K
+	if g.nodeOrder != nil {
+		keys = g.nodeOrder.Keys()
+	} else {
+		for key := range g.nodes {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			a, b := fmt.Sprint(keys[i]), fmt.Sprint(keys[j])
+			if a != b {
+				return a < b
+			}
+			return fmt.Sprintf("%#v", keys[i]) < fmt.Sprintf("%#v", keys[j])
+		})
+	}
+	ids := visIDs(keys)
+	for _, key := range keys {
+		node := visNode{ID: ids[key]}
+		meta := g.meta[key]
+		node.Label, node.Group = meta.Label, meta.Group
+		if node.Label == "" {
+			node.Label = node.ID
+		}
+		if meta.Position != nil {
+			node.X, node.Y = &meta.Position.X, &meta.Position.Y
+		}
+		out.Nodes = append(out.Nodes, node)
+	}
+
+	var edges [][2]K
+	if g.edgeOrder != nil {
+		edges = g.edgeOrder.Keys()
+	} else {
+		for from, tos := range g.edges {
+			for to := range tos {
+				edges = append(edges, [2]K{from, to})
+			}
+		}
+	}
+	for _, e := range edges {
+		from, ok := ids[e[0]]
+		if !ok {
+			continue
+		}
+		to, ok := ids[e[1]]
+		if !ok {
+			continue
+		}
+		out.Edges = append(out.Edges, visEdge{From: from, To: to, Arrows: "to"})
+	}
+
+	if g.nodeOrder == nil {
+		sort.Slice(out.Nodes, func(i, j int) bool { return out.Nodes[i].ID < out.Nodes[j].ID })
+		sort.Slice(out.Edges, func(i, j int) bool {
+			a, b := out.Edges[i], out.Edges[j]
+			if a.From != b.From {
+				return a.From < b.From
+			}
+			return a.To < b.To
+		})
+	}
+	return json.Marshal(out)
+}
+
+// visIDs assigns each key a distinct ID: fmt.Sprint of the key where that
+// is unambiguous, %#v where it is not, and a "#n" suffix for whatever
+// still clashes.
+func visIDs[K comparable](keys []K) map[K]string {
+	printed := make(map[string]int, len(keys))
+	for _, key := range keys {
+		printed[fmt.Sprint(key)]++
+	}
+	ids := make(map[K]string, len(keys))
+	taken := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		id := fmt.Sprint(key)
+		if printed[id] > 1 {
+			id = fmt.Sprintf("%#v", key)
+		}
+		for n := 1; taken[id]; n++ {
+			id = fmt.Sprintf("%s#%d", fmt.Sprintf("%#v", key), n)
+		}
+		taken[id] = true
+		ids[key] = id
+	}
+	return ids
+}
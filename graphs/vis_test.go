@@ -0,0 +1,86 @@
+package graphs
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGraphToVisJSON(t *testing.T) {
+	g := NewGraph[int, string]()
+	g.AddNode(2, "db")
+	g.AddNode(1, "api")
+	g.AddNode(3, "cache")
+	g.AddEdge(1, 2)
+	g.AddEdge(1, 3)
+
+	if err := g.SetNodeMeta(1, NodeMeta{Label: "api", Group: "frontend", Position: &Point{X: 10, Y: -5}}); err != nil {
+		t.Fatalf("SetNodeMeta() error = %v", err)
+	}
+	if err := g.SetNodeMeta(2, NodeMeta{Group: "storage"}); err != nil {
+		t.Fatalf("SetNodeMeta() error = %v", err)
+	}
+	if err := g.SetNodeMeta(9, NodeMeta{}); !errors.Is(err, ErrUnknownNode) {
+		t.Errorf("SetNodeMeta() of a missing node error = %v, want %v", err, ErrUnknownNode)
+	}
+	if meta, ok := g.NodeMeta(1); !ok || meta.Group != "frontend" {
+		t.Errorf("NodeMeta(1) = %+v, %v", meta, ok)
+	}
+
+	data, err := g.ToVisJSON()
+	if err != nil {
+		t.Fatalf("ToVisJSON() error = %v", err)
+	}
+	want := `{"nodes":[` +
+		`{"id":"1","label":"api","group":"frontend","x":10,"y":-5},` +
+		`{"id":"2","label":"2","group":"storage"},` +
+		`{"id":"3","label":"3"}],` +
+		`"edges":[{"from":"1","to":"2","arrows":"to"},{"from":"1","to":"3","arrows":"to"}]}`
+	if string(data) != want {
+		t.Errorf("ToVisJSON() = %s, want %s", data, want)
+	}
+
+	g.RemoveNode(1)
+	if _, ok := g.NodeMeta(1); ok {
+		t.Error("metadata should be dropped with its node")
+	}
+	g.AddNode(1, "api")
+	data, _ = g.ToVisJSON()
+	if want := `{"nodes":[{"id":"1","label":"1"},{"id":"2","label":"2","group":"storage"},{"id":"3","label":"3"}],"edges":[]}`; string(data) != want {
+		t.Errorf("ToVisJSON() after re-adding = %s, want %s", data, want)
+	}
+}
+
+func TestGraphToVisJSONStableOrder(t *testing.T) {
+	g := NewGraph[string, int](false).WithStableOrder()
+	g.AddNode("z", 0)
+	g.AddNode("a", 0)
+	g.AddEdge("z", "a")
+	data, err := g.ToVisJSON()
+	if err != nil {
+		t.Fatalf("ToVisJSON() error = %v", err)
+	}
+	if want := `{"nodes":[{"id":"z","label":"z"},{"id":"a","label":"a"}],"edges":[{"from":"z","to":"a","arrows":"to"}]}`; string(data) != want {
+		t.Errorf("ToVisJSON() = %s, want %s", data, want)
+	}
+}
+
+func TestGraphToVisJSONClashingIDs(t *testing.T) {
+	g := NewGraph[any, int]()
+	g.AddNode(1, 0)
+	g.AddNode("1", 0)
+	g.AddNode("x", 0)
+	g.AddEdge(1, "1")
+	g.AddEdge("x", "missing")
+	data, err := g.ToVisJSON()
+	if err != nil {
+		t.Fatalf("ToVisJSON() error = %v", err)
+	}
+	want := `{"nodes":[` +
+		`{"id":"\"1\"","label":"\"1\""},` +
+		`{"id":"1","label":"1"},` +
+		`{"id":"x","label":"x"}],` +
+		`"edges":[{"from":"1","to":"\"1\"","arrows":"to"}]}`
+	if string(data) != want {
+		t.Errorf("ToVisJSON() = %s, want %s", data, want)
+	}
+}
@@ -0,0 +1,171 @@
+package graphs
+
+import (
+	"errors"
+	"math"
+	"slices"
+
+	"dsgo/heaps"
+	"dsgo/utils"
+)
+
+var (
+	ErrNegativeWeight = errors.New("graphs: negative edge weight")
+	ErrInvalidWeight  = errors.New("graphs: edge weight is NaN or infinite")
+)
+
+// checkWeight reports whether w can be used as an edge weight or scale
+// factor. NaN would break the ordering Dijkstra's algorithm relies on.
+func checkWeight(w float64) error {
+	switch {
+	case math.IsNaN(w) || math.IsInf(w, 0):
+		return ErrInvalidWeight
+	case w < 0:
+		return ErrNegativeWeight
+	}
+	return nil
+}
+
+// edgeWeight returns the weight of an existing edge. The caller must hold
+// the lock.
+func (g *Graph[K, V]) edgeWeight(from, to K) float64 {
+	if w, ok := g.weights[[2]K{from, to}]; ok {
+		return w
+	}
+	return 1
+}
+
+// EdgeWeight returns the weight of the edge from 'from' to 'to', which is 1
+// unless it has been changed, or false if there is no such edge.
+func (g *Graph[K, V]) EdgeWeight(from, to K) (float64, bool) {
+	if g.threadSafe && !g.sealed.Load() {
+		g.mu.RLock()
+		defer g.mu.RUnlock()
+	}
+	if _, exists := g.edges[from][to]; !exists {
+		return 0, false
+	}
+	return g.edgeWeight(from, to), true
+}
+
+// UpdateEdgeWeight sets the weight of the edge from 'from' to 'to' to fn of
+// its current weight, under the write lock. It returns ErrUnknownEdge if
+// there is no such edge, and ErrNegativeWeight or ErrInvalidWeight,
+// leaving the weight as it was, if fn returns a negative, NaN or infinite
+// weight. Like adding or removing an edge it advances Generation, so cached
+// path results are recomputed.
+func (g *Graph[K, V]) UpdateEdgeWeight(from, to K, fn func(old float64) float64) error {
+	if g.threadSafe {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+	}
+	if g.sealed.Load() {
+		return utils.ErrSealed
+	}
+	if _, exists := g.edges[from][to]; !exists {
+		return ErrUnknownEdge
+	}
+	w := fn(g.edgeWeight(from, to))
+	if err := checkWeight(w); err != nil {
+		return err
+	}
+	if g.weights == nil {
+		g.weights = make(map[[2]K]float64)
+	}
+	g.weights[[2]K{from, to}] = w
+	g.gen++
+	return nil
+}
+
+// ScaleAllWeights multiplies the weight of every edge by factor in one
+// step, advancing Generation once. It returns ErrNegativeWeight for a
+// negative factor and ErrInvalidWeight for a NaN or infinite one, or if a
+// scaled weight would overflow to infinity; the weights are then left as
+// they were.
+func (g *Graph[K, V]) ScaleAllWeights(factor float64) error {
+	if g.threadSafe {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+	}
+	if g.sealed.Load() {
+		return utils.ErrSealed
+	}
+	if err := checkWeight(factor); err != nil {
+		return err
+	}
+	if factor == 1 {
+		return nil
+	}
+	scaled := make(map[[2]K]float64, len(g.weights))
+	for from, neighbors := range g.edges {
+		for to := range neighbors {
+			w := g.edgeWeight(from, to) * factor
+			if err := checkWeight(w); err != nil {
+				return err
+			}
+			scaled[[2]K{from, to}] = w
+		}
+	}
+	g.weights = scaled
+	g.gen++
+	return nil
+}
+
+// CheapestPath returns the path from 'from' to 'to' with the smallest total
+// edge weight, that total, and whether a path exists. It runs Dijkstra's
+// algorithm over a binary heap in O((V + E) log V). ShortestPath, by
+// contrast, ignores weights and minimizes the number of edges.
+func (g *Graph[K, V]) CheapestPath(from, to K) ([]K, float64, bool) {
+	if g.threadSafe && !g.sealed.Load() {
+		g.mu.RLock()
+		defer g.mu.RUnlock()
+	}
+	if _, exists := g.nodes[from]; !exists {
+		return nil, 0, false
+	}
+	if _, exists := g.nodes[to]; !exists {
+		return nil, 0, false
+	}
+
+	type item struct {
+		node K
+		dist float64
+	}
+	dist := map[K]float64{from: 0}
+	parent := map[K]K{}
+	done := map[K]bool{}
+	frontier := heaps.NewMinHeap(func(a, b item) bool { return a.dist < b.dist }, false)
+	frontier.Push(item{node: from})
+	for frontier.Size() > 0 {
+		cur, _ := frontier.Pop()
+		if done[cur.node] {
+			continue
+		}
+		if cur.node == to {
+			break
+		}
+		done[cur.node] = true
+		for next := range g.edges[cur.node] {
+			if _, exists := g.nodes[next]; !exists || done[next] {
+				continue
+			}
+			d := cur.dist + g.edgeWeight(cur.node, next)
+			if best, seen := dist[next]; !seen || d < best {
+				dist[next] = d
+				parent[next] = cur.node
+				frontier.Push(item{node: next, dist: d})
+			}
+		}
+	}
+	total, reached := dist[to]
+	if !reached {
+		return nil, 0, false
+	}
+	path := []K{to}
+	for node := to; node != from; {
+		node = parent[node]
+		path = append(path, node)
+	}
+	slices.Reverse(path)
+	return path, total, true
+}
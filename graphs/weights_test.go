@@ -0,0 +1,111 @@
+package graphs
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"dsgo/utils"
+)
+
+func TestEdgeWeights(t *testing.T) {
+	g := buildGraph([2]string{"A", "B"}, [2]string{"B", "C"})
+
+	if w, ok := g.EdgeWeight("A", "B"); !ok || w != 1 {
+		t.Errorf("EdgeWeight(A, B) = %v, %v, want 1, true", w, ok)
+	}
+	if _, ok := g.EdgeWeight("C", "A"); ok {
+		t.Error("EdgeWeight(C, A) found a missing edge")
+	}
+
+	gen := g.Generation()
+	if err := g.UpdateEdgeWeight("A", "B", func(old float64) float64 { return old + 4 }); err != nil {
+		t.Fatalf("UpdateEdgeWeight() error = %v", err)
+	}
+	if w, _ := g.EdgeWeight("A", "B"); w != 5 {
+		t.Errorf("EdgeWeight(A, B) = %v, want 5", w)
+	}
+	if g.Generation() == gen {
+		t.Error("UpdateEdgeWeight did not advance Generation")
+	}
+
+	if err := g.UpdateEdgeWeight("C", "A", func(old float64) float64 { return old }); err != ErrUnknownEdge {
+		t.Errorf("UpdateEdgeWeight(missing) error = %v, want %v", err, ErrUnknownEdge)
+	}
+	if err := g.UpdateEdgeWeight("A", "B", func(float64) float64 { return -1 }); err != ErrNegativeWeight {
+		t.Errorf("UpdateEdgeWeight(negative) error = %v, want %v", err, ErrNegativeWeight)
+	}
+
+	if err := g.ScaleAllWeights(0.5); err != nil {
+		t.Fatalf("ScaleAllWeights() error = %v", err)
+	}
+	if ab, _ := g.EdgeWeight("A", "B"); ab != 2.5 {
+		t.Errorf("EdgeWeight(A, B) after scaling = %v, want 2.5", ab)
+	}
+	if bc, _ := g.EdgeWeight("B", "C"); bc != 0.5 {
+		t.Errorf("EdgeWeight(B, C) after scaling = %v, want 0.5", bc)
+	}
+	if err := g.ScaleAllWeights(-2); err != ErrNegativeWeight {
+		t.Errorf("ScaleAllWeights(-2) error = %v, want %v", err, ErrNegativeWeight)
+	}
+	for _, bad := range []float64{math.NaN(), math.Inf(1)} {
+		if err := g.UpdateEdgeWeight("A", "B", func(float64) float64 { return bad }); err != ErrInvalidWeight {
+			t.Errorf("UpdateEdgeWeight(%v) error = %v, want %v", bad, err, ErrInvalidWeight)
+		}
+		if err := g.ScaleAllWeights(bad); err != ErrInvalidWeight {
+			t.Errorf("ScaleAllWeights(%v) error = %v, want %v", bad, err, ErrInvalidWeight)
+		}
+	}
+	gen = g.Generation()
+	if err := g.ScaleAllWeights(math.MaxFloat64); err != ErrInvalidWeight {
+		t.Errorf("ScaleAllWeights(MaxFloat64) error = %v, want %v", err, ErrInvalidWeight)
+	}
+	if g.Generation() != gen {
+		t.Error("rejected ScaleAllWeights advanced Generation")
+	}
+	if ab, _ := g.EdgeWeight("A", "B"); ab != 2.5 {
+		t.Errorf("EdgeWeight(A, B) after rejected updates = %v, want 2.5", ab)
+	}
+
+	// A re-added edge starts again from the default weight
+	g.RemoveEdge("A", "B")
+	g.AddEdge("A", "B")
+	if w, _ := g.EdgeWeight("A", "B"); w != 1 {
+		t.Errorf("EdgeWeight(A, B) after re-adding = %v, want 1", w)
+	}
+
+	g.Seal()
+	if err := g.ScaleAllWeights(2); err != utils.ErrSealed {
+		t.Errorf("ScaleAllWeights() on sealed graph error = %v, want %v", err, utils.ErrSealed)
+	}
+}
+
+func TestCheapestPath(t *testing.T) {
+	g := buildGraph(
+		[2]string{"A", "B"}, [2]string{"B", "D"},
+		[2]string{"A", "C"}, [2]string{"C", "E"}, [2]string{"E", "D"},
+	)
+	g.EnablePathCache(8)
+	g.UpdateEdgeWeight("B", "D", func(float64) float64 { return 10 })
+
+	path, cost, ok := g.CheapestPath("A", "D")
+	if !ok || cost != 3 || !slices.Equal(path, []string{"A", "C", "E", "D"}) {
+		t.Errorf("CheapestPath(A, D) = %v, %v, %v, want [A C E D], 3, true", path, cost, ok)
+	}
+	if fewest, _ := g.ShortestPath("A", "D"); !slices.Equal(fewest, []string{"A", "B", "D"}) {
+		t.Errorf("ShortestPath(A, D) = %v, want [A B D]", fewest)
+	}
+
+	// Cheapening the direct route flips the answer
+	g.UpdateEdgeWeight("B", "D", func(float64) float64 { return 0.5 })
+	if path, cost, _ := g.CheapestPath("A", "D"); cost != 1.5 || !slices.Equal(path, []string{"A", "B", "D"}) {
+		t.Errorf("CheapestPath(A, D) after reweighting = %v, %v, want [A B D], 1.5", path, cost)
+	}
+
+	if _, _, ok := g.CheapestPath("D", "A"); ok {
+		t.Error("CheapestPath(D, A) found a path against the edges")
+	}
+	if path, cost, ok := g.CheapestPath("A", "A"); !ok || cost != 0 || !slices.Equal(path, []string{"A"}) {
+		t.Errorf("CheapestPath(A, A) = %v, %v, %v, want [A], 0, true", path, cost, ok)
+	}
+}
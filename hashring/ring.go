@@ -0,0 +1,173 @@
+package hashring
+
+import (
+	"hash/fnv"
+	"math"
+	"slices"
+	"strconv"
+	"sync"
+
+	"dsgo/slicesx"
+	"dsgo/utils"
+)
+
+// Ring is a consistent hash ring. Every node is placed on the ring at a
+// number of virtual points, and a key belongs to the first point at or after
+// its hash. Adding or removing a node only moves the keys adjacent to that
+// node's points.
+type Ring struct {
+	replicas   int
+	points     []uint64
+	owners     map[uint64]string
+	nodes      map[string]struct{}
+	hash       utils.Hasher[string]
+	threadSafe bool
+	mu         sync.RWMutex
+}
+
+// NewRing creates an empty ring that places each node at replicas points.
+// Nodes and keys are hashed with a fixed function, so every process builds
+// the same ring from the same nodes.
+func NewRing(replicas int, threadSafe ...bool) *Ring {
+	return NewRingWithHasher(replicas, hash, threadSafe...)
+}
+
+// NewRingWithHasher is NewRing with custom hashing of nodes and keys. A
+// seeded hasher such as utils.NewHasher resists crafted keys, but processes
+// that must agree on key placement then need to share the same hasher.
+func NewRingWithHasher(replicas int, hasher utils.Hasher[string], threadSafe ...bool) *Ring {
+	if replicas <= 0 {
+		replicas = 1
+	}
+	isThreadSafe := true
+	if len(threadSafe) > 0 {
+		isThreadSafe = threadSafe[0]
+	}
+	return &Ring{
+		replicas:   replicas,
+		hash:       hasher,
+		owners:     make(map[uint64]string),
+		nodes:      make(map[string]struct{}),
+		threadSafe: isThreadSafe,
+	}
+}
+
+func hash(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	// FNV leaves the high bits of short, similar inputs poorly mixed, which
+	// clusters them on the ring; finish with the splitmix64 finalizer
+	x := h.Sum64()
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// Add places node on the ring. Adding an existing node is a no-op.
+func (r *Ring) Add(node string) {
+	if r.threadSafe {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+	}
+	if _, exists := r.nodes[node]; exists {
+		return
+	}
+	r.nodes[node] = struct{}{}
+	for i := 0; i < r.replicas; i++ {
+		point := r.hash(node + "#" + strconv.Itoa(i))
+		if _, taken := r.owners[point]; taken {
+			continue
+		}
+		r.owners[point] = node
+		r.points = append(r.points, point)
+	}
+	slices.Sort(r.points)
+}
+
+// Remove takes node off the ring.
+func (r *Ring) Remove(node string) {
+	if r.threadSafe {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+	}
+	if _, exists := r.nodes[node]; !exists {
+		return
+	}
+	delete(r.nodes, node)
+	r.points = slices.DeleteFunc(r.points, func(point uint64) bool {
+		if r.owners[point] == node {
+			delete(r.owners, point)
+			return true
+		}
+		return false
+	})
+}
+
+// Get returns the node that owns key, or false if the ring is empty.
+func (r *Ring) Get(key string) (string, bool) {
+	if r.threadSafe {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+	}
+	if len(r.points) == 0 {
+		return "", false
+	}
+	i := slicesx.BisectLeft(r.points, r.hash(key))
+	if i == len(r.points) {
+		i = 0
+	}
+	return r.owners[r.points[i]], true
+}
+
+// Nodes returns the nodes on the ring in sorted order.
+func (r *Ring) Nodes() []string {
+	if r.threadSafe {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+	}
+	nodes := make([]string, 0, len(r.nodes))
+	for node := range r.nodes {
+		nodes = append(nodes, node)
+	}
+	slices.Sort(nodes)
+	return nodes
+}
+
+// Shares returns the fraction of the hash space owned by each node. The
+// shares sum to 1 for a non-empty ring.
+func (r *Ring) Shares() map[string]float64 {
+	if r.threadSafe {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+	}
+	shares := make(map[string]float64, len(r.nodes))
+	if len(r.nodes) == 1 {
+		for node := range r.nodes {
+			shares[node] = 1
+		}
+		return shares
+	}
+	for i, point := range r.points {
+		// Each point owns the arc from the previous point up to itself; the
+		// subtraction wraps around for the first point
+		var prev uint64
+		if i > 0 {
+			prev = r.points[i-1]
+		} else {
+			prev = r.points[len(r.points)-1]
+		}
+		shares[r.owners[point]] += float64(point-prev) / math.MaxUint64
+	}
+	return shares
+}
+
+func (r *Ring) Len() int {
+	if r.threadSafe {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+	}
+	return len(r.nodes)
+}
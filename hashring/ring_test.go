@@ -0,0 +1,116 @@
+package hashring
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"dsgo/utils"
+)
+
+func TestRingGet(t *testing.T) {
+	r := NewRing(50)
+	if _, ok := r.Get("key"); ok {
+		t.Error("Get on empty ring should return false")
+	}
+
+	r.Add("a")
+	r.Add("b")
+	r.Add("c")
+	r.Add("a")
+	if r.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", r.Len())
+	}
+
+	node, ok := r.Get("key")
+	if !ok {
+		t.Fatal("Get should find a node")
+	}
+	if again, _ := r.Get("key"); again != node {
+		t.Errorf("Get is not stable: %s then %s", node, again)
+	}
+}
+
+func TestRingMinimalMovement(t *testing.T) {
+	r := NewRing(100, false)
+	for _, node := range []string{"a", "b", "c", "d"} {
+		r.Add(node)
+	}
+
+	before := make(map[string]string)
+	for i := 0; i < 10000; i++ {
+		key := strconv.Itoa(i)
+		before[key], _ = r.Get(key)
+	}
+
+	r.Add("e")
+	moved := 0
+	for key, owner := range before {
+		now, _ := r.Get(key)
+		if now != owner {
+			if now != "e" {
+				t.Fatalf("key %s moved from %s to %s, not to the new node", key, owner, now)
+			}
+			moved++
+		}
+	}
+	// Roughly a fifth of the keys should move to the new node
+	if moved < 1000 || moved > 3000 {
+		t.Errorf("moved %d of 10000 keys, want about 2000", moved)
+	}
+
+	r.Remove("e")
+	for key, owner := range before {
+		if now, _ := r.Get(key); now != owner {
+			t.Fatalf("key %s owned by %s after removing the new node, want %s", key, now, owner)
+		}
+	}
+}
+
+func TestRingShares(t *testing.T) {
+	r := NewRing(100)
+	r.Add("a")
+	if shares := r.Shares(); shares["a"] != 1 {
+		t.Errorf("single node share = %v, want 1", shares["a"])
+	}
+
+	r.Add("b")
+	shares := r.Shares()
+	if total := shares["a"] + shares["b"]; math.Abs(total-1) > 1e-9 {
+		t.Errorf("shares sum to %v, want 1", total)
+	}
+	if shares["a"] < 0.3 || shares["a"] > 0.7 {
+		t.Errorf("share of a = %v, want about 0.5", shares["a"])
+	}
+}
+
+func TestRingWithHasher(t *testing.T) {
+	calls := 0
+	counting := func(s string) uint64 {
+		calls++
+		return hash(s)
+	}
+	r := NewRingWithHasher(10, counting)
+	r.Add("a")
+	r.Add("b")
+	if calls != 20 {
+		t.Errorf("custom hasher called %d times placing nodes, want 20", calls)
+	}
+	r2 := NewRing(10)
+	r2.Add("a")
+	r2.Add("b")
+	want, _ := r2.Get("key")
+	if got, _ := r.Get("key"); got != want {
+		t.Errorf("Get() with the default hash wrapped = %q, want %q", got, want)
+	}
+
+	seeded := NewRingWithHasher(50, utils.NewHasher[string]())
+	for i := 0; i < 4; i++ {
+		seeded.Add("node" + strconv.Itoa(i))
+	}
+	for node, share := range seeded.Shares() {
+		if share < 0.1 {
+			t.Errorf("seeded ring gives %s only %.3f of the hash space", node, share)
+		}
+	}
+}
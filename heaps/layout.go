@@ -0,0 +1,124 @@
+package heaps
+
+import (
+	"math/bits"
+	"unsafe"
+
+	"dsgo/utils"
+)
+
+// Layout selects how a MinHeap arranges its items in its backing slice.
+type Layout int
+
+const (
+	// BinaryLayout is the classic implicit binary heap, with the children
+	// of item i at 2i+1 and 2i+2. A sift on a large heap touches a
+	// different memory page at almost every level.
+	BinaryLayout Layout = iota
+	// BHeapLayout groups the heap into page-sized blocks, each holding a
+	// complete binary subtree of about 4 KiB of items, so that a sift
+	// crosses into a new memory page only once every several levels. This
+	// saves page faults and TLB misses on heaps that outgrow memory or run
+	// without huge pages, at the cost of a few more levels and a little
+	// arithmetic per step. Where those misses are already rare it is
+	// slower than the binary layout; measure with BenchmarkMinHeapLayout.
+	BHeapLayout
+)
+
+// bheapPageBytes is the target size of one B-heap block.
+const bheapPageBytes = 4096
+
+// WithLayout switches the heap to the given layout, rearranging any items
+// it already holds, and returns the heap.
+func (h *MinHeap[T]) WithLayout(layout Layout) *MinHeap[T] {
+	if h.threadSafe {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+	}
+	if h.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
+
+	h.pageNodes, h.pageLevels = 0, 0
+	if layout == BHeapLayout {
+		// A block holds a complete subtree of pageLevels levels, the
+		// deepest that fits in a page, and never fewer than two
+		per := bheapPageBytes / max(int(unsafe.Sizeof(*new(T))), 1)
+		h.pageLevels = max(bits.Len(uint(per+1))-1, 2)
+		h.pageNodes = 1<<h.pageLevels - 1
+	}
+	items := h.items
+	h.items = make([]T, 0, cap(items))
+	for _, item := range items {
+		h.items = append(h.items, item)
+		h.up(len(h.items) - 1)
+	}
+	return h
+}
+
+// A B-heap position is tracked as a block and a slot within it, so moving
+// between levels needs only shifts; the index into items is
+// page*pageNodes + slot. Each block holds a complete binary subtree with the
+// usual in-block child arithmetic, and each of its leaves has two children
+// that are the roots of child blocks. Blocks are numbered breadth first, so
+// block p has child blocks p<<pageLevels + 1 onwards.
+
+// bheapParent returns the block and slot of the parent of the item at
+// (page, slot), which must not be the root.
+func (h *MinHeap[T]) bheapParent(page, slot int) (int, int) {
+	if slot > 0 {
+		return page, (slot - 1) >> 1
+	}
+	child := (page - 1) & (1<<h.pageLevels - 1)
+	return (page - 1) >> h.pageLevels, h.pageNodes>>1 + child>>1
+}
+
+// bheapChild returns the block and slot of the first child of the item at
+// (page, slot). The second child is in the next slot of the same block, or
+// at the root of the next block when the children start a new block.
+func (h *MinHeap[T]) bheapChild(page, slot int) (int, int) {
+	firstLeaf := h.pageNodes >> 1
+	if slot < firstLeaf {
+		return page, 2*slot + 1
+	}
+	return page<<h.pageLevels + 1 + 2*(slot-firstLeaf), 0
+}
+
+func (h *MinHeap[T]) bheapUp(i int) {
+	page, slot := i/h.pageNodes, i%h.pageNodes
+	for i > 0 {
+		page, slot = h.bheapParent(page, slot)
+		parent := page*h.pageNodes + slot
+		if !h.less(h.items[i], h.items[parent]) {
+			break
+		}
+		h.items[i], h.items[parent] = h.items[parent], h.items[i]
+		i = parent
+	}
+}
+
+func (h *MinHeap[T]) bheapDown(i int) {
+	page, slot := i/h.pageNodes, i%h.pageNodes
+	for {
+		leftPage, leftSlot := h.bheapChild(page, slot)
+		left := leftPage*h.pageNodes + leftSlot
+		if left >= len(h.items) {
+			break
+		}
+		rightPage, rightSlot := leftPage, leftSlot+1
+		if leftSlot == 0 {
+			rightPage, rightSlot = leftPage+1, 0
+		}
+		right := rightPage*h.pageNodes + rightSlot
+
+		smallest, smallestPage, smallestSlot := left, leftPage, leftSlot
+		if right < len(h.items) && h.less(h.items[right], h.items[left]) {
+			smallest, smallestPage, smallestSlot = right, rightPage, rightSlot
+		}
+		if !h.less(h.items[smallest], h.items[i]) {
+			break
+		}
+		h.items[i], h.items[smallest] = h.items[smallest], h.items[i]
+		i, page, slot = smallest, smallestPage, smallestSlot
+	}
+}
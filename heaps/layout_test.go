@@ -0,0 +1,120 @@
+package heaps
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestMinHeapBHeapIndexes(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	small := NewMinHeap(less, false).WithLayout(BHeapLayout)
+	small.pageLevels, small.pageNodes = 2, 3
+	for name, h := range map[string]*MinHeap[int]{
+		"4k pages":    NewMinHeap(less, false).WithLayout(BHeapLayout),
+		"small pages": small,
+	} {
+		t.Run(name, func(t *testing.T) {
+			index := func(page, slot int) int { return page*h.pageNodes + slot }
+			seen := make(map[int]bool)
+			for i := 0; i < 100_000; i++ {
+				page, slot := i/h.pageNodes, i%h.pageNodes
+				firstPage, firstSlot := h.bheapChild(page, slot)
+				secondPage, secondSlot := firstPage, firstSlot+1
+				if firstSlot == 0 {
+					secondPage, secondSlot = firstPage+1, 0
+				}
+				first, second := index(firstPage, firstSlot), index(secondPage, secondSlot)
+				if first <= i || second <= first {
+					t.Fatalf("children of %d are %d and %d, want larger indexes", i, first, second)
+				}
+				for _, child := range [][2]int{{firstPage, firstSlot}, {secondPage, secondSlot}} {
+					if p := index(h.bheapParent(child[0], child[1])); p != i {
+						t.Fatalf("parent of %d = %d, want %d", index(child[0], child[1]), p, i)
+					}
+				}
+				if seen[first] || seen[second] {
+					t.Fatalf("index %d or %d is the child of two items", first, second)
+				}
+				seen[first], seen[second] = true, true
+			}
+			// Every index past the root is somebody's child
+			for i := 1; i < 100_000; i++ {
+				if !seen[i] {
+					t.Fatalf("index %d has no parent", i)
+				}
+			}
+		})
+	}
+}
+
+type paddedItem struct {
+	key int
+	_   [1000]byte
+}
+
+func TestMinHeapBHeapLayout(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	h := NewMinHeap(func(a, b int) bool { return a < b }, false)
+	var want []int
+	for i := 0; i < 3000; i++ {
+		v := rng.Intn(1000)
+		h.Push(v)
+		want = append(want, v)
+	}
+	// Switching layout with items present rearranges them
+	h.WithLayout(BHeapLayout)
+	for i := 0; i < 20_000; i++ {
+		v := rng.Intn(1000)
+		h.Push(v)
+		want = append(want, v)
+	}
+	slices.Sort(want)
+	for i, w := range want {
+		if got, ok := h.Pop(); !ok || got != w {
+			t.Fatalf("Pop() #%d = %d, %v, want %d, true", i, got, ok, w)
+		}
+	}
+	if !h.IsEmpty() {
+		t.Error("heap should be empty after popping every item")
+	}
+
+	// Items too large for more than a few per page still get two-level
+	// blocks
+	big := NewMinHeap(func(a, b paddedItem) bool { return a.key < b.key }, false).WithLayout(BHeapLayout)
+	if big.pageLevels != 2 {
+		t.Errorf("pageLevels for 1 KiB items = %d, want 2", big.pageLevels)
+	}
+	for _, k := range rng.Perm(200) {
+		big.Push(paddedItem{key: k})
+	}
+	for i := 0; i < 200; i++ {
+		if got, _ := big.Pop(); got.key != i {
+			t.Fatalf("Pop() #%d = %d, want %d", i, got.key, i)
+		}
+	}
+}
+
+// BenchmarkMinHeapLayout replaces the minimum of a 10M-item heap, so each
+// operation sifts from the root to the bottom of a heap far larger than
+// the CPU caches. Run with -bench MinHeapLayout -benchtime 1000000x.
+func BenchmarkMinHeapLayout(b *testing.B) {
+	const size = 10_000_000
+	for _, layout := range []struct {
+		name   string
+		layout Layout
+	}{{"binary", BinaryLayout}, {"bheap", BHeapLayout}} {
+		b.Run(layout.name, func(b *testing.B) {
+			rng := rand.New(rand.NewSource(1))
+			h := NewMinHeap(func(a, b int64) bool { return a < b }, false).WithLayout(layout.layout)
+			for i := 0; i < size; i++ {
+				h.Push(rng.Int63())
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				h.Pop()
+				h.Push(rng.Int63())
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package heaps
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"dsgo/concurrencytest"
+)
+
+// heapModel is the multiset of queued items, kept sorted.
+type heapModel struct{ items []int }
+
+func cloneHeapModel(m *heapModel) *heapModel {
+	return &heapModel{items: slices.Clone(m.items)}
+}
+
+func (m *heapModel) push(item int) {
+	i, _ := slices.BinarySearch(m.items, item)
+	m.items = slices.Insert(m.items, i, item)
+}
+
+func (m *heapModel) pop() (int, bool) {
+	if len(m.items) == 0 {
+		return 0, false
+	}
+	item := m.items[0]
+	m.items = m.items[1:]
+	return item, true
+}
+
+func (m *heapModel) peek() (int, bool) {
+	if len(m.items) == 0 {
+		return 0, false
+	}
+	return m.items[0], true
+}
+
+func minHeapOp(r *rand.Rand) concurrencytest.Op[*MinHeap[int], *heapModel] {
+	item := r.Intn(10)
+	switch r.Intn(4) {
+	case 0:
+		return concurrencytest.Op[*MinHeap[int], *heapModel]{
+			Name:  fmt.Sprintf("Push(%d)", item),
+			Run:   func(h *MinHeap[int]) any { h.Push(item); return nil },
+			Model: func(m *heapModel) any { m.push(item); return nil },
+		}
+	case 1:
+		return concurrencytest.Op[*MinHeap[int], *heapModel]{
+			Name: "Pop()",
+			Run: func(h *MinHeap[int]) any {
+				v, ok := h.Pop()
+				return [2]any{v, ok}
+			},
+			Model: func(m *heapModel) any {
+				v, ok := m.pop()
+				return [2]any{v, ok}
+			},
+		}
+	case 2:
+		return concurrencytest.Op[*MinHeap[int], *heapModel]{
+			Name: "Peek()",
+			Run: func(h *MinHeap[int]) any {
+				v, ok := h.Peek()
+				return [2]any{v, ok}
+			},
+			Model: func(m *heapModel) any {
+				v, ok := m.peek()
+				return [2]any{v, ok}
+			},
+		}
+	default:
+		return concurrencytest.Op[*MinHeap[int], *heapModel]{
+			Name:  "Size()",
+			Run:   func(h *MinHeap[int]) any { return h.Size() },
+			Model: func(m *heapModel) any { return len(m.items) },
+		}
+	}
+}
+
+// Each value is enqueued with itself as its priority, so the item that
+// leaves among equal priorities does not matter.
+func priorityQueueOp(r *rand.Rand) concurrencytest.Op[*PriorityQueue[int], *heapModel] {
+	item := r.Intn(10)
+	switch r.Intn(3) {
+	case 0:
+		return concurrencytest.Op[*PriorityQueue[int], *heapModel]{
+			Name:  fmt.Sprintf("Enqueue(%d)", item),
+			Run:   func(pq *PriorityQueue[int]) any { pq.Enqueue(item, item); return nil },
+			Model: func(m *heapModel) any { m.push(item); return nil },
+		}
+	case 1:
+		return concurrencytest.Op[*PriorityQueue[int], *heapModel]{
+			Name: "Dequeue()",
+			Run: func(pq *PriorityQueue[int]) any {
+				v, _, ok := pq.Dequeue()
+				return [2]any{v, ok}
+			},
+			Model: func(m *heapModel) any {
+				v, ok := m.pop()
+				return [2]any{v, ok}
+			},
+		}
+	default:
+		return concurrencytest.Op[*PriorityQueue[int], *heapModel]{
+			Name: "Peek()",
+			Run: func(pq *PriorityQueue[int]) any {
+				v, _, ok := pq.Peek()
+				return [2]any{v, ok}
+			},
+			Model: func(m *heapModel) any {
+				v, ok := m.peek()
+				return [2]any{v, ok}
+			},
+		}
+	}
+}
+
+func TestMinHeapLinearizable(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		concurrencytest.Run(t, concurrencytest.Script[*MinHeap[int], *heapModel]{
+			New:      func() *MinHeap[int] { return NewMinHeap(func(a, b int) bool { return a < b }) },
+			NewModel: func() *heapModel { return &heapModel{} },
+			Clone:    cloneHeapModel,
+			Threads:  concurrencytest.RandomThreads(r, 4, 6, minHeapOp),
+			Runs:     10,
+		})
+	}
+}
+
+func TestPriorityQueueLinearizable(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		concurrencytest.Run(t, concurrencytest.Script[*PriorityQueue[int], *heapModel]{
+			New:      func() *PriorityQueue[int] { return NewPriorityQueue[int]() },
+			NewModel: func() *heapModel { return &heapModel{} },
+			Clone:    cloneHeapModel,
+			Threads:  concurrencytest.RandomThreads(r, 4, 6, priorityQueueOp),
+			Runs:     10,
+		})
+	}
+}
@@ -2,6 +2,10 @@ package heaps
 
 import (
 	"sync"
+	"sync/atomic"
+
+	"dsgo/slicesx"
+	"dsgo/utils"
 )
 
 type MinHeap[T any] struct {
@@ -9,6 +13,11 @@ type MinHeap[T any] struct {
 	less       func(a, b T) bool
 	threadSafe bool
 	mu         sync.RWMutex
+	sealed     atomic.Bool
+	// pageNodes and pageLevels describe the blocks of a BHeapLayout, and
+	// are zero for the binary layout
+	pageNodes  int
+	pageLevels int
 }
 
 func NewMinHeap[T any](less func(a, b T) bool, threadSafe ...bool) *MinHeap[T] {
@@ -28,6 +37,9 @@ func (h *MinHeap[T]) Push(item T) {
 		h.mu.Lock()
 		defer h.mu.Unlock()
 	}
+	if h.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
 	h.items = append(h.items, item)
 	h.up(len(h.items) - 1)
 }
@@ -37,6 +49,9 @@ func (h *MinHeap[T]) Pop() (T, bool) {
 		h.mu.Lock()
 		defer h.mu.Unlock()
 	}
+	if h.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
 	if len(h.items) == 0 {
 		var zero T
 		return zero, false
@@ -45,7 +60,8 @@ func (h *MinHeap[T]) Pop() (T, bool) {
 	item := h.items[0]
 	last := len(h.items) - 1
 	h.items[0] = h.items[last]
-	h.items = h.items[:last]
+	clear(h.items[last:])
+	h.items = slicesx.Shrink(h.items[:last])
 
 	if len(h.items) > 0 {
 		h.down(0)
@@ -55,7 +71,7 @@ func (h *MinHeap[T]) Pop() (T, bool) {
 }
 
 func (h *MinHeap[T]) Peek() (T, bool) {
-	if h.threadSafe {
+	if h.threadSafe && !h.sealed.Load() {
 		h.mu.RLock()
 		defer h.mu.RUnlock()
 	}
@@ -67,7 +83,7 @@ func (h *MinHeap[T]) Peek() (T, bool) {
 }
 
 func (h *MinHeap[T]) Size() int {
-	if h.threadSafe {
+	if h.threadSafe && !h.sealed.Load() {
 		h.mu.RLock()
 		defer h.mu.RUnlock()
 	}
@@ -75,7 +91,7 @@ func (h *MinHeap[T]) Size() int {
 }
 
 func (h *MinHeap[T]) IsEmpty() bool {
-	if h.threadSafe {
+	if h.threadSafe && !h.sealed.Load() {
 		h.mu.RLock()
 		defer h.mu.RUnlock()
 	}
@@ -83,6 +99,10 @@ func (h *MinHeap[T]) IsEmpty() bool {
 }
 
 func (h *MinHeap[T]) up(i int) {
+	if h.pageNodes != 0 {
+		h.bheapUp(i)
+		return
+	}
 	for {
 		parent := (i - 1) / 2
 		if i == parent || !h.less(h.items[i], h.items[parent]) {
@@ -94,6 +114,10 @@ func (h *MinHeap[T]) up(i int) {
 }
 
 func (h *MinHeap[T]) down(i int) {
+	if h.pageNodes != 0 {
+		h.bheapDown(i)
+		return
+	}
 	for {
 		left := 2*i + 1
 		if left >= len(h.items) {
@@ -115,3 +139,32 @@ func (h *MinHeap[T]) down(i int) {
 		i = smallest
 	}
 }
+
+// Compact releases the spare capacity of the backing slice. Removals
+// already shrink it once it is less than a quarter full; Compact is for
+// reclaiming memory right after a burst of removals.
+func (h *MinHeap[T]) Compact() {
+	if h.threadSafe {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+	}
+	if h.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
+	h.items = slicesx.Compact(h.items)
+}
+
+// Seal makes the heap read-only. Mutations after Seal panic with
+// utils.ErrSealed. Reads on a sealed heap skip locking.
+func (h *MinHeap[T]) Seal() {
+	if h.threadSafe {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+	}
+	h.sealed.Store(true)
+}
+
+// IsSealed reports whether Seal has been called.
+func (h *MinHeap[T]) IsSealed() bool {
+	return h.sealed.Load()
+}
@@ -3,6 +3,8 @@ package heaps
 import (
 	"sync"
 	"testing"
+
+	"dsgo/utils"
 )
 
 func TestMinHeapBasicOperations(t *testing.T) {
@@ -223,3 +225,43 @@ func TestMinHeapEdgeCases(t *testing.T) {
 		t.Error("Expected 0, got", val)
 	}
 }
+
+func TestMinHeapSeal(t *testing.T) {
+	h := NewMinHeap(func(a, b int) bool { return a < b })
+	h.Push(1)
+	h.Seal()
+
+	if v, ok := h.Peek(); !ok || v != 1 {
+		t.Error("Peek should keep working on a sealed heap")
+	}
+	defer func() {
+		if r := recover(); r != utils.ErrSealed {
+			t.Errorf("Pop on sealed heap should panic with ErrSealed, got %v", r)
+		}
+	}()
+	h.Pop()
+}
+
+func TestMinHeapShrinks(t *testing.T) {
+	h := NewMinHeap(func(a, b int) bool { return a < b }, false)
+	for i := 0; i < 10000; i++ {
+		h.Push(i)
+	}
+	peak := cap(h.items)
+	for i := 0; i < 9990; i++ {
+		if v, _ := h.Pop(); v != i {
+			t.Fatalf("Pop() = %d, want %d", v, i)
+		}
+	}
+	if cap(h.items) >= peak/4 {
+		t.Errorf("cap after draining = %d, want well below the peak of %d", cap(h.items), peak)
+	}
+
+	h.Compact()
+	if cap(h.items) != h.Size() {
+		t.Errorf("cap after Compact() = %d, want %d", cap(h.items), h.Size())
+	}
+	if v, ok := h.Peek(); !ok || v != 9990 {
+		t.Errorf("Peek() after Compact() = %d, %v, want 9990, true", v, ok)
+	}
+}
@@ -2,6 +2,10 @@ package heaps
 
 import (
 	"sync"
+	"sync/atomic"
+
+	"dsgo/slicesx"
+	"dsgo/utils"
 )
 
 type PriorityQueueItem[T any] struct {
@@ -14,6 +18,7 @@ type PriorityQueue[T any] struct {
 	less       func(a, b PriorityQueueItem[T]) bool
 	threadSafe bool
 	mu         sync.RWMutex
+	sealed     atomic.Bool
 }
 
 func NewPriorityQueue[T any](threadSafe ...bool) *PriorityQueue[T] {
@@ -35,6 +40,9 @@ func (pq *PriorityQueue[T]) Enqueue(value T, priority int) {
 		pq.mu.Lock()
 		defer pq.mu.Unlock()
 	}
+	if pq.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
 	pq.items = append(pq.items, PriorityQueueItem[T]{Value: value, Priority: priority})
 	pq.up(len(pq.items) - 1)
 }
@@ -44,6 +52,9 @@ func (pq *PriorityQueue[T]) Dequeue() (T, int, bool) {
 		pq.mu.Lock()
 		defer pq.mu.Unlock()
 	}
+	if pq.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
 	if len(pq.items) == 0 {
 		var zero T
 		return zero, 0, false
@@ -52,7 +63,8 @@ func (pq *PriorityQueue[T]) Dequeue() (T, int, bool) {
 	item := pq.items[0]
 	last := len(pq.items) - 1
 	pq.items[0] = pq.items[last]
-	pq.items = pq.items[:last]
+	clear(pq.items[last:])
+	pq.items = slicesx.Shrink(pq.items[:last])
 
 	if len(pq.items) > 0 {
 		pq.down(0)
@@ -62,7 +74,7 @@ func (pq *PriorityQueue[T]) Dequeue() (T, int, bool) {
 }
 
 func (pq *PriorityQueue[T]) Peek() (T, int, bool) {
-	if pq.threadSafe {
+	if pq.threadSafe && !pq.sealed.Load() {
 		pq.mu.RLock()
 		defer pq.mu.RUnlock()
 	}
@@ -74,7 +86,7 @@ func (pq *PriorityQueue[T]) Peek() (T, int, bool) {
 }
 
 func (pq *PriorityQueue[T]) Size() int {
-	if pq.threadSafe {
+	if pq.threadSafe && !pq.sealed.Load() {
 		pq.mu.RLock()
 		defer pq.mu.RUnlock()
 	}
@@ -82,7 +94,7 @@ func (pq *PriorityQueue[T]) Size() int {
 }
 
 func (pq *PriorityQueue[T]) IsEmpty() bool {
-	if pq.threadSafe {
+	if pq.threadSafe && !pq.sealed.Load() {
 		pq.mu.RLock()
 		defer pq.mu.RUnlock()
 	}
@@ -122,3 +134,32 @@ func (pq *PriorityQueue[T]) down(i int) {
 		i = smallest
 	}
 }
+
+// Compact releases the spare capacity of the backing slice. Removals
+// already shrink it once it is less than a quarter full; Compact is for
+// reclaiming memory right after a burst of removals.
+func (pq *PriorityQueue[T]) Compact() {
+	if pq.threadSafe {
+		pq.mu.Lock()
+		defer pq.mu.Unlock()
+	}
+	if pq.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
+	pq.items = slicesx.Compact(pq.items)
+}
+
+// Seal makes the queue read-only. Mutations after Seal panic with
+// utils.ErrSealed. Reads on a sealed queue skip locking.
+func (pq *PriorityQueue[T]) Seal() {
+	if pq.threadSafe {
+		pq.mu.Lock()
+		defer pq.mu.Unlock()
+	}
+	pq.sealed.Store(true)
+}
+
+// IsSealed reports whether Seal has been called.
+func (pq *PriorityQueue[T]) IsSealed() bool {
+	return pq.sealed.Load()
+}
@@ -3,6 +3,8 @@ package heaps
 import (
 	"sync"
 	"testing"
+
+	"dsgo/utils"
 )
 
 func TestPriorityQueueBasicOperations(t *testing.T) {
@@ -186,3 +188,40 @@ func TestPriorityQueueWithCustomType(t *testing.T) {
 		}
 	}
 }
+
+func TestPriorityQueueSeal(t *testing.T) {
+	pq := NewPriorityQueue[string]()
+	pq.Enqueue("a", 1)
+	pq.Seal()
+
+	if pq.Size() != 1 || !pq.IsSealed() {
+		t.Error("Sealed queue should keep its contents")
+	}
+	defer func() {
+		if r := recover(); r != utils.ErrSealed {
+			t.Errorf("Enqueue on sealed queue should panic with ErrSealed, got %v", r)
+		}
+	}()
+	pq.Enqueue("b", 2)
+}
+
+func TestPriorityQueueShrinks(t *testing.T) {
+	pq := NewPriorityQueue[string](false)
+	for i := 0; i < 10000; i++ {
+		pq.Enqueue("item", i)
+	}
+	peak := cap(pq.items)
+	for i := 0; i < 9990; i++ {
+		pq.Dequeue()
+	}
+	if cap(pq.items) >= peak/4 {
+		t.Errorf("cap after draining = %d, want well below the peak of %d", cap(pq.items), peak)
+	}
+	pq.Compact()
+	if cap(pq.items) != pq.Size() {
+		t.Errorf("cap after Compact() = %d, want %d", cap(pq.items), pq.Size())
+	}
+	if _, priority, ok := pq.Peek(); !ok || priority != 9990 {
+		t.Errorf("Peek() after Compact() priority = %d, %v, want 9990, true", priority, ok)
+	}
+}
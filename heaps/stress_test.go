@@ -0,0 +1,216 @@
+package heaps
+
+import (
+	"container/heap"
+	"math/rand"
+	"testing"
+)
+
+// intHeap is a container/heap reference model.
+type intHeap []int
+
+func (h intHeap) Len() int           { return len(h) }
+func (h intHeap) Less(i, j int) bool { return h[i] < h[j] }
+func (h intHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *intHeap) Push(x any)        { *h = append(*h, x.(int)) }
+func (h *intHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+func stressOps() int {
+	if testing.Short() {
+		return 100_000
+	}
+	return 2_000_000
+}
+
+func checkHeapOrder[T any](t *testing.T, items []T, less func(a, b T) bool) {
+	t.Helper()
+	for i := 1; i < len(items); i++ {
+		if less(items[i], items[(i-1)/2]) {
+			t.Fatalf("heap order violated at index %d", i)
+		}
+	}
+}
+
+func TestMinHeapStress(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	h := NewMinHeap(func(a, b int) bool { return a < b }, false)
+	model := &intHeap{}
+	ops := stressOps()
+	for i := 0; i < ops; i++ {
+		// Bias towards pushes so the heap grows into the thousands
+		if r.Intn(5) < 3 {
+			v := r.Intn(1 << 20)
+			h.Push(v)
+			heap.Push(model, v)
+		} else {
+			got, ok := h.Pop()
+			if ok != (model.Len() > 0) {
+				t.Fatalf("op %d: Pop() ok = %v with %d items in model", i, ok, model.Len())
+			}
+			if ok {
+				if want := heap.Pop(model).(int); got != want {
+					t.Fatalf("op %d: Pop() = %d, want %d", i, got, want)
+				}
+			}
+		}
+		if i%50_000 == 0 {
+			checkHeapOrder(t, h.items, h.less)
+		}
+	}
+	checkHeapOrder(t, h.items, h.less)
+	if h.Size() != model.Len() {
+		t.Errorf("Size() = %d, want %d", h.Size(), model.Len())
+	}
+}
+
+func TestPriorityQueueStress(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	pq := NewPriorityQueue[int](false)
+	model := &intHeap{}
+	ops := stressOps()
+	for i := 0; i < ops; i++ {
+		if r.Intn(5) < 3 {
+			p := r.Intn(1 << 20)
+			pq.Enqueue(i, p)
+			heap.Push(model, p)
+		} else {
+			_, got, ok := pq.Dequeue()
+			if ok != (model.Len() > 0) {
+				t.Fatalf("op %d: Dequeue() ok = %v with %d items in model", i, ok, model.Len())
+			}
+			if ok {
+				if want := heap.Pop(model).(int); got != want {
+					t.Fatalf("op %d: Dequeue() priority = %d, want %d", i, got, want)
+				}
+			}
+		}
+		if i%50_000 == 0 {
+			checkHeapOrder(t, pq.items, pq.less)
+		}
+	}
+	checkHeapOrder(t, pq.items, pq.less)
+}
+
+// dijkstraGraph is a random sparse graph as adjacency lists of (to, weight).
+type dijkstraGraph [][][2]int
+
+func newDijkstraGraph(nodes, degree int) dijkstraGraph {
+	r := rand.New(rand.NewSource(42))
+	g := make(dijkstraGraph, nodes)
+	for u := range g {
+		for k := 0; k < degree; k++ {
+			g[u] = append(g[u], [2]int{r.Intn(nodes), 1 + r.Intn(1000)})
+		}
+	}
+	return g
+}
+
+// dijkstra runs Dijkstra's algorithm from node 0 with lazy deletion: a
+// decrease-key pushes a fresh entry and stale entries are skipped on pop,
+// which is how both heaps in this package support decrease-key workloads.
+func (g dijkstraGraph) dijkstra(push func(node, dist int), pop func() (int, int, bool)) []int {
+	dist := make([]int, len(g))
+	for i := range dist {
+		dist[i] = -1
+	}
+	dist[0] = 0
+	push(0, 0)
+	for {
+		u, d, ok := pop()
+		if !ok {
+			return dist
+		}
+		if d > dist[u] {
+			continue
+		}
+		for _, e := range g[u] {
+			if nd := d + e[1]; dist[e[0]] == -1 || nd < dist[e[0]] {
+				dist[e[0]] = nd
+				push(e[0], nd)
+			}
+		}
+	}
+}
+
+func BenchmarkDijkstra(b *testing.B) {
+	g := newDijkstraGraph(20_000, 16)
+
+	b.Run("PriorityQueue", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			pq := NewPriorityQueue[int](false)
+			g.dijkstra(pq.Enqueue, pq.Dequeue)
+		}
+	})
+	b.Run("PriorityQueue/threadSafe", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			pq := NewPriorityQueue[int]()
+			g.dijkstra(pq.Enqueue, pq.Dequeue)
+		}
+	})
+	b.Run("MinHeap", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			h := NewMinHeap(func(a, b [2]int) bool { return a[1] < b[1] }, false)
+			g.dijkstra(
+				func(node, dist int) { h.Push([2]int{node, dist}) },
+				func() (int, int, bool) {
+					item, ok := h.Pop()
+					return item[0], item[1], ok
+				},
+			)
+		}
+	})
+	b.Run("container/heap", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			h := &pairHeap{}
+			g.dijkstra(
+				func(node, dist int) { heap.Push(h, [2]int{node, dist}) },
+				func() (int, int, bool) {
+					if h.Len() == 0 {
+						return 0, 0, false
+					}
+					item := heap.Pop(h).([2]int)
+					return item[0], item[1], true
+				},
+			)
+		}
+	})
+}
+
+// pairHeap is a container/heap baseline of (node, dist) pairs.
+type pairHeap [][2]int
+
+func (h pairHeap) Len() int           { return len(h) }
+func (h pairHeap) Less(i, j int) bool { return h[i][1] < h[j][1] }
+func (h pairHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *pairHeap) Push(x any)        { *h = append(*h, x.([2]int)) }
+func (h *pairHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+func TestDijkstraHeapsAgree(t *testing.T) {
+	g := newDijkstraGraph(2_000, 8)
+	pq := NewPriorityQueue[int](false)
+	want := g.dijkstra(pq.Enqueue, pq.Dequeue)
+
+	h := NewMinHeap(func(a, b [2]int) bool { return a[1] < b[1] }, false)
+	got := g.dijkstra(
+		func(node, dist int) { h.Push([2]int{node, dist}) },
+		func() (int, int, bool) {
+			item, ok := h.Pop()
+			return item[0], item[1], ok
+		},
+	)
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("dist[%d] = %d with MinHeap, %d with PriorityQueue", i, got[i], want[i])
+		}
+	}
+}
@@ -3,12 +3,17 @@ package linkedlist
 import (
 	"errors"
 	"sync"
+	"sync/atomic"
+
+	"dsgo/utils"
 )
 
 type DNode[T comparable] struct {
 	value T
 	prev  *DNode[T]
 	next  *DNode[T]
+	// list is the list the node belongs to, or nil once it is removed
+	list *DoubleLinkedList[T]
 }
 
 // GetValue returns the value stored in the node
@@ -22,6 +27,7 @@ type DoubleLinkedList[T comparable] struct {
 	len        int
 	threadSafe bool
 	mu         sync.RWMutex
+	sealed     atomic.Bool
 }
 
 func NewDoubleLinkedList[T comparable](threadSafe ...bool) *DoubleLinkedList[T] {
@@ -41,8 +47,11 @@ func (l *DoubleLinkedList[T]) PushBack(value T) {
 		l.mu.Lock()
 		defer l.mu.Unlock()
 	}
+	if l.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
 
-	newNode := &DNode[T]{value: value}
+	newNode := &DNode[T]{value: value, list: l}
 	if l.head == nil {
 		l.head = newNode
 		l.tail = newNode
@@ -59,8 +68,11 @@ func (l *DoubleLinkedList[T]) PushFront(value T) {
 		l.mu.Lock()
 		defer l.mu.Unlock()
 	}
+	if l.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
 
-	newNode := &DNode[T]{value: value}
+	newNode := &DNode[T]{value: value, list: l}
 	if l.head == nil {
 		l.head = newNode
 		l.tail = newNode
@@ -77,6 +89,9 @@ func (l *DoubleLinkedList[T]) Remove(value T) error {
 		l.mu.Lock()
 		defer l.mu.Unlock()
 	}
+	if l.sealed.Load() {
+		return utils.ErrSealed
+	}
 
 	if l.head == nil {
 		return ErrEmptyList
@@ -84,6 +99,7 @@ func (l *DoubleLinkedList[T]) Remove(value T) error {
 
 	// Special case: removing head
 	if l.head.value == value {
+		l.head.list = nil
 		l.head = l.head.next
 		if l.head == nil {
 			l.tail = nil
@@ -96,6 +112,7 @@ func (l *DoubleLinkedList[T]) Remove(value T) error {
 
 	// Special case: removing tail
 	if l.tail.value == value {
+		l.tail.list = nil
 		l.tail = l.tail.prev
 		l.tail.next = nil
 		l.len--
@@ -108,6 +125,7 @@ func (l *DoubleLinkedList[T]) Remove(value T) error {
 		if current.value == value {
 			current.prev.next = current.next
 			current.next.prev = current.prev
+			current.list = nil
 			l.len--
 			return nil
 		}
@@ -118,7 +136,7 @@ func (l *DoubleLinkedList[T]) Remove(value T) error {
 }
 
 func (l *DoubleLinkedList[T]) Contains(value T) bool {
-	if l.threadSafe {
+	if l.threadSafe && !l.sealed.Load() {
 		l.mu.RLock()
 		defer l.mu.RUnlock()
 	}
@@ -134,7 +152,7 @@ func (l *DoubleLinkedList[T]) Contains(value T) bool {
 }
 
 func (l *DoubleLinkedList[T]) At(index int) (T, error) {
-	if l.threadSafe {
+	if l.threadSafe && !l.sealed.Load() {
 		l.mu.RLock()
 		defer l.mu.RUnlock()
 	}
@@ -165,14 +183,20 @@ func (l *DoubleLinkedList[T]) Clear() {
 		l.mu.Lock()
 		defer l.mu.Unlock()
 	}
+	if l.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
 
+	for node := l.head; node != nil; node = node.next {
+		node.list = nil
+	}
 	l.head = nil
 	l.tail = nil
 	l.len = 0
 }
 
 func (l *DoubleLinkedList[T]) ForEach(f func(T)) {
-	if l.threadSafe {
+	if l.threadSafe && !l.sealed.Load() {
 		l.mu.RLock()
 		defer l.mu.RUnlock()
 	}
@@ -185,7 +209,7 @@ func (l *DoubleLinkedList[T]) ForEach(f func(T)) {
 }
 
 func (l *DoubleLinkedList[T]) ForEachReverse(f func(T)) {
-	if l.threadSafe {
+	if l.threadSafe && !l.sealed.Load() {
 		l.mu.RLock()
 		defer l.mu.RUnlock()
 	}
@@ -198,7 +222,7 @@ func (l *DoubleLinkedList[T]) ForEachReverse(f func(T)) {
 }
 
 func (l *DoubleLinkedList[T]) Back() (*DNode[T], error) {
-	if l.threadSafe {
+	if l.threadSafe && !l.sealed.Load() {
 		l.mu.RLock()
 		defer l.mu.RUnlock()
 	}
@@ -210,7 +234,7 @@ func (l *DoubleLinkedList[T]) Back() (*DNode[T], error) {
 }
 
 func (l *DoubleLinkedList[T]) Front() (*DNode[T], error) {
-	if l.threadSafe {
+	if l.threadSafe && !l.sealed.Load() {
 		l.mu.RLock()
 		defer l.mu.RUnlock()
 	}
@@ -222,7 +246,7 @@ func (l *DoubleLinkedList[T]) Front() (*DNode[T], error) {
 }
 
 func (l *DoubleLinkedList[T]) Len() int {
-	if l.threadSafe {
+	if l.threadSafe && !l.sealed.Load() {
 		l.mu.RLock()
 		defer l.mu.RUnlock()
 	}
@@ -235,6 +259,9 @@ func (l *DoubleLinkedList[T]) InsertAfter(target, value T) error {
 		l.mu.Lock()
 		defer l.mu.Unlock()
 	}
+	if l.sealed.Load() {
+		return utils.ErrSealed
+	}
 
 	if l.head == nil {
 		return ErrEmptyList
@@ -243,7 +270,7 @@ func (l *DoubleLinkedList[T]) InsertAfter(target, value T) error {
 	current := l.head
 	for current != nil {
 		if current.value == target {
-			newNode := &DNode[T]{value: value}
+			newNode := &DNode[T]{value: value, list: l}
 			newNode.next = current.next
 			newNode.prev = current
 			if current.next != nil {
@@ -267,6 +294,9 @@ func (l *DoubleLinkedList[T]) InsertBefore(target, value T) error {
 		l.mu.Lock()
 		defer l.mu.Unlock()
 	}
+	if l.sealed.Load() {
+		return utils.ErrSealed
+	}
 
 	if l.head == nil {
 		return ErrEmptyList
@@ -274,7 +304,7 @@ func (l *DoubleLinkedList[T]) InsertBefore(target, value T) error {
 
 	// Special case: inserting before head
 	if l.head.value == target {
-		newNode := &DNode[T]{value: value}
+		newNode := &DNode[T]{value: value, list: l}
 		newNode.next = l.head
 		l.head.prev = newNode
 		l.head = newNode
@@ -285,7 +315,7 @@ func (l *DoubleLinkedList[T]) InsertBefore(target, value T) error {
 	current := l.head.next
 	for current != nil {
 		if current.value == target {
-			newNode := &DNode[T]{value: value}
+			newNode := &DNode[T]{value: value, list: l}
 			newNode.next = current
 			newNode.prev = current.prev
 			current.prev.next = newNode
@@ -298,3 +328,125 @@ func (l *DoubleLinkedList[T]) InsertBefore(target, value T) error {
 
 	return ErrNotFound
 }
+
+// MoveBefore moves node to just before mark. It returns ErrNotFound if
+// either node is not in the list; moving a node relative to itself is a no-op.
+func (l *DoubleLinkedList[T]) MoveBefore(node, mark *DNode[T]) error {
+	if l.threadSafe {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+	}
+	if l.sealed.Load() {
+		return utils.ErrSealed
+	}
+	if node == nil || mark == nil || node.list != l || mark.list != l {
+		return ErrNotFound
+	}
+	if node == mark {
+		return nil
+	}
+	l.unlink(node)
+	l.linkAfter(node, mark.prev)
+	return nil
+}
+
+// MoveAfter moves node to just after mark. It returns ErrNotFound if either
+// node is not in the list; moving a node relative to itself is a no-op.
+func (l *DoubleLinkedList[T]) MoveAfter(node, mark *DNode[T]) error {
+	if l.threadSafe {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+	}
+	if l.sealed.Load() {
+		return utils.ErrSealed
+	}
+	if node == nil || mark == nil || node.list != l || mark.list != l {
+		return ErrNotFound
+	}
+	if node == mark {
+		return nil
+	}
+	l.unlink(node)
+	l.linkAfter(node, mark)
+	return nil
+}
+
+// Swap exchanges the positions of a and b. It returns ErrNotFound if either
+// node is not in the list.
+func (l *DoubleLinkedList[T]) Swap(a, b *DNode[T]) error {
+	if l.threadSafe {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+	}
+	if l.sealed.Load() {
+		return utils.ErrSealed
+	}
+	if a == nil || b == nil || a.list != l || b.list != l {
+		return ErrNotFound
+	}
+
+	switch {
+	case a == b:
+	case a.next == b:
+		l.unlink(a)
+		l.linkAfter(a, b)
+	case b.next == a:
+		l.unlink(b)
+		l.linkAfter(b, a)
+	default:
+		aPrev, bPrev := a.prev, b.prev
+		l.unlink(a)
+		l.unlink(b)
+		l.linkAfter(a, bPrev)
+		l.linkAfter(b, aPrev)
+	}
+	return nil
+}
+
+// unlink detaches node from its neighbours without changing the length.
+func (l *DoubleLinkedList[T]) unlink(node *DNode[T]) {
+	if node.prev == nil {
+		l.head = node.next
+	} else {
+		node.prev.next = node.next
+	}
+	if node.next == nil {
+		l.tail = node.prev
+	} else {
+		node.next.prev = node.prev
+	}
+	node.prev, node.next = nil, nil
+}
+
+// linkAfter inserts a detached node after at, or at the front if at is nil.
+func (l *DoubleLinkedList[T]) linkAfter(node, at *DNode[T]) {
+	node.prev = at
+	if at == nil {
+		node.next = l.head
+		l.head = node
+	} else {
+		node.next = at.next
+		at.next = node
+	}
+	if node.next == nil {
+		l.tail = node
+	} else {
+		node.next.prev = node
+	}
+}
+
+// Seal makes the list read-only. Mutations after Seal panic with
+// utils.ErrSealed, or return it from
+// methods that already report errors. Reads on a sealed list skip locking.
+func (l *DoubleLinkedList[T]) Seal() {
+	if l.threadSafe {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+	}
+	l.sealed.Store(true)
+}
+
+// IsSealed reports whether Seal has been called.
+func (l *DoubleLinkedList[T]) IsSealed() bool {
+	return l.sealed.Load()
+}
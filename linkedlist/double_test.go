@@ -1,8 +1,11 @@
 package linkedlist
 
 import (
+	"runtime"
 	"sync"
 	"testing"
+
+	"dsgo/utils"
 )
 
 func TestDoubleLinkedListBasic(t *testing.T) {
@@ -161,3 +164,124 @@ func TestDoubleLinkedListForEach(t *testing.T) {
 		index--
 	})
 }
+
+func TestDoubleLinkedListSeal(t *testing.T) {
+	list := NewDoubleLinkedList[int]()
+	list.PushBack(1)
+	list.PushBack(2)
+	list.Seal()
+
+	if err := list.Remove(1); err != utils.ErrSealed {
+		t.Errorf("Remove on sealed list = %v, want ErrSealed", err)
+	}
+	if err := list.InsertAfter(1, 3); err != utils.ErrSealed {
+		t.Errorf("InsertAfter on sealed list = %v, want ErrSealed", err)
+	}
+	if list.Len() != 2 {
+		t.Errorf("Sealed list should be unchanged, got length %d", list.Len())
+	}
+
+	defer func() {
+		if r := recover(); r != utils.ErrSealed {
+			t.Errorf("PushBack on sealed list should panic with ErrSealed, got %v", r)
+		}
+	}()
+	list.PushBack(3)
+}
+
+func TestDoubleLinkedListMove(t *testing.T) {
+	list := NewDoubleLinkedList[int]()
+	var nodes []*DNode[int]
+	for i := 0; i < 5; i++ {
+		list.PushBack(i)
+		back, _ := list.Back()
+		nodes = append(nodes, back)
+	}
+
+	// Each step applies to the list left by the previous one
+	tests := []struct {
+		name string
+		op   func() error
+		want []int
+	}{
+		{"MoveBefore head", func() error { return list.MoveBefore(nodes[3], nodes[0]) }, []int{3, 0, 1, 2, 4}},
+		{"MoveAfter tail", func() error { return list.MoveAfter(nodes[3], nodes[4]) }, []int{0, 1, 2, 4, 3}},
+		{"MoveAfter neighbour", func() error { return list.MoveAfter(nodes[1], nodes[2]) }, []int{0, 2, 1, 4, 3}},
+		{"MoveBefore self", func() error { return list.MoveBefore(nodes[1], nodes[1]) }, []int{0, 2, 1, 4, 3}},
+		{"Swap adjacent", func() error { return list.Swap(nodes[0], nodes[2]) }, []int{2, 0, 1, 4, 3}},
+		{"Swap reversed adjacent", func() error { return list.Swap(nodes[3], nodes[4]) }, []int{2, 0, 1, 3, 4}},
+		{"Swap ends", func() error { return list.Swap(nodes[2], nodes[4]) }, []int{4, 0, 1, 3, 2}},
+		{"Swap self", func() error { return list.Swap(nodes[1], nodes[1]) }, []int{4, 0, 1, 3, 2}},
+	}
+
+	for _, tt := range tests {
+		if err := tt.op(); err != nil {
+			t.Fatalf("%s: error = %v", tt.name, err)
+		}
+		if got := collectDouble(list); !equalInts(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+		var backward []int
+		list.ForEachReverse(func(v int) { backward = append([]int{v}, backward...) })
+		if !equalInts(backward, tt.want) {
+			t.Errorf("%s: backward links give %v, want %v", tt.name, backward, tt.want)
+		}
+	}
+	if list.Len() != 5 {
+		t.Errorf("Len() = %d, want 5", list.Len())
+	}
+}
+
+func TestDoubleLinkedListMoveForeignNode(t *testing.T) {
+	list := NewDoubleLinkedList[int]()
+	other := NewDoubleLinkedList[int]()
+	list.PushBack(1)
+	list.PushBack(2)
+	other.PushBack(3)
+	front, _ := list.Front()
+	back, _ := list.Back()
+	foreign, _ := other.Front()
+
+	if err := list.MoveBefore(foreign, front); err != ErrNotFound {
+		t.Errorf("MoveBefore(foreign) error = %v, want ErrNotFound", err)
+	}
+	if err := list.Swap(front, foreign); err != ErrNotFound {
+		t.Errorf("Swap(foreign) error = %v, want ErrNotFound", err)
+	}
+
+	list.Remove(2)
+	if err := list.MoveAfter(back, front); err != ErrNotFound {
+		t.Errorf("MoveAfter(removed) error = %v, want ErrNotFound", err)
+	}
+	if got := collectDouble(list); !equalInts(got, []int{1}) {
+		t.Errorf("list changed by rejected moves: %v", got)
+	}
+}
+
+// BenchmarkDoubleLinkedListInlineValues compares int elements, stored inline
+// in each node, with the same elements boxed behind an interface.
+func BenchmarkDoubleLinkedListInlineValues(b *testing.B) {
+	const n = 100000
+	b.Run("inline", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			l := NewDoubleLinkedList[int](false)
+			for k := 0; k < n; k++ {
+				l.PushBack(k * 1000)
+			}
+			runtime.GC()
+			runtime.KeepAlive(l)
+		}
+	})
+	b.Run("boxed", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			l := NewDoubleLinkedList[any](false)
+			for k := 0; k < n; k++ {
+				l.PushBack(k * 1000)
+			}
+			runtime.GC()
+			runtime.KeepAlive(l)
+		}
+	})
+}
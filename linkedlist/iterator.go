@@ -0,0 +1,169 @@
+package linkedlist
+
+import "dsgo/utils"
+
+// Iterator walks a SingleLinkedList front to back and can delete the current
+// node in O(1). On a thread-safe list the iterator holds the list's write
+// lock from creation until Close, or until Next returns false, so the list
+// must not be used through its other methods meanwhile.
+type Iterator[T comparable] struct {
+	list      *SingleLinkedList[T]
+	prev      *Node[T]
+	node      *Node[T]
+	following *Node[T]
+	started   bool
+	deleted   bool
+	closed    bool
+}
+
+// Iterator returns an iterator positioned before the first node. Call Next
+// to advance to it.
+func (l *SingleLinkedList[T]) Iterator() *Iterator[T] {
+	if l.threadSafe {
+		l.mu.Lock()
+	}
+	return &Iterator[T]{list: l}
+}
+
+// Next advances to the next node and reports whether there is one.
+func (it *Iterator[T]) Next() bool {
+	if it.closed {
+		return false
+	}
+	if it.node != nil && !it.deleted {
+		it.prev = it.node
+	}
+	if !it.started {
+		it.node = it.list.head
+		it.started = true
+	} else {
+		it.node = it.following
+	}
+	it.deleted = false
+	if it.node == nil {
+		it.Close()
+		return false
+	}
+	it.following = it.node.next
+	return true
+}
+
+// Value returns the value of the current node, or the zero value if there
+// is none.
+func (it *Iterator[T]) Value() T {
+	if it.node == nil || it.deleted {
+		var zero T
+		return zero
+	}
+	return it.node.value
+}
+
+// Delete unlinks the current node. Calling it twice for the same node is a
+// no-op. It panics with utils.ErrSealed if the list is sealed.
+func (it *Iterator[T]) Delete() {
+	if it.closed || it.node == nil || it.deleted {
+		return
+	}
+	l := it.list
+	if l.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
+	if it.prev == nil {
+		l.head = it.node.next
+	} else {
+		it.prev.next = it.node.next
+	}
+	if l.tail == it.node {
+		l.tail = it.prev
+	}
+	l.len--
+	it.deleted = true
+}
+
+// Close releases the list's lock. It is safe to call more than once.
+func (it *Iterator[T]) Close() {
+	if it.closed {
+		return
+	}
+	it.closed = true
+	if it.list.threadSafe {
+		it.list.mu.Unlock()
+	}
+}
+
+// DIterator walks a DoubleLinkedList front to back and can delete the
+// current node in O(1). Locking follows the same rules as Iterator.
+type DIterator[T comparable] struct {
+	list      *DoubleLinkedList[T]
+	node      *DNode[T]
+	following *DNode[T]
+	started   bool
+	deleted   bool
+	closed    bool
+}
+
+// Iterator returns an iterator positioned before the first node. Call Next
+// to advance to it.
+func (l *DoubleLinkedList[T]) Iterator() *DIterator[T] {
+	if l.threadSafe {
+		l.mu.Lock()
+	}
+	return &DIterator[T]{list: l}
+}
+
+// Next advances to the next node and reports whether there is one.
+func (it *DIterator[T]) Next() bool {
+	if it.closed {
+		return false
+	}
+	if !it.started {
+		it.node = it.list.head
+		it.started = true
+	} else {
+		it.node = it.following
+	}
+	it.deleted = false
+	if it.node == nil {
+		it.Close()
+		return false
+	}
+	it.following = it.node.next
+	return true
+}
+
+// Value returns the value of the current node, or the zero value if there
+// is none.
+func (it *DIterator[T]) Value() T {
+	if it.node == nil || it.deleted {
+		var zero T
+		return zero
+	}
+	return it.node.value
+}
+
+// Delete unlinks the current node. Calling it twice for the same node is a
+// no-op. It panics with utils.ErrSealed if the list is sealed.
+func (it *DIterator[T]) Delete() {
+	if it.closed || it.node == nil || it.deleted {
+		return
+	}
+	l := it.list
+	if l.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
+	l.unlink(it.node)
+	it.node.list = nil
+	l.len--
+	it.deleted = true
+}
+
+// Close releases the list's lock. It is safe to call more than once.
+func (it *DIterator[T]) Close() {
+	if it.closed {
+		return
+	}
+	it.closed = true
+	if it.list.threadSafe {
+		it.list.mu.Unlock()
+	}
+}
@@ -0,0 +1,137 @@
+package linkedlist
+
+import (
+	"testing"
+
+	"dsgo/utils"
+)
+
+func collectSingle(l *SingleLinkedList[int]) []int {
+	var values []int
+	l.ForEach(func(v int) { values = append(values, v) })
+	return values
+}
+
+func collectDouble(l *DoubleLinkedList[int]) []int {
+	var values []int
+	l.ForEach(func(v int) { values = append(values, v) })
+	return values
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSingleLinkedListIteratorDelete(t *testing.T) {
+	for _, threadSafe := range []bool{false, true} {
+		list := NewSingleLinkedList[int](threadSafe)
+		for i := 0; i < 10; i++ {
+			list.PushBack(i)
+		}
+
+		it := list.Iterator()
+		for it.Next() {
+			// Remove evens, including the head, and the tail
+			if it.Value()%2 == 0 || it.Value() == 9 {
+				it.Delete()
+			}
+		}
+
+		if got, want := collectSingle(list), []int{1, 3, 5, 7}; !equalInts(got, want) {
+			t.Errorf("after filtering got %v, want %v", got, want)
+		}
+		if list.Len() != 4 {
+			t.Errorf("Len() = %d, want 4", list.Len())
+		}
+		if back, _ := list.Back(); back.value != 7 {
+			t.Errorf("Back() = %d, want 7", back.value)
+		}
+		list.PushBack(11)
+		if got, want := collectSingle(list), []int{1, 3, 5, 7, 11}; !equalInts(got, want) {
+			t.Errorf("after PushBack got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestSingleLinkedListIteratorDeleteAll(t *testing.T) {
+	list := NewSingleLinkedList[int]()
+	list.PushBack(1)
+	list.PushBack(2)
+	it := list.Iterator()
+	for it.Next() {
+		it.Delete()
+		it.Delete()
+	}
+	if list.Len() != 0 {
+		t.Errorf("Len() = %d, want 0", list.Len())
+	}
+	if _, err := list.Front(); err != ErrEmptyList {
+		t.Errorf("Front() error = %v, want ErrEmptyList", err)
+	}
+}
+
+func TestDoubleLinkedListIteratorDelete(t *testing.T) {
+	for _, threadSafe := range []bool{false, true} {
+		list := NewDoubleLinkedList[int](threadSafe)
+		for i := 0; i < 10; i++ {
+			list.PushBack(i)
+		}
+
+		it := list.Iterator()
+		for it.Next() {
+			if it.Value()%2 == 0 || it.Value() == 9 {
+				it.Delete()
+			}
+		}
+
+		if got, want := collectDouble(list), []int{1, 3, 5, 7}; !equalInts(got, want) {
+			t.Errorf("after filtering got %v, want %v", got, want)
+		}
+		var reversed []int
+		list.ForEachReverse(func(v int) { reversed = append(reversed, v) })
+		if want := []int{7, 5, 3, 1}; !equalInts(reversed, want) {
+			t.Errorf("ForEachReverse got %v, want %v", reversed, want)
+		}
+	}
+}
+
+func TestIteratorCloseEarly(t *testing.T) {
+	list := NewDoubleLinkedList[int]()
+	list.PushBack(1)
+	list.PushBack(2)
+	it := list.Iterator()
+	it.Next()
+	it.Close()
+	it.Close()
+	if it.Next() {
+		t.Error("Next() after Close should return false")
+	}
+	// The lock was released, so the list is usable again
+	list.PushBack(3)
+	if list.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", list.Len())
+	}
+}
+
+func TestIteratorDeleteSealed(t *testing.T) {
+	list := NewSingleLinkedList[int](false)
+	list.PushBack(1)
+	list.Seal()
+	it := list.Iterator()
+	defer it.Close()
+	it.Next()
+	defer func() {
+		if r := recover(); r != utils.ErrSealed {
+			t.Errorf("Delete() on sealed list panicked with %v, want ErrSealed", r)
+		}
+	}()
+	it.Delete()
+}
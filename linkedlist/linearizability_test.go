@@ -0,0 +1,124 @@
+package linkedlist
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"dsgo/concurrencytest"
+)
+
+// list is the surface shared by the lists.
+type list interface {
+	PushBack(value int)
+	PushFront(value int)
+	Len() int
+	ForEach(f func(int))
+}
+
+type listModel struct{ items []int }
+
+func cloneList(m *listModel) *listModel {
+	return &listModel{items: slices.Clone(m.items)}
+}
+
+func items(l list) []int {
+	result := []int{}
+	l.ForEach(func(v int) { result = append(result, v) })
+	return result
+}
+
+// listOps generates pushes, Len and a full ForEach over a few small values
+// so that removals find duplicates, plus the list-specific ops from extra.
+func listOps[S list](extra func(r *rand.Rand, value int) concurrencytest.Op[S, *listModel]) func(r *rand.Rand) concurrencytest.Op[S, *listModel] {
+	return func(r *rand.Rand) concurrencytest.Op[S, *listModel] {
+		value := r.Intn(3)
+		switch r.Intn(6) {
+		case 0:
+			return concurrencytest.Op[S, *listModel]{
+				Name:  fmt.Sprintf("PushBack(%d)", value),
+				Run:   func(l S) any { l.PushBack(value); return nil },
+				Model: func(m *listModel) any { m.items = append(m.items, value); return nil },
+			}
+		case 1:
+			return concurrencytest.Op[S, *listModel]{
+				Name:  fmt.Sprintf("PushFront(%d)", value),
+				Run:   func(l S) any { l.PushFront(value); return nil },
+				Model: func(m *listModel) any { m.items = slices.Insert(m.items, 0, value); return nil },
+			}
+		case 2:
+			return concurrencytest.Op[S, *listModel]{
+				Name:  "Len()",
+				Run:   func(l S) any { return l.Len() },
+				Model: func(m *listModel) any { return len(m.items) },
+			}
+		case 3:
+			return concurrencytest.Op[S, *listModel]{
+				Name:  "ForEach()",
+				Run:   func(l S) any { return items(l) },
+				Model: func(m *listModel) any { return append([]int{}, m.items...) },
+			}
+		default:
+			return extra(r, value)
+		}
+	}
+}
+
+func runListScript[S list](t *testing.T, newList func() S, extra func(r *rand.Rand, value int) concurrencytest.Op[S, *listModel]) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		concurrencytest.Run(t, concurrencytest.Script[S, *listModel]{
+			New:      newList,
+			NewModel: func() *listModel { return &listModel{} },
+			Clone:    cloneList,
+			Threads:  concurrencytest.RandomThreads(r, 4, 6, listOps(extra)),
+			Runs:     10,
+		})
+	}
+}
+
+// removeOp builds a Remove op whose model drops the element at the index
+// chosen by pick, which returns -1 when value is absent.
+func removeOp[S interface{ Remove(int) error }](value int, pick func(items []int, value int) int) concurrencytest.Op[S, *listModel] {
+	return concurrencytest.Op[S, *listModel]{
+		Name: fmt.Sprintf("Remove(%d)", value),
+		Run:  func(l S) any { return l.Remove(value) },
+		Model: func(m *listModel) any {
+			if len(m.items) == 0 {
+				return ErrEmptyList
+			}
+			i := pick(m.items, value)
+			if i < 0 {
+				return ErrNotFound
+			}
+			m.items = slices.Delete(m.items, i, i+1)
+			return nil
+		},
+	}
+}
+
+func TestSingleLinkedListLinearizable(t *testing.T) {
+	runListScript(t, func() *SingleLinkedList[int] { return NewSingleLinkedList[int]() },
+		func(r *rand.Rand, value int) concurrencytest.Op[*SingleLinkedList[int], *listModel] {
+			return removeOp[*SingleLinkedList[int]](value, slices.Index[[]int])
+		})
+}
+
+// DoubleLinkedList.Remove checks the head, then the tail, then the nodes in
+// between, so with duplicates it may remove the last occurrence.
+func TestDoubleLinkedListLinearizable(t *testing.T) {
+	pick := func(items []int, value int) int {
+		switch {
+		case items[0] == value:
+			return 0
+		case items[len(items)-1] == value:
+			return len(items) - 1
+		}
+		return slices.Index(items, value)
+	}
+	runListScript(t, func() *DoubleLinkedList[int] { return NewDoubleLinkedList[int]() },
+		func(r *rand.Rand, value int) concurrencytest.Op[*DoubleLinkedList[int], *listModel] {
+			return removeOp[*DoubleLinkedList[int]](value, pick)
+		})
+}
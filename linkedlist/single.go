@@ -3,6 +3,9 @@ package linkedlist
 import (
 	"errors"
 	"sync"
+	"sync/atomic"
+
+	"dsgo/utils"
 )
 
 type Node[T comparable] struct {
@@ -16,6 +19,7 @@ type SingleLinkedList[T comparable] struct {
 	len        int
 	threadSafe bool
 	mu         sync.RWMutex
+	sealed     atomic.Bool
 }
 
 func NewSingleLinkedList[T comparable](threadSafe ...bool) *SingleLinkedList[T] {
@@ -35,6 +39,9 @@ func (l *SingleLinkedList[T]) PushBack(value T) {
 		l.mu.Lock()
 		defer l.mu.Unlock()
 	}
+	if l.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
 
 	newNode := &Node[T]{value: value}
 	if l.head == nil {
@@ -52,6 +59,9 @@ func (l *SingleLinkedList[T]) PushFront(value T) {
 		l.mu.Lock()
 		defer l.mu.Unlock()
 	}
+	if l.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
 
 	newNode := &Node[T]{value: value}
 	if l.head == nil {
@@ -69,6 +79,9 @@ func (l *SingleLinkedList[T]) Remove(value T) error {
 		l.mu.Lock()
 		defer l.mu.Unlock()
 	}
+	if l.sealed.Load() {
+		return utils.ErrSealed
+	}
 
 	if l.head == nil {
 		return ErrEmptyList
@@ -102,7 +115,7 @@ func (l *SingleLinkedList[T]) Remove(value T) error {
 }
 
 func (l *SingleLinkedList[T]) Contains(value T) bool {
-	if l.threadSafe {
+	if l.threadSafe && !l.sealed.Load() {
 		l.mu.RLock()
 		defer l.mu.RUnlock()
 	}
@@ -118,7 +131,7 @@ func (l *SingleLinkedList[T]) Contains(value T) bool {
 }
 
 func (l *SingleLinkedList[T]) At(index int) (T, error) {
-	if l.threadSafe {
+	if l.threadSafe && !l.sealed.Load() {
 		l.mu.RLock()
 		defer l.mu.RUnlock()
 	}
@@ -140,6 +153,9 @@ func (l *SingleLinkedList[T]) Clear() {
 		l.mu.Lock()
 		defer l.mu.Unlock()
 	}
+	if l.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
 
 	l.head = nil
 	l.tail = nil
@@ -147,7 +163,7 @@ func (l *SingleLinkedList[T]) Clear() {
 }
 
 func (l *SingleLinkedList[T]) ForEach(f func(T)) {
-	if l.threadSafe {
+	if l.threadSafe && !l.sealed.Load() {
 		l.mu.RLock()
 		defer l.mu.RUnlock()
 	}
@@ -160,7 +176,7 @@ func (l *SingleLinkedList[T]) ForEach(f func(T)) {
 }
 
 func (l *SingleLinkedList[T]) Back() (*Node[T], error) {
-	if l.threadSafe {
+	if l.threadSafe && !l.sealed.Load() {
 		l.mu.RLock()
 		defer l.mu.RUnlock()
 	}
@@ -172,7 +188,7 @@ func (l *SingleLinkedList[T]) Back() (*Node[T], error) {
 }
 
 func (l *SingleLinkedList[T]) Front() (*Node[T], error) {
-	if l.threadSafe {
+	if l.threadSafe && !l.sealed.Load() {
 		l.mu.RLock()
 		defer l.mu.RUnlock()
 	}
@@ -184,9 +200,25 @@ func (l *SingleLinkedList[T]) Front() (*Node[T], error) {
 }
 
 func (l *SingleLinkedList[T]) Len() int {
-	if l.threadSafe {
+	if l.threadSafe && !l.sealed.Load() {
 		l.mu.RLock()
 		defer l.mu.RUnlock()
 	}
 	return l.len
 }
+
+// Seal makes the list read-only. Mutations after Seal panic with
+// utils.ErrSealed, or return it from
+// methods that already report errors. Reads on a sealed list skip locking.
+func (l *SingleLinkedList[T]) Seal() {
+	if l.threadSafe {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+	}
+	l.sealed.Store(true)
+}
+
+// IsSealed reports whether Seal has been called.
+func (l *SingleLinkedList[T]) IsSealed() bool {
+	return l.sealed.Load()
+}
@@ -3,6 +3,8 @@ package linkedlist
 import (
 	"sync"
 	"testing"
+
+	"dsgo/utils"
 )
 
 func TestSingleLinkedListBasic(t *testing.T) {
@@ -199,3 +201,16 @@ func TestSingleLinkedListRemoveEdgeCases(t *testing.T) {
 		t.Errorf("Expected back value 1, got %v", back)
 	}
 }
+
+func TestSingleLinkedListSeal(t *testing.T) {
+	list := NewSingleLinkedList[int]()
+	list.PushBack(1)
+	list.Seal()
+
+	if err := list.Remove(1); err != utils.ErrSealed {
+		t.Errorf("Remove on sealed list = %v, want ErrSealed", err)
+	}
+	if !list.Contains(1) {
+		t.Error("Contains should keep working on a sealed list")
+	}
+}
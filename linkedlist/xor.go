@@ -0,0 +1,213 @@
+//go:build xorlist
+
+package linkedlist
+
+import (
+	"sync"
+	"unsafe"
+)
+
+// xorChunkSize is the number of nodes allocated at a time.
+const xorChunkSize = 1024
+
+type xorNode[T comparable] struct {
+	value T
+	// link is the XOR of the previous and next node addresses
+	link uintptr
+}
+
+// XORLinkedList is an experimental doubly linked list that stores one link
+// word per node instead of two: each node keeps prev XOR next, and a
+// traversal recovers the next address from the one it came from.
+//
+// Build with -tags xorlist to use it. The list cannot be used under -race
+// or -gcflags=all=-d=checkptr: the pointer checks reject any address
+// rebuilt from an integer, which is the whole technique.
+//
+// GC caveats: an XORed address is not a pointer, so the garbage collector
+// cannot see it. Nodes are therefore allocated in fixed chunks that the list
+// holds with ordinary pointers, which keeps every node alive and in place
+// (the Go collector does not move heap objects). Values are still scanned
+// normally. Removed nodes go to a free list for reuse; their memory is only
+// returned by Clear. Nodes are never handed out, since their addresses
+// would be meaningless without the neighbours.
+type XORLinkedList[T comparable] struct {
+	chunks     [][]xorNode[T]
+	free       *xorNode[T]
+	head       *xorNode[T]
+	tail       *xorNode[T]
+	len        int
+	threadSafe bool
+	mu         sync.RWMutex
+}
+
+func NewXORLinkedList[T comparable](threadSafe ...bool) *XORLinkedList[T] {
+	isThreadSafe := true
+	if len(threadSafe) > 0 {
+		isThreadSafe = threadSafe[0]
+	}
+	return &XORLinkedList[T]{
+		threadSafe: isThreadSafe,
+	}
+}
+
+func addr[T comparable](n *xorNode[T]) uintptr {
+	return uintptr(unsafe.Pointer(n))
+}
+
+// at converts an address back to a node. It is only valid for addresses of
+// nodes held in l.chunks, or zero. go vet -tags xorlist flags this
+// conversion as a possible misuse of unsafe.Pointer; it is deliberate.
+func at[T comparable](a uintptr) *xorNode[T] {
+	return (*xorNode[T])(unsafe.Pointer(a))
+}
+
+func (l *XORLinkedList[T]) alloc(value T) *xorNode[T] {
+	if n := l.free; n != nil {
+		l.free = at[T](n.link)
+		n.value, n.link = value, 0
+		return n
+	}
+	last := len(l.chunks) - 1
+	if last < 0 || len(l.chunks[last]) == cap(l.chunks[last]) {
+		l.chunks = append(l.chunks, make([]xorNode[T], 0, xorChunkSize))
+		last++
+	}
+	// Appending within capacity never moves the chunk's existing nodes
+	l.chunks[last] = append(l.chunks[last], xorNode[T]{value: value})
+	return &l.chunks[last][len(l.chunks[last])-1]
+}
+
+func (l *XORLinkedList[T]) release(n *xorNode[T]) {
+	var zero T
+	n.value = zero
+	n.link = addr(l.free)
+	l.free = n
+}
+
+func (l *XORLinkedList[T]) PushBack(value T) {
+	if l.threadSafe {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+	}
+	n := l.alloc(value)
+	n.link = addr(l.tail)
+	if l.tail == nil {
+		l.head = n
+	} else {
+		l.tail.link ^= addr(n)
+	}
+	l.tail = n
+	l.len++
+}
+
+func (l *XORLinkedList[T]) PushFront(value T) {
+	if l.threadSafe {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+	}
+	n := l.alloc(value)
+	n.link = addr(l.head)
+	if l.head == nil {
+		l.tail = n
+	} else {
+		l.head.link ^= addr(n)
+	}
+	l.head = n
+	l.len++
+}
+
+// PopFront removes and returns the first value.
+func (l *XORLinkedList[T]) PopFront() (T, error) {
+	if l.threadSafe {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+	}
+	if l.head == nil {
+		var zero T
+		return zero, ErrEmptyList
+	}
+	n := l.head
+	next := at[T](n.link)
+	if next == nil {
+		l.tail = nil
+	} else {
+		next.link ^= addr(n)
+	}
+	l.head = next
+	value := n.value
+	l.release(n)
+	l.len--
+	return value, nil
+}
+
+// PopBack removes and returns the last value.
+func (l *XORLinkedList[T]) PopBack() (T, error) {
+	if l.threadSafe {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+	}
+	if l.tail == nil {
+		var zero T
+		return zero, ErrEmptyList
+	}
+	n := l.tail
+	prev := at[T](n.link)
+	if prev == nil {
+		l.head = nil
+	} else {
+		prev.link ^= addr(n)
+	}
+	l.tail = prev
+	value := n.value
+	l.release(n)
+	l.len--
+	return value, nil
+}
+
+func (l *XORLinkedList[T]) Len() int {
+	if l.threadSafe {
+		l.mu.RLock()
+		defer l.mu.RUnlock()
+	}
+	return l.len
+}
+
+func (l *XORLinkedList[T]) ForEach(f func(T)) {
+	if l.threadSafe {
+		l.mu.RLock()
+		defer l.mu.RUnlock()
+	}
+	walkXOR(l.head, f)
+}
+
+func (l *XORLinkedList[T]) ForEachReverse(f func(T)) {
+	if l.threadSafe {
+		l.mu.RLock()
+		defer l.mu.RUnlock()
+	}
+	walkXOR(l.tail, f)
+}
+
+// walkXOR follows links from an end of the list; the same loop works in
+// both directions.
+func walkXOR[T comparable](start *xorNode[T], f func(T)) {
+	var prev uintptr
+	for n := start; n != nil; {
+		f(n.value)
+		next := n.link ^ prev
+		prev = addr(n)
+		n = at[T](next)
+	}
+}
+
+// Clear removes every value and releases the node chunks.
+func (l *XORLinkedList[T]) Clear() {
+	if l.threadSafe {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+	}
+	l.chunks = nil
+	l.free, l.head, l.tail = nil, nil, nil
+	l.len = 0
+}
@@ -0,0 +1,116 @@
+//go:build xorlist && !race
+
+package linkedlist
+
+import (
+	"math/rand"
+	"runtime"
+	"slices"
+	"strconv"
+	"testing"
+
+	"dsgo/concurrencytest"
+)
+
+func collectXOR(l *XORLinkedList[string]) (forward, backward []string) {
+	l.ForEach(func(v string) { forward = append(forward, v) })
+	l.ForEachReverse(func(v string) { backward = append([]string{v}, backward...) })
+	return forward, backward
+}
+
+func TestXORLinkedList(t *testing.T) {
+	l := NewXORLinkedList[string]()
+	if _, err := l.PopFront(); err != ErrEmptyList {
+		t.Errorf("PopFront() on empty list error = %v, want ErrEmptyList", err)
+	}
+
+	// Model the list with a slice through a mix of operations, forcing
+	// collections so any node the GC could not see would be reclaimed
+	var model []string
+	for i := 0; i < 5000; i++ {
+		value := strconv.Itoa(i)
+		switch i % 5 {
+		case 0, 1:
+			l.PushBack(value)
+			model = append(model, value)
+		case 2:
+			l.PushFront(value)
+			model = append([]string{value}, model...)
+		case 3:
+			got, err := l.PopFront()
+			if err != nil || got != model[0] {
+				t.Fatalf("PopFront() = %q, %v, want %q", got, err, model[0])
+			}
+			model = model[1:]
+		case 4:
+			got, err := l.PopBack()
+			if err != nil || got != model[len(model)-1] {
+				t.Fatalf("PopBack() = %q, %v, want %q", got, err, model[len(model)-1])
+			}
+			model = model[:len(model)-1]
+		}
+		if i%1000 == 0 {
+			runtime.GC()
+		}
+	}
+	runtime.GC()
+
+	if l.Len() != len(model) {
+		t.Fatalf("Len() = %d, want %d", l.Len(), len(model))
+	}
+	forward, backward := collectXOR(l)
+	for i := range model {
+		if forward[i] != model[i] || backward[i] != model[i] {
+			t.Fatalf("index %d: forward %q, backward %q, want %q", i, forward[i], backward[i], model[i])
+		}
+	}
+
+	for l.Len() > 0 {
+		l.PopBack()
+	}
+	l.PushBack("reused")
+	if got, _ := l.PopFront(); got != "reused" {
+		t.Errorf("PopFront() = %q, want reused", got)
+	}
+
+	l.PushBack("a")
+	l.Clear()
+	if l.Len() != 0 {
+		t.Errorf("Len() after Clear = %d, want 0", l.Len())
+	}
+	if _, err := l.PopBack(); err != ErrEmptyList {
+		t.Errorf("PopBack() after Clear error = %v, want ErrEmptyList", err)
+	}
+}
+
+func TestXORLinkedListLinearizable(t *testing.T) {
+	pop := func(front bool) concurrencytest.Op[*XORLinkedList[int], *listModel] {
+		name, run := "PopBack()", (*XORLinkedList[int]).PopBack
+		if front {
+			name, run = "PopFront()", (*XORLinkedList[int]).PopFront
+		}
+		return concurrencytest.Op[*XORLinkedList[int], *listModel]{
+			Name: name,
+			Run: func(l *XORLinkedList[int]) any {
+				v, err := run(l)
+				return [2]any{v, err}
+			},
+			Model: func(m *listModel) any {
+				if len(m.items) == 0 {
+					return [2]any{0, ErrEmptyList}
+				}
+				i := len(m.items) - 1
+				if front {
+					i = 0
+				}
+				v := m.items[i]
+				m.items = slices.Delete(m.items, i, i+1)
+				return [2]any{v, nil}
+			},
+		}
+	}
+	runListScript(t, func() *XORLinkedList[int] { return NewXORLinkedList[int]() },
+		func(r *rand.Rand, value int) concurrencytest.Op[*XORLinkedList[int], *listModel] {
+			return pop(r.Intn(2) == 0)
+		})
+}
@@ -0,0 +1,91 @@
+package maps
+
+import (
+	"errors"
+	"slices"
+)
+
+// ErrUnknownSnapshot is returned when rolling back to or releasing a
+// snapshot that was never taken, was released, or was discarded by a
+// rollback to an earlier one.
+var ErrUnknownSnapshot = errors.New("maps: unknown snapshot")
+
+// SnapshotID identifies a checkpoint taken with Checkpoint.
+type SnapshotID uint64
+
+type snapshot[K comparable, V any] struct {
+	id     SnapshotID
+	keys   []K
+	values []V
+	index  map[K]int
+}
+
+// checkpoints keeps the saved states of a map. Taking one only records the
+// current slices and index; the map copies them on its next mutation, so
+// a checkpoint that is never followed by a write costs nothing.
+type checkpoints[K comparable, V any] struct {
+	saved []snapshot[K, V]
+	next  SnapshotID
+	// shared is set while the live slices and index may belong to a
+	// snapshot and must be copied before they are written
+	shared bool
+}
+
+func (c *checkpoints[K, V]) take(keys []K, values []V, index map[K]int) SnapshotID {
+	c.next++
+	c.saved = append(c.saved, snapshot[K, V]{id: c.next, keys: keys, values: values, index: index})
+	c.shared = true
+	return c.next
+}
+
+// unshare gives the map its own copy of its state if a snapshot may still
+// refer to it. Every mutator calls it before writing.
+func (c *checkpoints[K, V]) unshare(keys *[]K, values *[]V, index *map[K]int) {
+	if !c.shared {
+		return
+	}
+	*keys = slices.Clone(*keys)
+	*values = slices.Clone(*values)
+	copied := make(map[K]int, len(*index))
+	for k, i := range *index {
+		copied[k] = i
+	}
+	*index = copied
+	c.shared = false
+}
+
+func (c *checkpoints[K, V]) find(id SnapshotID) int {
+	for i := range c.saved {
+		if c.saved[i].id == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// rollback restores the state saved as id into the map and discards the
+// snapshots taken after it. The snapshot itself is kept so the map can be
+// rolled back to it again.
+func (c *checkpoints[K, V]) rollback(id SnapshotID, keys *[]K, values *[]V, index *map[K]int) error {
+	i := c.find(id)
+	if i < 0 {
+		return ErrUnknownSnapshot
+	}
+	s := c.saved[i]
+	*keys, *values, *index = s.keys, s.values, s.index
+	clear(c.saved[i+1:])
+	c.saved = c.saved[:i+1]
+	c.shared = true
+	return nil
+}
+
+// release forgets id and every snapshot taken after it.
+func (c *checkpoints[K, V]) release(id SnapshotID) error {
+	i := c.find(id)
+	if i < 0 {
+		return ErrUnknownSnapshot
+	}
+	clear(c.saved[i:])
+	c.saved = c.saved[:i]
+	return nil
+}
@@ -0,0 +1,137 @@
+package maps
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"dsgo/utils"
+)
+
+func TestOrderedMapRollback(t *testing.T) {
+	m := NewOrderedMap[string, int](false)
+	m.Set("a", 1)
+	m.Set("b", 2)
+
+	id := m.Checkpoint()
+	m.Set("a", 10)
+	m.Delete("b")
+	m.Set("c", 3)
+	if got := m.Keys(); !slices.Equal(got, []string{"a", "c"}) {
+		t.Errorf("Keys() before rollback = %v, want [a c]", got)
+	}
+
+	if err := m.Rollback(id); err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+	if got := m.Keys(); !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("Keys() = %v, want [a b]", got)
+	}
+	if v, _ := m.Get("a"); v != 1 {
+		t.Errorf("Get(a) = %d, want 1", v)
+	}
+	if _, ok := m.Get("c"); ok {
+		t.Error("Get(c) found a key added after the checkpoint")
+	}
+
+	// The checkpoint survives a rollback and can be used again
+	m.Truncate(1)
+	if err := m.Rollback(id); err != nil {
+		t.Fatalf("second Rollback() error = %v", err)
+	}
+	if got := m.Values(); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("Values() = %v, want [1 2]", got)
+	}
+}
+
+func TestOrderedMapNestedCheckpoints(t *testing.T) {
+	m := NewOrderedMap[int, int]()
+	m.Set(1, 1)
+	outer := m.Checkpoint()
+	m.Set(2, 2)
+	inner := m.Checkpoint()
+	m.Set(3, 3)
+
+	if err := m.Rollback(inner); err != nil {
+		t.Fatalf("Rollback(inner) error = %v", err)
+	}
+	if got := m.Keys(); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("Keys() = %v, want [1 2]", got)
+	}
+	if err := m.Rollback(outer); err != nil {
+		t.Fatalf("Rollback(outer) error = %v", err)
+	}
+	if got := m.Keys(); !slices.Equal(got, []int{1}) {
+		t.Errorf("Keys() = %v, want [1]", got)
+	}
+	if err := m.Rollback(inner); !errors.Is(err, ErrUnknownSnapshot) {
+		t.Errorf("Rollback(discarded) = %v, want ErrUnknownSnapshot", err)
+	}
+}
+
+func TestOrderedMapRelease(t *testing.T) {
+	m := NewOrderedMap[string, int](false)
+	id := m.Checkpoint()
+	m.Set("a", 1)
+	if err := m.Release(id); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+	if err := m.Rollback(id); !errors.Is(err, ErrUnknownSnapshot) {
+		t.Errorf("Rollback(released) = %v, want ErrUnknownSnapshot", err)
+	}
+	if err := m.Release(id); !errors.Is(err, ErrUnknownSnapshot) {
+		t.Errorf("Release(released) = %v, want ErrUnknownSnapshot", err)
+	}
+	if v, ok := m.Get("a"); !ok || v != 1 {
+		t.Errorf("Get(a) = %d, %v, want 1, true", v, ok)
+	}
+}
+
+func TestOrderedMapRollbackSealed(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	id := m.Checkpoint()
+	m.Seal()
+	if err := m.Rollback(id); !errors.Is(err, utils.ErrSealed) {
+		t.Errorf("Rollback() on sealed map = %v, want ErrSealed", err)
+	}
+}
+
+func TestSortedMapRollback(t *testing.T) {
+	m := NewSortedMap[int, string]()
+	for _, k := range []int{5, 1, 3} {
+		m.Set(k, "v")
+	}
+	id := m.Checkpoint()
+	m.Set(2, "new")
+	m.Set(3, "changed")
+	m.DeleteFunc(func(k int, _ string) bool { return k > 4 })
+
+	if err := m.Rollback(id); err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+	if got := m.Keys(); !slices.Equal(got, []int{1, 3, 5}) {
+		t.Errorf("Keys() = %v, want [1 3 5]", got)
+	}
+	if v, _ := m.Get(3); v != "v" {
+		t.Errorf("Get(3) = %q, want v", v)
+	}
+	if k, _, ok := m.Floor(4); !ok || k != 3 {
+		t.Errorf("Floor(4) = %d, %v, want 3, true", k, ok)
+	}
+}
+
+func TestSafeSortedMapRollback(t *testing.T) {
+	m := NewSafeSortedMap[string, int]()
+	m.Set("x", 1)
+	id := m.Checkpoint()
+	m.Remove("x")
+	if err := m.Rollback(id); err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+	if v, ok := m.Get("x"); !ok || v != 1 {
+		t.Errorf("Get(x) = %d, %v, want 1, true", v, ok)
+	}
+	if err := m.Rollback(id + 1); !errors.Is(err, ErrUnknownSnapshot) {
+		t.Errorf("Rollback(unknown) = %v, want ErrUnknownSnapshot", err)
+	}
+}
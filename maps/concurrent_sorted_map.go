@@ -0,0 +1,301 @@
+package maps
+
+import (
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+
+	"dsgo/epoch"
+	"dsgo/utils"
+)
+
+const (
+	skipListMaxLevel = 32
+	skipListP        = 0.25
+)
+
+type skipNode[K utils.Ordered, V any] struct {
+	key     K
+	value   atomic.Pointer[V]
+	next    []atomic.Pointer[skipNode[K, V]]
+	deleted atomic.Bool
+}
+
+// ConcurrentSortedMap is a sorted map backed by a skip list. Reads (Get,
+// Range, Keys, ...) never take a lock: every link and value is published
+// atomically, so readers proceed while writers mutate the list. Writers are
+// serialized by a mutex. Range observes a weakly consistent view: entries
+// added or removed during the iteration may or may not be visited, but each
+// key is visited at most once and in order.
+//
+// Readers pin an epoch while they walk the list, and deleted nodes are
+// recycled for later inserts only once every reader that could still be
+// standing on them has finished.
+type ConcurrentSortedMap[K utils.Ordered, V any] struct {
+	head     *skipNode[K, V]
+	level    atomic.Int32
+	size     atomic.Int64
+	mu       sync.Mutex
+	sealed   bool
+	watchers map[*Watcher[K, V]]struct{}
+	epochs   epoch.Domain
+	// free holds recycled nodes, indexed by level - 1
+	free [skipListMaxLevel]sync.Pool
+}
+
+func NewConcurrentSortedMap[K utils.Ordered, V any]() *ConcurrentSortedMap[K, V] {
+	m := &ConcurrentSortedMap[K, V]{
+		head: &skipNode[K, V]{next: make([]atomic.Pointer[skipNode[K, V]], skipListMaxLevel)},
+	}
+	m.level.Store(1)
+	return m
+}
+
+func randomLevel() int {
+	level := 1
+	for level < skipListMaxLevel && rand.Float64() < skipListP {
+		level++
+	}
+	return level
+}
+
+// findPredecessors fills preds with the rightmost node before key at every
+// level and returns the node following preds[0].
+func (m *ConcurrentSortedMap[K, V]) findPredecessors(key K, preds []*skipNode[K, V]) *skipNode[K, V] {
+	node := m.head
+	for i := skipListMaxLevel - 1; i >= 0; i-- {
+		for next := node.next[i].Load(); next != nil && next.key < key; next = node.next[i].Load() {
+			node = next
+		}
+		preds[i] = node
+	}
+	return node.next[0].Load()
+}
+
+func (m *ConcurrentSortedMap[K, V]) Get(key K) (V, bool) {
+	g := m.epochs.Pin()
+	defer g.Unpin()
+	node := m.head
+	for i := int(m.level.Load()) - 1; i >= 0; i-- {
+		for next := node.next[i].Load(); next != nil && next.key < key; next = node.next[i].Load() {
+			node = next
+		}
+	}
+	node = node.next[0].Load()
+	if node != nil && node.key == key && !node.deleted.Load() {
+		return *node.value.Load(), true
+	}
+	var zero V
+	return zero, false
+}
+
+func (m *ConcurrentSortedMap[K, V]) Set(key K, value V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.sealed {
+		panic(utils.ErrSealed)
+	}
+
+	var preds [skipListMaxLevel]*skipNode[K, V]
+	if next := m.findPredecessors(key, preds[:]); next != nil && next.key == key {
+		old := next.value.Swap(&value)
+		m.notify(Event[K, V]{Type: EventSet, Key: key, OldValue: *old, HadOld: true, NewValue: value})
+		return
+	}
+
+	level := randomLevel()
+	node := m.newNode(key, level)
+	node.value.Store(&value)
+	for i := 0; i < level; i++ {
+		node.next[i].Store(preds[i].next[i].Load())
+	}
+	// Publish bottom-up so a node reachable at level i is reachable below it
+	for i := 0; i < level; i++ {
+		preds[i].next[i].Store(node)
+	}
+	if int32(level) > m.level.Load() {
+		m.level.Store(int32(level))
+	}
+	m.size.Add(1)
+	m.notify(Event[K, V]{Type: EventSet, Key: key, NewValue: value})
+}
+
+func (m *ConcurrentSortedMap[K, V]) Delete(key K) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.sealed {
+		panic(utils.ErrSealed)
+	}
+	m.delete(key)
+}
+
+// Remove deletes key and returns the value it held, or false if the key was
+// not present.
+func (m *ConcurrentSortedMap[K, V]) Remove(key K) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.sealed {
+		panic(utils.ErrSealed)
+	}
+	return m.delete(key)
+}
+
+// TryDelete deletes key and reports whether it was present.
+func (m *ConcurrentSortedMap[K, V]) TryDelete(key K) bool {
+	_, ok := m.Remove(key)
+	return ok
+}
+
+// DeleteFunc removes every entry for which pred returns true while holding
+// the writer lock once, and returns the number of entries removed.
+func (m *ConcurrentSortedMap[K, V]) DeleteFunc(pred func(key K, value V) bool) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.sealed {
+		panic(utils.ErrSealed)
+	}
+
+	var matched []K
+	for node := m.head.next[0].Load(); node != nil; node = node.next[0].Load() {
+		if pred(node.key, *node.value.Load()) {
+			matched = append(matched, node.key)
+		}
+	}
+	for _, key := range matched {
+		m.delete(key)
+	}
+	return len(matched)
+}
+
+// delete unlinks key and returns its value. The caller must hold m.mu.
+func (m *ConcurrentSortedMap[K, V]) delete(key K) (V, bool) {
+	var preds [skipListMaxLevel]*skipNode[K, V]
+	node := m.findPredecessors(key, preds[:])
+	if node == nil || node.key != key {
+		var zero V
+		return zero, false
+	}
+
+	node.deleted.Store(true)
+	// Unlink top-down; the node keeps its own links so readers standing on
+	// it can still move forward
+	for i := len(node.next) - 1; i >= 0; i-- {
+		preds[i].next[i].Store(node.next[i].Load())
+	}
+	m.size.Add(-1)
+	value := *node.value.Load()
+	m.notify(Event[K, V]{Type: EventDelete, Key: key, OldValue: value, HadOld: true})
+	m.epochs.Retire(func() { m.recycle(node) })
+	return value, true
+}
+
+// newNode returns a node for key, reusing a recycled one of the same level
+// when available.
+func (m *ConcurrentSortedMap[K, V]) newNode(key K, level int) *skipNode[K, V] {
+	if node, ok := m.free[level-1].Get().(*skipNode[K, V]); ok {
+		node.key = key
+		node.deleted.Store(false)
+		return node
+	}
+	return &skipNode[K, V]{key: key, next: make([]atomic.Pointer[skipNode[K, V]], level)}
+}
+
+// recycle resets a retired node and makes it available to newNode. It runs
+// only after no reader can reach the node.
+func (m *ConcurrentSortedMap[K, V]) recycle(node *skipNode[K, V]) {
+	node.value.Store(nil)
+	for i := range node.next {
+		node.next[i].Store(nil)
+	}
+	var zero K
+	node.key = zero
+	m.free[len(node.next)-1].Put(node)
+}
+
+// Seal makes the map read-only. Mutations after Seal panic with utils.ErrSealed.
+func (m *ConcurrentSortedMap[K, V]) Seal() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sealed = true
+}
+
+// IsSealed reports whether Seal has been called.
+func (m *ConcurrentSortedMap[K, V]) IsSealed() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.sealed
+}
+
+func (m *ConcurrentSortedMap[K, V]) Len() int {
+	return int(m.size.Load())
+}
+
+func (m *ConcurrentSortedMap[K, V]) IsEmpty() bool {
+	return m.Len() == 0
+}
+
+func (m *ConcurrentSortedMap[K, V]) Keys() []K {
+	return m.AppendKeys(make([]K, 0, m.Len()))
+}
+
+func (m *ConcurrentSortedMap[K, V]) Values() []V {
+	return m.AppendValues(make([]V, 0, m.Len()))
+}
+
+// AppendKeys appends the keys in sorted order to dst and returns the
+// extended slice, with the same consistency as Range.
+func (m *ConcurrentSortedMap[K, V]) AppendKeys(dst []K) []K {
+	g := m.epochs.Pin()
+	defer g.Unpin()
+	for node := m.head.next[0].Load(); node != nil; node = node.next[0].Load() {
+		if !node.deleted.Load() {
+			dst = append(dst, node.key)
+		}
+	}
+	return dst
+}
+
+// AppendValues appends the values in key order to dst and returns the
+// extended slice, with the same consistency as Range.
+func (m *ConcurrentSortedMap[K, V]) AppendValues(dst []V) []V {
+	g := m.epochs.Pin()
+	defer g.Unpin()
+	for node := m.head.next[0].Load(); node != nil; node = node.next[0].Load() {
+		if !node.deleted.Load() {
+			dst = append(dst, *node.value.Load())
+		}
+	}
+	return dst
+}
+
+// Range iterates over the map in key order without blocking writers.
+func (m *ConcurrentSortedMap[K, V]) Range(f func(key K, value V) bool) {
+	g := m.epochs.Pin()
+	defer g.Unpin()
+	m.rangeFrom(m.head.next[0].Load(), f)
+}
+
+// RangeFrom iterates in key order, without blocking writers, starting at the
+// first key greater than or equal to from.
+func (m *ConcurrentSortedMap[K, V]) RangeFrom(from K, f func(key K, value V) bool) {
+	g := m.epochs.Pin()
+	defer g.Unpin()
+	node := m.head
+	for i := int(m.level.Load()) - 1; i >= 0; i-- {
+		for next := node.next[i].Load(); next != nil && next.key < from; next = node.next[i].Load() {
+			node = next
+		}
+	}
+	m.rangeFrom(node.next[0].Load(), f)
+}
+
+func (m *ConcurrentSortedMap[K, V]) rangeFrom(start *skipNode[K, V], f func(key K, value V) bool) {
+	for node := start; node != nil; node = node.next[0].Load() {
+		if node.deleted.Load() {
+			continue
+		}
+		if !f(node.key, *node.value.Load()) {
+			break
+		}
+	}
+}
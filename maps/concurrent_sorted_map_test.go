@@ -0,0 +1,280 @@
+package maps
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"testing"
+
+	"dsgo/utils"
+)
+
+func TestConcurrentSortedMap_BasicOperations(t *testing.T) {
+	m := NewConcurrentSortedMap[int, string]()
+
+	if !m.IsEmpty() {
+		t.Error("New map should be empty")
+	}
+
+	m.Set(3, "three")
+	m.Set(1, "one")
+	m.Set(2, "two")
+
+	if val, exists := m.Get(2); !exists || val != "two" {
+		t.Errorf("Get(2) = %v, %v; want 'two', true", val, exists)
+	}
+	if _, exists := m.Get(4); exists {
+		t.Error("Get(4) should not exist")
+	}
+
+	m.Set(2, "TWO")
+	if val, _ := m.Get(2); val != "TWO" {
+		t.Errorf("Get(2) after update = %v; want 'TWO'", val)
+	}
+	if m.Len() != 3 {
+		t.Errorf("Len() = %d; want 3", m.Len())
+	}
+
+	keys := m.Keys()
+	expectedKeys := []int{1, 2, 3}
+	for i, k := range keys {
+		if k != expectedKeys[i] {
+			t.Errorf("Keys()[%d] = %v; want %v", i, k, expectedKeys[i])
+		}
+	}
+
+	m.Delete(2)
+	m.Delete(5)
+	if _, exists := m.Get(2); exists {
+		t.Error("Get(2) after delete should not exist")
+	}
+	if m.Len() != 2 {
+		t.Errorf("Len() after delete = %d; want 2", m.Len())
+	}
+
+	values := m.Values()
+	if len(values) != 2 || values[0] != "one" || values[1] != "three" {
+		t.Errorf("Values() = %v; want [one three]", values)
+	}
+}
+
+func TestConcurrentSortedMap_DeleteFunc(t *testing.T) {
+	m := NewConcurrentSortedMap[int, int]()
+	for i := 0; i < 100; i++ {
+		m.Set(i, i*i)
+	}
+
+	removed := m.DeleteFunc(func(key, value int) bool {
+		return key%3 == 0
+	})
+	if removed != 34 {
+		t.Errorf("DeleteFunc removed %d; want 34", removed)
+	}
+	if m.Len() != 66 {
+		t.Errorf("Len() = %d; want 66", m.Len())
+	}
+	if _, exists := m.Get(9); exists {
+		t.Error("Get(9) should not exist after DeleteFunc")
+	}
+	if val, exists := m.Get(10); !exists || val != 100 {
+		t.Errorf("Get(10) = %d, %v; want 100, true", val, exists)
+	}
+}
+
+func TestConcurrentSortedMap_MatchesModel(t *testing.T) {
+	m := NewConcurrentSortedMap[int, int]()
+	model := NewSortedMap[int, int]()
+	r := rand.New(rand.NewPCG(1, 2))
+
+	for i := 0; i < 5000; i++ {
+		key := r.IntN(500)
+		if r.IntN(3) == 0 {
+			m.Delete(key)
+			model.Delete(key)
+		} else {
+			m.Set(key, i)
+			model.Set(key, i)
+		}
+	}
+
+	if m.Len() != model.Len() {
+		t.Fatalf("Len() = %d; want %d", m.Len(), model.Len())
+	}
+	keys, want := m.Keys(), model.Keys()
+	for i := range want {
+		if keys[i] != want[i] {
+			t.Fatalf("Keys()[%d] = %d; want %d", i, keys[i], want[i])
+		}
+		got, _ := m.Get(keys[i])
+		expected, _ := model.Get(keys[i])
+		if got != expected {
+			t.Errorf("Get(%d) = %d; want %d", keys[i], got, expected)
+		}
+	}
+}
+
+func TestConcurrentSortedMap_RangeDuringMutation(t *testing.T) {
+	m := NewConcurrentSortedMap[int, int]()
+	for i := 0; i < 1000; i++ {
+		m.Set(i, i)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i += 2 {
+			m.Delete(i)
+			m.Set(i+1000, i)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for n := 0; n < 20; n++ {
+			prev := -1
+			m.Range(func(key, value int) bool {
+				if key <= prev {
+					t.Errorf("Range out of order: %d after %d", key, prev)
+					return false
+				}
+				prev = key
+				return true
+			})
+		}
+	}()
+	wg.Wait()
+
+	if m.Len() != 1000 {
+		t.Errorf("Len() = %d; want 1000", m.Len())
+	}
+}
+
+func TestConcurrentSortedMap_RecycledNodes(t *testing.T) {
+	m := NewConcurrentSortedMap[int, int]()
+	const keys = 256
+
+	// Writers churn the same keys so deleted nodes are recycled while
+	// readers are walking the list; a reader must never see a node that was
+	// reused for another key or reset mid-read
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				for k := 0; k < keys; k += 7 {
+					if v, ok := m.Get(k); ok && v != k*10 {
+						t.Errorf("Get(%d) = %d; want %d", k, v, k*10)
+						return
+					}
+				}
+				prev := -1
+				m.Range(func(key, value int) bool {
+					if key <= prev || value != key*10 {
+						t.Errorf("Range saw (%d, %d) after %d", key, value, prev)
+						return false
+					}
+					prev = key
+					return true
+				})
+			}
+		}()
+	}
+
+	for round := 0; round < 50; round++ {
+		for k := 0; k < keys; k++ {
+			m.Set(k, k*10)
+		}
+		for k := 0; k < keys; k++ {
+			m.Delete(k)
+		}
+	}
+	close(stop)
+	wg.Wait()
+
+	if m.Len() != 0 {
+		t.Errorf("Len() = %d; want 0", m.Len())
+	}
+}
+
+func benchmarkSortedMapMixed(b *testing.B, goroutines int, get func(int) (int, bool), set func(int, int)) {
+	const keySpace = 1 << 16
+	for i := 0; i < keySpace; i += 2 {
+		set(i, i)
+	}
+
+	b.ResetTimer()
+	var wg sync.WaitGroup
+	perWorker := b.N/goroutines + 1
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(seed uint64) {
+			defer wg.Done()
+			r := rand.New(rand.NewPCG(seed, seed))
+			for i := 0; i < perWorker; i++ {
+				key := r.IntN(keySpace)
+				// 90% reads, 10% writes
+				if r.IntN(10) == 0 {
+					set(key, i)
+				} else {
+					get(key)
+				}
+			}
+		}(uint64(g))
+	}
+	wg.Wait()
+}
+
+func BenchmarkSortedMapMixed(b *testing.B) {
+	for _, goroutines := range []int{1, 8, 64} {
+		b.Run(fmt.Sprintf("ConcurrentSortedMap/goroutines=%d", goroutines), func(b *testing.B) {
+			m := NewConcurrentSortedMap[int, int]()
+			benchmarkSortedMapMixed(b, goroutines, m.Get, m.Set)
+		})
+		b.Run(fmt.Sprintf("SafeSortedMap/goroutines=%d", goroutines), func(b *testing.B) {
+			m := NewSafeSortedMap[int, int]()
+			benchmarkSortedMapMixed(b, goroutines, m.Get, m.Set)
+		})
+	}
+}
+
+func TestConcurrentSortedMap_Seal(t *testing.T) {
+	m := NewConcurrentSortedMap[int, int]()
+	m.Set(1, 1)
+	m.Seal()
+
+	if !m.IsSealed() {
+		t.Error("IsSealed should be true after Seal")
+	}
+	defer func() {
+		if r := recover(); r != utils.ErrSealed {
+			t.Errorf("Set on sealed map should panic with ErrSealed, got %v", r)
+		}
+	}()
+	m.Set(2, 2)
+}
+
+func TestConcurrentSortedMap_Remove(t *testing.T) {
+	m := NewConcurrentSortedMap[int, string]()
+	m.Set(1, "one")
+	m.Set(2, "two")
+
+	if value, ok := m.Remove(1); !ok || value != "one" {
+		t.Errorf("Remove(1) = %q, %v, want \"one\", true", value, ok)
+	}
+	if _, ok := m.Remove(1); ok {
+		t.Error("Remove(1) again reported the key as present")
+	}
+	if !m.TryDelete(2) || m.TryDelete(2) {
+		t.Error("TryDelete(2) should succeed once and then report false")
+	}
+	if !m.IsEmpty() {
+		t.Errorf("Len() = %d, want 0", m.Len())
+	}
+}
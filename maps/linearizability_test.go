@@ -0,0 +1,118 @@
+package maps
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"dsgo/concurrencytest"
+)
+
+// pointMap is the point-operation surface shared by the thread-safe maps.
+type pointMap interface {
+	Get(key int) (int, bool)
+	Set(key, value int)
+	Delete(key int)
+	Len() int
+}
+
+type mapModel map[int]int
+
+func cloneModel(m mapModel) mapModel {
+	c := make(mapModel, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+// pointOps generates random Get/Set/Delete operations over a small key
+// space so goroutines collide, plus Len when the map's size is maintained
+// linearizably and sorted Keys when keys is true.
+func pointOps[S pointMap](withLen, withKeys bool) func(r *rand.Rand) concurrencytest.Op[S, mapModel] {
+	return func(r *rand.Rand) concurrencytest.Op[S, mapModel] {
+		key, value := r.Intn(4), r.Intn(100)
+		choices := 3
+		if withLen {
+			choices++
+		}
+		if withKeys {
+			choices++
+		}
+		switch c := r.Intn(choices); {
+		case c == 0:
+			return concurrencytest.Op[S, mapModel]{
+				Name: fmt.Sprintf("Get(%d)", key),
+				Run: func(m S) any {
+					v, ok := m.Get(key)
+					return [2]any{v, ok}
+				},
+				Model: func(m mapModel) any {
+					v, ok := m[key]
+					return [2]any{v, ok}
+				},
+			}
+		case c == 1:
+			return concurrencytest.Op[S, mapModel]{
+				Name:  fmt.Sprintf("Set(%d, %d)", key, value),
+				Run:   func(m S) any { m.Set(key, value); return nil },
+				Model: func(m mapModel) any { m[key] = value; return nil },
+			}
+		case c == 2:
+			return concurrencytest.Op[S, mapModel]{
+				Name:  fmt.Sprintf("Delete(%d)", key),
+				Run:   func(m S) any { m.Delete(key); return nil },
+				Model: func(m mapModel) any { delete(m, key); return nil },
+			}
+		case c == 3 && withLen:
+			return concurrencytest.Op[S, mapModel]{
+				Name:  "Len()",
+				Run:   func(m S) any { return m.Len() },
+				Model: func(m mapModel) any { return len(m) },
+			}
+		default:
+			return concurrencytest.Op[S, mapModel]{
+				Name: "Keys()",
+				Run: func(m S) any {
+					return any(m).(interface{ Keys() []int }).Keys()
+				},
+				Model: func(m mapModel) any {
+					keys := make([]int, 0, len(m))
+					for k := range m {
+						keys = append(keys, k)
+					}
+					slices.Sort(keys)
+					return keys
+				},
+			}
+		}
+	}
+}
+
+func runPointScript[S pointMap](t *testing.T, newMap func() S, withLen, withKeys bool) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		concurrencytest.Run(t, concurrencytest.Script[S, mapModel]{
+			New:      newMap,
+			NewModel: func() mapModel { return mapModel{} },
+			Clone:    cloneModel,
+			Threads:  concurrencytest.RandomThreads(r, 4, 6, pointOps[S](withLen, withKeys)),
+			Runs:     10,
+		})
+	}
+}
+
+func TestSafeSortedMapLinearizable(t *testing.T) {
+	runPointScript(t, func() *SafeSortedMap[int, int] { return NewSafeSortedMap[int, int]() }, true, true)
+}
+
+func TestOrderedMapLinearizable(t *testing.T) {
+	runPointScript(t, func() *OrderedMap[int, int] { return NewOrderedMap[int, int]() }, true, false)
+}
+
+// ConcurrentSortedMap updates its size after publishing a node and its Keys
+// are only weakly consistent, so only point operations are checked.
+func TestConcurrentSortedMapLinearizable(t *testing.T) {
+	runPointScript(t, NewConcurrentSortedMap[int, int], false, false)
+}
@@ -3,6 +3,9 @@ package maps
 import (
 	"slices"
 	"sync"
+	"sync/atomic"
+
+	"dsgo/utils"
 )
 
 type OrderedMap[K comparable, V any] struct {
@@ -11,6 +14,8 @@ type OrderedMap[K comparable, V any] struct {
 	index      map[K]int // Maps key to its position in the slices
 	threadSafe bool
 	mu         sync.RWMutex
+	sealed     atomic.Bool
+	snaps      checkpoints[K, V]
 }
 
 func NewOrderedMap[K comparable, V any](threadSafe ...bool) *OrderedMap[K, V] {
@@ -27,7 +32,7 @@ func NewOrderedMap[K comparable, V any](threadSafe ...bool) *OrderedMap[K, V] {
 }
 
 func (m *OrderedMap[K, V]) Get(key K) (V, bool) {
-	if m.threadSafe {
+	if m.threadSafe && !m.sealed.Load() {
 		m.mu.RLock()
 		defer m.mu.RUnlock()
 	}
@@ -43,6 +48,10 @@ func (m *OrderedMap[K, V]) Set(key K, value V) {
 		m.mu.Lock()
 		defer m.mu.Unlock()
 	}
+	if m.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
+	m.snaps.unshare(&m.keys, &m.values, &m.index)
 	if pos, exists := m.index[key]; exists {
 		m.values[pos] = value
 		return
@@ -58,10 +67,39 @@ func (m *OrderedMap[K, V]) Delete(key K) {
 		m.mu.Lock()
 		defer m.mu.Unlock()
 	}
+	if m.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
+	m.remove(key)
+}
+
+// Remove deletes key and returns the value it held, or false if the key was
+// not present.
+func (m *OrderedMap[K, V]) Remove(key K) (V, bool) {
+	if m.threadSafe {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+	}
+	if m.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
+	return m.remove(key)
+}
+
+// TryDelete deletes key and reports whether it was present.
+func (m *OrderedMap[K, V]) TryDelete(key K) bool {
+	_, ok := m.Remove(key)
+	return ok
+}
+
+func (m *OrderedMap[K, V]) remove(key K) (V, bool) {
 	pos, exists := m.index[key]
 	if !exists {
-		return
+		var zero V
+		return zero, false
 	}
+	m.snaps.unshare(&m.keys, &m.values, &m.index)
+	value := m.values[pos]
 
 	// Remove from slices
 	m.keys = slices.Delete(m.keys, pos, pos+1)
@@ -72,10 +110,25 @@ func (m *OrderedMap[K, V]) Delete(key K) {
 	for i := pos; i < len(m.keys); i++ {
 		m.index[m.keys[i]] = i
 	}
+	return value, true
 }
 
-func (m *OrderedMap[K, V]) Len() int {
+// DeleteFunc removes every entry for which pred returns true, under a single
+// lock, and returns the number of entries removed.
+func (m *OrderedMap[K, V]) DeleteFunc(pred func(key K, value V) bool) int {
 	if m.threadSafe {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+	}
+	if m.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
+	m.snaps.unshare(&m.keys, &m.values, &m.index)
+	return deleteEntriesFunc(&m.keys, &m.values, m.index, pred)
+}
+
+func (m *OrderedMap[K, V]) Len() int {
+	if m.threadSafe && !m.sealed.Load() {
 		m.mu.RLock()
 		defer m.mu.RUnlock()
 	}
@@ -83,7 +136,7 @@ func (m *OrderedMap[K, V]) Len() int {
 }
 
 func (m *OrderedMap[K, V]) IsEmpty() bool {
-	if m.threadSafe {
+	if m.threadSafe && !m.sealed.Load() {
 		m.mu.RLock()
 		defer m.mu.RUnlock()
 	}
@@ -91,7 +144,7 @@ func (m *OrderedMap[K, V]) IsEmpty() bool {
 }
 
 func (m *OrderedMap[K, V]) Next(key K) (K, V, bool) {
-	if m.threadSafe {
+	if m.threadSafe && !m.sealed.Load() {
 		m.mu.RLock()
 		defer m.mu.RUnlock()
 	}
@@ -105,7 +158,7 @@ func (m *OrderedMap[K, V]) Next(key K) (K, V, bool) {
 }
 
 func (m *OrderedMap[K, V]) Prev(key K) (K, V, bool) {
-	if m.threadSafe {
+	if m.threadSafe && !m.sealed.Load() {
 		m.mu.RLock()
 		defer m.mu.RUnlock()
 	}
@@ -120,7 +173,7 @@ func (m *OrderedMap[K, V]) Prev(key K) (K, V, bool) {
 
 // Keys returns a slice of all keys in insertion order
 func (m *OrderedMap[K, V]) Keys() []K {
-	if m.threadSafe {
+	if m.threadSafe && !m.sealed.Load() {
 		m.mu.RLock()
 		defer m.mu.RUnlock()
 	}
@@ -131,7 +184,7 @@ func (m *OrderedMap[K, V]) Keys() []K {
 
 // Values returns a slice of all values in insertion order
 func (m *OrderedMap[K, V]) Values() []V {
-	if m.threadSafe {
+	if m.threadSafe && !m.sealed.Load() {
 		m.mu.RLock()
 		defer m.mu.RUnlock()
 	}
@@ -140,9 +193,83 @@ func (m *OrderedMap[K, V]) Values() []V {
 	return values
 }
 
+// AppendKeys appends the keys in insertion order to dst and returns the
+// extended slice, letting callers reuse a buffer across calls.
+func (m *OrderedMap[K, V]) AppendKeys(dst []K) []K {
+	if m.threadSafe && !m.sealed.Load() {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
+	return append(dst, m.keys...)
+}
+
+// AppendValues appends the values in insertion order to dst and returns the
+// extended slice.
+func (m *OrderedMap[K, V]) AppendValues(dst []V) []V {
+	if m.threadSafe && !m.sealed.Load() {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
+	return append(dst, m.values...)
+}
+
+// Page returns up to limit entries starting at offset in insertion order,
+// along with the offset of the next page or -1 if there are no more entries.
+// Offsets stay valid while the map is only appended to.
+func (m *OrderedMap[K, V]) Page(offset, limit int) ([]utils.Entry[K, V], int) {
+	if m.threadSafe && !m.sealed.Load() {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
+	return pageEntries(m.keys, m.values, offset, limit)
+}
+
+// Slice returns the entries at insertion positions [from, to), with the
+// bounds clamped to the map's length.
+func (m *OrderedMap[K, V]) Slice(from, to int) []utils.Entry[K, V] {
+	if m.threadSafe && !m.sealed.Load() {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
+	from = max(from, 0)
+	to = min(to, len(m.keys))
+	if from >= to {
+		return nil
+	}
+	entries, _ := pageEntries(m.keys, m.values, from, to-from)
+	return entries
+}
+
+// Truncate drops the oldest entries so that at most n remain, keeping the n
+// most recently inserted, and returns the number of entries removed. This
+// lets the map serve as an append-mostly log trimmed to a window.
+func (m *OrderedMap[K, V]) Truncate(n int) int {
+	if m.threadSafe {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+	}
+	if m.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
+	drop := len(m.keys) - max(n, 0)
+	if drop <= 0 {
+		return 0
+	}
+	m.snaps.unshare(&m.keys, &m.values, &m.index)
+	for _, key := range m.keys[:drop] {
+		delete(m.index, key)
+	}
+	m.keys = slices.Delete(m.keys, 0, drop)
+	m.values = slices.Delete(m.values, 0, drop)
+	for i, key := range m.keys {
+		m.index[key] = i
+	}
+	return drop
+}
+
 // Range iterates over the map in insertion order
 func (m *OrderedMap[K, V]) Range(f func(key K, value V) bool) {
-	if m.threadSafe {
+	if m.threadSafe && !m.sealed.Load() {
 		m.mu.RLock()
 		defer m.mu.RUnlock()
 	}
@@ -152,3 +279,90 @@ func (m *OrderedMap[K, V]) Range(f func(key K, value V) bool) {
 		}
 	}
 }
+
+// deleteEntriesFunc compacts the parallel key/value slices in place, dropping
+// the entries matched by pred and reindexing the survivors in one pass.
+func deleteEntriesFunc[K comparable, V any](keys *[]K, values *[]V, index map[K]int, pred func(key K, value V) bool) int {
+	ks, vs := *keys, *values
+	kept := 0
+	for i, key := range ks {
+		if pred(key, vs[i]) {
+			delete(index, key)
+			continue
+		}
+		ks[kept], vs[kept] = key, vs[i]
+		index[key] = kept
+		kept++
+	}
+	removed := len(ks) - kept
+	*keys = slices.Delete(ks, kept, len(ks))
+	*values = slices.Delete(vs, kept, len(vs))
+	return removed
+}
+
+// Seal makes the map read-only. Mutations after Seal panic with
+// utils.ErrSealed. Reads on a sealed map skip locking.
+func (m *OrderedMap[K, V]) Seal() {
+	if m.threadSafe {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+	}
+	m.sealed.Store(true)
+}
+
+// IsSealed reports whether Seal has been called.
+func (m *OrderedMap[K, V]) IsSealed() bool {
+	return m.sealed.Load()
+}
+
+// Checkpoint saves the current contents and returns an id to roll back to.
+// It is O(1): the map is copied on the first mutation after a checkpoint
+// instead, so a run of speculative writes pays for one copy.
+func (m *OrderedMap[K, V]) Checkpoint() SnapshotID {
+	if m.threadSafe {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+	}
+	return m.snaps.take(m.keys, m.values, m.index)
+}
+
+// Rollback restores the contents saved by Checkpoint, insertion order
+// included, and discards any checkpoints taken after id. It returns
+// ErrUnknownSnapshot if id is not a live checkpoint and utils.ErrSealed on
+// a sealed map.
+func (m *OrderedMap[K, V]) Rollback(id SnapshotID) error {
+	if m.threadSafe {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+	}
+	if m.sealed.Load() {
+		return utils.ErrSealed
+	}
+	return m.snaps.rollback(id, &m.keys, &m.values, &m.index)
+}
+
+// Release drops the checkpoint id, and any taken after it, once the writes
+// since it are to be kept, so the saved contents can be collected.
+func (m *OrderedMap[K, V]) Release(id SnapshotID) error {
+	if m.threadSafe {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+	}
+	return m.snaps.release(id)
+}
+
+func pageEntries[K any, V any](keys []K, values []V, offset, limit int) ([]utils.Entry[K, V], int) {
+	offset = max(offset, 0)
+	if limit <= 0 || offset >= len(keys) {
+		return nil, -1
+	}
+	end := min(offset+limit, len(keys))
+	entries := make([]utils.Entry[K, V], 0, end-offset)
+	for i := offset; i < end; i++ {
+		entries = append(entries, utils.Entry[K, V]{Key: keys[i], Value: values[i]})
+	}
+	if end == len(keys) {
+		return entries, -1
+	}
+	return entries, end
+}
@@ -1,8 +1,11 @@
 package maps
 
 import (
+	"slices"
 	"sync"
 	"testing"
+
+	"dsgo/utils"
 )
 
 func TestNewOrderedMap(t *testing.T) {
@@ -175,6 +178,70 @@ func TestRange(t *testing.T) {
 	}
 }
 
+func TestDeleteFunc(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	m.Set("one", 1)
+	m.Set("two", 2)
+	m.Set("three", 3)
+	m.Set("four", 4)
+
+	removed := m.DeleteFunc(func(key string, value int) bool {
+		return value%2 == 0
+	})
+	if removed != 2 {
+		t.Errorf("DeleteFunc removed %d, want 2", removed)
+	}
+
+	keys := m.Keys()
+	expectedKeys := []string{"one", "three"}
+	if len(keys) != len(expectedKeys) {
+		t.Fatalf("Keys() = %v, want %v", keys, expectedKeys)
+	}
+	for i, k := range keys {
+		if k != expectedKeys[i] {
+			t.Errorf("Keys()[%d] = %s, want %s", i, k, expectedKeys[i])
+		}
+	}
+
+	// Index must be rebuilt for the surviving entries
+	if key, val, ok := m.Next("one"); !ok || key != "three" || val != 3 {
+		t.Errorf("Next(one) = %s, %d, %v; want three, 3, true", key, val, ok)
+	}
+	if _, exists := m.Get("two"); exists {
+		t.Error("Expected 'two' to be deleted")
+	}
+
+	if removed := m.DeleteFunc(func(string, int) bool { return false }); removed != 0 {
+		t.Errorf("DeleteFunc removed %d, want 0", removed)
+	}
+}
+
+func TestPage(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	for i, key := range []string{"e", "d", "c", "b", "a"} {
+		m.Set(key, i)
+	}
+
+	entries, next := m.Page(0, 2)
+	if len(entries) != 2 || entries[0].Key != "e" || entries[1].Key != "d" || next != 2 {
+		t.Errorf("Page(0, 2) = %v, %d", entries, next)
+	}
+
+	entries, next = m.Page(next, 2)
+	if len(entries) != 2 || entries[0].Key != "c" || entries[1].Value != 3 || next != 4 {
+		t.Errorf("Page(2, 2) = %v, %d", entries, next)
+	}
+
+	entries, next = m.Page(next, 2)
+	if len(entries) != 1 || entries[0].Key != "a" || next != -1 {
+		t.Errorf("Page(4, 2) = %v, %d", entries, next)
+	}
+
+	if entries, next := m.Page(10, 2); entries != nil || next != -1 {
+		t.Errorf("Page(10, 2) = %v, %d; want nil, -1", entries, next)
+	}
+}
+
 func TestOrderedMapWithDifferentTypes(t *testing.T) {
 	// Test with int keys
 	m1 := NewOrderedMap[int, string]()
@@ -387,3 +454,136 @@ func TestSafeOrderedMap_Empty(t *testing.T) {
 		t.Errorf("Range on empty map: processed %v items, want 0", count)
 	}
 }
+
+func TestOrderedMapSeal(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	m.Set("a", 1)
+	m.Seal()
+
+	if val, ok := m.Get("a"); !ok || val != 1 {
+		t.Error("Reads should keep working on a sealed map")
+	}
+
+	for name, mutate := range map[string]func(){
+		"Set":        func() { m.Set("b", 2) },
+		"Delete":     func() { m.Delete("a") },
+		"DeleteFunc": func() { m.DeleteFunc(func(string, int) bool { return true }) },
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r != utils.ErrSealed {
+					t.Errorf("%s on sealed map should panic with ErrSealed, got %v", name, r)
+				}
+			}()
+			mutate()
+		}()
+	}
+	if m.Len() != 1 {
+		t.Errorf("Sealed map should be unchanged, got length %d", m.Len())
+	}
+}
+
+func TestOrderedMapSlice(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	for i, key := range []string{"a", "b", "c", "d"} {
+		m.Set(key, i)
+	}
+
+	tests := []struct {
+		from, to int
+		want     []string
+	}{
+		{1, 3, []string{"b", "c"}},
+		{-5, 2, []string{"a", "b"}},
+		{2, 100, []string{"c", "d"}},
+		{3, 1, nil},
+		{4, 5, nil},
+	}
+	for _, tt := range tests {
+		entries := m.Slice(tt.from, tt.to)
+		if len(entries) != len(tt.want) {
+			t.Errorf("Slice(%d, %d) = %v, want keys %v", tt.from, tt.to, entries, tt.want)
+			continue
+		}
+		for i, e := range entries {
+			if e.Key != tt.want[i] {
+				t.Errorf("Slice(%d, %d) = %v, want keys %v", tt.from, tt.to, entries, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestOrderedMapTruncate(t *testing.T) {
+	m := NewOrderedMap[int, int](false)
+	for i := 0; i < 10; i++ {
+		m.Set(i, i*i)
+	}
+
+	if removed := m.Truncate(20); removed != 0 {
+		t.Errorf("Truncate(20) = %d, want 0", removed)
+	}
+	if removed := m.Truncate(3); removed != 7 {
+		t.Errorf("Truncate(3) = %d, want 7", removed)
+	}
+	if got := m.Keys(); len(got) != 3 || got[0] != 7 || got[2] != 9 {
+		t.Errorf("Keys() = %v, want [7 8 9]", got)
+	}
+	if _, ok := m.Get(6); ok {
+		t.Error("Get(6) should miss after Truncate")
+	}
+
+	// Positions must be reindexed for Next/Prev and later deletes
+	if k, v, ok := m.Next(7); !ok || k != 8 || v != 64 {
+		t.Errorf("Next(7) = %d, %d, %v, want 8, 64, true", k, v, ok)
+	}
+	m.Delete(8)
+	m.Set(10, 100)
+	if got := m.Keys(); len(got) != 3 || got[1] != 9 || got[2] != 10 {
+		t.Errorf("Keys() = %v, want [7 9 10]", got)
+	}
+
+	m.Truncate(0)
+	if !m.IsEmpty() {
+		t.Errorf("Truncate(0) left %d entries", m.Len())
+	}
+}
+
+func TestOrderedMapAppendKeys(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	m.Set("b", 2)
+	m.Set("a", 1)
+	buf := make([]string, 0, 8)
+	if got := m.AppendKeys(buf[:0]); !slices.Equal(got, []string{"b", "a"}) {
+		t.Errorf("AppendKeys() = %v, want [b a]", got)
+	}
+	if got := m.AppendValues([]int{0}); !slices.Equal(got, []int{0, 2, 1}) {
+		t.Errorf("AppendValues() = %v, want [0 2 1]", got)
+	}
+	if allocs := testing.AllocsPerRun(100, func() { buf = m.AppendKeys(buf[:0]) }); allocs != 0 {
+		t.Errorf("AppendKeys() into a large enough buffer allocated %v times", allocs)
+	}
+}
+
+func TestOrderedMap_Remove(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("c", 3)
+
+	if value, ok := m.Remove("b"); !ok || value != 2 {
+		t.Errorf("Remove(b) = %d, %v, want 2, true", value, ok)
+	}
+	if _, ok := m.Remove("b"); ok {
+		t.Error("Remove(b) again reported the key as present")
+	}
+	if next, _, _ := m.Next("a"); next != "c" {
+		t.Errorf("Next(a) after Remove = %q, want c", next)
+	}
+	if !m.TryDelete("a") || m.TryDelete("a") {
+		t.Error("TryDelete(a) should succeed once and then report false")
+	}
+	if m.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", m.Len())
+	}
+}
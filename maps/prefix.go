@@ -0,0 +1,23 @@
+package maps
+
+import (
+	"strings"
+)
+
+type rangeFromer[V any] interface {
+	RangeFrom(from string, f func(key string, value V) bool)
+}
+
+// RangePrefix calls f, in key order, for every entry of a string-keyed
+// SortedMap, SafeSortedMap or ConcurrentSortedMap whose key starts with
+// prefix, until f returns false. It seeks to prefix and stops at the first
+// key past the prefix range, so it only touches matching keys. The map must
+// use the natural string order.
+func RangePrefix[V any](m rangeFromer[V], prefix string, f func(key string, value V) bool) {
+	m.RangeFrom(prefix, func(key string, value V) bool {
+		if !strings.HasPrefix(key, prefix) {
+			return false
+		}
+		return f(key, value)
+	})
+}
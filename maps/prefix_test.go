@@ -0,0 +1,53 @@
+package maps
+
+import (
+	"testing"
+)
+
+func TestRangePrefix(t *testing.T) {
+	keys := []string{"a", "app/a", "app/b", "app/c/d", "apple", "b/app/x", "app"}
+	sorted := NewSortedMap[string, int]()
+	safe := NewSafeSortedMap[string, int]()
+	concurrent := NewConcurrentSortedMap[string, int]()
+	for i, key := range keys {
+		sorted.Set(key, i)
+		safe.Set(key, i)
+		concurrent.Set(key, i)
+	}
+
+	maps := map[string]rangeFromer[int]{
+		"SortedMap":           sorted,
+		"SafeSortedMap":       safe,
+		"ConcurrentSortedMap": concurrent,
+	}
+	for name, m := range maps {
+		var got []string
+		RangePrefix(m, "app/", func(key string, value int) bool {
+			got = append(got, key)
+			return true
+		})
+		expected := []string{"app/a", "app/b", "app/c/d"}
+		if len(got) != len(expected) {
+			t.Fatalf("%s: RangePrefix(app/) = %v, want %v", name, got, expected)
+		}
+		for i := range expected {
+			if got[i] != expected[i] {
+				t.Errorf("%s: RangePrefix(app/)[%d] = %s, want %s", name, i, got[i], expected[i])
+			}
+		}
+
+		count := 0
+		RangePrefix(m, "app", func(key string, value int) bool {
+			count++
+			return count < 2
+		})
+		if count != 2 {
+			t.Errorf("%s: RangePrefix should stop when f returns false, visited %d", name, count)
+		}
+
+		RangePrefix(m, "zzz", func(key string, value int) bool {
+			t.Errorf("%s: unexpected key %s", name, key)
+			return true
+		})
+	}
+}
@@ -2,9 +2,10 @@ package maps
 
 import (
 	"slices"
-	"sort"
 	"sync"
+	"sync/atomic"
 
+	"dsgo/slicesx"
 	"dsgo/utils"
 )
 
@@ -12,16 +13,32 @@ type SortedMap[K utils.Ordered, V any] struct {
 	keys   []K
 	values []V
 	index  map[K]int
+	cmp    utils.CompareFunc[K]
+	sealed bool
+	snaps  checkpoints[K, V]
 }
 
 func NewSortedMap[K utils.Ordered, V any](threadSafe ...bool) *SortedMap[K, V] {
+	return NewSortedMapFunc[K, V](utils.Natural[K]())
+}
+
+// NewSortedMapFunc creates a sorted map ordered by cmp instead of the natural
+// ordering of K. Keys are still identified by ==, so keys that compare equal
+// under cmp but are not identical are kept as separate adjacent entries.
+func NewSortedMapFunc[K utils.Ordered, V any](cmp utils.CompareFunc[K]) *SortedMap[K, V] {
 	return &SortedMap[K, V]{
 		keys:   make([]K, 0),
 		values: make([]V, 0),
 		index:  make(map[K]int),
+		cmp:    cmp,
 	}
 }
 
+// NewSortedMapDesc creates a sorted map that keeps keys in descending order.
+func NewSortedMapDesc[K utils.Ordered, V any]() *SortedMap[K, V] {
+	return NewSortedMapFunc[K, V](utils.Reversed(utils.Natural[K]()))
+}
+
 func (m *SortedMap[K, V]) Get(key K) (V, bool) {
 	if pos, exists := m.index[key]; exists {
 		return m.values[pos], true
@@ -31,23 +48,39 @@ func (m *SortedMap[K, V]) Get(key K) (V, bool) {
 }
 
 func (m *SortedMap[K, V]) Set(key K, value V) {
+	if m.sealed {
+		panic(utils.ErrSealed)
+	}
+	m.snaps.unshare(&m.keys, &m.values, &m.index)
 	if pos, exists := m.index[key]; exists {
 		m.values[pos] = value
 		return
 	}
-	pos := sort.Search(len(m.keys), func(i int) bool {
-		return m.keys[i] >= key
-	})
+	pos := slicesx.BisectRightFunc(m.keys, key, m.cmp)
 	m.keys = slices.Insert(m.keys, pos, key)
 	m.values = slices.Insert(m.values, pos, value)
-	m.index[key] = pos
+	for i := pos; i < len(m.keys); i++ {
+		m.index[m.keys[i]] = i
+	}
 }
 
 func (m *SortedMap[K, V]) Delete(key K) {
+	m.Remove(key)
+}
+
+// Remove deletes key and returns the value it held, or false if the key was
+// not present.
+func (m *SortedMap[K, V]) Remove(key K) (V, bool) {
+	if m.sealed {
+		panic(utils.ErrSealed)
+	}
 	pos, exists := m.index[key]
 	if !exists {
-		return
+		var zero V
+		return zero, false
 	}
+	m.snaps.unshare(&m.keys, &m.values, &m.index)
+	value := m.values[pos]
 
 	m.keys = slices.Delete(m.keys, pos, pos+1)
 	m.values = slices.Delete(m.values, pos, pos+1)
@@ -56,6 +89,84 @@ func (m *SortedMap[K, V]) Delete(key K) {
 	for i := pos; i < len(m.keys); i++ {
 		m.index[m.keys[i]] = i
 	}
+	m.shrink()
+	return value, true
+}
+
+// TryDelete deletes key and reports whether it was present.
+func (m *SortedMap[K, V]) TryDelete(key K) bool {
+	_, ok := m.Remove(key)
+	return ok
+}
+
+// DeleteFunc removes every entry for which pred returns true and returns the
+// number of entries removed.
+func (m *SortedMap[K, V]) DeleteFunc(pred func(key K, value V) bool) int {
+	if m.sealed {
+		panic(utils.ErrSealed)
+	}
+	m.snaps.unshare(&m.keys, &m.values, &m.index)
+	removed := deleteEntriesFunc(&m.keys, &m.values, m.index, pred)
+	m.shrink()
+	return removed
+}
+
+// Compact releases the spare capacity of the key and value slices. Deletes
+// already shrink them once they are less than a quarter full; Compact is for
+// reclaiming memory right after a burst of deletes.
+func (m *SortedMap[K, V]) Compact() {
+	if m.sealed {
+		panic(utils.ErrSealed)
+	}
+	m.keys = slicesx.Compact(m.keys)
+	m.values = slicesx.Compact(m.values)
+}
+
+// DeleteRange removes every key in [low, high) and returns the number of
+// entries removed; low and high are in the map's own order, so a descending
+// map takes the larger bound first. The entries go in one slice deletion with a single
+// reindex of the keys after them, rather than one per key.
+func (m *SortedMap[K, V]) DeleteRange(low, high K) int {
+	if m.sealed {
+		panic(utils.ErrSealed)
+	}
+	lo, hi := m.BisectLeft(low), m.BisectLeft(high)
+	if hi <= lo {
+		return 0
+	}
+	m.snaps.unshare(&m.keys, &m.values, &m.index)
+	for _, key := range m.keys[lo:hi] {
+		delete(m.index, key)
+	}
+	m.keys = slices.Delete(m.keys, lo, hi)
+	m.values = slices.Delete(m.values, lo, hi)
+	for i := lo; i < len(m.keys); i++ {
+		m.index[m.keys[i]] = i
+	}
+	m.shrink()
+	return hi - lo
+}
+
+// SubMap returns a new map holding a copy of the entries with keys in
+// [low, high), ordered by the same comparison. Later writes to either map
+// do not show in the other.
+func (m *SortedMap[K, V]) SubMap(low, high K) *SortedMap[K, V] {
+	sub := NewSortedMapFunc[K, V](m.cmp)
+	lo, hi := m.BisectLeft(low), m.BisectLeft(high)
+	if hi <= lo {
+		return sub
+	}
+	sub.keys = slices.Clone(m.keys[lo:hi])
+	sub.values = slices.Clone(m.values[lo:hi])
+	for i, key := range sub.keys {
+		sub.index[key] = i
+	}
+	return sub
+}
+
+func (m *SortedMap[K, V]) shrink() {
+	m.keys = slicesx.Shrink(m.keys)
+	m.values = slicesx.Shrink(m.values)
 }
 
 func (m *SortedMap[K, V]) Len() int {
@@ -86,12 +197,72 @@ func (m *SortedMap[K, V]) Prev(key K) (K, V, bool) {
 	return m.keys[pos-1], m.values[pos-1], true
 }
 
+// SearchKeys returns the position of key in the sorted key order, or the
+// position where it would be inserted, and whether it is present.
+func (m *SortedMap[K, V]) SearchKeys(key K) (int, bool) {
+	if pos, exists := m.index[key]; exists {
+		return pos, true
+	}
+	return slicesx.BisectLeftFunc(m.keys, key, m.cmp), false
+}
+
+// BisectLeft returns the position of the first key that does not sort before key.
+func (m *SortedMap[K, V]) BisectLeft(key K) int {
+	return slicesx.BisectLeftFunc(m.keys, key, m.cmp)
+}
+
+// BisectRight returns the position of the first key that sorts after key.
+func (m *SortedMap[K, V]) BisectRight(key K) int {
+	return slicesx.BisectRightFunc(m.keys, key, m.cmp)
+}
+
+// At returns the entry at position i in the sorted key order.
+func (m *SortedMap[K, V]) At(i int) (K, V, bool) {
+	if i < 0 || i >= len(m.keys) {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	return m.keys[i], m.values[i], true
+}
+
+// Floor returns the last entry whose key does not sort after key, or false
+// if there is none. Under the default ordering that is the largest key <= key.
+func (m *SortedMap[K, V]) Floor(key K) (K, V, bool) {
+	if pos, exists := m.index[key]; exists {
+		return m.keys[pos], m.values[pos], true
+	}
+	return m.At(m.BisectRight(key) - 1)
+}
+
+// Ceiling returns the first entry whose key does not sort before key, or
+// false if there is none. Under the default ordering that is the smallest
+// key >= key.
+func (m *SortedMap[K, V]) Ceiling(key K) (K, V, bool) {
+	if pos, exists := m.index[key]; exists {
+		return m.keys[pos], m.values[pos], true
+	}
+	return m.At(m.BisectLeft(key))
+}
+
 func (m *SortedMap[K, V]) Keys() []K {
 	keys := make([]K, len(m.keys))
 	copy(keys, m.keys)
 	return keys
 }
 
+// AppendKeys appends the keys in sorted order to dst and returns the
+// extended slice, letting callers reuse a buffer across calls.
+func (m *SortedMap[K, V]) AppendKeys(dst []K) []K {
+	return append(dst, m.keys...)
+}
+
+// AppendValues appends the values in key order to dst and returns the
+// extended slice.
+func (m *SortedMap[K, V]) AppendValues(dst []V) []V {
+	return append(dst, m.values...)
+}
+
 func (m *SortedMap[K, V]) Values() []V {
 	values := make([]V, len(m.values))
 	copy(values, m.values)
@@ -106,10 +277,76 @@ func (m *SortedMap[K, V]) Range(f func(key K, value V) bool) {
 	}
 }
 
+// RangeFrom iterates in key order starting at the first key that does not
+// sort before from.
+func (m *SortedMap[K, V]) RangeFrom(from K, f func(key K, value V) bool) {
+	for i := m.BisectLeft(from); i < len(m.keys); i++ {
+		if !f(m.keys[i], m.values[i]) {
+			break
+		}
+	}
+}
+
+// Page returns up to limit entries starting at offset in key order, along
+// with the offset of the next page or -1 if there are no more entries.
+func (m *SortedMap[K, V]) Page(offset, limit int) ([]utils.Entry[K, V], int) {
+	return pageEntries(m.keys, m.values, offset, limit)
+}
+
+// PageAfter returns up to limit entries whose keys sort after key, and
+// whether more entries follow. Passing the last key of the previous page as
+// key gives pagination that stays stable while the map is modified.
+func (m *SortedMap[K, V]) PageAfter(key K, limit int) ([]utils.Entry[K, V], bool) {
+	entries, next := pageEntries(m.keys, m.values, m.BisectRight(key), limit)
+	return entries, next != -1
+}
+
+// ReverseRange iterates over the map from the last key to the first.
+func (m *SortedMap[K, V]) ReverseRange(f func(key K, value V) bool) {
+	for i := len(m.keys) - 1; i >= 0; i-- {
+		if !f(m.keys[i], m.values[i]) {
+			break
+		}
+	}
+}
+
+// Seal makes the map read-only. Mutations after Seal panic with utils.ErrSealed.
+func (m *SortedMap[K, V]) Seal() {
+	m.sealed = true
+}
+
+// IsSealed reports whether Seal has been called.
+func (m *SortedMap[K, V]) IsSealed() bool {
+	return m.sealed
+}
+
+// Checkpoint saves the current contents and returns an id to roll back to.
+// The map is not copied until it is next written.
+func (m *SortedMap[K, V]) Checkpoint() SnapshotID {
+	return m.snaps.take(m.keys, m.values, m.index)
+}
+
+// Rollback restores the contents saved by Checkpoint and discards any
+// checkpoints taken after id. It returns ErrUnknownSnapshot if id is not a
+// live checkpoint and utils.ErrSealed on a sealed map.
+func (m *SortedMap[K, V]) Rollback(id SnapshotID) error {
+	if m.sealed {
+		return utils.ErrSealed
+	}
+	return m.snaps.rollback(id, &m.keys, &m.values, &m.index)
+}
+
+// Release drops the checkpoint id and any taken after it.
+func (m *SortedMap[K, V]) Release(id SnapshotID) error {
+	return m.snaps.release(id)
+}
+
 // SafeSortedMap is a thread-safe wrapper around SortedMap.
 type SafeSortedMap[K utils.Ordered, V any] struct {
-	mu    sync.RWMutex
-	inner *SortedMap[K, V]
+	mu     sync.RWMutex
+	sealed atomic.Bool
+	inner  *SortedMap[K, V]
+	id     atomic.Uint64
 }
 
 func NewSafeSortedMap[K utils.Ordered, V any](threadSafe ...bool) *SafeSortedMap[K, V] {
@@ -118,9 +355,18 @@ func NewSafeSortedMap[K utils.Ordered, V any](threadSafe ...bool) *SafeSortedMap
 	}
 }
 
+// NewSafeSortedMapFunc creates a thread-safe sorted map ordered by cmp.
+func NewSafeSortedMapFunc[K utils.Ordered, V any](cmp utils.CompareFunc[K]) *SafeSortedMap[K, V] {
+	return &SafeSortedMap[K, V]{
+		inner: NewSortedMapFunc[K, V](cmp),
+	}
+}
+
 func (m *SafeSortedMap[K, V]) Get(key K) (V, bool) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+	if !m.sealed.Load() {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
 	return m.inner.Get(key)
 }
 
@@ -136,44 +382,253 @@ func (m *SafeSortedMap[K, V]) Delete(key K) {
 	m.inner.Delete(key)
 }
 
+func (m *SafeSortedMap[K, V]) Remove(key K) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.inner.Remove(key)
+}
+
+func (m *SafeSortedMap[K, V]) TryDelete(key K) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.inner.TryDelete(key)
+}
+
+func (m *SafeSortedMap[K, V]) DeleteFunc(pred func(key K, value V) bool) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.inner.DeleteFunc(pred)
+}
+
+func (m *SafeSortedMap[K, V]) DeleteRange(low, high K) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.inner.DeleteRange(low, high)
+}
+
+// SubMap returns a new thread-safe map holding a copy of the entries with
+// keys in [low, high).
+func (m *SafeSortedMap[K, V]) SubMap(low, high K) *SafeSortedMap[K, V] {
+	if !m.sealed.Load() {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
+	return &SafeSortedMap[K, V]{inner: m.inner.SubMap(low, high)}
+}
+
+func (m *SafeSortedMap[K, V]) Compact() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.inner.Compact()
+}
+
 func (m *SafeSortedMap[K, V]) Len() int {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+	if !m.sealed.Load() {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
 	return m.inner.Len()
 }
 
 func (m *SafeSortedMap[K, V]) IsEmpty() bool {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+	if !m.sealed.Load() {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
 	return m.inner.IsEmpty()
 }
 
 func (m *SafeSortedMap[K, V]) Next(key K) (K, V, bool) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+	if !m.sealed.Load() {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
 	return m.inner.Next(key)
 }
 
 func (m *SafeSortedMap[K, V]) Prev(key K) (K, V, bool) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+	if !m.sealed.Load() {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
 	return m.inner.Prev(key)
 }
 
+func (m *SafeSortedMap[K, V]) SearchKeys(key K) (int, bool) {
+	if !m.sealed.Load() {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
+	return m.inner.SearchKeys(key)
+}
+
+func (m *SafeSortedMap[K, V]) BisectLeft(key K) int {
+	if !m.sealed.Load() {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
+	return m.inner.BisectLeft(key)
+}
+
+func (m *SafeSortedMap[K, V]) BisectRight(key K) int {
+	if !m.sealed.Load() {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
+	return m.inner.BisectRight(key)
+}
+
+func (m *SafeSortedMap[K, V]) At(i int) (K, V, bool) {
+	if !m.sealed.Load() {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
+	return m.inner.At(i)
+}
+
+func (m *SafeSortedMap[K, V]) Floor(key K) (K, V, bool) {
+	if !m.sealed.Load() {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
+	return m.inner.Floor(key)
+}
+
+func (m *SafeSortedMap[K, V]) Ceiling(key K) (K, V, bool) {
+	if !m.sealed.Load() {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
+	return m.inner.Ceiling(key)
+}
+
 func (m *SafeSortedMap[K, V]) Keys() []K {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+	if !m.sealed.Load() {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
 	return m.inner.Keys()
 }
 
 func (m *SafeSortedMap[K, V]) Values() []V {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+	if !m.sealed.Load() {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
 	return m.inner.Values()
 }
 
+func (m *SafeSortedMap[K, V]) AppendKeys(dst []K) []K {
+	if !m.sealed.Load() {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
+	return m.inner.AppendKeys(dst)
+}
+
+func (m *SafeSortedMap[K, V]) AppendValues(dst []V) []V {
+	if !m.sealed.Load() {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
+	return m.inner.AppendValues(dst)
+}
+
 func (m *SafeSortedMap[K, V]) Range(f func(key K, value V) bool) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+	if !m.sealed.Load() {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
 	m.inner.Range(f)
 }
+
+func (m *SafeSortedMap[K, V]) RangeFrom(from K, f func(key K, value V) bool) {
+	if !m.sealed.Load() {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
+	m.inner.RangeFrom(from, f)
+}
+
+func (m *SafeSortedMap[K, V]) Page(offset, limit int) ([]utils.Entry[K, V], int) {
+	if !m.sealed.Load() {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
+	return m.inner.Page(offset, limit)
+}
+
+func (m *SafeSortedMap[K, V]) PageAfter(key K, limit int) ([]utils.Entry[K, V], bool) {
+	if !m.sealed.Load() {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
+	return m.inner.PageAfter(key, limit)
+}
+
+func (m *SafeSortedMap[K, V]) ReverseRange(f func(key K, value V) bool) {
+	if !m.sealed.Load() {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
+	m.inner.ReverseRange(f)
+}
+
+// Seal makes the map read-only. Mutations after Seal panic with
+// utils.ErrSealed, and reads stop taking the lock.
+func (m *SafeSortedMap[K, V]) Seal() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.inner.Seal()
+	m.sealed.Store(true)
+}
+
+// IsSealed reports whether Seal has been called.
+func (m *SafeSortedMap[K, V]) IsSealed() bool {
+	return m.sealed.Load()
+}
+
+func (m *SafeSortedMap[K, V]) Checkpoint() SnapshotID {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.inner.Checkpoint()
+}
+
+func (m *SafeSortedMap[K, V]) Rollback(id SnapshotID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.inner.Rollback(id)
+}
+
+func (m *SafeSortedMap[K, V]) Release(id SnapshotID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.inner.Release(id)
+}
+
+// LockID returns the map's stable identifier, used by dsgo.Atomic to lock
+// structures in a canonical order.
+func (m *SafeSortedMap[K, V]) LockID() uint64 {
+	return utils.LazyID(&m.id)
+}
+
+// Lock takes the map's write lock. It exists for dsgo.Atomic; prefer the
+// map's own methods, which lock for you.
+func (m *SafeSortedMap[K, V]) Lock() {
+	m.mu.Lock()
+}
+
+// Unlock releases the lock taken by Lock.
+func (m *SafeSortedMap[K, V]) Unlock() {
+	m.mu.Unlock()
+}
+
+// InTx returns the underlying SortedMap for use inside a dsgo.Atomic
+// callback that holds this map's lock. It panics with utils.ErrNotInTx
+// otherwise. The returned map must not be used after the callback returns.
+func (m *SafeSortedMap[K, V]) InTx(tx interface{ Holds(any) bool }) *SortedMap[K, V] {
+	if !tx.Holds(m) {
+		panic(utils.ErrNotInTx)
+	}
+	return m.inner
+}
@@ -1,8 +1,12 @@
 package maps
 
 import (
+	"slices"
+	"strings"
 	"sync"
 	"testing"
+
+	"dsgo/utils"
 )
 
 func TestNewSortedMap(t *testing.T) {
@@ -166,6 +170,193 @@ func TestSortedMap_Ordering(t *testing.T) {
 	}
 }
 
+func TestSortedMap_InsertMiddleKeepsIndex(t *testing.T) {
+	m := NewSortedMap[int, string]()
+	m.Set(1, "one")
+	m.Set(3, "three")
+	m.Set(2, "two")
+
+	key, val, exists := m.Next(2)
+	if !exists || key != 3 || val != "three" {
+		t.Errorf("Next(2) = %v, %v, %v; want 3, 'three', true", key, val, exists)
+	}
+	if val, exists := m.Get(3); !exists || val != "three" {
+		t.Errorf("Get(3) = %v, %v; want 'three', true", val, exists)
+	}
+}
+
+func TestSortedMapFunc_CustomOrdering(t *testing.T) {
+	m := NewSortedMapFunc[string, int](func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	m.Set("banana", 1)
+	m.Set("Apple", 2)
+	m.Set("cherry", 3)
+
+	keys := m.Keys()
+	expectedKeys := []string{"Apple", "banana", "cherry"}
+	for i, k := range keys {
+		if k != expectedKeys[i] {
+			t.Errorf("Keys()[%d] = %v; want %v", i, k, expectedKeys[i])
+		}
+	}
+}
+
+func TestSortedMapDesc(t *testing.T) {
+	m := NewSortedMapDesc[int, string]()
+	m.Set(1, "one")
+	m.Set(3, "three")
+	m.Set(2, "two")
+
+	keys := m.Keys()
+	expectedKeys := []int{3, 2, 1}
+	for i, k := range keys {
+		if k != expectedKeys[i] {
+			t.Errorf("Keys()[%d] = %v; want %v", i, k, expectedKeys[i])
+		}
+	}
+
+	key, _, exists := m.Next(3)
+	if !exists || key != 2 {
+		t.Errorf("Next(3) = %v, %v; want 2, true", key, exists)
+	}
+}
+
+func TestSortedMap_ReverseRange(t *testing.T) {
+	m := NewSortedMap[int, string]()
+	m.Set(2, "two")
+	m.Set(1, "one")
+	m.Set(3, "three")
+
+	var got []int
+	m.ReverseRange(func(key int, value string) bool {
+		got = append(got, key)
+		return true
+	})
+	expectedKeys := []int{3, 2, 1}
+	if len(got) != len(expectedKeys) {
+		t.Fatalf("ReverseRange visited %d items; want %d", len(got), len(expectedKeys))
+	}
+	for i, k := range got {
+		if k != expectedKeys[i] {
+			t.Errorf("ReverseRange key %d = %v; want %v", i, k, expectedKeys[i])
+		}
+	}
+
+	count := 0
+	m.ReverseRange(func(key int, value string) bool {
+		count++
+		return count < 2
+	})
+	if count != 2 {
+		t.Errorf("ReverseRange with early exit visited %d items; want 2", count)
+	}
+}
+
+func TestSafeSortedMapFunc(t *testing.T) {
+	m := NewSafeSortedMapFunc[int, string](utils.Reversed(utils.Natural[int]()))
+	m.Set(1, "one")
+	m.Set(2, "two")
+
+	var got []int
+	m.ReverseRange(func(key int, value string) bool {
+		got = append(got, key)
+		return true
+	})
+	if len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Errorf("ReverseRange keys = %v; want [1 2]", got)
+	}
+}
+
+func TestSortedMap_SearchAndBisect(t *testing.T) {
+	m := NewSortedMap[int, string]()
+	m.Set(10, "ten")
+	m.Set(20, "twenty")
+	m.Set(30, "thirty")
+
+	if pos, found := m.SearchKeys(20); !found || pos != 1 {
+		t.Errorf("SearchKeys(20) = %d, %v; want 1, true", pos, found)
+	}
+	if pos, found := m.SearchKeys(25); found || pos != 2 {
+		t.Errorf("SearchKeys(25) = %d, %v; want 2, false", pos, found)
+	}
+	if got := m.BisectLeft(20); got != 1 {
+		t.Errorf("BisectLeft(20) = %d; want 1", got)
+	}
+	if got := m.BisectRight(20); got != 2 {
+		t.Errorf("BisectRight(20) = %d; want 2", got)
+	}
+	if got := m.BisectRight(35); got != 3 {
+		t.Errorf("BisectRight(35) = %d; want 3", got)
+	}
+
+	if key, val, ok := m.At(m.BisectLeft(15)); !ok || key != 20 || val != "twenty" {
+		t.Errorf("At(BisectLeft(15)) = %v, %v, %v; want 20, 'twenty', true", key, val, ok)
+	}
+	if _, _, ok := m.At(3); ok {
+		t.Error("At(3) should be out of range")
+	}
+}
+
+func TestSortedMap_DeleteFunc(t *testing.T) {
+	m := NewSortedMap[int, string]()
+	for i := 1; i <= 10; i++ {
+		m.Set(i, "v")
+	}
+
+	removed := m.DeleteFunc(func(key int, value string) bool {
+		return key > 3 && key < 8
+	})
+	if removed != 4 {
+		t.Errorf("DeleteFunc removed %d; want 4", removed)
+	}
+	if m.Len() != 6 {
+		t.Errorf("Len() = %d; want 6", m.Len())
+	}
+	if key, _, ok := m.Next(3); !ok || key != 8 {
+		t.Errorf("Next(3) = %v, %v; want 8, true", key, ok)
+	}
+
+	sm := NewSafeSortedMap[int, string]()
+	sm.Set(1, "a")
+	sm.Set(2, "b")
+	if removed := sm.DeleteFunc(func(key int, value string) bool { return value == "a" }); removed != 1 {
+		t.Errorf("SafeSortedMap.DeleteFunc removed %d; want 1", removed)
+	}
+}
+
+func TestSortedMap_Page(t *testing.T) {
+	m := NewSortedMap[int, string]()
+	for _, k := range []int{50, 10, 40, 20, 30} {
+		m.Set(k, "v")
+	}
+
+	entries, next := m.Page(1, 3)
+	if len(entries) != 3 || entries[0].Key != 20 || entries[2].Key != 40 || next != 4 {
+		t.Errorf("Page(1, 3) = %v, %d", entries, next)
+	}
+
+	entries, more := m.PageAfter(20, 2)
+	if len(entries) != 2 || entries[0].Key != 30 || entries[1].Key != 40 || !more {
+		t.Errorf("PageAfter(20, 2) = %v, %v", entries, more)
+	}
+
+	m.Delete(10)
+	entries, more = m.PageAfter(40, 2)
+	if len(entries) != 1 || entries[0].Key != 50 || more {
+		t.Errorf("PageAfter(40, 2) = %v, %v", entries, more)
+	}
+
+	sm := NewSafeSortedMap[int, string]()
+	sm.Set(1, "one")
+	if entries, next := sm.Page(0, 5); len(entries) != 1 || next != -1 {
+		t.Errorf("SafeSortedMap.Page(0, 5) = %v, %d", entries, next)
+	}
+	if entries, more := sm.PageAfter(1, 5); len(entries) != 0 || more {
+		t.Errorf("SafeSortedMap.PageAfter(1, 5) = %v, %v", entries, more)
+	}
+}
+
 // SafeSortedMap tests
 
 func TestSafeSortedMap_BasicOperations(t *testing.T) {
@@ -309,3 +500,209 @@ func TestSafeSortedMap_Concurrent(t *testing.T) {
 		return true
 	})
 }
+
+func TestSortedMapSeal(t *testing.T) {
+	m := NewSortedMap[int, string]()
+	m.Set(1, "one")
+	m.Seal()
+	if !m.IsSealed() {
+		t.Error("IsSealed should be true after Seal")
+	}
+
+	sm := NewSafeSortedMap[int, string]()
+	sm.Set(1, "one")
+	sm.Seal()
+	if val, ok := sm.Get(1); !ok || val != "one" {
+		t.Error("Reads should keep working on a sealed map")
+	}
+
+	for name, mutate := range map[string]func(){
+		"SortedMap.Set":        func() { m.Set(2, "two") },
+		"SortedMap.Delete":     func() { m.Delete(1) },
+		"SafeSortedMap.Set":    func() { sm.Set(2, "two") },
+		"SafeSortedMap.Delete": func() { sm.Delete(1) },
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r != utils.ErrSealed {
+					t.Errorf("%s on sealed map should panic with ErrSealed, got %v", name, r)
+				}
+			}()
+			mutate()
+		}()
+	}
+}
+
+func TestSortedMapAppendKeys(t *testing.T) {
+	m := NewSafeSortedMap[int, string]()
+	m.Set(3, "c")
+	m.Set(1, "a")
+	buf := make([]int, 0, 8)
+	if got := m.AppendKeys(buf[:0]); len(got) != 2 || got[0] != 1 || got[1] != 3 {
+		t.Errorf("AppendKeys() = %v, want [1 3]", got)
+	}
+	if got := m.AppendValues(nil); strings.Join(got, "") != "ac" {
+		t.Errorf("AppendValues() = %v, want [a c]", got)
+	}
+	if allocs := testing.AllocsPerRun(100, func() { buf = m.AppendKeys(buf[:0]) }); allocs != 0 {
+		t.Errorf("AppendKeys() into a large enough buffer allocated %v times", allocs)
+	}
+
+	c := NewConcurrentSortedMap[int, string]()
+	c.Set(2, "b")
+	c.Set(1, "a")
+	if got := c.AppendKeys([]int{0}); len(got) != 3 || got[1] != 1 || got[2] != 2 {
+		t.Errorf("ConcurrentSortedMap.AppendKeys() = %v, want [0 1 2]", got)
+	}
+}
+
+func TestSortedMap_Remove(t *testing.T) {
+	m := NewSortedMap[int, string]()
+	sm := NewSafeSortedMap[int, string]()
+	for _, k := range []int{3, 1, 2} {
+		m.Set(k, strings.Repeat("x", k))
+		sm.Set(k, strings.Repeat("x", k))
+	}
+
+	if value, ok := m.Remove(2); !ok || value != "xx" {
+		t.Errorf("SortedMap.Remove(2) = %q, %v, want \"xx\", true", value, ok)
+	}
+	if value, ok := sm.Remove(2); !ok || value != "xx" {
+		t.Errorf("SafeSortedMap.Remove(2) = %q, %v, want \"xx\", true", value, ok)
+	}
+	if _, ok := m.Remove(2); ok {
+		t.Error("SortedMap.Remove(2) again reported the key as present")
+	}
+	if pos, found := m.SearchKeys(3); !found || pos != 1 {
+		t.Errorf("SearchKeys(3) after Remove = %d, %v, want 1, true", pos, found)
+	}
+	if !m.TryDelete(1) || m.TryDelete(1) {
+		t.Error("SortedMap.TryDelete(1) should succeed once and then report false")
+	}
+	if !sm.TryDelete(1) || sm.TryDelete(1) {
+		t.Error("SafeSortedMap.TryDelete(1) should succeed once and then report false")
+	}
+}
+
+func TestSortedMap_FloorCeiling(t *testing.T) {
+	m := NewSortedMap[int, string]()
+	desc := NewSortedMapDesc[int, string]()
+	for _, k := range []int{10, 20, 30} {
+		m.Set(k, strings.Repeat("x", k/10))
+		desc.Set(k, strings.Repeat("x", k/10))
+	}
+
+	tests := []struct {
+		key         int
+		floor, ceil int
+		hasF, hasC  bool
+	}{
+		{key: 5, ceil: 10, hasC: true},
+		{key: 20, floor: 20, ceil: 20, hasF: true, hasC: true},
+		{key: 25, floor: 20, ceil: 30, hasF: true, hasC: true},
+		{key: 35, floor: 30, hasF: true},
+	}
+	for _, tt := range tests {
+		key, value, ok := m.Floor(tt.key)
+		if ok != tt.hasF || (ok && (key != tt.floor || len(value) != key/10)) {
+			t.Errorf("Floor(%d) = %d, %q, %v, want %d, %v", tt.key, key, value, ok, tt.floor, tt.hasF)
+		}
+		key, value, ok = m.Ceiling(tt.key)
+		if ok != tt.hasC || (ok && (key != tt.ceil || len(value) != key/10)) {
+			t.Errorf("Ceiling(%d) = %d, %q, %v, want %d, %v", tt.key, key, value, ok, tt.ceil, tt.hasC)
+		}
+	}
+
+	// In a descending map the neighbours follow the map's own order
+	if key, _, ok := desc.Floor(25); !ok || key != 30 {
+		t.Errorf("descending Floor(25) = %d, %v, want 30, true", key, ok)
+	}
+	if key, _, ok := desc.Ceiling(25); !ok || key != 20 {
+		t.Errorf("descending Ceiling(25) = %d, %v, want 20, true", key, ok)
+	}
+}
+
+func TestSortedMap_Shrinks(t *testing.T) {
+	m := NewSortedMap[int, int]()
+	for i := 0; i < 1000; i++ {
+		m.Set(i, i)
+	}
+	peak := cap(m.keys)
+	m.DeleteFunc(func(key, _ int) bool { return key >= 10 })
+	if cap(m.keys) >= peak/4 || cap(m.values) >= peak/4 {
+		t.Errorf("cap after DeleteFunc = %d, %d, want well below the peak of %d", cap(m.keys), cap(m.values), peak)
+	}
+	m.Compact()
+	if cap(m.keys) != 10 || cap(m.values) != 10 {
+		t.Errorf("cap after Compact() = %d, %d, want 10", cap(m.keys), cap(m.values))
+	}
+	if value, ok := m.Get(9); !ok || value != 9 {
+		t.Errorf("Get(9) after Compact() = %d, %v, want 9, true", value, ok)
+	}
+}
+
+func TestSortedMap_DeleteRange(t *testing.T) {
+	m := NewSortedMap[int, int]()
+	for i := 0; i < 10; i++ {
+		m.Set(i, i*i)
+	}
+	if n := m.DeleteRange(3, 7); n != 4 {
+		t.Errorf("DeleteRange(3, 7) = %d, want 4", n)
+	}
+	if got := m.Keys(); !slices.Equal(got, []int{0, 1, 2, 7, 8, 9}) {
+		t.Errorf("Keys() = %v, want [0 1 2 7 8 9]", got)
+	}
+	if value, ok := m.Get(8); !ok || value != 64 {
+		t.Errorf("Get(8) = %d, %v, want 64, true", value, ok)
+	}
+	if n := m.DeleteRange(5, 5); n != 0 {
+		t.Errorf("DeleteRange(5, 5) = %d, want 0", n)
+	}
+	if n := m.DeleteRange(9, 2); n != 0 {
+		t.Errorf("DeleteRange(9, 2) = %d, want 0", n)
+	}
+
+	desc := NewSortedMapDesc[int, int]()
+	for i := 0; i < 5; i++ {
+		desc.Set(i, i)
+	}
+	if n := desc.DeleteRange(3, 1); n != 2 {
+		t.Errorf("descending DeleteRange(3, 1) = %d, want 2", n)
+	}
+	if got := desc.Keys(); !slices.Equal(got, []int{4, 1, 0}) {
+		t.Errorf("descending Keys() = %v, want [4 1 0]", got)
+	}
+}
+
+func TestSortedMap_SubMap(t *testing.T) {
+	m := NewSortedMap[string, int]()
+	for i, key := range []string{"a", "b", "c", "d"} {
+		m.Set(key, i)
+	}
+	sub := m.SubMap("b", "d")
+	if got := sub.Keys(); !slices.Equal(got, []string{"b", "c"}) {
+		t.Errorf("SubMap(b, d).Keys() = %v, want [b c]", got)
+	}
+	sub.Set("b", 100)
+	sub.Set("z", 1)
+	if value, _ := m.Get("b"); value != 1 {
+		t.Errorf("Get(b) on the source = %d, want 1", value)
+	}
+	if _, ok := m.Get("z"); ok {
+		t.Error("key set on the SubMap showed in the source")
+	}
+	if empty := m.SubMap("x", "y"); empty.Len() != 0 {
+		t.Errorf("SubMap(x, y).Len() = %d, want 0", empty.Len())
+	}
+
+	safe := NewSafeSortedMap[int, int]()
+	for i := 0; i < 5; i++ {
+		safe.Set(i, i)
+	}
+	if got := safe.SubMap(1, 3).Keys(); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("SafeSortedMap SubMap(1, 3).Keys() = %v, want [1 2]", got)
+	}
+	if n := safe.DeleteRange(0, 4); n != 4 || safe.Len() != 1 {
+		t.Errorf("SafeSortedMap DeleteRange(0, 4) = %d, Len() = %d, want 4, 1", n, safe.Len())
+	}
+}
@@ -0,0 +1,170 @@
+package maps
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Point is a bucket of a TimeSeriesMap: the bucket's start time and value.
+type Point[V any] struct {
+	Time  time.Time
+	Value V
+}
+
+type TimeSeriesOptions[V any] struct {
+	// Resolution is the bucket width; points are grouped by
+	// t.Truncate(Resolution). Zero keeps every distinct timestamp.
+	Resolution time.Duration
+	// Retention evicts buckets older than the newest point minus Retention.
+	// Zero keeps everything.
+	Retention time.Duration
+	// Merge folds a new point into an existing bucket. Nil keeps the newest
+	// value.
+	Merge func(existing, incoming V) V
+}
+
+// TimeSeriesMap stores values in time buckets backed by a SortedMap keyed by
+// bucket start. Retention is measured from the newest point appended rather
+// than the wall clock, so replaying old data behaves the same as live data.
+type TimeSeriesMap[V any] struct {
+	buckets    *SortedMap[int64, V]
+	opts       TimeSeriesOptions[V]
+	newest     int64
+	threadSafe bool
+	mu         sync.RWMutex
+}
+
+func NewTimeSeriesMap[V any](opts TimeSeriesOptions[V], threadSafe ...bool) *TimeSeriesMap[V] {
+	isThreadSafe := true
+	if len(threadSafe) > 0 {
+		isThreadSafe = threadSafe[0]
+	}
+	return &TimeSeriesMap[V]{
+		buckets:    NewSortedMap[int64, V](false),
+		opts:       opts,
+		threadSafe: isThreadSafe,
+	}
+}
+
+func (m *TimeSeriesMap[V]) bucket(t time.Time) int64 {
+	if m.opts.Resolution > 0 {
+		t = t.Truncate(m.opts.Resolution)
+	}
+	return t.UnixNano()
+}
+
+// AppendPoint adds value at t, merging it into t's bucket if one exists. It
+// returns false if t is already outside the retention window.
+func (m *TimeSeriesMap[V]) AppendPoint(t time.Time, value V) bool {
+	if m.threadSafe {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+	}
+	key := m.bucket(t)
+	if m.buckets.Len() > 0 && key < m.cutoff() {
+		return false
+	}
+	if existing, ok := m.buckets.Get(key); ok && m.opts.Merge != nil {
+		value = m.opts.Merge(existing, value)
+	}
+	m.buckets.Set(key, value)
+	if key > m.newest || m.buckets.Len() == 1 {
+		m.newest = key
+		m.evict(m.cutoff())
+	}
+	return true
+}
+
+// cutoff returns the oldest bucket start still inside the retention window.
+func (m *TimeSeriesMap[V]) cutoff() int64 {
+	if m.opts.Retention <= 0 {
+		return math.MinInt64
+	}
+	return m.newest - int64(m.opts.Retention)
+}
+
+// evict removes every bucket starting before cutoff.
+func (m *TimeSeriesMap[V]) evict(cutoff int64) int {
+	if m.buckets.BisectLeft(cutoff) == 0 {
+		return 0
+	}
+	return m.buckets.DeleteFunc(func(key int64, _ V) bool {
+		return key < cutoff
+	})
+}
+
+// EvictBefore removes every bucket starting before t and returns how many
+// were removed.
+func (m *TimeSeriesMap[V]) EvictBefore(t time.Time) int {
+	if m.threadSafe {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+	}
+	return m.evict(t.UnixNano())
+}
+
+// QueryRange returns the buckets starting in [from, to), oldest first.
+func (m *TimeSeriesMap[V]) QueryRange(from, to time.Time) []Point[V] {
+	if m.threadSafe {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
+	var points []Point[V]
+	m.queryRange(from, to, func(p Point[V]) {
+		points = append(points, p)
+	})
+	return points
+}
+
+func (m *TimeSeriesMap[V]) queryRange(from, to time.Time, f func(Point[V])) {
+	end := to.UnixNano()
+	m.buckets.RangeFrom(from.UnixNano(), func(key int64, value V) bool {
+		if key >= end {
+			return false
+		}
+		f(Point[V]{Time: time.Unix(0, key), Value: value})
+		return true
+	})
+}
+
+// Downsample regroups the buckets in [from, to) into windows of width step
+// and reduces each non-empty window with reduce, e.g. to average or take the
+// max. Windows are aligned to from.
+func (m *TimeSeriesMap[V]) Downsample(from, to time.Time, step time.Duration, reduce func(values []V) V) []Point[V] {
+	if step <= 0 {
+		return m.QueryRange(from, to)
+	}
+	if m.threadSafe {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
+	var points []Point[V]
+	var window []V
+	var windowStart time.Time
+	flush := func() {
+		if len(window) > 0 {
+			points = append(points, Point[V]{Time: windowStart, Value: reduce(window)})
+			window = window[:0]
+		}
+	}
+	m.queryRange(from, to, func(p Point[V]) {
+		start := from.Add(p.Time.Sub(from) / step * step)
+		if len(window) == 0 || !start.Equal(windowStart) {
+			flush()
+			windowStart = start
+		}
+		window = append(window, p.Value)
+	})
+	flush()
+	return points
+}
+
+// Len returns the number of buckets.
+func (m *TimeSeriesMap[V]) Len() int {
+	if m.threadSafe {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
+	return m.buckets.Len()
+}
@@ -0,0 +1,85 @@
+package maps
+
+import (
+	"testing"
+	"time"
+)
+
+var tsEpoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func at(seconds int) time.Time {
+	return tsEpoch.Add(time.Duration(seconds) * time.Second)
+}
+
+func TestTimeSeriesMapBuckets(t *testing.T) {
+	m := NewTimeSeriesMap(TimeSeriesOptions[int]{
+		Resolution: 10 * time.Second,
+		Merge:      func(a, b int) int { return a + b },
+	})
+	m.AppendPoint(at(1), 1)
+	m.AppendPoint(at(9), 2)
+	m.AppendPoint(at(10), 5)
+	m.AppendPoint(at(35), 7)
+
+	points := m.QueryRange(at(0), at(30))
+	if len(points) != 2 {
+		t.Fatalf("QueryRange() = %v; want 2 buckets", points)
+	}
+	if !points[0].Time.Equal(at(0)) || points[0].Value != 3 {
+		t.Errorf("first bucket = %v; want {%v 3}", points[0], at(0))
+	}
+	if !points[1].Time.Equal(at(10)) || points[1].Value != 5 {
+		t.Errorf("second bucket = %v; want {%v 5}", points[1], at(10))
+	}
+	if got := m.QueryRange(at(30), at(40)); len(got) != 1 || got[0].Value != 7 {
+		t.Errorf("QueryRange(30, 40) = %v; want the 30s bucket", got)
+	}
+}
+
+func TestTimeSeriesMapRetention(t *testing.T) {
+	m := NewTimeSeriesMap(TimeSeriesOptions[int]{
+		Resolution: time.Second,
+		Retention:  time.Minute,
+	}, false)
+	for s := 0; s < 120; s++ {
+		m.AppendPoint(at(s), s)
+	}
+	if m.Len() != 61 {
+		t.Errorf("Len() = %d; want 61 buckets inside the window", m.Len())
+	}
+	if m.AppendPoint(at(10), 10) {
+		t.Error("AppendPoint() outside the retention window should be rejected")
+	}
+	if got := m.QueryRange(at(0), at(59)); len(got) != 0 {
+		t.Errorf("evicted buckets still returned: %v", got)
+	}
+
+	if removed := m.EvictBefore(at(100)); removed != 41 {
+		t.Errorf("EvictBefore() = %d; want 41", removed)
+	}
+}
+
+func TestTimeSeriesMapDownsample(t *testing.T) {
+	m := NewTimeSeriesMap(TimeSeriesOptions[float64]{Resolution: time.Second})
+	for s := 0; s < 10; s++ {
+		m.AppendPoint(at(s), float64(s))
+	}
+	mean := func(values []float64) float64 {
+		sum := 0.0
+		for _, v := range values {
+			sum += v
+		}
+		return sum / float64(len(values))
+	}
+
+	points := m.Downsample(at(0), at(10), 5*time.Second, mean)
+	if len(points) != 2 {
+		t.Fatalf("Downsample() = %v; want 2 windows", points)
+	}
+	if points[0].Value != 2 || points[1].Value != 7 {
+		t.Errorf("Downsample() means = %v, %v; want 2, 7", points[0].Value, points[1].Value)
+	}
+	if !points[1].Time.Equal(at(5)) {
+		t.Errorf("second window starts at %v; want %v", points[1].Time, at(5))
+	}
+}
@@ -0,0 +1,202 @@
+package maps
+
+import (
+	"sort"
+	"sync"
+
+	"dsgo/utils"
+)
+
+type versionedValue[V any] struct {
+	version uint64
+	value   V
+	deleted bool
+}
+
+// VersionedSortedMap is a SortedMap that keeps every value a key has held.
+// Each Set or Delete bumps the map's version and records the change under
+// it, so GetAt can read the map as it was at any earlier version. This is a
+// lightweight MVCC for config stores and similar: readers can pin a version
+// while writers move on. History grows until CompactBefore discards it.
+type VersionedSortedMap[K utils.Ordered, V any] struct {
+	history    *SortedMap[K, []versionedValue[V]]
+	version    uint64
+	floor      uint64
+	live       int
+	threadSafe bool
+	mu         sync.RWMutex
+}
+
+func NewVersionedSortedMap[K utils.Ordered, V any](threadSafe ...bool) *VersionedSortedMap[K, V] {
+	isThreadSafe := true
+	if len(threadSafe) > 0 {
+		isThreadSafe = threadSafe[0]
+	}
+	return &VersionedSortedMap[K, V]{
+		history:    NewSortedMap[K, []versionedValue[V]](),
+		threadSafe: isThreadSafe,
+	}
+}
+
+// Version returns the version of the latest change, or zero if there has
+// been none.
+func (m *VersionedSortedMap[K, V]) Version() uint64 {
+	if m.threadSafe {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
+	return m.version
+}
+
+// Set stores value under key and returns the new version.
+func (m *VersionedSortedMap[K, V]) Set(key K, value V) uint64 {
+	if m.threadSafe {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+	}
+	if _, ok := m.latest(key); !ok {
+		m.live++
+	}
+	return m.record(key, versionedValue[V]{value: value})
+}
+
+// Delete removes key as of a new version, which it returns. Earlier
+// versions still see the old value. Deleting a missing key records nothing
+// and returns the current version.
+func (m *VersionedSortedMap[K, V]) Delete(key K) uint64 {
+	if m.threadSafe {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+	}
+	if _, ok := m.latest(key); !ok {
+		return m.version
+	}
+	m.live--
+	return m.record(key, versionedValue[V]{deleted: true})
+}
+
+func (m *VersionedSortedMap[K, V]) record(key K, v versionedValue[V]) uint64 {
+	m.version++
+	v.version = m.version
+	versions, _ := m.history.Get(key)
+	m.history.Set(key, append(versions, v))
+	return m.version
+}
+
+// Get returns the current value of key.
+func (m *VersionedSortedMap[K, V]) Get(key K) (V, bool) {
+	if m.threadSafe {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
+	return m.latest(key)
+}
+
+func (m *VersionedSortedMap[K, V]) latest(key K) (V, bool) {
+	versions, _ := m.history.Get(key)
+	return visibleAt(versions, m.version)
+}
+
+// GetAt returns the value key had at version. It returns false if key was
+// absent then, or if version is older than the last CompactBefore point and
+// so no longer known.
+func (m *VersionedSortedMap[K, V]) GetAt(key K, version uint64) (V, bool) {
+	if m.threadSafe {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
+	if version < m.floor {
+		var zero V
+		return zero, false
+	}
+	versions, _ := m.history.Get(key)
+	return visibleAt(versions, version)
+}
+
+// visibleAt returns the newest value recorded at or before version.
+func visibleAt[V any](versions []versionedValue[V], version uint64) (V, bool) {
+	i := sort.Search(len(versions), func(i int) bool {
+		return versions[i].version > version
+	})
+	if i == 0 || versions[i-1].deleted {
+		var zero V
+		return zero, false
+	}
+	return versions[i-1].value, true
+}
+
+// RangeAt calls f in key order for every key present at version, with the
+// value it had then, until f returns false.
+func (m *VersionedSortedMap[K, V]) RangeAt(version uint64, f func(key K, value V) bool) {
+	if m.threadSafe {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
+	if version < m.floor {
+		return
+	}
+	m.history.Range(func(key K, versions []versionedValue[V]) bool {
+		if value, ok := visibleAt(versions, version); ok {
+			return f(key, value)
+		}
+		return true
+	})
+}
+
+// Range calls f in key order for every current key until f returns false.
+func (m *VersionedSortedMap[K, V]) Range(f func(key K, value V) bool) {
+	m.RangeAt(m.Version(), f)
+}
+
+// CompactBefore discards history that only versions older than version can
+// see. Reads at version and later are unaffected; reads at older versions
+// report nothing afterwards. Keys deleted at or before version are dropped
+// entirely. It returns the number of history entries removed.
+func (m *VersionedSortedMap[K, V]) CompactBefore(version uint64) int {
+	if m.threadSafe {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+	}
+	version = min(version, m.version)
+	if version <= m.floor {
+		return 0
+	}
+	m.floor = version
+
+	removed := 0
+	var emptied []K
+	m.history.Range(func(key K, versions []versionedValue[V]) bool {
+		// Keep the newest entry visible at version and everything after it
+		i := sort.Search(len(versions), func(i int) bool {
+			return versions[i].version > version
+		})
+		keep := max(i-1, 0)
+		if i > 0 && versions[i-1].deleted {
+			keep = i
+		}
+		if keep == 0 {
+			return true
+		}
+		removed += keep
+		if keep == len(versions) {
+			emptied = append(emptied, key)
+			return true
+		}
+		// Copy so the discarded prefix can be collected
+		m.history.Set(key, append([]versionedValue[V](nil), versions[keep:]...))
+		return true
+	})
+	for _, key := range emptied {
+		m.history.Delete(key)
+	}
+	return removed
+}
+
+// Len returns the number of current keys.
+func (m *VersionedSortedMap[K, V]) Len() int {
+	if m.threadSafe {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
+	return m.live
+}
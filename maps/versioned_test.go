@@ -0,0 +1,86 @@
+package maps
+
+import "testing"
+
+func TestVersionedSortedMap(t *testing.T) {
+	m := NewVersionedSortedMap[string, int]()
+	v1 := m.Set("a", 1)
+	v2 := m.Set("b", 2)
+	v3 := m.Set("a", 10)
+	v4 := m.Delete("b")
+	if v4 != 4 || m.Version() != 4 {
+		t.Fatalf("versions = %d, %d, %d, %d; want 1..4", v1, v2, v3, v4)
+	}
+	if got := m.Delete("missing"); got != 4 {
+		t.Errorf("Delete(missing) = %d; want 4", got)
+	}
+
+	tests := []struct {
+		key     string
+		version uint64
+		want    int
+		ok      bool
+	}{
+		{"a", 0, 0, false},
+		{"a", v1, 1, true},
+		{"a", v2, 1, true},
+		{"a", v3, 10, true},
+		{"b", v1, 0, false},
+		{"b", v3, 2, true},
+		{"b", v4, 0, false},
+	}
+	for _, tt := range tests {
+		if got, ok := m.GetAt(tt.key, tt.version); got != tt.want || ok != tt.ok {
+			t.Errorf("GetAt(%q, %d) = %d, %v; want %d, %v", tt.key, tt.version, got, ok, tt.want, tt.ok)
+		}
+	}
+	if got, ok := m.Get("a"); !ok || got != 10 {
+		t.Errorf("Get(a) = %d, %v; want 10, true", got, ok)
+	}
+	if m.Len() != 1 {
+		t.Errorf("Len() = %d; want 1", m.Len())
+	}
+
+	var keys []string
+	m.RangeAt(v2, func(key string, value int) bool {
+		keys = append(keys, key)
+		return true
+	})
+	if len(keys) != 2 || keys[0] != "a" || keys[1] != "b" {
+		t.Errorf("RangeAt(%d) keys = %v; want [a b]", v2, keys)
+	}
+}
+
+func TestVersionedSortedMapCompactBefore(t *testing.T) {
+	m := NewVersionedSortedMap[string, int](false)
+	m.Set("a", 1) // 1
+	m.Set("a", 2) // 2
+	m.Set("b", 1) // 3
+	m.Delete("b") // 4
+	m.Set("a", 3) // 5
+	m.Set("c", 1) // 6
+
+	// At version 4: a's entry from version 2 is still visible, the one from
+	// version 1 is not; b is deleted, so its whole history goes
+	if removed := m.CompactBefore(4); removed != 3 {
+		t.Errorf("CompactBefore(4) removed %d entries; want 3", removed)
+	}
+	if got, ok := m.GetAt("a", 4); !ok || got != 2 {
+		t.Errorf("GetAt(a, 4) = %d, %v; want 2, true", got, ok)
+	}
+	if got, ok := m.GetAt("a", 5); !ok || got != 3 {
+		t.Errorf("GetAt(a, 5) = %d, %v; want 3, true", got, ok)
+	}
+	if _, ok := m.GetAt("a", 1); ok {
+		t.Error("GetAt(a, 1) should report nothing after compaction")
+	}
+	if _, ok := m.GetAt("b", 4); ok {
+		t.Error("GetAt(b, 4) should miss")
+	}
+	if m.CompactBefore(2) != 0 {
+		t.Error("compacting below the current floor should be a no-op")
+	}
+	if m.Len() != 2 {
+		t.Errorf("Len() = %d; want 2", m.Len())
+	}
+}
@@ -0,0 +1,130 @@
+package maps
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"dsgo/utils"
+)
+
+type EventType int
+
+const (
+	EventSet EventType = iota
+	EventDelete
+)
+
+// Event describes a change to a watched key. OldValue is only meaningful
+// when HadOld is true, and NewValue only for EventSet.
+type Event[K any, V any] struct {
+	Type     EventType
+	Key      K
+	OldValue V
+	HadOld   bool
+	NewValue V
+}
+
+// WatchOptions controls how events are delivered to a Watcher.
+type WatchOptions struct {
+	// Buffer is the capacity of the event channel.
+	Buffer int
+	// Blocking makes writers wait for the watcher to receive each event
+	// instead of dropping events when the buffer is full. A slow blocking
+	// watcher stalls every writer on the map.
+	Blocking bool
+}
+
+// Watcher receives change events from a ConcurrentSortedMap on C until it
+// is cancelled, at which point C is closed.
+type Watcher[K utils.Ordered, V any] struct {
+	C <-chan Event[K, V]
+
+	ch       chan Event[K, V]
+	match    func(K) bool
+	blocking bool
+	done     chan struct{}
+	once     sync.Once
+	dropped  atomic.Int64
+	m        *ConcurrentSortedMap[K, V]
+}
+
+// Cancel stops delivery and closes C. It is safe to call more than once.
+func (w *Watcher[K, V]) Cancel() {
+	w.once.Do(func() {
+		// Release any writer blocked on delivery before taking the map lock
+		close(w.done)
+		w.m.mu.Lock()
+		defer w.m.mu.Unlock()
+		delete(w.m.watchers, w)
+		close(w.ch)
+	})
+}
+
+// Dropped returns the number of events discarded because the buffer was full.
+func (w *Watcher[K, V]) Dropped() int64 {
+	return w.dropped.Load()
+}
+
+func (w *Watcher[K, V]) deliver(ev Event[K, V]) {
+	if w.blocking {
+		select {
+		case w.ch <- ev:
+		case <-w.done:
+		}
+		return
+	}
+	select {
+	case w.ch <- ev:
+	default:
+		w.dropped.Add(1)
+	}
+}
+
+// Watch returns a Watcher for changes to key.
+func (m *ConcurrentSortedMap[K, V]) Watch(key K, opts ...WatchOptions) *Watcher[K, V] {
+	return m.WatchFunc(func(k K) bool { return k == key }, opts...)
+}
+
+// WatchFunc returns a Watcher for changes to every key accepted by match.
+// Events are delivered while the writer lock is held, so they arrive in the
+// order the mutations were applied.
+func (m *ConcurrentSortedMap[K, V]) WatchFunc(match func(K) bool, opts ...WatchOptions) *Watcher[K, V] {
+	var opt WatchOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	ch := make(chan Event[K, V], opt.Buffer)
+	w := &Watcher[K, V]{
+		C:        ch,
+		ch:       ch,
+		match:    match,
+		blocking: opt.Blocking,
+		done:     make(chan struct{}),
+		m:        m,
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.watchers == nil {
+		m.watchers = make(map[*Watcher[K, V]]struct{})
+	}
+	m.watchers[w] = struct{}{}
+	return w
+}
+
+// WatchPrefix returns a Watcher for changes to every key starting with prefix.
+func WatchPrefix[V any](m *ConcurrentSortedMap[string, V], prefix string, opts ...WatchOptions) *Watcher[string, V] {
+	return m.WatchFunc(func(key string) bool {
+		return strings.HasPrefix(key, prefix)
+	}, opts...)
+}
+
+// notify delivers ev to the matching watchers. The caller must hold m.mu.
+func (m *ConcurrentSortedMap[K, V]) notify(ev Event[K, V]) {
+	for w := range m.watchers {
+		if w.match(ev.Key) {
+			w.deliver(ev)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package maps
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWatchKey(t *testing.T) {
+	m := NewConcurrentSortedMap[string, int]()
+	w := m.Watch("a", WatchOptions{Buffer: 10})
+
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("a", 3)
+	m.Delete("a")
+	m.Delete("missing")
+
+	want := []Event[string, int]{
+		{Type: EventSet, Key: "a", NewValue: 1},
+		{Type: EventSet, Key: "a", OldValue: 1, HadOld: true, NewValue: 3},
+		{Type: EventDelete, Key: "a", OldValue: 3, HadOld: true},
+	}
+	for i, expected := range want {
+		select {
+		case ev := <-w.C:
+			if ev != expected {
+				t.Errorf("event %d = %+v, want %+v", i, ev, expected)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for event %d", i)
+		}
+	}
+
+	w.Cancel()
+	w.Cancel()
+	if _, ok := <-w.C; ok {
+		t.Error("C should be closed after Cancel")
+	}
+
+	// Mutations after Cancel must not panic on the closed channel
+	m.Set("a", 4)
+}
+
+func TestWatchPrefix(t *testing.T) {
+	m := NewConcurrentSortedMap[string, string]()
+	w := WatchPrefix(m, "/config/", WatchOptions{Buffer: 10})
+	defer w.Cancel()
+
+	m.Set("/config/a", "1")
+	m.Set("/other/b", "2")
+	m.Set("/config/c", "3")
+	m.DeleteFunc(func(key, value string) bool { return true })
+
+	var keys []string
+	for i := 0; i < 4; i++ {
+		ev := <-w.C
+		keys = append(keys, ev.Key)
+	}
+	expected := []string{"/config/a", "/config/c", "/config/a", "/config/c"}
+	for i := range expected {
+		if keys[i] != expected[i] {
+			t.Errorf("event %d key = %s, want %s", i, keys[i], expected[i])
+		}
+	}
+	select {
+	case ev := <-w.C:
+		t.Errorf("unexpected event %+v", ev)
+	default:
+	}
+}
+
+func TestWatchDropsWhenFull(t *testing.T) {
+	m := NewConcurrentSortedMap[int, int]()
+	w := m.Watch(1, WatchOptions{Buffer: 1})
+	defer w.Cancel()
+
+	m.Set(1, 1)
+	m.Set(1, 2)
+	m.Set(1, 3)
+
+	if w.Dropped() != 2 {
+		t.Errorf("Dropped() = %d, want 2", w.Dropped())
+	}
+	if ev := <-w.C; ev.NewValue != 1 {
+		t.Errorf("first event value = %d, want 1", ev.NewValue)
+	}
+}
+
+func TestWatchBlocking(t *testing.T) {
+	m := NewConcurrentSortedMap[int, int]()
+	w := m.Watch(1, WatchOptions{Blocking: true})
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 5; i++ {
+			m.Set(1, i)
+		}
+		close(done)
+	}()
+
+	for i := 0; i < 5; i++ {
+		if ev := <-w.C; ev.NewValue != i {
+			t.Errorf("event %d value = %d, want %d", i, ev.NewValue, i)
+		}
+	}
+	<-done
+
+	// Cancel must release a writer blocked on delivery
+	blocked := make(chan struct{})
+	go func() {
+		m.Set(1, 99)
+		close(blocked)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	w.Cancel()
+	select {
+	case <-blocked:
+	case <-time.After(time.Second):
+		t.Fatal("writer stayed blocked after Cancel")
+	}
+}
@@ -0,0 +1,123 @@
+package queues
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"dsgo/heaps"
+)
+
+type delayed[T any] struct {
+	item     T
+	deadline time.Time
+	seq      uint64
+}
+
+// DelayQueue holds items until their deadline has passed. Items become
+// available in deadline order, and items with the same deadline in the order
+// they were pushed. It is always safe for concurrent use.
+type DelayQueue[T any] struct {
+	items *heaps.MinHeap[delayed[T]]
+	seq   uint64
+	// wake is signalled when the earliest deadline may have moved forward
+	wake chan struct{}
+	now  func() time.Time
+	mu   sync.Mutex
+}
+
+// NewDelayQueue creates an empty delay queue.
+func NewDelayQueue[T any]() *DelayQueue[T] {
+	return &DelayQueue[T]{
+		items: heaps.NewMinHeap(func(a, b delayed[T]) bool {
+			if a.deadline.Equal(b.deadline) {
+				return a.seq < b.seq
+			}
+			return a.deadline.Before(b.deadline)
+		}, false),
+		wake: make(chan struct{}, 1),
+		now:  time.Now,
+	}
+}
+
+// Push adds item, to become available at deadline.
+func (q *DelayQueue[T]) Push(item T, deadline time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.seq++
+	q.items.Push(delayed[T]{item: item, deadline: deadline, seq: q.seq})
+	if head, _ := q.items.Peek(); head.seq == q.seq {
+		q.signal()
+	}
+}
+
+// Poll removes and returns the earliest item if its deadline has passed,
+// without blocking.
+func (q *DelayQueue[T]) Poll() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	item, _, ok := q.pollLocked()
+	return item, ok
+}
+
+// Take removes and returns the earliest item, blocking until its deadline
+// has passed or ctx is done.
+func (q *DelayQueue[T]) Take(ctx context.Context) (T, error) {
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+	for {
+		q.mu.Lock()
+		item, wait, ok := q.pollLocked()
+		q.mu.Unlock()
+		if ok {
+			return item, nil
+		}
+
+		var expired <-chan time.Time
+		if wait > 0 {
+			timer.Reset(wait)
+			expired = timer.C
+		}
+		select {
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		case <-q.wake:
+		case <-expired:
+		}
+	}
+}
+
+// pollLocked pops the earliest item if it is due. Otherwise it returns how
+// long until it is, or zero if the queue is empty.
+func (q *DelayQueue[T]) pollLocked() (T, time.Duration, bool) {
+	head, ok := q.items.Peek()
+	if !ok {
+		var zero T
+		return zero, 0, false
+	}
+	if wait := head.deadline.Sub(q.now()); wait > 0 {
+		var zero T
+		return zero, wait, false
+	}
+	q.items.Pop()
+	if !q.items.IsEmpty() {
+		// Hand the next item on to another waiting Take
+		q.signal()
+	}
+	return head.item, 0, true
+}
+
+func (q *DelayQueue[T]) signal() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Len returns the number of items in the queue, due or not.
+func (q *DelayQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.items.Size()
+}
@@ -0,0 +1,63 @@
+package queues
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDelayQueueOrder(t *testing.T) {
+	q := NewDelayQueue[string]()
+	now := time.Unix(1000, 0)
+	q.now = func() time.Time { return now }
+
+	q.Push("late", now.Add(2*time.Second))
+	q.Push("early", now.Add(time.Second))
+	q.Push("also early", now.Add(time.Second))
+	if _, ok := q.Poll(); ok {
+		t.Fatal("Poll() returned an item before its deadline")
+	}
+
+	now = now.Add(3 * time.Second)
+	for _, want := range []string{"early", "also early", "late"} {
+		if got, ok := q.Poll(); !ok || got != want {
+			t.Errorf("Poll() = %q, %v, want %q, true", got, ok, want)
+		}
+	}
+	if q.Len() != 0 {
+		t.Errorf("Len() = %d, want 0", q.Len())
+	}
+}
+
+func TestDelayQueueTake(t *testing.T) {
+	q := NewDelayQueue[int]()
+	start := time.Now()
+	q.Push(2, start.Add(40*time.Millisecond))
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		// An earlier item pushed while Take is waiting must wake it
+		time.Sleep(5 * time.Millisecond)
+		q.Push(1, start.Add(10*time.Millisecond))
+	}()
+
+	for _, want := range []int{1, 2} {
+		got, err := q.Take(context.Background())
+		if err != nil || got != want {
+			t.Fatalf("Take() = %d, %v, want %d", got, err, want)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("Take() returned after %v, before the last deadline", elapsed)
+	}
+	wg.Wait()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := q.Take(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Take() on an empty queue error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
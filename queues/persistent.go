@@ -0,0 +1,472 @@
+package queues
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"dsgo/codec"
+)
+
+const (
+	// DefaultSegmentSize is the size at which a new segment file is started
+	// when PersistentOptions.SegmentSize is not set.
+	DefaultSegmentSize = 64 << 20
+
+	segmentExt = ".seg"
+	commitFile = "commit"
+)
+
+var ErrClosed = errors.New("queues: queue is closed")
+
+type PersistentOptions struct {
+	// SegmentSize is the approximate maximum size of a segment file in bytes.
+	SegmentSize int64
+	// Sync calls fsync after every Push. Without it a machine crash can lose
+	// the most recent items; a process crash cannot.
+	Sync bool
+}
+
+type segment struct {
+	first uint64 // sequence number of the first record
+	count uint64
+	size  int64
+}
+
+// PersistentQueue is a durable FIFO queue stored in a directory of
+// append-only segment files. Items survive process restarts.
+//
+// Pop hands out items in order but does not remove them durably: the
+// consumer calls Commit once it has processed what it popped, and after a
+// restart every item popped since the last Commit is delivered again. This
+// gives at-least-once delivery. Fully committed segments are deleted on
+// Commit.
+//
+// Each record is framed as uvarint length | CRC-32 of the data | data. A
+// record torn by a crash mid-write is dropped when the queue is reopened.
+// The queue is safe for concurrent use.
+type PersistentQueue[T any] struct {
+	dir      string
+	codec    codec.Codec[T]
+	opts     PersistentOptions
+	segments []segment
+	writer   *os.File
+
+	head      uint64 // next sequence number to pop
+	tail      uint64 // next sequence number to push
+	committed uint64
+
+	reader   *bufio.Reader
+	readFile *os.File
+	readSeg  int
+	readSeq  uint64 // sequence number the reader returns next
+	peeked   *T
+	readErr  error // a failed read the reader cannot recover from
+	err      error
+	closed   bool
+	mu       sync.Mutex
+}
+
+// OpenPersistentQueue opens the queue stored in dir, creating it if needed.
+func OpenPersistentQueue[T any](dir string, c codec.Codec[T], opts ...PersistentOptions) (*PersistentQueue[T], error) {
+	var o PersistentOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	if o.SegmentSize <= 0 {
+		o.SegmentSize = DefaultSegmentSize
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	q := &PersistentQueue[T]{dir: dir, codec: c, opts: o}
+	if err := q.load(); err != nil {
+		q.closeFiles()
+		return nil, err
+	}
+	return q, nil
+}
+
+func (q *PersistentQueue[T]) segmentPath(first uint64) string {
+	return filepath.Join(q.dir, fmt.Sprintf("%020d%s", first, segmentExt))
+}
+
+// load scans the segments, repairs a torn tail and positions the reader at
+// the committed sequence number.
+func (q *PersistentQueue[T]) load() error {
+	entries, err := os.ReadDir(q.dir)
+	if err != nil {
+		return err
+	}
+	var firsts []uint64
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), segmentExt)
+		if !ok {
+			continue
+		}
+		first, err := strconv.ParseUint(name, 10, 64)
+		if err != nil {
+			continue
+		}
+		firsts = append(firsts, first)
+	}
+	slices.Sort(firsts)
+
+	for i, first := range firsts {
+		seg, err := q.scanSegment(first, i == len(firsts)-1)
+		if err != nil {
+			return err
+		}
+		q.segments = append(q.segments, seg)
+	}
+
+	if data, err := os.ReadFile(filepath.Join(q.dir, commitFile)); err == nil {
+		if len(data) != 8 {
+			return codec.ErrInvalidData
+		}
+		q.committed = binary.LittleEndian.Uint64(data)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	if len(q.segments) == 0 {
+		q.segments = append(q.segments, segment{first: q.committed})
+		if err := os.WriteFile(q.segmentPath(q.committed), nil, 0o644); err != nil {
+			return err
+		}
+	}
+	first := q.segments[0].first
+	last := q.segments[len(q.segments)-1]
+	q.tail = last.first + last.count
+	q.committed = min(max(q.committed, first), q.tail)
+	q.head = q.committed
+
+	q.writer, err = os.OpenFile(q.segmentPath(last.first), os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	if err := q.dropCommittedSegments(); err != nil {
+		return err
+	}
+	// Skip records that were committed before the restart
+	q.readSeq = q.segments[0].first
+	for q.readSeq < q.head {
+		if _, err := q.nextFrame(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scanSegment counts the valid records of a segment. A damaged record in the
+// last segment is treated as a torn write and truncated away.
+func (q *PersistentQueue[T]) scanSegment(first uint64, last bool) (segment, error) {
+	seg := segment{first: first}
+	path := q.segmentPath(first)
+	f, err := os.Open(path)
+	if err != nil {
+		return seg, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return seg, err
+	}
+
+	r := bufio.NewReader(f)
+	for {
+		_, n, err := readFrame(r, info.Size()-seg.size)
+		if err == io.EOF {
+			return seg, nil
+		}
+		if err != nil {
+			if !last {
+				return seg, fmt.Errorf("queues: segment %s: %w", path, err)
+			}
+			return seg, os.Truncate(path, seg.size)
+		}
+		seg.count++
+		seg.size += n
+	}
+}
+
+// readFrame reads one record and returns its data and framed size. A
+// length prefix larger than limit, the most bytes left for the record,
+// cannot have been written whole and is reported as a torn record rather
+// than allocated.
+func readFrame(r *bufio.Reader, limit int64) ([]byte, int64, error) {
+	length, err := binary.ReadUvarint(r)
+	if err == io.EOF {
+		return nil, 0, io.EOF
+	}
+	if err != nil || length > uint64(max(limit, 0)) {
+		return nil, 0, io.ErrUnexpectedEOF
+	}
+	var sum [4]byte
+	if _, err := io.ReadFull(r, sum[:]); err != nil {
+		return nil, 0, io.ErrUnexpectedEOF
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, 0, io.ErrUnexpectedEOF
+	}
+	if crc32.ChecksumIEEE(data) != binary.LittleEndian.Uint32(sum[:]) {
+		return nil, 0, codec.ErrInvalidData
+	}
+	return data, int64(uvarintLen(length) + 4 + len(data)), nil
+}
+
+func uvarintLen(x uint64) int {
+	return len(binary.AppendUvarint(nil, x))
+}
+
+// Push appends item to the queue. It returns false if the queue is closed or
+// the write failed; Err reports the failure.
+func (q *PersistentQueue[T]) Push(item T) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return false
+	}
+	if err := q.push(item); err != nil {
+		q.err = err
+		return false
+	}
+	return true
+}
+
+func (q *PersistentQueue[T]) push(item T) error {
+	data, err := q.codec.Encode(item)
+	if err != nil {
+		return err
+	}
+	frame := binary.AppendUvarint(nil, uint64(len(data)))
+	frame = binary.LittleEndian.AppendUint32(frame, crc32.ChecksumIEEE(data))
+	frame = append(frame, data...)
+
+	last := &q.segments[len(q.segments)-1]
+	if last.count > 0 && last.size+int64(len(frame)) > q.opts.SegmentSize {
+		if err := q.roll(); err != nil {
+			return err
+		}
+		last = &q.segments[len(q.segments)-1]
+	}
+	if _, err := q.writer.Write(frame); err != nil {
+		// Cut off any partial frame so later pushes are not appended after it
+		if terr := q.writer.Truncate(last.size); terr != nil {
+			return errors.Join(err, terr)
+		}
+		return err
+	}
+	if q.opts.Sync {
+		if err := q.writer.Sync(); err != nil {
+			return err
+		}
+	}
+	last.count++
+	last.size += int64(len(frame))
+	q.tail++
+	return nil
+}
+
+// roll closes the current segment and starts a new one at the tail.
+func (q *PersistentQueue[T]) roll() error {
+	if err := q.writer.Close(); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(q.segmentPath(q.tail), os.O_WRONLY|os.O_CREATE|os.O_EXCL|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	q.writer = f
+	q.segments = append(q.segments, segment{first: q.tail})
+	return nil
+}
+
+// nextFrame reads the record at the read position and advances it, moving
+// on to the next segment when the current one is exhausted. The position
+// only moves once the whole record has been read.
+func (q *PersistentQueue[T]) nextFrame() ([]byte, error) {
+	for q.readFile == nil || q.readSeq >= q.segments[q.readSeg].first+q.segments[q.readSeg].count {
+		if q.readFile != nil {
+			if q.readSeg+1 >= len(q.segments) {
+				return nil, io.ErrUnexpectedEOF
+			}
+			q.readFile.Close()
+			q.readFile = nil
+			q.readSeg++
+		}
+		f, err := os.Open(q.segmentPath(q.segments[q.readSeg].first))
+		if err != nil {
+			return nil, err
+		}
+		q.readFile = f
+		q.reader = bufio.NewReader(f)
+	}
+	data, _, err := readFrame(q.reader, q.segments[q.readSeg].size)
+	if err != nil {
+		return nil, err
+	}
+	q.readSeq++
+	return data, nil
+}
+
+// Pop returns the next item. The item is delivered again after a restart
+// unless Commit is called first. An item that fails to decode is skipped:
+// Pop returns false and Err reports the codec's error. A record that cannot
+// be read at all leaves the reader out of step with the file, so Pop and
+// Peek keep failing with that error until the queue is reopened.
+func (q *PersistentQueue[T]) Pop() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	item, ok := q.peek()
+	if ok {
+		q.peeked = nil
+		q.head++
+	}
+	return item, ok
+}
+
+// Peek returns the next item without popping it. It skips an item that
+// fails to decode as Pop does.
+func (q *PersistentQueue[T]) Peek() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.peek()
+}
+
+func (q *PersistentQueue[T]) peek() (T, bool) {
+	var zero T
+	if q.closed || q.head == q.tail {
+		return zero, false
+	}
+	if q.readErr != nil {
+		q.err = q.readErr
+		return zero, false
+	}
+	if q.peeked == nil {
+		data, err := q.nextFrame()
+		if err != nil {
+			q.readErr, q.err = err, err
+			return zero, false
+		}
+		item, err := q.codec.Decode(data)
+		if err != nil {
+			// The record was read whole, so the reader is still in step
+			q.head++
+			q.err = err
+			return zero, false
+		}
+		q.peeked = &item
+	}
+	return *q.peeked, true
+}
+
+// Commit durably acknowledges every item popped so far and deletes the
+// segments that no longer hold unacknowledged items.
+func (q *PersistentQueue[T]) Commit() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return ErrClosed
+	}
+	if q.head == q.committed {
+		return nil
+	}
+
+	// Write then rename so a crash leaves either the old or the new commit
+	tmp := filepath.Join(q.dir, commitFile+".tmp")
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(binary.LittleEndian.AppendUint64(nil, q.head)); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, filepath.Join(q.dir, commitFile)); err != nil {
+		return err
+	}
+	q.committed = q.head
+	return q.dropCommittedSegments()
+}
+
+// dropCommittedSegments deletes the segments before the reader's whose
+// records are all committed. The segment being written is always kept.
+func (q *PersistentQueue[T]) dropCommittedSegments() error {
+	limit := len(q.segments) - 1
+	if q.readFile != nil {
+		limit = min(limit, q.readSeg)
+	}
+	drop := 0
+	for ; drop < limit; drop++ {
+		seg := q.segments[drop]
+		if seg.first+seg.count > q.committed {
+			break
+		}
+		if err := os.Remove(q.segmentPath(seg.first)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	q.segments = q.segments[drop:]
+	q.readSeg = max(q.readSeg-drop, 0)
+	return nil
+}
+
+// Len returns the number of items not yet popped.
+func (q *PersistentQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return int(q.tail - q.head)
+}
+
+func (q *PersistentQueue[T]) IsEmpty() bool {
+	return q.Len() == 0
+}
+
+// Err returns the most recent error from Push, Pop or Peek.
+func (q *PersistentQueue[T]) Err() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.err
+}
+
+// Close releases the queue's files. Uncommitted pops are redelivered when
+// the queue is reopened.
+func (q *PersistentQueue[T]) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return nil
+	}
+	q.closed = true
+	return q.closeFiles()
+}
+
+func (q *PersistentQueue[T]) closeFiles() error {
+	var errs []error
+	if q.writer != nil {
+		errs = append(errs, q.writer.Close())
+	}
+	if q.readFile != nil {
+		errs = append(errs, q.readFile.Close())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,221 @@
+package queues
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"dsgo/codec"
+)
+
+func openQueue(t *testing.T, dir string, opts ...PersistentOptions) *PersistentQueue[string] {
+	t.Helper()
+	q, err := OpenPersistentQueue(dir, codec.Default[string](), opts...)
+	if err != nil {
+		t.Fatalf("OpenPersistentQueue() error = %v", err)
+	}
+	return q
+}
+
+func segmentFiles(t *testing.T, dir string) int {
+	t.Helper()
+	matches, _ := filepath.Glob(filepath.Join(dir, "*.seg"))
+	return len(matches)
+}
+
+func TestPersistentQueue(t *testing.T) {
+	q := openQueue(t, t.TempDir())
+	defer q.Close()
+
+	if _, ok := q.Pop(); ok {
+		t.Error("Pop() on empty queue should fail")
+	}
+	for i := 0; i < 3; i++ {
+		if !q.Push(strconv.Itoa(i)) {
+			t.Fatalf("Push(%d) failed: %v", i, q.Err())
+		}
+	}
+	if q.Len() != 3 || q.IsEmpty() {
+		t.Errorf("Len() = %d, want 3", q.Len())
+	}
+	if v, ok := q.Peek(); !ok || v != "0" {
+		t.Errorf("Peek() = %q, %v, want 0, true", v, ok)
+	}
+	for i := 0; i < 3; i++ {
+		if v, ok := q.Pop(); !ok || v != strconv.Itoa(i) {
+			t.Errorf("Pop() = %q, %v, want %d, true", v, ok, i)
+		}
+	}
+	if !q.IsEmpty() {
+		t.Errorf("queue should be empty, Len() = %d", q.Len())
+	}
+}
+
+func TestPersistentQueueRestart(t *testing.T) {
+	dir := t.TempDir()
+	q := openQueue(t, dir)
+	for i := 0; i < 5; i++ {
+		q.Push(strconv.Itoa(i))
+	}
+	q.Pop()
+	q.Pop()
+	if err := q.Commit(); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	// Popped but not committed: redelivered after the restart
+	q.Pop()
+	q.Close()
+
+	q = openQueue(t, dir)
+	defer q.Close()
+	if q.Len() != 3 {
+		t.Fatalf("Len() after restart = %d, want 3", q.Len())
+	}
+	for _, want := range []string{"2", "3", "4"} {
+		if v, ok := q.Pop(); !ok || v != want {
+			t.Errorf("Pop() = %q, %v, want %q", v, ok, want)
+		}
+	}
+	q.Push("5")
+	if v, _ := q.Pop(); v != "5" {
+		t.Errorf("Pop() = %q, want 5", v)
+	}
+}
+
+func TestPersistentQueueSegments(t *testing.T) {
+	dir := t.TempDir()
+	q := openQueue(t, dir, PersistentOptions{SegmentSize: 64})
+	for i := 0; i < 100; i++ {
+		q.Push("item-" + strconv.Itoa(i))
+	}
+	if n := segmentFiles(t, dir); n < 10 {
+		t.Fatalf("expected many segments, got %d", n)
+	}
+
+	for i := 0; i < 90; i++ {
+		if v, _ := q.Pop(); v != "item-"+strconv.Itoa(i) {
+			t.Fatalf("Pop() = %q, want item-%d", v, i)
+		}
+	}
+	before := segmentFiles(t, dir)
+	if err := q.Commit(); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	if after := segmentFiles(t, dir); after >= before {
+		t.Errorf("Commit() kept %d of %d segments", after, before)
+	}
+	q.Close()
+
+	q = openQueue(t, dir, PersistentOptions{SegmentSize: 64})
+	defer q.Close()
+	for i := 90; i < 100; i++ {
+		if v, ok := q.Pop(); !ok || v != "item-"+strconv.Itoa(i) {
+			t.Fatalf("Pop() after restart = %q, %v, want item-%d", v, ok, i)
+		}
+	}
+	if err := q.Commit(); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	if n := segmentFiles(t, dir); n != 1 {
+		t.Errorf("fully committed queue kept %d segments, want 1", n)
+	}
+}
+
+func TestPersistentQueueTornWrite(t *testing.T) {
+	dir := t.TempDir()
+	q := openQueue(t, dir)
+	q.Push("a")
+	q.Push("b")
+	q.Close()
+
+	matches, _ := filepath.Glob(filepath.Join(dir, "*.seg"))
+	f, err := os.OpenFile(matches[0], os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A length prefix promising more data than follows
+	f.Write([]byte{10, 1, 2})
+	f.Close()
+
+	q = openQueue(t, dir)
+	defer q.Close()
+	if q.Len() != 2 {
+		t.Fatalf("Len() = %d, want 2 after dropping the torn record", q.Len())
+	}
+	q.Push("c")
+	for _, want := range []string{"a", "b", "c"} {
+		if v, ok := q.Pop(); !ok || v != want {
+			t.Errorf("Pop() = %q, %v, want %q", v, ok, want)
+		}
+	}
+}
+
+func TestPersistentQueueForgedLength(t *testing.T) {
+	dir := t.TempDir()
+	q := openQueue(t, dir)
+	q.Push("a")
+	q.Close()
+
+	matches, _ := filepath.Glob(filepath.Join(dir, "*.seg"))
+	f, err := os.OpenFile(matches[0], os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A length prefix far larger than the file must not be allocated
+	f.Write(binary.AppendUvarint(nil, 1<<62))
+	f.Write([]byte{0, 0, 0, 0})
+	f.Close()
+
+	q = openQueue(t, dir)
+	defer q.Close()
+	if q.Len() != 1 {
+		t.Fatalf("Len() = %d, want 1 after dropping the forged record", q.Len())
+	}
+	if v, ok := q.Pop(); !ok || v != "a" {
+		t.Errorf("Pop() = %q, %v, want %q", v, ok, "a")
+	}
+}
+
+func TestPersistentQueueDecodeError(t *testing.T) {
+	errBad := errors.New("bad record")
+	inner := codec.Default[int]()
+	c := codec.Funcs[int]{
+		EncodeFunc: inner.Encode,
+		DecodeFunc: func(data []byte) (int, error) {
+			v, err := inner.Decode(data)
+			if v == 2 {
+				return 0, errBad
+			}
+			return v, err
+		},
+	}
+	q, err := OpenPersistentQueue(t.TempDir(), c, PersistentOptions{SegmentSize: 16})
+	if err != nil {
+		t.Fatalf("OpenPersistentQueue() error = %v", err)
+	}
+	defer q.Close()
+	for i := 1; i <= 4; i++ {
+		q.Push(i)
+	}
+
+	if v, ok := q.Pop(); !ok || v != 1 {
+		t.Errorf("Pop() = %d, %v, want 1, true", v, ok)
+	}
+	if _, ok := q.Pop(); ok || !errors.Is(q.Err(), errBad) {
+		t.Errorf("Pop() of an undecodable item = %v, Err() = %v, want false, %v", ok, q.Err(), errBad)
+	}
+	if q.Len() != 2 {
+		t.Errorf("Len() after skipping = %d, want 2", q.Len())
+	}
+	for _, want := range []int{3, 4} {
+		if v, ok := q.Pop(); !ok || v != want {
+			t.Errorf("Pop() = %d, %v, want %d, true", v, ok, want)
+		}
+	}
+	if _, ok := q.Pop(); ok || !q.IsEmpty() {
+		t.Errorf("Pop() on a drained queue = %v, Len() = %d", ok, q.Len())
+	}
+}
@@ -0,0 +1,17 @@
+package queues
+
+// Queue is the FIFO interface shared by the queues in this package. Push
+// reports whether the item was accepted: a bounded queue refuses items when
+// full, and a durable queue when the write fails.
+type Queue[T any] interface {
+	Push(item T) bool
+	Pop() (T, bool)
+	Peek() (T, bool)
+	Len() int
+	IsEmpty() bool
+}
+
+var (
+	_ Queue[int] = (*RingBuffer[int])(nil)
+	_ Queue[int] = (*PersistentQueue[int])(nil)
+)
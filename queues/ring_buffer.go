@@ -0,0 +1,149 @@
+package queues
+
+import (
+	"sync"
+)
+
+// RingBuffer is a fixed-capacity FIFO queue backed by a circular slice.
+type RingBuffer[T any] struct {
+	items      []T
+	head       int
+	size       int
+	threadSafe bool
+	mu         sync.RWMutex
+}
+
+// NewRingBuffer creates an empty ring buffer holding at most capacity items.
+func NewRingBuffer[T any](capacity int, threadSafe ...bool) *RingBuffer[T] {
+	if capacity <= 0 {
+		panic("queues: ring buffer capacity must be positive")
+	}
+	isThreadSafe := true
+	if len(threadSafe) > 0 {
+		isThreadSafe = threadSafe[0]
+	}
+	return &RingBuffer[T]{
+		items:      make([]T, capacity),
+		threadSafe: isThreadSafe,
+	}
+}
+
+// Push appends item and reports whether there was room for it.
+func (r *RingBuffer[T]) Push(item T) bool {
+	if r.threadSafe {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+	}
+	if r.size == len(r.items) {
+		return false
+	}
+	r.items[(r.head+r.size)%len(r.items)] = item
+	r.size++
+	return true
+}
+
+// PushOverwrite appends item, discarding the oldest item if the buffer is
+// full. It returns the discarded item, if any.
+func (r *RingBuffer[T]) PushOverwrite(item T) (T, bool) {
+	if r.threadSafe {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+	}
+	var evicted T
+	overwrote := r.size == len(r.items)
+	if overwrote {
+		evicted = r.items[r.head]
+		r.head = (r.head + 1) % len(r.items)
+		r.size--
+	}
+	r.items[(r.head+r.size)%len(r.items)] = item
+	r.size++
+	return evicted, overwrote
+}
+
+// Pop removes and returns the oldest item.
+func (r *RingBuffer[T]) Pop() (T, bool) {
+	if r.threadSafe {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+	}
+	var zero T
+	if r.size == 0 {
+		return zero, false
+	}
+	item := r.items[r.head]
+	r.items[r.head] = zero
+	r.head = (r.head + 1) % len(r.items)
+	r.size--
+	return item, true
+}
+
+// Peek returns the oldest item without removing it.
+func (r *RingBuffer[T]) Peek() (T, bool) {
+	if r.threadSafe {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+	}
+	if r.size == 0 {
+		var zero T
+		return zero, false
+	}
+	return r.items[r.head], true
+}
+
+// Drain removes every item and returns them oldest first.
+func (r *RingBuffer[T]) Drain() []T {
+	if r.threadSafe {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+	}
+	items := r.copyItems()
+	clear(r.items)
+	r.head, r.size = 0, 0
+	return items
+}
+
+// Items returns a copy of the buffered items, oldest first.
+func (r *RingBuffer[T]) Items() []T {
+	if r.threadSafe {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+	}
+	return r.copyItems()
+}
+
+func (r *RingBuffer[T]) copyItems() []T {
+	items := make([]T, r.size)
+	n := copy(items, r.items[r.head:min(r.head+r.size, len(r.items))])
+	copy(items[n:], r.items[:r.size-n])
+	return items
+}
+
+func (r *RingBuffer[T]) Len() int {
+	if r.threadSafe {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+	}
+	return r.size
+}
+
+func (r *RingBuffer[T]) Cap() int {
+	return len(r.items)
+}
+
+func (r *RingBuffer[T]) IsEmpty() bool {
+	return r.Len() == 0
+}
+
+func (r *RingBuffer[T]) IsFull() bool {
+	return r.Len() == len(r.items)
+}
+
+func (r *RingBuffer[T]) Clear() {
+	if r.threadSafe {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+	}
+	clear(r.items)
+	r.head, r.size = 0, 0
+}
@@ -0,0 +1,87 @@
+package queues
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestRingBufferBasicOperations(t *testing.T) {
+	r := NewRingBuffer[int](3, false)
+
+	if !r.IsEmpty() {
+		t.Error("New ring buffer should be empty")
+	}
+	if _, ok := r.Pop(); ok {
+		t.Error("Pop on empty ring buffer should return false")
+	}
+
+	for i := 1; i <= 3; i++ {
+		if !r.Push(i) {
+			t.Errorf("Push(%d) should succeed", i)
+		}
+	}
+	if r.Push(4) {
+		t.Error("Push on full ring buffer should return false")
+	}
+	if !r.IsFull() {
+		t.Error("Ring buffer should be full")
+	}
+	if val, ok := r.Peek(); !ok || val != 1 {
+		t.Errorf("Peek() = %d, want 1", val)
+	}
+
+	// Wrap around the end of the backing slice
+	r.Pop()
+	r.Push(4)
+	expected := []int{2, 3, 4}
+	for i, item := range r.Items() {
+		if item != expected[i] {
+			t.Errorf("Items()[%d] = %d, want %d", i, item, expected[i])
+		}
+	}
+	for _, want := range expected {
+		if val, ok := r.Pop(); !ok || val != want {
+			t.Errorf("Pop() = %d, want %d", val, want)
+		}
+	}
+}
+
+func TestRingBufferPushOverwrite(t *testing.T) {
+	r := NewRingBuffer[int](2)
+	r.PushOverwrite(1)
+	r.PushOverwrite(2)
+
+	evicted, ok := r.PushOverwrite(3)
+	if !ok || evicted != 1 {
+		t.Errorf("PushOverwrite() = %d, %v, want 1, true", evicted, ok)
+	}
+	if r.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", r.Len())
+	}
+
+	items := r.Drain()
+	if len(items) != 2 || items[0] != 2 || items[1] != 3 {
+		t.Errorf("Drain() = %v, want [2 3]", items)
+	}
+	if !r.IsEmpty() {
+		t.Error("Ring buffer should be empty after Drain")
+	}
+}
+
+func TestRingBufferConcurrent(t *testing.T) {
+	r := NewRingBuffer[int](1000)
+	var wg sync.WaitGroup
+
+	for i := 0; i < 1000; i++ {
+		wg.Add(1)
+		go func(val int) {
+			defer wg.Done()
+			r.Push(val)
+		}(i)
+	}
+	wg.Wait()
+
+	if r.Len() != 1000 {
+		t.Errorf("Expected length 1000 after concurrent pushes, got %d", r.Len())
+	}
+}
@@ -0,0 +1,130 @@
+package queues
+
+import (
+	"sync"
+
+	"dsgo/heaps"
+)
+
+type wfqItem[T any] struct {
+	item  T
+	class string
+	// finish is the virtual time at which the item would finish if every
+	// backlogged class were served at a rate proportional to its weight
+	finish float64
+	seq    uint64
+}
+
+// WFQ is a weighted fair queue: items are queued under a class, and
+// Dequeue interleaves the backlogged classes in proportion to their
+// weights, FIFO within each class. A class with weight 3 gets three items
+// out for every one from a class with weight 1 while both have items
+// waiting, and a class that falls idle earns no credit for later. Classes
+// have weight 1 until SetWeight is called.
+type WFQ[T any] struct {
+	items   *heaps.MinHeap[wfqItem[T]]
+	weights map[string]int
+	// finish holds the finish tag of the last queued item of each class
+	// with items waiting
+	finish map[string]float64
+	// vtime is the finish tag of the last item dequeued
+	vtime      float64
+	seq        uint64
+	threadSafe bool
+	mu         sync.RWMutex
+}
+
+// NewWFQ creates an empty weighted fair queue.
+func NewWFQ[T any](threadSafe ...bool) *WFQ[T] {
+	isThreadSafe := true
+	if len(threadSafe) > 0 {
+		isThreadSafe = threadSafe[0]
+	}
+	return &WFQ[T]{
+		items: heaps.NewMinHeap(func(a, b wfqItem[T]) bool {
+			if a.finish == b.finish {
+				return a.seq < b.seq
+			}
+			return a.finish < b.finish
+		}, false),
+		weights:    make(map[string]int),
+		finish:     make(map[string]float64),
+		threadSafe: isThreadSafe,
+	}
+}
+
+// SetWeight sets the share of class. It applies to items enqueued from now
+// on. It panics if weight is not positive.
+func (q *WFQ[T]) SetWeight(class string, weight int) {
+	if weight <= 0 {
+		panic("queues: class weight must be positive")
+	}
+	if q.threadSafe {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+	}
+	q.weights[class] = weight
+}
+
+// Enqueue adds item to the back of class.
+func (q *WFQ[T]) Enqueue(class string, item T) {
+	if q.threadSafe {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+	}
+	weight, ok := q.weights[class]
+	if !ok {
+		weight = 1
+	}
+	start := q.vtime
+	if last, ok := q.finish[class]; ok && last > start {
+		start = last
+	}
+	finish := start + 1/float64(weight)
+	q.finish[class] = finish
+	q.seq++
+	q.items.Push(wfqItem[T]{item: item, class: class, finish: finish, seq: q.seq})
+}
+
+// Dequeue removes and returns the next item and its class.
+func (q *WFQ[T]) Dequeue() (T, string, bool) {
+	if q.threadSafe {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+	}
+	next, ok := q.items.Pop()
+	if !ok {
+		var zero T
+		return zero, "", false
+	}
+	q.vtime = next.finish
+	if q.finish[next.class] == next.finish {
+		// That was the last item of its class
+		delete(q.finish, next.class)
+	}
+	return next.item, next.class, true
+}
+
+// Peek returns the next item and its class without removing it.
+func (q *WFQ[T]) Peek() (T, string, bool) {
+	if q.threadSafe {
+		q.mu.RLock()
+		defer q.mu.RUnlock()
+	}
+	next, ok := q.items.Peek()
+	return next.item, next.class, ok
+}
+
+// Len returns the number of queued items across all classes.
+func (q *WFQ[T]) Len() int {
+	if q.threadSafe {
+		q.mu.RLock()
+		defer q.mu.RUnlock()
+	}
+	return q.items.Size()
+}
+
+// IsEmpty reports whether no items are queued.
+func (q *WFQ[T]) IsEmpty() bool {
+	return q.Len() == 0
+}
@@ -0,0 +1,107 @@
+package queues
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestWFQ_Proportional(t *testing.T) {
+	q := NewWFQ[string]()
+	q.SetWeight("gold", 3)
+	for i := 0; i < 40; i++ {
+		q.Enqueue("gold", fmt.Sprintf("gold-%d", i))
+		q.Enqueue("bronze", fmt.Sprintf("bronze-%d", i))
+	}
+	if q.Len() != 80 {
+		t.Errorf("Len() = %d, want 80", q.Len())
+	}
+
+	counts := make(map[string]int)
+	next := map[string]int{}
+	for i := 0; i < 20; i++ {
+		item, class, ok := q.Dequeue()
+		if !ok {
+			t.Fatal("Dequeue() on a non-empty queue failed")
+		}
+		if want := fmt.Sprintf("%s-%d", class, next[class]); item != want {
+			t.Errorf("Dequeue() = %q, want %q: classes must stay FIFO", item, want)
+		}
+		next[class]++
+		counts[class]++
+	}
+	if counts["gold"] != 15 || counts["bronze"] != 5 {
+		t.Errorf("first 20 dequeues = %v, want 15 gold and 5 bronze", counts)
+	}
+}
+
+func TestWFQ_IdleClassEarnsNoCredit(t *testing.T) {
+	q := NewWFQ[int](false)
+	for i := 0; i < 100; i++ {
+		q.Enqueue("busy", i)
+	}
+	for i := 0; i < 50; i++ {
+		q.Dequeue()
+	}
+	// A class that was idle while busy was served joins at the current
+	// virtual time, so the two alternate rather than late draining first
+	for i := 0; i < 10; i++ {
+		q.Enqueue("late", i)
+	}
+	counts := make(map[string]int)
+	for i := 0; i < 10; i++ {
+		_, class, _ := q.Dequeue()
+		counts[class]++
+	}
+	if counts["busy"] != 5 || counts["late"] != 5 {
+		t.Errorf("dequeues after the idle class joined = %v, want 5 each", counts)
+	}
+
+	for !q.IsEmpty() {
+		q.Dequeue()
+	}
+	if len(q.finish) != 0 {
+		t.Errorf("finish tags kept for %d drained classes", len(q.finish))
+	}
+	if _, _, ok := q.Dequeue(); ok {
+		t.Error("Dequeue() on an empty queue succeeded")
+	}
+	if _, _, ok := q.Peek(); ok {
+		t.Error("Peek() on an empty queue succeeded")
+	}
+}
+
+func TestWFQ_Concurrent(t *testing.T) {
+	q := NewWFQ[int]()
+	q.SetWeight("b", 2)
+	var wg sync.WaitGroup
+	for _, class := range []string{"a", "b", "c"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				q.Enqueue(class, i)
+			}
+		}()
+	}
+	wg.Wait()
+	n := 0
+	for {
+		if _, _, ok := q.Dequeue(); !ok {
+			break
+		}
+		n++
+	}
+	if n != 3000 {
+		t.Errorf("dequeued %d items, want 3000", n)
+	}
+}
+
+func TestWFQ_InvalidWeight(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("SetWeight(0) did not panic")
+		}
+	}()
+	NewWFQ[int]().SetWeight("a", 0)
+}
@@ -0,0 +1,74 @@
+package sets
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"dsgo/concurrencytest"
+)
+
+type setModel map[int]bool
+
+func setOp(r *rand.Rand) concurrencytest.Op[*Set[int], setModel] {
+	item := r.Intn(4)
+	switch r.Intn(5) {
+	case 0:
+		return concurrencytest.Op[*Set[int], setModel]{
+			Name:  fmt.Sprintf("Add(%d)", item),
+			Run:   func(s *Set[int]) any { s.Add(item); return nil },
+			Model: func(m setModel) any { m[item] = true; return nil },
+		}
+	case 1:
+		return concurrencytest.Op[*Set[int], setModel]{
+			Name:  fmt.Sprintf("Remove(%d)", item),
+			Run:   func(s *Set[int]) any { s.Remove(item); return nil },
+			Model: func(m setModel) any { delete(m, item); return nil },
+		}
+	case 2:
+		return concurrencytest.Op[*Set[int], setModel]{
+			Name:  fmt.Sprintf("Contains(%d)", item),
+			Run:   func(s *Set[int]) any { return s.Contains(item) },
+			Model: func(m setModel) any { return m[item] },
+		}
+	case 3:
+		return concurrencytest.Op[*Set[int], setModel]{
+			Name:  "Size()",
+			Run:   func(s *Set[int]) any { return s.Size() },
+			Model: func(m setModel) any { return len(m) },
+		}
+	default:
+		return concurrencytest.Op[*Set[int], setModel]{
+			Name: "Items()",
+			Run:  func(s *Set[int]) any { return SortedItems(s) },
+			Model: func(m setModel) any {
+				items := make([]int, 0, len(m))
+				for item := range m {
+					items = append(items, item)
+				}
+				slices.Sort(items)
+				return items
+			},
+		}
+	}
+}
+
+func TestSetLinearizable(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		concurrencytest.Run(t, concurrencytest.Script[*Set[int], setModel]{
+			New:      func() *Set[int] { return NewSet[int]() },
+			NewModel: func() setModel { return setModel{} },
+			Clone: func(m setModel) setModel {
+				c := make(setModel, len(m))
+				for k, v := range m {
+					c[k] = v
+				}
+				return c
+			},
+			Threads: concurrencytest.RandomThreads(r, 4, 6, setOp),
+			Runs:    10,
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package sets
+
+import (
+	"sync"
+)
+
+// linkedItem is an entry of a LinkedHashSet's insertion-order list.
+type linkedItem[T comparable] struct {
+	item       T
+	prev, next *linkedItem[T]
+}
+
+// LinkedHashSet is a set that remembers insertion order. Contains, Add and
+// Remove are O(1): the map holds each item's node in a doubly linked list,
+// so removing an item unlinks it without a scan. Items and Range return
+// items in the order they were first added.
+type LinkedHashSet[T comparable] struct {
+	items map[T]*linkedItem[T]
+	// root is the sentinel of a circular list: root.next is the oldest item
+	// and root.prev the newest
+	root       linkedItem[T]
+	threadSafe bool
+	mu         sync.RWMutex
+}
+
+func NewLinkedHashSet[T comparable](threadSafe ...bool) *LinkedHashSet[T] {
+	isThreadSafe := true
+	if len(threadSafe) > 0 {
+		isThreadSafe = threadSafe[0]
+	}
+	s := &LinkedHashSet[T]{
+		items:      make(map[T]*linkedItem[T]),
+		threadSafe: isThreadSafe,
+	}
+	s.root.prev, s.root.next = &s.root, &s.root
+	return s
+}
+
+// Add adds item to the end of the set. Re-adding an existing item keeps its
+// original position.
+func (s *LinkedHashSet[T]) Add(item T) {
+	if s.threadSafe {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+	}
+	if _, exists := s.items[item]; exists {
+		return
+	}
+	node := &linkedItem[T]{item: item, prev: s.root.prev, next: &s.root}
+	node.prev.next = node
+	s.root.prev = node
+	s.items[item] = node
+}
+
+func (s *LinkedHashSet[T]) Remove(item T) {
+	if s.threadSafe {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+	}
+	if node, exists := s.items[item]; exists {
+		s.unlink(node)
+	}
+}
+
+// unlink removes node from the list and the map. The caller must hold the
+// write lock.
+func (s *LinkedHashSet[T]) unlink(node *linkedItem[T]) {
+	node.prev.next = node.next
+	node.next.prev = node.prev
+	node.prev, node.next = nil, nil
+	delete(s.items, node.item)
+}
+
+// RemoveFunc removes every item for which pred returns true, under a single
+// lock, and returns the number of items removed.
+func (s *LinkedHashSet[T]) RemoveFunc(pred func(item T) bool) int {
+	if s.threadSafe {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+	}
+	removed := 0
+	for node := s.root.next; node != &s.root; {
+		next := node.next
+		if pred(node.item) {
+			s.unlink(node)
+			removed++
+		}
+		node = next
+	}
+	return removed
+}
+
+func (s *LinkedHashSet[T]) Contains(item T) bool {
+	if s.threadSafe {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+	}
+	_, exists := s.items[item]
+	return exists
+}
+
+func (s *LinkedHashSet[T]) Size() int {
+	if s.threadSafe {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+	}
+	return len(s.items)
+}
+
+func (s *LinkedHashSet[T]) IsEmpty() bool {
+	return s.Size() == 0
+}
+
+func (s *LinkedHashSet[T]) Clear() {
+	if s.threadSafe {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+	}
+	clear(s.items)
+	s.root.prev, s.root.next = &s.root, &s.root
+}
+
+// Items returns the items in insertion order.
+func (s *LinkedHashSet[T]) Items() []T {
+	if s.threadSafe {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+	}
+	return s.appendItems(make([]T, 0, len(s.items)))
+}
+
+// AppendItems appends the items in insertion order to dst and returns the
+// extended slice.
+func (s *LinkedHashSet[T]) AppendItems(dst []T) []T {
+	if s.threadSafe {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+	}
+	return s.appendItems(dst)
+}
+
+func (s *LinkedHashSet[T]) appendItems(dst []T) []T {
+	for node := s.root.next; node != &s.root; node = node.next {
+		dst = append(dst, node.item)
+	}
+	return dst
+}
+
+// Range iterates over the items in insertion order until f returns false.
+func (s *LinkedHashSet[T]) Range(f func(item T) bool) {
+	if s.threadSafe {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+	}
+	for node := s.root.next; node != &s.root; node = node.next {
+		if !f(node.item) {
+			return
+		}
+	}
+}
+
+// Union returns the items of s followed by the items of other not in s.
+func (s *LinkedHashSet[T]) Union(other *LinkedHashSet[T]) *LinkedHashSet[T] {
+	result := NewLinkedHashSet[T](s.threadSafe)
+	s.Range(func(item T) bool {
+		result.Add(item)
+		return true
+	})
+	other.Range(func(item T) bool {
+		result.Add(item)
+		return true
+	})
+	return result
+}
+
+// Intersection returns the items of s that are also in other, in the order of s.
+func (s *LinkedHashSet[T]) Intersection(other *LinkedHashSet[T]) *LinkedHashSet[T] {
+	result := NewLinkedHashSet[T](s.threadSafe)
+	s.Range(func(item T) bool {
+		if other.Contains(item) {
+			result.Add(item)
+		}
+		return true
+	})
+	return result
+}
+
+// Difference returns the items of s that are not in other, in the order of s.
+func (s *LinkedHashSet[T]) Difference(other *LinkedHashSet[T]) *LinkedHashSet[T] {
+	result := NewLinkedHashSet[T](s.threadSafe)
+	s.Range(func(item T) bool {
+		if !other.Contains(item) {
+			result.Add(item)
+		}
+		return true
+	})
+	return result
+}
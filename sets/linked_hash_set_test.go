@@ -0,0 +1,106 @@
+package sets
+
+import (
+	"sync"
+	"testing"
+)
+
+func assertItems[T comparable](t *testing.T, got []T, want ...T) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("Items() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Items()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestLinkedHashSetInsertionOrder(t *testing.T) {
+	s := NewLinkedHashSet[string](false)
+	s.Add("c")
+	s.Add("a")
+	s.Add("b")
+	s.Add("a") // Re-adding keeps the original position
+
+	assertItems(t, s.Items(), "c", "a", "b")
+	if s.Size() != 3 {
+		t.Errorf("Set should have size 3, got %d", s.Size())
+	}
+	if !s.Contains("a") || s.Contains("z") {
+		t.Error("Contains returned an unexpected result")
+	}
+
+	s.Remove("c")
+	s.Add("c")
+	assertItems(t, s.Items(), "a", "b", "c")
+
+	var ranged []string
+	s.Range(func(item string) bool {
+		ranged = append(ranged, item)
+		return len(ranged) < 2
+	})
+	assertItems(t, ranged, "a", "b")
+
+	if removed := s.RemoveFunc(func(item string) bool { return item != "b" }); removed != 2 {
+		t.Errorf("RemoveFunc removed %d, want 2", removed)
+	}
+	assertItems(t, s.Items(), "b")
+
+	s.Clear()
+	if !s.IsEmpty() {
+		t.Error("Set should be empty after Clear")
+	}
+}
+
+func TestLinkedHashSetOperations(t *testing.T) {
+	s1 := NewLinkedHashSet[int]()
+	s2 := NewLinkedHashSet[int]()
+	for _, item := range []int{3, 1, 2} {
+		s1.Add(item)
+	}
+	for _, item := range []int{4, 2, 3} {
+		s2.Add(item)
+	}
+
+	assertItems(t, s1.Union(s2).Items(), 3, 1, 2, 4)
+	assertItems(t, s1.Intersection(s2).Items(), 3, 2)
+	assertItems(t, s1.Difference(s2).Items(), 1)
+}
+
+func TestLinkedHashSetConcurrent(t *testing.T) {
+	s := NewLinkedHashSet[int](true)
+	var wg sync.WaitGroup
+
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(val int) {
+			defer wg.Done()
+			s.Add(val)
+			s.Contains(val)
+		}(i)
+	}
+	wg.Wait()
+
+	if s.Size() != 100 {
+		t.Errorf("Set should have size 100, got %d", s.Size())
+	}
+}
+
+func TestLinkedHashSetRemoveKeepsOrder(t *testing.T) {
+	s := NewLinkedHashSet[int](false)
+	for i := 0; i < 5; i++ {
+		s.Add(i)
+	}
+	s.Remove(2)
+	s.Remove(4)
+	s.Remove(9)
+	assertItems(t, s.Items(), 0, 1, 3)
+	s.Add(4)
+	s.Remove(0)
+	assertItems(t, s.Items(), 1, 3, 4)
+	if s.Size() != 3 || s.Contains(2) {
+		t.Errorf("Size() = %d, Contains(2) = %v, want 3, false", s.Size(), s.Contains(2))
+	}
+}
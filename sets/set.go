@@ -1,11 +1,19 @@
 package sets
 
-import "sync"
+import (
+	"slices"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"dsgo/utils"
+)
 
 type Set[T comparable] struct {
 	items      map[T]struct{}
 	threadSafe bool
 	mu         sync.RWMutex
+	sealed     atomic.Bool
 }
 
 func NewSet[T comparable](threadSafe ...bool) *Set[T] {
@@ -24,6 +32,9 @@ func (s *Set[T]) Add(item T) {
 		s.mu.Lock()
 		defer s.mu.Unlock()
 	}
+	if s.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
 	s.items[item] = struct{}{}
 }
 
@@ -32,11 +43,34 @@ func (s *Set[T]) Remove(item T) {
 		s.mu.Lock()
 		defer s.mu.Unlock()
 	}
+	if s.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
 	delete(s.items, item)
 }
 
-func (s *Set[T]) Contains(item T) bool {
+// RemoveFunc removes every item for which pred returns true, under a single
+// lock, and returns the number of items removed.
+func (s *Set[T]) RemoveFunc(pred func(item T) bool) int {
 	if s.threadSafe {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+	}
+	if s.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
+	removed := 0
+	for item := range s.items {
+		if pred(item) {
+			delete(s.items, item)
+			removed++
+		}
+	}
+	return removed
+}
+
+func (s *Set[T]) Contains(item T) bool {
+	if s.threadSafe && !s.sealed.Load() {
 		s.mu.RLock()
 		defer s.mu.RUnlock()
 	}
@@ -45,7 +79,7 @@ func (s *Set[T]) Contains(item T) bool {
 }
 
 func (s *Set[T]) Size() int {
-	if s.threadSafe {
+	if s.threadSafe && !s.sealed.Load() {
 		s.mu.RLock()
 		defer s.mu.RUnlock()
 	}
@@ -61,15 +95,18 @@ func (s *Set[T]) Clear() {
 		s.mu.Lock()
 		defer s.mu.Unlock()
 	}
+	if s.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
 	s.items = make(map[T]struct{})
 }
 
 func (s *Set[T]) Union(other *Set[T]) *Set[T] {
-	if s.threadSafe {
+	if s.threadSafe && !s.sealed.Load() {
 		s.mu.RLock()
 		defer s.mu.RUnlock()
 	}
-	if other.threadSafe {
+	if other.threadSafe && !other.sealed.Load() {
 		other.mu.RLock()
 		defer other.mu.RUnlock()
 	}
@@ -85,11 +122,11 @@ func (s *Set[T]) Union(other *Set[T]) *Set[T] {
 }
 
 func (s *Set[T]) Intersection(other *Set[T]) *Set[T] {
-	if s.threadSafe {
+	if s.threadSafe && !s.sealed.Load() {
 		s.mu.RLock()
 		defer s.mu.RUnlock()
 	}
-	if other.threadSafe {
+	if other.threadSafe && !other.sealed.Load() {
 		other.mu.RLock()
 		defer other.mu.RUnlock()
 	}
@@ -104,11 +141,11 @@ func (s *Set[T]) Intersection(other *Set[T]) *Set[T] {
 }
 
 func (s *Set[T]) Difference(other *Set[T]) *Set[T] {
-	if s.threadSafe {
+	if s.threadSafe && !s.sealed.Load() {
 		s.mu.RLock()
 		defer s.mu.RUnlock()
 	}
-	if other.threadSafe {
+	if other.threadSafe && !other.sealed.Load() {
 		other.mu.RLock()
 		defer other.mu.RUnlock()
 	}
@@ -123,13 +160,58 @@ func (s *Set[T]) Difference(other *Set[T]) *Set[T] {
 }
 
 func (s *Set[T]) Items() []T {
-	if s.threadSafe {
+	if s.threadSafe && !s.sealed.Load() {
 		s.mu.RLock()
 		defer s.mu.RUnlock()
 	}
-	items := make([]T, 0, s.Size())
+	return s.appendItems(make([]T, 0, len(s.items)))
+}
+
+// AppendItems appends the items to dst, in no particular order, and returns
+// the extended slice, letting callers reuse a buffer across calls.
+func (s *Set[T]) AppendItems(dst []T) []T {
+	if s.threadSafe && !s.sealed.Load() {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+	}
+	return s.appendItems(dst)
+}
+
+func (s *Set[T]) appendItems(dst []T) []T {
 	for item := range s.items {
-		items = append(items, item)
+		dst = append(dst, item)
 	}
+	return dst
+}
+
+// ItemsSorted returns the items ordered by less, giving a stable order where
+// Items follows Go's randomized map iteration.
+func (s *Set[T]) ItemsSorted(less func(a, b T) bool) []T {
+	items := s.Items()
+	sort.Slice(items, func(i, j int) bool {
+		return less(items[i], items[j])
+	})
+	return items
+}
+
+// SortedItems returns the items of s in ascending natural order.
+func SortedItems[T utils.Ordered](s *Set[T]) []T {
+	items := s.Items()
+	slices.Sort(items)
 	return items
 }
+
+// Seal makes the set read-only. Mutations after Seal panic with
+// utils.ErrSealed. Reads on a sealed set skip locking.
+func (s *Set[T]) Seal() {
+	if s.threadSafe {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+	}
+	s.sealed.Store(true)
+}
+
+// IsSealed reports whether Seal has been called.
+func (s *Set[T]) IsSealed() bool {
+	return s.sealed.Load()
+}
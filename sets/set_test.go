@@ -1,8 +1,11 @@
 package sets
 
 import (
+	"sort"
 	"sync"
 	"testing"
+
+	"dsgo/utils"
 )
 
 func TestNew(t *testing.T) {
@@ -46,6 +49,26 @@ func TestRemove(t *testing.T) {
 	}
 }
 
+func TestRemoveFunc(t *testing.T) {
+	s := NewSet[int]()
+	for i := 0; i < 10; i++ {
+		s.Add(i)
+	}
+
+	removed := s.RemoveFunc(func(item int) bool {
+		return item >= 5
+	})
+	if removed != 5 {
+		t.Errorf("RemoveFunc removed %d, want 5", removed)
+	}
+	if s.Size() != 5 {
+		t.Errorf("Set should have size 5, got %d", s.Size())
+	}
+	if s.Contains(7) {
+		t.Error("Set should not contain 7 after RemoveFunc")
+	}
+}
+
 func TestUnion(t *testing.T) {
 	s1 := NewSet[int]()
 	s2 := NewSet[int]()
@@ -363,3 +386,75 @@ func TestSetDifferenceConcurrent(t *testing.T) {
 		t.Errorf("Expected difference size 500, got %d", difference.Size())
 	}
 }
+
+func TestSetSeal(t *testing.T) {
+	s := NewSet[int]()
+	s.Add(1)
+	s.Seal()
+
+	if !s.IsSealed() {
+		t.Error("IsSealed should be true after Seal")
+	}
+	if !s.Contains(1) || s.Size() != 1 {
+		t.Error("Reads should keep working on a sealed set")
+	}
+
+	defer func() {
+		if r := recover(); r != utils.ErrSealed {
+			t.Errorf("Add on sealed set should panic with ErrSealed, got %v", r)
+		}
+	}()
+	s.Add(2)
+}
+
+func TestItemsSorted(t *testing.T) {
+	s := NewSet[string]()
+	for _, item := range []string{"pear", "apple", "fig", "banana"} {
+		s.Add(item)
+	}
+
+	byLength := s.ItemsSorted(func(a, b string) bool {
+		if len(a) != len(b) {
+			return len(a) < len(b)
+		}
+		return a < b
+	})
+	expected := []string{"fig", "pear", "apple", "banana"}
+	for i, item := range byLength {
+		if item != expected[i] {
+			t.Errorf("ItemsSorted()[%d] = %s, want %s", i, item, expected[i])
+		}
+	}
+
+	natural := SortedItems(s)
+	expected = []string{"apple", "banana", "fig", "pear"}
+	for i, item := range natural {
+		if item != expected[i] {
+			t.Errorf("SortedItems()[%d] = %s, want %s", i, item, expected[i])
+		}
+	}
+}
+
+func TestSetAppendItems(t *testing.T) {
+	s := NewSet[int]()
+	s.Add(2)
+	s.Add(1)
+	got := s.AppendItems([]int{9})
+	sort.Ints(got[1:])
+	if len(got) != 3 || got[0] != 9 || got[1] != 1 || got[2] != 2 {
+		t.Errorf("AppendItems() = %v, want [9 1 2]", got)
+	}
+
+	l := NewLinkedHashSet[string]()
+	l.Add("y")
+	l.Add("x")
+	if got := l.AppendItems(nil); len(got) != 2 || got[0] != "y" {
+		t.Errorf("LinkedHashSet.AppendItems() = %v, want [y x]", got)
+	}
+
+	small := NewSmallSet[uint8](3, 70)
+	buf := make([]uint8, 0, 4)
+	if allocs := testing.AllocsPerRun(100, func() { buf = small.AppendItems(buf[:0]) }); allocs != 0 || len(buf) != 2 {
+		t.Errorf("SmallSet.AppendItems() = %v with %v allocations", buf, allocs)
+	}
+}
@@ -0,0 +1,130 @@
+package sets
+
+import (
+	"fmt"
+	"math/bits"
+
+	"dsgo/utils"
+)
+
+// SmallSetCapacity is the size of the domain [0, SmallSetCapacity) that a
+// SmallSet can hold.
+const SmallSetCapacity = 128
+
+// SmallSet is a set of small non-negative integers backed by a fixed-size
+// bitmask. It is a plain value (no pointers, no locks), so it can live on
+// the stack and be copied freely; all set algebra runs in constant time.
+// The zero value is an empty set.
+type SmallSet[T utils.Integer] struct {
+	bits [SmallSetCapacity / 64]uint64
+}
+
+func NewSmallSet[T utils.Integer](items ...T) SmallSet[T] {
+	var s SmallSet[T]
+	for _, item := range items {
+		s.Add(item)
+	}
+	return s
+}
+
+func inSmallDomain[T utils.Integer](item T) bool {
+	return item >= 0 && uint64(item) < SmallSetCapacity
+}
+
+// Add adds item to the set. It panics if item is outside [0, SmallSetCapacity).
+func (s *SmallSet[T]) Add(item T) {
+	if !inSmallDomain(item) {
+		panic(fmt.Sprintf("sets: SmallSet item %d out of range [0, %d)", item, SmallSetCapacity))
+	}
+	s.bits[uint64(item)/64] |= 1 << (uint64(item) % 64)
+}
+
+func (s *SmallSet[T]) Remove(item T) {
+	if !inSmallDomain(item) {
+		return
+	}
+	s.bits[uint64(item)/64] &^= 1 << (uint64(item) % 64)
+}
+
+func (s SmallSet[T]) Contains(item T) bool {
+	if !inSmallDomain(item) {
+		return false
+	}
+	return s.bits[uint64(item)/64]&(1<<(uint64(item)%64)) != 0
+}
+
+func (s SmallSet[T]) Size() int {
+	n := 0
+	for _, word := range s.bits {
+		n += bits.OnesCount64(word)
+	}
+	return n
+}
+
+func (s SmallSet[T]) IsEmpty() bool {
+	return s.bits == [len(s.bits)]uint64{}
+}
+
+func (s *SmallSet[T]) Clear() {
+	s.bits = [len(s.bits)]uint64{}
+}
+
+func (s SmallSet[T]) Union(other SmallSet[T]) SmallSet[T] {
+	for i := range s.bits {
+		s.bits[i] |= other.bits[i]
+	}
+	return s
+}
+
+func (s SmallSet[T]) Intersection(other SmallSet[T]) SmallSet[T] {
+	for i := range s.bits {
+		s.bits[i] &= other.bits[i]
+	}
+	return s
+}
+
+func (s SmallSet[T]) Difference(other SmallSet[T]) SmallSet[T] {
+	for i := range s.bits {
+		s.bits[i] &^= other.bits[i]
+	}
+	return s
+}
+
+func (s SmallSet[T]) SymmetricDifference(other SmallSet[T]) SmallSet[T] {
+	for i := range s.bits {
+		s.bits[i] ^= other.bits[i]
+	}
+	return s
+}
+
+func (s SmallSet[T]) Equal(other SmallSet[T]) bool {
+	return s.bits == other.bits
+}
+
+// IsSubset reports whether every item of s is also in other.
+func (s SmallSet[T]) IsSubset(other SmallSet[T]) bool {
+	for i := range s.bits {
+		if s.bits[i]&^other.bits[i] != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Items returns the items of the set in ascending order.
+func (s SmallSet[T]) Items() []T {
+	return s.AppendItems(make([]T, 0, s.Size()))
+}
+
+// AppendItems appends the items in ascending order to dst and returns the
+// extended slice.
+func (s SmallSet[T]) AppendItems(dst []T) []T {
+	for i, word := range s.bits {
+		for word != 0 {
+			bit := bits.TrailingZeros64(word)
+			dst = append(dst, T(i*64+bit))
+			word &= word - 1
+		}
+	}
+	return dst
+}
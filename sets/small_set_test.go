@@ -0,0 +1,96 @@
+package sets
+
+import (
+	"testing"
+)
+
+type flag uint8
+
+func TestSmallSetBasicOperations(t *testing.T) {
+	var s SmallSet[int]
+	if !s.IsEmpty() {
+		t.Error("Zero value should be empty")
+	}
+
+	s.Add(0)
+	s.Add(63)
+	s.Add(64)
+	s.Add(127)
+	s.Add(64)
+
+	for _, item := range []int{0, 63, 64, 127} {
+		if !s.Contains(item) {
+			t.Errorf("Set should contain %d", item)
+		}
+	}
+	if s.Contains(1) || s.Contains(-1) || s.Contains(128) {
+		t.Error("Set should not contain items that were never added")
+	}
+	if s.Size() != 4 {
+		t.Errorf("Set should have size 4, got %d", s.Size())
+	}
+
+	s.Remove(63)
+	s.Remove(500)
+	if s.Contains(63) || s.Size() != 3 {
+		t.Error("Remove(63) failed")
+	}
+
+	items := s.Items()
+	expected := []int{0, 64, 127}
+	for i, item := range items {
+		if item != expected[i] {
+			t.Errorf("Items()[%d] = %d, want %d", i, item, expected[i])
+		}
+	}
+
+	s.Clear()
+	if !s.IsEmpty() {
+		t.Error("Set should be empty after Clear")
+	}
+}
+
+func TestSmallSetAlgebra(t *testing.T) {
+	a := NewSmallSet[flag](1, 2, 3, 100)
+	b := NewSmallSet[flag](3, 4, 100)
+
+	tests := []struct {
+		name string
+		got  SmallSet[flag]
+		want SmallSet[flag]
+	}{
+		{"union", a.Union(b), NewSmallSet[flag](1, 2, 3, 4, 100)},
+		{"intersection", a.Intersection(b), NewSmallSet[flag](3, 100)},
+		{"difference", a.Difference(b), NewSmallSet[flag](1, 2)},
+		{"symmetric difference", a.SymmetricDifference(b), NewSmallSet[flag](1, 2, 4)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.got.Equal(tt.want) {
+				t.Errorf("got %v, want %v", tt.got.Items(), tt.want.Items())
+			}
+		})
+	}
+
+	// Operands are values and must not be modified
+	if a.Size() != 4 || b.Size() != 3 {
+		t.Error("Set algebra should not modify its operands")
+	}
+
+	if !NewSmallSet[flag](1, 3).IsSubset(a) {
+		t.Error("{1, 3} should be a subset of a")
+	}
+	if b.IsSubset(a) {
+		t.Error("b should not be a subset of a")
+	}
+}
+
+func TestSmallSetOutOfRangePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Add(128) should panic")
+		}
+	}()
+	var s SmallSet[int]
+	s.Add(SmallSetCapacity)
+}
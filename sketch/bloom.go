@@ -0,0 +1,178 @@
+package sketch
+
+import (
+	"encoding/binary"
+	"math"
+	"math/bits"
+	"slices"
+	"sync"
+)
+
+const (
+	bloomVersion = 1
+	// version, bit count, hash count
+	bloomHeaderSize = 1 + 8 + 4
+	// bloomMaxHashes bounds the hash count accepted by UnmarshalBinary. The
+	// smallest false positive rate NewBloomFilter allows needs about 30.
+	bloomMaxHashes = 64
+)
+
+// BloomFilter is an approximate set membership filter: MightContain never
+// returns false for an item that was added, and returns true for an item
+// that was not with roughly the false positive rate the filter was sized
+// for. Items cannot be removed; see CuckooFilter for that.
+type BloomFilter struct {
+	bits       []uint64
+	m          uint64
+	k          int
+	threadSafe bool
+	mu         sync.RWMutex
+}
+
+// NewBloomFilter creates a filter sized so that, after capacity items, the
+// false positive rate is about fpRate. fpRate is clamped to [1e-9, 0.5].
+func NewBloomFilter(capacity int, fpRate float64, threadSafe ...bool) *BloomFilter {
+	isThreadSafe := true
+	if len(threadSafe) > 0 {
+		isThreadSafe = threadSafe[0]
+	}
+	n := float64(max(1, capacity))
+	p := min(max(fpRate, 1e-9), 0.5)
+	m := uint64(math.Ceil(-n * math.Log(p) / (math.Ln2 * math.Ln2)))
+	k := max(1, int(math.Round(float64(m)/n*math.Ln2)))
+	return &BloomFilter{
+		bits:       make([]uint64, (m+63)/64),
+		m:          m,
+		k:          k,
+		threadSafe: isThreadSafe,
+	}
+}
+
+// locations calls fn with the k bit positions for item, derived from one
+// 64-bit hash by double hashing.
+func (f *BloomFilter) locations(item []byte, fn func(bit uint64) bool) bool {
+	h := hash64(item)
+	step := mix64(h) | 1
+	for i := 0; i < f.k; i++ {
+		if !fn(h % f.m) {
+			return false
+		}
+		h += step
+	}
+	return true
+}
+
+// Add inserts item.
+func (f *BloomFilter) Add(item []byte) {
+	if f.threadSafe {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+	}
+	f.locations(item, func(bit uint64) bool {
+		f.bits[bit/64] |= 1 << (bit % 64)
+		return true
+	})
+}
+
+// MightContain reports whether item may have been added.
+func (f *BloomFilter) MightContain(item []byte) bool {
+	if f.threadSafe {
+		f.mu.RLock()
+		defer f.mu.RUnlock()
+	}
+	return f.locations(item, func(bit uint64) bool {
+		return f.bits[bit/64]&(1<<(bit%64)) != 0
+	})
+}
+
+// FillRatio returns the fraction of bits set. The false positive rate is
+// about FillRatio to the power of the number of hash functions.
+func (f *BloomFilter) FillRatio() float64 {
+	if f.threadSafe {
+		f.mu.RLock()
+		defer f.mu.RUnlock()
+	}
+	set := 0
+	for _, w := range f.bits {
+		set += bits.OnesCount64(w)
+	}
+	return float64(set) / float64(f.m)
+}
+
+// Merge adds every item in other to f by OR-ing their bits, so f then
+// answers for the union of both sets. The filters must have been created
+// with the same capacity and false positive rate; otherwise
+// ErrIncompatible is returned.
+func (f *BloomFilter) Merge(other *BloomFilter) error {
+	if f == other {
+		return nil
+	}
+	// Copy other before locking f, so a.Merge(b) and b.Merge(a) running
+	// together cannot each hold one lock while waiting for the other
+	m, k, words := other.snapshot()
+	if f.threadSafe {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+	}
+	if f.m != m || f.k != k {
+		return ErrIncompatible
+	}
+	for i, w := range words {
+		f.bits[i] |= w
+	}
+	return nil
+}
+
+// snapshot returns the filter's parameters and a copy of its bits.
+func (f *BloomFilter) snapshot() (uint64, int, []uint64) {
+	if f.threadSafe {
+		f.mu.RLock()
+		defer f.mu.RUnlock()
+	}
+	return f.m, f.k, slices.Clone(f.bits)
+}
+
+// MarshalBinary encodes the filter in a portable little-endian format.
+func (f *BloomFilter) MarshalBinary() ([]byte, error) {
+	if f.threadSafe {
+		f.mu.RLock()
+		defer f.mu.RUnlock()
+	}
+	buf := make([]byte, 0, bloomHeaderSize+len(f.bits)*8)
+	buf = append(buf, bloomVersion)
+	buf = binary.LittleEndian.AppendUint64(buf, f.m)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(f.k))
+	for _, w := range f.bits {
+		buf = binary.LittleEndian.AppendUint64(buf, w)
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary replaces the filter's contents and parameters with data
+// produced by MarshalBinary. The thread-safety setting of f is kept.
+func (f *BloomFilter) UnmarshalBinary(data []byte) error {
+	if len(data) < bloomHeaderSize || data[0] != bloomVersion {
+		return ErrInvalidData
+	}
+	m := binary.LittleEndian.Uint64(data[1:])
+	k := binary.LittleEndian.Uint32(data[9:])
+	// Written so a forged m near the top of the range cannot wrap to zero
+	words := m / 64
+	if m%64 != 0 {
+		words++
+	}
+	if m == 0 || k == 0 || k > bloomMaxHashes || uint64(len(data)-bloomHeaderSize) != words*8 {
+		return ErrInvalidData
+	}
+	bitset := make([]uint64, words)
+	for i := range bitset {
+		bitset[i] = binary.LittleEndian.Uint64(data[bloomHeaderSize+i*8:])
+	}
+
+	if f.threadSafe {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+	}
+	f.bits, f.m, f.k = bitset, m, int(k)
+	return nil
+}
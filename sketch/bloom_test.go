@@ -0,0 +1,103 @@
+package sketch
+
+import (
+	"encoding/binary"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestBloomFilter(t *testing.T) {
+	f := NewBloomFilter(1000, 0.01)
+	for i := 0; i < 1000; i++ {
+		f.Add([]byte(strconv.Itoa(i)))
+	}
+	for i := 0; i < 1000; i++ {
+		if !f.MightContain([]byte(strconv.Itoa(i))) {
+			t.Fatalf("MightContain(%d) = false for an added item", i)
+		}
+	}
+	falsePositives := 0
+	for i := 1000; i < 11000; i++ {
+		if f.MightContain([]byte(strconv.Itoa(i))) {
+			falsePositives++
+		}
+	}
+	if rate := float64(falsePositives) / 10000; rate > 0.02 {
+		t.Errorf("false positive rate = %.4f, want about 0.01", rate)
+	}
+	if ratio := f.FillRatio(); ratio < 0.4 || ratio > 0.6 {
+		t.Errorf("FillRatio() = %.2f, want about 0.5 at capacity", ratio)
+	}
+}
+
+func TestBloomFilterMarshalMerge(t *testing.T) {
+	a, b := NewBloomFilter(500, 0.01), NewBloomFilter(500, 0.01, false)
+	for i := 0; i < 200; i++ {
+		a.Add([]byte("a" + strconv.Itoa(i)))
+		b.Add([]byte("b" + strconv.Itoa(i)))
+	}
+	data, err := b.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() error = %v", err)
+	}
+	shipped := NewBloomFilter(1, 0.5)
+	if err := shipped.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary() error = %v", err)
+	}
+	if err := a.Merge(shipped); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	for i := 0; i < 200; i++ {
+		if !a.MightContain([]byte("a"+strconv.Itoa(i))) || !a.MightContain([]byte("b"+strconv.Itoa(i))) {
+			t.Fatalf("merged filter lost item %d", i)
+		}
+	}
+
+	if err := a.Merge(NewBloomFilter(500, 0.001)); !errors.Is(err, ErrIncompatible) {
+		t.Errorf("Merge(different rate) = %v, want ErrIncompatible", err)
+	}
+	if err := shipped.UnmarshalBinary(data[:len(data)-1]); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("UnmarshalBinary(truncated) = %v, want ErrInvalidData", err)
+	}
+}
+
+func TestBloomFilterUnmarshalForged(t *testing.T) {
+	header := func(m uint64, k uint32) []byte {
+		buf := []byte{bloomVersion}
+		buf = binary.LittleEndian.AppendUint64(buf, m)
+		return binary.LittleEndian.AppendUint32(buf, k)
+	}
+	f := NewBloomFilter(10, 0.01)
+	// A bit count whose word count would wrap to zero must not be accepted
+	if err := f.UnmarshalBinary(header(^uint64(0), 3)); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("UnmarshalBinary(m = MaxUint64) = %v, want ErrInvalidData", err)
+	}
+	forged := binary.LittleEndian.AppendUint64(header(64, 1<<32-1), 0)
+	if err := f.UnmarshalBinary(forged); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("UnmarshalBinary(k = MaxUint32) = %v, want ErrInvalidData", err)
+	}
+	f.Add([]byte("still usable"))
+	if !f.MightContain([]byte("still usable")) {
+		t.Error("rejected UnmarshalBinary left the filter broken")
+	}
+}
+
+func TestBloomFilterCrossMerge(t *testing.T) {
+	a, b := NewBloomFilter(100, 0.01), NewBloomFilter(100, 0.01)
+	a.Add([]byte("a"))
+	b.Add([]byte("b"))
+
+	// Merging each way at once must not deadlock
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(2)
+		go func() { defer wg.Done(); a.Merge(b) }()
+		go func() { defer wg.Done(); b.Merge(a) }()
+	}
+	wg.Wait()
+	if !a.MightContain([]byte("b")) || !b.MightContain([]byte("a")) {
+		t.Error("cross-merged filters are missing each other's items")
+	}
+}
@@ -0,0 +1,163 @@
+package sketch
+
+import (
+	"encoding/binary"
+	"slices"
+	"sync"
+)
+
+const (
+	countMinVersion = 1
+	// version, width, depth, total
+	countMinHeaderSize = 1 + 4 + 4 + 8
+)
+
+// CountMinSketch estimates how often each item occurs in a stream using a
+// depth x width table of counters. Count never underestimates; with total
+// count N it overestimates by more than about 2N/width with probability
+// at most 2^-depth.
+type CountMinSketch struct {
+	counts     []uint64
+	width      int
+	depth      int
+	total      uint64
+	threadSafe bool
+	mu         sync.RWMutex
+}
+
+// NewCountMinSketch creates a sketch with depth rows of width counters.
+// Both are raised to at least 1.
+func NewCountMinSketch(width, depth int, threadSafe ...bool) *CountMinSketch {
+	isThreadSafe := true
+	if len(threadSafe) > 0 {
+		isThreadSafe = threadSafe[0]
+	}
+	width, depth = max(1, width), max(1, depth)
+	return &CountMinSketch{
+		counts:     make([]uint64, width*depth),
+		width:      width,
+		depth:      depth,
+		threadSafe: isThreadSafe,
+	}
+}
+
+// cells calls fn with the index of item's counter in each row.
+func (s *CountMinSketch) cells(item []byte, fn func(i int)) {
+	h := hash64(item)
+	step := mix64(h) | 1
+	for row := 0; row < s.depth; row++ {
+		fn(row*s.width + int(h%uint64(s.width)))
+		h += step
+	}
+}
+
+// Add records count more occurrences of item.
+func (s *CountMinSketch) Add(item []byte, count uint64) {
+	if s.threadSafe {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+	}
+	s.cells(item, func(i int) { s.counts[i] += count })
+	s.total += count
+}
+
+// Count returns the estimated number of occurrences of item.
+func (s *CountMinSketch) Count(item []byte) uint64 {
+	if s.threadSafe {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+	}
+	var est uint64
+	first := true
+	s.cells(item, func(i int) {
+		if first || s.counts[i] < est {
+			est, first = s.counts[i], false
+		}
+	})
+	return est
+}
+
+// Total returns the sum of all counts added.
+func (s *CountMinSketch) Total() uint64 {
+	if s.threadSafe {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+	}
+	return s.total
+}
+
+// Merge adds other's counters to s, so s then estimates counts over both
+// streams. The sketches must have the same width and depth; otherwise
+// ErrIncompatible is returned. Merging a sketch into itself doubles it.
+func (s *CountMinSketch) Merge(other *CountMinSketch) error {
+	// Copy other before locking s, as BloomFilter.Merge does
+	width, depth, total, counts := other.snapshot()
+	if s.threadSafe {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+	}
+	if s.width != width || s.depth != depth {
+		return ErrIncompatible
+	}
+	for i, c := range counts {
+		s.counts[i] += c
+	}
+	s.total += total
+	return nil
+}
+
+// snapshot returns the sketch's dimensions and total and a copy of its
+// counters.
+func (s *CountMinSketch) snapshot() (int, int, uint64, []uint64) {
+	if s.threadSafe {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+	}
+	return s.width, s.depth, s.total, slices.Clone(s.counts)
+}
+
+// MarshalBinary encodes the sketch in a portable little-endian format.
+func (s *CountMinSketch) MarshalBinary() ([]byte, error) {
+	if s.threadSafe {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+	}
+	buf := make([]byte, 0, countMinHeaderSize+len(s.counts)*8)
+	buf = append(buf, countMinVersion)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(s.width))
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(s.depth))
+	buf = binary.LittleEndian.AppendUint64(buf, s.total)
+	for _, c := range s.counts {
+		buf = binary.LittleEndian.AppendUint64(buf, c)
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary replaces the sketch's contents and dimensions with data
+// produced by MarshalBinary. The thread-safety setting of s is kept.
+func (s *CountMinSketch) UnmarshalBinary(data []byte) error {
+	if len(data) < countMinHeaderSize || data[0] != countMinVersion {
+		return ErrInvalidData
+	}
+	width := binary.LittleEndian.Uint32(data[1:])
+	depth := binary.LittleEndian.Uint32(data[5:])
+	total := binary.LittleEndian.Uint64(data[9:])
+	cells := uint64(width) * uint64(depth)
+	// Compare in counters rather than bytes so a forged size cannot
+	// overflow cells*8
+	body := len(data) - countMinHeaderSize
+	if cells == 0 || body%8 != 0 || uint64(body/8) != cells {
+		return ErrInvalidData
+	}
+	counts := make([]uint64, cells)
+	for i := range counts {
+		counts[i] = binary.LittleEndian.Uint64(data[countMinHeaderSize+i*8:])
+	}
+
+	if s.threadSafe {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+	}
+	s.counts, s.width, s.depth, s.total = counts, int(width), int(depth), total
+	return nil
+}
@@ -0,0 +1,96 @@
+package sketch
+
+import (
+	"encoding/binary"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestCountMinSketch(t *testing.T) {
+	s := NewCountMinSketch(1000, 5)
+	for i := 0; i < 100; i++ {
+		s.Add([]byte(strconv.Itoa(i)), uint64(i+1))
+	}
+	if s.Total() != 5050 {
+		t.Errorf("Total() = %d, want 5050", s.Total())
+	}
+	for i := 0; i < 100; i++ {
+		got, want := s.Count([]byte(strconv.Itoa(i))), uint64(i+1)
+		if got < want || got > want+2*5050/1000 {
+			t.Errorf("Count(%d) = %d, want %d within 2N/width", i, got, want)
+		}
+	}
+	if got := s.Count([]byte("missing")); got > 2*5050/1000 {
+		t.Errorf("Count(missing) = %d, want near 0", got)
+	}
+}
+
+func TestCountMinSketchMarshalMerge(t *testing.T) {
+	a, b := NewCountMinSketch(200, 4), NewCountMinSketch(200, 4)
+	a.Add([]byte("shared"), 3)
+	b.Add([]byte("shared"), 4)
+	b.Add([]byte("b only"), 2)
+
+	data, err := b.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() error = %v", err)
+	}
+	shipped := NewCountMinSketch(1, 1, false)
+	if err := shipped.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary() error = %v", err)
+	}
+	if err := a.Merge(shipped); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if got := a.Count([]byte("shared")); got != 7 {
+		t.Errorf("Count(shared) = %d, want 7", got)
+	}
+	if got := a.Count([]byte("b only")); got != 2 {
+		t.Errorf("Count(b only) = %d, want 2", got)
+	}
+	if a.Total() != 9 {
+		t.Errorf("Total() = %d, want 9", a.Total())
+	}
+
+	if err := a.Merge(a); err != nil || a.Count([]byte("shared")) != 14 {
+		t.Errorf("Merge(self) = %v with Count(shared) = %d, want nil, 14", err, a.Count([]byte("shared")))
+	}
+	if err := a.Merge(NewCountMinSketch(200, 3)); !errors.Is(err, ErrIncompatible) {
+		t.Errorf("Merge(different depth) = %v, want ErrIncompatible", err)
+	}
+	if err := shipped.UnmarshalBinary(data[:10]); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("UnmarshalBinary(truncated) = %v, want ErrInvalidData", err)
+	}
+}
+
+func TestCountMinSketchUnmarshalForged(t *testing.T) {
+	// 2^30 x 2^31 counters is 2^64 bytes, which wraps to an empty body
+	buf := []byte{countMinVersion}
+	buf = binary.LittleEndian.AppendUint32(buf, 1<<30)
+	buf = binary.LittleEndian.AppendUint32(buf, 1<<31)
+	buf = binary.LittleEndian.AppendUint64(buf, 0)
+	s := NewCountMinSketch(10, 2)
+	if err := s.UnmarshalBinary(buf); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("UnmarshalBinary(forged size) = %v, want ErrInvalidData", err)
+	}
+}
+
+func TestCountMinSketchCrossMerge(t *testing.T) {
+	a, b := NewCountMinSketch(100, 4), NewCountMinSketch(100, 4)
+	a.Add([]byte("a"), 1)
+	b.Add([]byte("b"), 1)
+
+	// Merging each way at once must not deadlock
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(2)
+		go func() { defer wg.Done(); a.Merge(b) }()
+		go func() { defer wg.Done(); b.Merge(a) }()
+	}
+	wg.Wait()
+	if a.Count([]byte("b")) == 0 || b.Count([]byte("a")) == 0 {
+		t.Error("cross-merged sketches are missing each other's items")
+	}
+}
@@ -0,0 +1,310 @@
+package sketch
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+	"math/rand/v2"
+	"slices"
+	"sync"
+)
+
+const (
+	cuckooBucketSize = 4
+	cuckooMaxKicks   = 500
+	cuckooVersion    = 1
+	// version, bucket count, item count, victim bucket, victim fingerprint
+	cuckooHeaderSize = 1 + 4 + 8 + 4 + 2
+)
+
+var (
+	ErrIncompatible = errors.New("sketch: sketches have different parameters")
+	ErrFilterFull   = errors.New("sketch: cuckoo filter is full")
+	ErrInvalidData  = errors.New("sketch: invalid encoded data")
+)
+
+type cuckooBucket [cuckooBucketSize]uint16
+
+// CuckooFilter is an approximate set membership filter that, unlike a Bloom
+// filter, supports Delete. Each item is reduced to a 16-bit fingerprint
+// stored in one of two candidate buckets, giving a false positive rate of
+// roughly 0.01% at 95% load. MightContain never returns false for an item
+// that was added and not deleted. Deleting an item that was never added may
+// remove another item's fingerprint.
+type CuckooFilter struct {
+	buckets []cuckooBucket
+	mask    uint32
+	count   int
+	// victim holds a fingerprint evicted by an insert that ran out of kicks,
+	// so a failed Add never loses an item that was already stored
+	victim       uint16
+	victimBucket uint32
+	threadSafe   bool
+	mu           sync.RWMutex
+}
+
+// NewCuckooFilter creates a filter sized to hold at least capacity items.
+func NewCuckooFilter(capacity int, threadSafe ...bool) *CuckooFilter {
+	isThreadSafe := true
+	if len(threadSafe) > 0 {
+		isThreadSafe = threadSafe[0]
+	}
+	n := max(1, (capacity+cuckooBucketSize-1)/cuckooBucketSize)
+	// Bucket counts are powers of two so the alternate index is an XOR
+	n = 1 << bits.Len(uint(n-1))
+	return &CuckooFilter{
+		buckets:    make([]cuckooBucket, n),
+		mask:       uint32(n - 1),
+		threadSafe: isThreadSafe,
+	}
+}
+
+// fingerprint returns a non-zero fingerprint and primary bucket for item.
+func (f *CuckooFilter) fingerprint(item []byte) (uint16, uint32) {
+	h := hash64(item)
+	fp := uint16(h >> 48)
+	if fp == 0 {
+		fp = 1
+	}
+	return fp, uint32(h) & f.mask
+}
+
+func (f *CuckooFilter) altIndex(i uint32, fp uint16) uint32 {
+	return (i ^ uint32(mix64(uint64(fp)))) & f.mask
+}
+
+// Add inserts item and reports whether there was room for it. Adding the
+// same item twice stores two fingerprints, so it must be deleted twice.
+func (f *CuckooFilter) Add(item []byte) bool {
+	if f.threadSafe {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+	}
+	fp, i := f.fingerprint(item)
+	return f.insert(fp, i)
+}
+
+func (f *CuckooFilter) insert(fp uint16, i uint32) bool {
+	if f.victim != 0 {
+		return false
+	}
+	if f.tryPut(fp, i) || f.tryPut(fp, f.altIndex(i, fp)) {
+		f.count++
+		return true
+	}
+	if rand.IntN(2) == 1 {
+		i = f.altIndex(i, fp)
+	}
+	for kick := 0; kick < cuckooMaxKicks; kick++ {
+		slot := rand.IntN(cuckooBucketSize)
+		fp, f.buckets[i][slot] = f.buckets[i][slot], fp
+		i = f.altIndex(i, fp)
+		if f.tryPut(fp, i) {
+			f.count++
+			return true
+		}
+	}
+	// The new item is stored; the displaced one waits in the victim slot
+	f.victim, f.victimBucket = fp, i
+	f.count++
+	return true
+}
+
+func (f *CuckooFilter) tryPut(fp uint16, i uint32) bool {
+	b := &f.buckets[i]
+	for slot := range b {
+		if b[slot] == 0 {
+			b[slot] = fp
+			return true
+		}
+	}
+	return false
+}
+
+// MightContain reports whether item may have been added. False positives
+// are possible; false negatives are not.
+func (f *CuckooFilter) MightContain(item []byte) bool {
+	if f.threadSafe {
+		f.mu.RLock()
+		defer f.mu.RUnlock()
+	}
+	fp, i1 := f.fingerprint(item)
+	i2 := f.altIndex(i1, fp)
+	if f.victim == fp && (f.victimBucket == i1 || f.victimBucket == i2) {
+		return true
+	}
+	return f.buckets[i1].has(fp) || f.buckets[i2].has(fp)
+}
+
+func (b *cuckooBucket) has(fp uint16) bool {
+	for _, stored := range b {
+		if stored == fp {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *cuckooBucket) remove(fp uint16) bool {
+	for slot, stored := range b {
+		if stored == fp {
+			b[slot] = 0
+			return true
+		}
+	}
+	return false
+}
+
+// Delete removes one copy of item and reports whether a matching
+// fingerprint was found. Only delete items known to have been added.
+func (f *CuckooFilter) Delete(item []byte) bool {
+	if f.threadSafe {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+	}
+	fp, i1 := f.fingerprint(item)
+	i2 := f.altIndex(i1, fp)
+	switch {
+	case f.buckets[i1].remove(fp), f.buckets[i2].remove(fp):
+	case f.victim == fp && (f.victimBucket == i1 || f.victimBucket == i2):
+		f.victim = 0
+		f.count--
+		return true
+	default:
+		return false
+	}
+	f.count--
+	// A slot opened up; give the victim another chance to settle
+	if f.victim != 0 {
+		fp, i := f.victim, f.victimBucket
+		f.victim = 0
+		f.count--
+		f.insert(fp, i)
+	}
+	return true
+}
+
+// Len returns the number of fingerprints stored.
+func (f *CuckooFilter) Len() int {
+	if f.threadSafe {
+		f.mu.RLock()
+		defer f.mu.RUnlock()
+	}
+	return f.count
+}
+
+// LoadFactor returns the fraction of fingerprint slots in use.
+func (f *CuckooFilter) LoadFactor() float64 {
+	if f.threadSafe {
+		f.mu.RLock()
+		defer f.mu.RUnlock()
+	}
+	return float64(f.count) / float64(len(f.buckets)*cuckooBucketSize)
+}
+
+// Merge adds every fingerprint in other to f. The filters must have been
+// created with the same capacity; otherwise ErrIncompatible is returned. If
+// f cannot hold the union, ErrFilterFull is returned and f is unchanged.
+func (f *CuckooFilter) Merge(other *CuckooFilter) error {
+	if f == other {
+		return nil
+	}
+	// Copy other before locking f, as BloomFilter.Merge does
+	buckets, victim, victimBucket := other.snapshot()
+	if f.threadSafe {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+	}
+	if len(f.buckets) != len(buckets) {
+		return ErrIncompatible
+	}
+
+	merged := &CuckooFilter{
+		buckets:      append([]cuckooBucket(nil), f.buckets...),
+		mask:         f.mask,
+		count:        f.count,
+		victim:       f.victim,
+		victimBucket: f.victimBucket,
+	}
+	add := func(fp uint16, i uint32) bool {
+		return fp == 0 || merged.insert(fp, i)
+	}
+	for i, b := range buckets {
+		for _, fp := range b {
+			if !add(fp, uint32(i)) {
+				return ErrFilterFull
+			}
+		}
+	}
+	if !add(victim, victimBucket) {
+		return ErrFilterFull
+	}
+	f.buckets, f.count = merged.buckets, merged.count
+	f.victim, f.victimBucket = merged.victim, merged.victimBucket
+	return nil
+}
+
+// snapshot returns a copy of the filter's buckets and its victim slot.
+func (f *CuckooFilter) snapshot() ([]cuckooBucket, uint16, uint32) {
+	if f.threadSafe {
+		f.mu.RLock()
+		defer f.mu.RUnlock()
+	}
+	return slices.Clone(f.buckets), f.victim, f.victimBucket
+}
+
+// MarshalBinary encodes the filter in a portable little-endian format.
+func (f *CuckooFilter) MarshalBinary() ([]byte, error) {
+	if f.threadSafe {
+		f.mu.RLock()
+		defer f.mu.RUnlock()
+	}
+	buf := make([]byte, 0, cuckooHeaderSize+len(f.buckets)*cuckooBucketSize*2)
+	buf = append(buf, cuckooVersion)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(f.buckets)))
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(f.count))
+	buf = binary.LittleEndian.AppendUint32(buf, f.victimBucket)
+	buf = binary.LittleEndian.AppendUint16(buf, f.victim)
+	for _, b := range f.buckets {
+		for _, fp := range b {
+			buf = binary.LittleEndian.AppendUint16(buf, fp)
+		}
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary replaces the filter's contents with data produced by
+// MarshalBinary. The thread-safety setting of f is kept.
+func (f *CuckooFilter) UnmarshalBinary(data []byte) error {
+	if len(data) < cuckooHeaderSize || data[0] != cuckooVersion {
+		return ErrInvalidData
+	}
+	n := binary.LittleEndian.Uint32(data[1:])
+	if n == 0 || n&(n-1) != 0 || uint64(len(data)-cuckooHeaderSize) != uint64(n)*cuckooBucketSize*2 {
+		return ErrInvalidData
+	}
+	count := binary.LittleEndian.Uint64(data[5:])
+	victimBucket := binary.LittleEndian.Uint32(data[13:])
+	victim := binary.LittleEndian.Uint16(data[17:])
+	if victimBucket >= n {
+		return ErrInvalidData
+	}
+	buckets := make([]cuckooBucket, n)
+	body := data[cuckooHeaderSize:]
+	for i := range buckets {
+		for slot := range buckets[i] {
+			buckets[i][slot] = binary.LittleEndian.Uint16(body)
+			body = body[2:]
+		}
+	}
+
+	if f.threadSafe {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+	}
+	f.buckets = buckets
+	f.mask = n - 1
+	f.count = int(count)
+	f.victim, f.victimBucket = victim, victimBucket
+	return nil
+}
@@ -0,0 +1,152 @@
+package sketch
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestCuckooFilter(t *testing.T) {
+	f := NewCuckooFilter(1000)
+	for i := 0; i < 900; i++ {
+		if !f.Add([]byte(strconv.Itoa(i))) {
+			t.Fatalf("Add(%d) = false, want true", i)
+		}
+	}
+	if f.Len() != 900 {
+		t.Errorf("Len() = %d, want 900", f.Len())
+	}
+	for i := 0; i < 900; i++ {
+		if !f.MightContain([]byte(strconv.Itoa(i))) {
+			t.Fatalf("MightContain(%d) = false after Add", i)
+		}
+	}
+
+	falsePositives := 0
+	for i := 0; i < 10000; i++ {
+		if f.MightContain([]byte("absent-" + strconv.Itoa(i))) {
+			falsePositives++
+		}
+	}
+	if rate := float64(falsePositives) / 10000; rate > 0.005 {
+		t.Errorf("false positive rate = %v, want <= 0.005", rate)
+	}
+
+	for i := 0; i < 900; i += 2 {
+		if !f.Delete([]byte(strconv.Itoa(i))) {
+			t.Fatalf("Delete(%d) = false, want true", i)
+		}
+	}
+	if f.Len() != 450 {
+		t.Errorf("Len() = %d, want 450", f.Len())
+	}
+	for i := 1; i < 900; i += 2 {
+		if !f.MightContain([]byte(strconv.Itoa(i))) {
+			t.Fatalf("MightContain(%d) = false after deleting others", i)
+		}
+	}
+}
+
+func TestCuckooFilterFull(t *testing.T) {
+	f := NewCuckooFilter(64, false)
+	added := 0
+	for i := 0; i < 1000; i++ {
+		if f.Add([]byte(strconv.Itoa(i))) {
+			added++
+		}
+	}
+	if added >= 1000 || added < 48 {
+		t.Errorf("added %d items to a 64-slot filter", added)
+	}
+	// Every item the filter accepted must still be found
+	for i := 0; i < added; i++ {
+		if !f.MightContain([]byte(strconv.Itoa(i))) {
+			t.Fatalf("MightContain(%d) = false for an accepted item", i)
+		}
+	}
+}
+
+func TestCuckooFilterMarshal(t *testing.T) {
+	f := NewCuckooFilter(100)
+	for i := 0; i < 50; i++ {
+		f.Add([]byte(strconv.Itoa(i)))
+	}
+	data, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() error = %v", err)
+	}
+
+	restored := NewCuckooFilter(1)
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary() error = %v", err)
+	}
+	if restored.Len() != 50 {
+		t.Errorf("Len() = %d, want 50", restored.Len())
+	}
+	for i := 0; i < 50; i++ {
+		if !restored.MightContain([]byte(strconv.Itoa(i))) {
+			t.Fatalf("MightContain(%d) = false after round trip", i)
+		}
+	}
+
+	if err := restored.UnmarshalBinary(data[:len(data)-1]); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("UnmarshalBinary(truncated) = %v, want ErrInvalidData", err)
+	}
+}
+
+func TestCuckooFilterMerge(t *testing.T) {
+	a := NewCuckooFilter(1000)
+	b := NewCuckooFilter(1000)
+	for i := 0; i < 300; i++ {
+		a.Add([]byte("a" + strconv.Itoa(i)))
+		b.Add([]byte("b" + strconv.Itoa(i)))
+	}
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if a.Len() != 600 {
+		t.Errorf("Len() = %d, want 600", a.Len())
+	}
+	for i := 0; i < 300; i++ {
+		if !a.MightContain([]byte("a"+strconv.Itoa(i))) || !a.MightContain([]byte("b"+strconv.Itoa(i))) {
+			t.Fatalf("merged filter lost item %d", i)
+		}
+	}
+
+	if err := a.Merge(NewCuckooFilter(10)); !errors.Is(err, ErrIncompatible) {
+		t.Errorf("Merge(smaller) = %v, want ErrIncompatible", err)
+	}
+
+	full := NewCuckooFilter(8)
+	other := NewCuckooFilter(8)
+	for i := 0; i < 8; i++ {
+		full.Add([]byte("x" + strconv.Itoa(i)))
+		other.Add([]byte("y" + strconv.Itoa(i)))
+	}
+	before := full.Len()
+	if err := full.Merge(other); !errors.Is(err, ErrFilterFull) {
+		t.Errorf("Merge(overflowing) = %v, want ErrFilterFull", err)
+	}
+	if full.Len() != before {
+		t.Errorf("Len() = %d after failed Merge, want %d", full.Len(), before)
+	}
+}
+
+func TestCuckooFilterCrossMerge(t *testing.T) {
+	a, b := NewCuckooFilter(1000), NewCuckooFilter(1000)
+	a.Add([]byte("a"))
+	b.Add([]byte("b"))
+
+	// Merging each way at once must not deadlock
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func() { defer wg.Done(); a.Merge(b) }()
+		go func() { defer wg.Done(); b.Merge(a) }()
+	}
+	wg.Wait()
+	if !a.MightContain([]byte("b")) || !b.MightContain([]byte("a")) {
+		t.Error("cross-merged filters are missing each other's items")
+	}
+}
@@ -0,0 +1,23 @@
+// Package sketch provides compact probabilistic summaries of large data sets:
+// approximate membership, similarity and cardinality. Hashing is
+// deterministic across processes so sketches can be serialized and merged.
+package sketch
+
+import "hash/fnv"
+
+// hash64 is FNV-64a finished with the splitmix64 finalizer, which spreads
+// short, similar inputs across all 64 bits.
+func hash64(data []byte) uint64 {
+	h := fnv.New64a()
+	h.Write(data)
+	return mix64(h.Sum64())
+}
+
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,75 @@
+package sketch
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestHyperLogLogCount(t *testing.T) {
+	for _, n := range []int{0, 10, 1000, 100000} {
+		h := NewHyperLogLog(14)
+		for i := 0; i < n; i++ {
+			h.Add([]byte(strconv.Itoa(i)))
+			h.Add([]byte(strconv.Itoa(i)))
+		}
+		got := float64(h.Count())
+		if math.Abs(got-float64(n)) > 0.03*float64(n)+1 {
+			t.Errorf("Count() after %d distinct items = %.0f, want within 3%%", n, got)
+		}
+	}
+}
+
+func TestHyperLogLogMarshalMerge(t *testing.T) {
+	a, b := NewHyperLogLog(12), NewHyperLogLog(12, false)
+	for i := 0; i < 20000; i++ {
+		a.Add([]byte("a" + strconv.Itoa(i)))
+		b.Add([]byte("b" + strconv.Itoa(i)))
+	}
+	data, err := b.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() error = %v", err)
+	}
+	shipped := NewHyperLogLog(4)
+	if err := shipped.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary() error = %v", err)
+	}
+	if shipped.Count() != b.Count() {
+		t.Errorf("Count() after round trip = %d, want %d", shipped.Count(), b.Count())
+	}
+	if err := a.Merge(shipped); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if got := float64(a.Count()); math.Abs(got-40000) > 0.05*40000 {
+		t.Errorf("Count() after Merge = %.0f, want about 40000", got)
+	}
+
+	if err := a.Merge(NewHyperLogLog(10)); !errors.Is(err, ErrIncompatible) {
+		t.Errorf("Merge(different precision) = %v, want ErrIncompatible", err)
+	}
+	if err := shipped.UnmarshalBinary(data[:len(data)-1]); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("UnmarshalBinary(truncated) = %v, want ErrInvalidData", err)
+	}
+}
+
+func TestHyperLogLogCrossMerge(t *testing.T) {
+	a, b := NewHyperLogLog(10), NewHyperLogLog(10)
+	for i := 0; i < 100; i++ {
+		a.Add([]byte("a" + strconv.Itoa(i)))
+		b.Add([]byte("b" + strconv.Itoa(i)))
+	}
+
+	// Merging each way at once must not deadlock
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(2)
+		go func() { defer wg.Done(); a.Merge(b) }()
+		go func() { defer wg.Done(); b.Merge(a) }()
+	}
+	wg.Wait()
+	if est := a.Count(); est < 180 || est > 220 {
+		t.Errorf("Count() after cross-merge = %d, want about 200", est)
+	}
+}
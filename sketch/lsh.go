@@ -0,0 +1,103 @@
+package sketch
+
+import (
+	"encoding/binary"
+	"sync"
+)
+
+// MinHashBands splits a MinHash signature into bands rows and hashes each
+// band to a bucket key. Two signatures with Jaccard similarity s share at
+// least one key with probability 1 - (1 - s^r)^bands, where r is the number
+// of rows per band. Trailing slots that do not fill a band are ignored.
+func MinHashBands(sig []uint64, bands int) []uint64 {
+	if bands <= 0 || bands > len(sig) {
+		return nil
+	}
+	rows := len(sig) / bands
+	keys := make([]uint64, bands)
+	buf := make([]byte, 0, rows*8)
+	for b := range keys {
+		buf = buf[:0]
+		for _, v := range sig[b*rows : (b+1)*rows] {
+			buf = binary.LittleEndian.AppendUint64(buf, v)
+		}
+		keys[b] = bandKey(b, hash64(buf))
+	}
+	return keys
+}
+
+// SimHashBands splits a SimHash signature into bands contiguous bit ranges.
+// By the pigeonhole principle, two signatures within Hamming distance
+// bands-1 of each other share at least one key.
+func SimHashBands(sig uint64, bands int) []uint64 {
+	if bands <= 0 || bands > 64 {
+		return nil
+	}
+	keys := make([]uint64, bands)
+	start := 0
+	for b := range keys {
+		width := (64 - start) / (bands - b)
+		part := (sig >> start) & (1<<width - 1)
+		keys[b] = bandKey(b, part)
+		start += width
+	}
+	return keys
+}
+
+// bandKey mixes the band number in so equal values in different bands land
+// in different buckets.
+func bandKey(band int, value uint64) uint64 {
+	return mix64(value ^ uint64(band)<<56)
+}
+
+// LSHIndex buckets items by their band keys so near-duplicate candidates can
+// be found without comparing every pair.
+type LSHIndex[ID comparable] struct {
+	buckets    map[uint64][]ID
+	threadSafe bool
+	mu         sync.RWMutex
+}
+
+func NewLSHIndex[ID comparable](threadSafe ...bool) *LSHIndex[ID] {
+	isThreadSafe := true
+	if len(threadSafe) > 0 {
+		isThreadSafe = threadSafe[0]
+	}
+	return &LSHIndex[ID]{
+		buckets:    make(map[uint64][]ID),
+		threadSafe: isThreadSafe,
+	}
+}
+
+// Add files id under each of its band keys, as returned by MinHashBands or
+// SimHashBands.
+func (x *LSHIndex[ID]) Add(id ID, keys []uint64) {
+	if x.threadSafe {
+		x.mu.Lock()
+		defer x.mu.Unlock()
+	}
+	for _, key := range keys {
+		x.buckets[key] = append(x.buckets[key], id)
+	}
+}
+
+// Candidates returns the distinct ids sharing at least one band key with
+// keys, in the order they were first found. Candidates should be confirmed
+// with an exact or estimated similarity check.
+func (x *LSHIndex[ID]) Candidates(keys []uint64) []ID {
+	if x.threadSafe {
+		x.mu.RLock()
+		defer x.mu.RUnlock()
+	}
+	seen := make(map[ID]struct{})
+	var result []ID
+	for _, key := range keys {
+		for _, id := range x.buckets[key] {
+			if _, dup := seen[id]; !dup {
+				seen[id] = struct{}{}
+				result = append(result, id)
+			}
+		}
+	}
+	return result
+}
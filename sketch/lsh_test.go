@@ -0,0 +1,72 @@
+package sketch
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestMinHashBands(t *testing.T) {
+	sig := make([]uint64, 20)
+	for i := range sig {
+		sig[i] = uint64(i)
+	}
+	keys := MinHashBands(sig, 5)
+	if len(keys) != 5 {
+		t.Fatalf("len(MinHashBands()) = %d, want 5", len(keys))
+	}
+	other := slices.Clone(sig)
+	other[0] = 99
+	changed := MinHashBands(other, 5)
+	if changed[0] == keys[0] || !slices.Equal(changed[1:], keys[1:]) {
+		t.Errorf("changing one slot should change exactly its band")
+	}
+	if MinHashBands(sig, 0) != nil || MinHashBands(sig, 21) != nil {
+		t.Errorf("MinHashBands() with an invalid band count should return nil")
+	}
+}
+
+func TestSimHashBands(t *testing.T) {
+	sig := uint64(0xdeadbeefcafebabe)
+	keys := SimHashBands(sig, 4)
+	// Three flipped bits can touch at most three of the four bands
+	flipped := sig ^ (1 | 1<<20 | 1<<40)
+	shared := 0
+	for i, key := range SimHashBands(flipped, 4) {
+		if key == keys[i] {
+			shared++
+		}
+	}
+	if shared == 0 {
+		t.Errorf("signatures within distance 3 should share a band")
+	}
+	if len(SimHashBands(sig, 1)) != 1 || SimHashBands(sig, 65) != nil {
+		t.Errorf("unexpected band counts from SimHashBands()")
+	}
+}
+
+func TestLSHIndex(t *testing.T) {
+	docs := map[string][]int{
+		"a":  {0, 100},
+		"a2": {5, 100},
+		"b":  {1000, 1100},
+	}
+	index := NewLSHIndex[string]()
+	sigs := make(map[string][]uint64)
+	for id, span := range docs {
+		m := NewMinHash(64, false)
+		for i := span[0]; i < span[1]; i++ {
+			m.Add([]byte(strconv.Itoa(i)))
+		}
+		sigs[id] = m.Signature()
+		index.Add(id, MinHashBands(sigs[id], 16))
+	}
+
+	candidates := index.Candidates(MinHashBands(sigs["a"], 16))
+	if !slices.Contains(candidates, "a") || !slices.Contains(candidates, "a2") {
+		t.Errorf("Candidates(a) = %v, want a and a2", candidates)
+	}
+	if slices.Contains(candidates, "b") {
+		t.Errorf("Candidates(a) = %v, should not contain b", candidates)
+	}
+}
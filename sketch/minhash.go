@@ -0,0 +1,78 @@
+package sketch
+
+import (
+	"math"
+	"sync"
+)
+
+// MinHash estimates the Jaccard similarity of two token sets from fixed-size
+// signatures. Each of the k signature slots keeps the minimum of a different
+// hash function over the tokens added; the fraction of slots two signatures
+// agree on is an unbiased estimate of |A ∩ B| / |A ∪ B| with standard error
+// about 1/sqrt(k).
+type MinHash struct {
+	mins       []uint64
+	threadSafe bool
+	mu         sync.RWMutex
+}
+
+// NewMinHash creates an empty MinHash with k hash functions.
+func NewMinHash(k int, threadSafe ...bool) *MinHash {
+	isThreadSafe := true
+	if len(threadSafe) > 0 {
+		isThreadSafe = threadSafe[0]
+	}
+	mins := make([]uint64, max(1, k))
+	for i := range mins {
+		mins[i] = math.MaxUint64
+	}
+	return &MinHash{
+		mins:       mins,
+		threadSafe: isThreadSafe,
+	}
+}
+
+// Add folds token into the signature. Adding a token twice has no effect.
+func (m *MinHash) Add(token []byte) {
+	if m.threadSafe {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+	}
+	base := hash64(token)
+	for i := range m.mins {
+		// Derive the i-th hash function by reseeding the finalizer
+		if h := mix64(base + uint64(i)*0x9e3779b97f4a7c15); h < m.mins[i] {
+			m.mins[i] = h
+		}
+	}
+}
+
+// Signature returns a copy of the current signature.
+func (m *MinHash) Signature() []uint64 {
+	if m.threadSafe {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
+	return append([]uint64(nil), m.mins...)
+}
+
+// Jaccard estimates the Jaccard similarity between the sets summarized by m
+// and other. It returns ErrIncompatible if their signature sizes differ.
+func (m *MinHash) Jaccard(other *MinHash) (float64, error) {
+	return JaccardEstimate(m.Signature(), other.Signature())
+}
+
+// JaccardEstimate estimates Jaccard similarity from two MinHash signatures,
+// for signatures that were stored rather than kept as MinHash values.
+func JaccardEstimate(a, b []uint64) (float64, error) {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0, ErrIncompatible
+	}
+	equal := 0
+	for i := range a {
+		if a[i] == b[i] {
+			equal++
+		}
+	}
+	return float64(equal) / float64(len(a)), nil
+}
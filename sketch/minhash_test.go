@@ -0,0 +1,48 @@
+package sketch
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestMinHashJaccard(t *testing.T) {
+	a := NewMinHash(256)
+	b := NewMinHash(256)
+	// A = [0, 1000), B = [500, 1500): true Jaccard is 500/1500
+	for i := 0; i < 1000; i++ {
+		a.Add([]byte(strconv.Itoa(i)))
+		b.Add([]byte(strconv.Itoa(i + 500)))
+	}
+	got, err := a.Jaccard(b)
+	if err != nil {
+		t.Fatalf("Jaccard() error = %v", err)
+	}
+	if want := 1.0 / 3; math.Abs(got-want) > 0.1 {
+		t.Errorf("Jaccard() = %v, want about %v", got, want)
+	}
+
+	same, _ := a.Jaccard(a)
+	if same != 1 {
+		t.Errorf("Jaccard(self) = %v, want 1", same)
+	}
+
+	if _, err := a.Jaccard(NewMinHash(16)); !errors.Is(err, ErrIncompatible) {
+		t.Errorf("Jaccard(different k) error = %v, want ErrIncompatible", err)
+	}
+}
+
+func TestMinHashSignature(t *testing.T) {
+	m := NewMinHash(8, false)
+	m.Add([]byte("x"))
+	sig := m.Signature()
+	m.Add([]byte("x"))
+	if got, _ := JaccardEstimate(sig, m.Signature()); got != 1 {
+		t.Errorf("re-adding a token changed the signature")
+	}
+	sig[0] = 0
+	if m.Signature()[0] == 0 {
+		t.Errorf("Signature() should return a copy")
+	}
+}
@@ -0,0 +1,74 @@
+package sketch
+
+import (
+	"math/bits"
+	"sync"
+)
+
+// SimHash builds a 64-bit fingerprint whose Hamming distance to another
+// SimHash fingerprint tracks the cosine distance between the weighted token
+// sets. Near-duplicate documents differ in only a few bits.
+type SimHash struct {
+	weights    [64]int64
+	threadSafe bool
+	mu         sync.RWMutex
+}
+
+func NewSimHash(threadSafe ...bool) *SimHash {
+	isThreadSafe := true
+	if len(threadSafe) > 0 {
+		isThreadSafe = threadSafe[0]
+	}
+	return &SimHash{
+		threadSafe: isThreadSafe,
+	}
+}
+
+// Add folds token into the fingerprint with weight 1.
+func (s *SimHash) Add(token []byte) {
+	s.AddWeighted(token, 1)
+}
+
+// AddWeighted folds token into the fingerprint with the given weight, e.g.
+// a term frequency. Unlike MinHash, adding a token twice doubles its weight.
+func (s *SimHash) AddWeighted(token []byte, weight int) {
+	if s.threadSafe {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+	}
+	h := hash64(token)
+	for i := range s.weights {
+		if h&(1<<i) != 0 {
+			s.weights[i] += int64(weight)
+		} else {
+			s.weights[i] -= int64(weight)
+		}
+	}
+}
+
+// Signature returns the fingerprint: bit i is set when the tokens whose hash
+// has bit i set outweigh those that do not.
+func (s *SimHash) Signature() uint64 {
+	if s.threadSafe {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+	}
+	var sig uint64
+	for i, w := range s.weights {
+		if w > 0 {
+			sig |= 1 << i
+		}
+	}
+	return sig
+}
+
+// Hamming returns the number of bits in which two SimHash signatures differ.
+func Hamming(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// SimHashSimilarity maps the Hamming distance between two signatures to a
+// similarity in [0, 1].
+func SimHashSimilarity(a, b uint64) float64 {
+	return 1 - float64(Hamming(a, b))/64
+}
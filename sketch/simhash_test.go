@@ -0,0 +1,37 @@
+package sketch
+
+import (
+	"strings"
+	"testing"
+)
+
+func simhashOf(text string) uint64 {
+	s := NewSimHash(false)
+	for _, word := range strings.Fields(text) {
+		s.Add([]byte(word))
+	}
+	return s.Signature()
+}
+
+func TestSimHash(t *testing.T) {
+	base := "the quick brown fox jumps over the lazy dog while the cat sleeps on the warm mat near the door"
+	near := "the quick brown fox jumps over the lazy dog while the cat sleeps on the warm rug near the door"
+	far := "stock markets rallied today as investors cheered strong quarterly earnings from technology firms"
+
+	a, b, c := simhashOf(base), simhashOf(near), simhashOf(far)
+	if Hamming(a, a) != 0 || SimHashSimilarity(a, a) != 1 {
+		t.Errorf("a signature should be identical to itself")
+	}
+	if Hamming(a, b) >= Hamming(a, c) {
+		t.Errorf("Hamming(near) = %d, Hamming(far) = %d, want near < far", Hamming(a, b), Hamming(a, c))
+	}
+}
+
+func TestSimHashWeighted(t *testing.T) {
+	s := NewSimHash()
+	s.AddWeighted([]byte("heavy"), 10)
+	s.Add([]byte("light"))
+	if got, want := s.Signature(), simhashOf("heavy"); got != want {
+		t.Errorf("Signature() = %x, want the heavy token's hash %x", got, want)
+	}
+}
@@ -0,0 +1,45 @@
+package slicesx
+
+import (
+	"slices"
+	"sort"
+
+	"dsgo/utils"
+)
+
+// BinarySearch searches for x in the sorted slice s and returns the position
+// where x is found, or the position where it would be inserted, and whether
+// it was found.
+func BinarySearch[T utils.Ordered](s []T, x T) (int, bool) {
+	return slices.BinarySearch(s, x)
+}
+
+// BinarySearchFunc is like BinarySearch but uses cmp to compare elements of s
+// with the target.
+func BinarySearchFunc[E, T any](s []E, target T, cmp func(E, T) int) (int, bool) {
+	return slices.BinarySearchFunc(s, target, cmp)
+}
+
+// BisectLeft returns the first position in the sorted slice s at which x
+// could be inserted while keeping s sorted, i.e. before any existing copies of x.
+func BisectLeft[T utils.Ordered](s []T, x T) int {
+	return sort.Search(len(s), func(i int) bool { return s[i] >= x })
+}
+
+// BisectRight returns the last position in the sorted slice s at which x
+// could be inserted while keeping s sorted, i.e. after any existing copies of x.
+func BisectRight[T utils.Ordered](s []T, x T) int {
+	return sort.Search(len(s), func(i int) bool { return s[i] > x })
+}
+
+// BisectLeftFunc is like BisectLeft but uses cmp to compare elements of s
+// with the target.
+func BisectLeftFunc[E, T any](s []E, target T, cmp func(E, T) int) int {
+	return sort.Search(len(s), func(i int) bool { return cmp(s[i], target) >= 0 })
+}
+
+// BisectRightFunc is like BisectRight but uses cmp to compare elements of s
+// with the target.
+func BisectRightFunc[E, T any](s []E, target T, cmp func(E, T) int) int {
+	return sort.Search(len(s), func(i int) bool { return cmp(s[i], target) > 0 })
+}
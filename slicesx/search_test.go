@@ -0,0 +1,66 @@
+package slicesx
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBinarySearch(t *testing.T) {
+	s := []int{1, 3, 5, 7}
+
+	tests := []struct {
+		x         int
+		wantPos   int
+		wantFound bool
+	}{
+		{0, 0, false},
+		{1, 0, true},
+		{4, 2, false},
+		{7, 3, true},
+		{8, 4, false},
+	}
+
+	for _, tt := range tests {
+		pos, found := BinarySearch(s, tt.x)
+		if pos != tt.wantPos || found != tt.wantFound {
+			t.Errorf("BinarySearch(%d) = %d, %v; want %d, %v", tt.x, pos, found, tt.wantPos, tt.wantFound)
+		}
+	}
+}
+
+func TestBisect(t *testing.T) {
+	s := []int{1, 2, 2, 2, 3}
+
+	if got := BisectLeft(s, 2); got != 1 {
+		t.Errorf("BisectLeft(2) = %d, want 1", got)
+	}
+	if got := BisectRight(s, 2); got != 4 {
+		t.Errorf("BisectRight(2) = %d, want 4", got)
+	}
+	if got := BisectLeft(s, 0); got != 0 {
+		t.Errorf("BisectLeft(0) = %d, want 0", got)
+	}
+	if got := BisectRight(s, 9); got != 5 {
+		t.Errorf("BisectRight(9) = %d, want 5", got)
+	}
+}
+
+func TestBisectFunc(t *testing.T) {
+	type entry struct {
+		name string
+	}
+	s := []entry{{"Apple"}, {"banana"}, {"Banana"}, {"cherry"}}
+	cmp := func(e entry, target string) int {
+		return strings.Compare(strings.ToLower(e.name), target)
+	}
+
+	if got := BisectLeftFunc(s, "banana", cmp); got != 1 {
+		t.Errorf("BisectLeftFunc(banana) = %d, want 1", got)
+	}
+	if got := BisectRightFunc(s, "banana", cmp); got != 3 {
+		t.Errorf("BisectRightFunc(banana) = %d, want 3", got)
+	}
+	if pos, found := BinarySearchFunc(s, "date", cmp); found || pos != 4 {
+		t.Errorf("BinarySearchFunc(date) = %d, %v; want 4, false", pos, found)
+	}
+}
@@ -0,0 +1,30 @@
+package slicesx
+
+import "slices"
+
+// minShrinkCap is the capacity at or below which Shrink leaves a slice
+// alone, so small slices do not bounce between allocations.
+const minShrinkCap = 64
+
+// Shrink returns s copied into a backing array of twice its length once its
+// length has fallen below a quarter of its capacity, and s itself otherwise.
+// Calling it after every removal lets a slice that grew large give the
+// memory back as it drains, while the 4x/2x gap keeps alternating pushes and
+// pops from reallocating each time.
+func Shrink[S ~[]E, E any](s S) S {
+	if cap(s) <= minShrinkCap || len(s) >= cap(s)/4 {
+		return s
+	}
+	shrunk := make(S, len(s), 2*len(s))
+	copy(shrunk, s)
+	return shrunk
+}
+
+// Compact returns s copied into a backing array that exactly fits it,
+// releasing any spare capacity.
+func Compact[S ~[]E, E any](s S) S {
+	if len(s) == cap(s) {
+		return s
+	}
+	return slices.Clone(s)
+}
@@ -0,0 +1,41 @@
+package slicesx
+
+import (
+	"testing"
+)
+
+func TestShrink(t *testing.T) {
+	small := make([]int, 1, minShrinkCap)
+	if got := Shrink(small); cap(got) != minShrinkCap {
+		t.Errorf("Shrink() of a small slice changed capacity to %d", cap(got))
+	}
+
+	s := make([]int, 1000)
+	for i := range s {
+		s[i] = i
+	}
+	if got := Shrink(s[:250]); cap(got) != 1000 {
+		t.Errorf("Shrink() at a quarter full changed capacity to %d, want 1000", cap(got))
+	}
+	got := Shrink(s[:100])
+	if len(got) != 100 || cap(got) != 200 {
+		t.Fatalf("Shrink() len, cap = %d, %d, want 100, 200", len(got), cap(got))
+	}
+	if got[99] != 99 {
+		t.Errorf("Shrink() lost contents: got[99] = %d", got[99])
+	}
+	got[0] = -1
+	if s[0] != 0 {
+		t.Error("Shrink() result shares its backing array with the input")
+	}
+}
+
+func TestCompact(t *testing.T) {
+	s := make([]int, 3, 100)
+	if got := Compact(s); len(got) != 3 || cap(got) != 3 {
+		t.Errorf("Compact() len, cap = %d, %d, want 3, 3", len(got), cap(got))
+	}
+	if got := Compact(make([]int, 0, 10)); got == nil || cap(got) != 0 {
+		t.Errorf("Compact() of an empty slice = %#v, want an empty non-nil slice", got)
+	}
+}
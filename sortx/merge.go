@@ -0,0 +1,91 @@
+package sortx
+
+import (
+	"iter"
+
+	"dsgo/heaps"
+	"dsgo/utils"
+)
+
+type mergeEntry[K utils.Ordered, V any] struct {
+	key   K
+	value V
+}
+
+// MergeSorted lazily merges sources that each yield keys in ascending order
+// into one ascending sequence; it is MergeSortedFunc over key-value pairs
+// ordered by key. Keys present in several sources are yielded once per
+// source, in source order, so callers can resolve duplicates (e.g. newest
+// run wins in LSM compaction) by the order they arrive. A source that yields keys out
+// of order produces out-of-order output rather than an error.
+//
+// Any ascending iterator works as a source; a sorted map or tree's Range
+// method can be adapted with func(yield func(K, V) bool) { m.Range(yield) }.
+func MergeSorted[K utils.Ordered, V any](sources ...iter.Seq2[K, V]) iter.Seq2[K, V] {
+	entries := make([]iter.Seq[mergeEntry[K, V]], len(sources))
+	for i, source := range sources {
+		entries[i] = func(yield func(mergeEntry[K, V]) bool) {
+			for key, value := range source {
+				if !yield(mergeEntry[K, V]{key: key, value: value}) {
+					return
+				}
+			}
+		}
+	}
+	byKey := utils.ComparingField(func(e mergeEntry[K, V]) K { return e.key })
+	merged := MergeSortedFunc(byKey, entries...)
+	return func(yield func(K, V) bool) {
+		for e := range merged {
+			if !yield(e.key, e.value) {
+				return
+			}
+		}
+	}
+}
+
+type mergeCursor[T any] struct {
+	value  T
+	source int
+}
+
+// MergeSortedFunc lazily merges sources that each yield values in the
+// order given by cmp into one sequence in that order, holding one pending
+// value per source in a min-heap. Values that compare equal are yielded in
+// source order, so merging stable sorted runs in creation order gives a
+// stable result.
+func MergeSortedFunc[T any](cmp utils.CompareFunc[T], sources ...iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		nexts := make([]func() (T, bool), len(sources))
+		for i, source := range sources {
+			next, stop := iter.Pull(source)
+			defer stop()
+			nexts[i] = next
+		}
+
+		h := heaps.NewMinHeap(func(a, b mergeCursor[T]) bool {
+			if c := cmp(a.value, b.value); c != 0 {
+				return c < 0
+			}
+			return a.source < b.source
+		}, false)
+		advance := func(source int) {
+			if value, ok := nexts[source](); ok {
+				h.Push(mergeCursor[T]{value: value, source: source})
+			}
+		}
+		for i := range nexts {
+			advance(i)
+		}
+
+		for {
+			c, ok := h.Pop()
+			if !ok {
+				return
+			}
+			if !yield(c.value) {
+				return
+			}
+			advance(c.source)
+		}
+	}
+}
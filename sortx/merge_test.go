@@ -0,0 +1,103 @@
+package sortx
+
+import (
+	"iter"
+	"testing"
+
+	"dsgo/maps"
+	"dsgo/utils"
+)
+
+func seqOf(entries ...utils.Entry[int, string]) iter.Seq2[int, string] {
+	return func(yield func(int, string) bool) {
+		for _, e := range entries {
+			if !yield(e.Key, e.Value) {
+				return
+			}
+		}
+	}
+}
+
+func TestMergeSorted(t *testing.T) {
+	a := seqOf(utils.Entry[int, string]{Key: 1, Value: "a1"}, utils.Entry[int, string]{Key: 4, Value: "a4"}, utils.Entry[int, string]{Key: 7, Value: "a7"})
+	b := seqOf(utils.Entry[int, string]{Key: 2, Value: "b2"}, utils.Entry[int, string]{Key: 4, Value: "b4"})
+	empty := seqOf()
+
+	m := maps.NewSortedMap[int, string]()
+	m.Set(3, "m3")
+	m.Set(9, "m9")
+	fromMap := func(yield func(int, string) bool) { m.Range(yield) }
+
+	var got []string
+	for _, v := range MergeSorted(a, empty, b, fromMap) {
+		got = append(got, v)
+	}
+	want := []string{"a1", "b2", "m3", "a4", "b4", "a7", "m9"}
+	if len(got) != len(want) {
+		t.Fatalf("MergeSorted() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("MergeSorted() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestMergeSortedEarlyStop(t *testing.T) {
+	pulled := 0
+	counting := func(yield func(int, string) bool) {
+		for i := 0; i < 100; i++ {
+			pulled++
+			if !yield(i, "") {
+				return
+			}
+		}
+	}
+
+	count := 0
+	for range MergeSorted(counting) {
+		count++
+		if count == 3 {
+			break
+		}
+	}
+	// One entry is pulled ahead of what has been yielded
+	if count != 3 || pulled > 4 {
+		t.Errorf("yielded %d and pulled %d entries, want 3 and at most 4", count, pulled)
+	}
+
+	for range MergeSorted[int, string]() {
+		t.Fatal("MergeSorted() with no sources should yield nothing")
+	}
+}
+
+func TestMergeSortedFunc(t *testing.T) {
+	type item struct {
+		n   int
+		tag string
+	}
+	byN := func(a, b item) int { return a.n - b.n }
+	run := func(items ...item) iter.Seq[item] {
+		return func(yield func(item) bool) {
+			for _, it := range items {
+				if !yield(it) {
+					return
+				}
+			}
+		}
+	}
+
+	var got []item
+	for it := range MergeSortedFunc(byN, run(item{1, "a"}, item{3, "a"}), run(item{1, "b"}, item{2, "b"})) {
+		got = append(got, it)
+	}
+	want := []item{{1, "a"}, {1, "b"}, {2, "b"}, {3, "a"}}
+	if len(got) != len(want) {
+		t.Fatalf("MergeSortedFunc() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("MergeSortedFunc() = %v, want %v", got, want)
+		}
+	}
+}
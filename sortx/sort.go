@@ -0,0 +1,66 @@
+package sortx
+
+import (
+	"slices"
+
+	"dsgo/linkedlist"
+	"dsgo/maps"
+	"dsgo/utils"
+)
+
+// SortSlice stably sorts s in place by cmp and returns it.
+func SortSlice[T any](s []T, cmp utils.CompareFunc[T]) []T {
+	slices.SortStableFunc(s, cmp)
+	return s
+}
+
+// SortLinkedList returns a new list holding the values of l stably sorted by cmp.
+// The source list is left untouched.
+func SortLinkedList[T comparable](l *linkedlist.DoubleLinkedList[T], cmp utils.CompareFunc[T], threadSafe ...bool) *linkedlist.DoubleLinkedList[T] {
+	values := make([]T, 0, l.Len())
+	l.ForEach(func(v T) {
+		values = append(values, v)
+	})
+	slices.SortStableFunc(values, cmp)
+
+	sorted := linkedlist.NewDoubleLinkedList[T](threadSafe...)
+	for _, v := range values {
+		sorted.PushBack(v)
+	}
+	return sorted
+}
+
+// SortSingleLinkedList is the SingleLinkedList counterpart of SortLinkedList.
+func SortSingleLinkedList[T comparable](l *linkedlist.SingleLinkedList[T], cmp utils.CompareFunc[T], threadSafe ...bool) *linkedlist.SingleLinkedList[T] {
+	values := make([]T, 0, l.Len())
+	l.ForEach(func(v T) {
+		values = append(values, v)
+	})
+	slices.SortStableFunc(values, cmp)
+
+	sorted := linkedlist.NewSingleLinkedList[T](threadSafe...)
+	for _, v := range values {
+		sorted.PushBack(v)
+	}
+	return sorted
+}
+
+// SortedKeys returns the keys of m in ascending order.
+func SortedKeys[K utils.Ordered, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// ToSortedMap copies m into a SortedMap. Keys are sorted up front so every
+// insert appends, keeping the build O(n log n).
+func ToSortedMap[K utils.Ordered, V any](m map[K]V) *maps.SortedMap[K, V] {
+	sm := maps.NewSortedMap[K, V]()
+	for _, k := range SortedKeys(m) {
+		sm.Set(k, m[k])
+	}
+	return sm
+}
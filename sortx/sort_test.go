@@ -0,0 +1,93 @@
+package sortx
+
+import (
+	"testing"
+
+	"dsgo/linkedlist"
+	"dsgo/utils"
+)
+
+type record struct {
+	group int
+	name  string
+}
+
+func TestSortSliceIsStable(t *testing.T) {
+	records := []record{
+		{2, "a"},
+		{1, "b"},
+		{2, "c"},
+		{1, "d"},
+	}
+	SortSlice(records, utils.ComparingField(func(r record) int { return r.group }))
+
+	want := []string{"b", "d", "a", "c"}
+	for i, r := range records {
+		if r.name != want[i] {
+			t.Errorf("records[%d] = %s, want %s", i, r.name, want[i])
+		}
+	}
+}
+
+func TestSortLinkedList(t *testing.T) {
+	list := linkedlist.NewDoubleLinkedList[int](false)
+	for _, v := range []int{3, 1, 2} {
+		list.PushBack(v)
+	}
+
+	sorted := SortLinkedList(list, utils.Reversed(utils.Natural[int]()), false)
+	var got []int
+	sorted.ForEach(func(v int) {
+		got = append(got, v)
+	})
+	want := []int{3, 2, 1}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("sorted[%d] = %d, want %d", i, got[i], want[i])
+		}
+	}
+
+	if first, _ := list.At(0); first != 3 || list.Len() != 3 {
+		t.Error("SortLinkedList should not modify the source list")
+	}
+}
+
+func TestSortSingleLinkedList(t *testing.T) {
+	list := linkedlist.NewSingleLinkedList[string](false)
+	for _, v := range []string{"b", "c", "a"} {
+		list.PushBack(v)
+	}
+
+	sorted := SortSingleLinkedList(list, utils.Natural[string]())
+	want := []string{"a", "b", "c"}
+	for i := range want {
+		if got, _ := sorted.At(i); got != want[i] {
+			t.Errorf("sorted.At(%d) = %s, want %s", i, got, want[i])
+		}
+	}
+}
+
+func TestSortedKeysAndToSortedMap(t *testing.T) {
+	m := map[string]int{"c": 3, "a": 1, "b": 2}
+
+	keys := SortedKeys(m)
+	want := []string{"a", "b", "c"}
+	for i := range want {
+		if keys[i] != want[i] {
+			t.Errorf("SortedKeys()[%d] = %s, want %s", i, keys[i], want[i])
+		}
+	}
+
+	sm := ToSortedMap(m)
+	if sm.Len() != 3 {
+		t.Fatalf("ToSortedMap().Len() = %d, want 3", sm.Len())
+	}
+	for i, k := range sm.Keys() {
+		if k != want[i] {
+			t.Errorf("ToSortedMap().Keys()[%d] = %s, want %s", i, k, want[i])
+		}
+		if v, _ := sm.Get(k); v != m[k] {
+			t.Errorf("ToSortedMap().Get(%s) = %d, want %d", k, v, m[k])
+		}
+	}
+}
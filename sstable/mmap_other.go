@@ -0,0 +1,13 @@
+//go:build !unix
+
+package sstable
+
+import "os"
+
+func mapFile(path string) ([]byte, func([]byte) error, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, func([]byte) error { return nil }, nil
+}
@@ -0,0 +1,95 @@
+package sstable
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+)
+
+var ErrClosed = errors.New("sstable: reader is closed")
+
+// Reader queries a table file mapped into memory. Slices returned by Get and
+// passed to Range point into the mapping: they must not be modified and are
+// only valid until Close. Copy them to keep them longer. A Reader is safe
+// for concurrent use.
+type Reader struct {
+	table
+	mapping []byte
+	unmap   func([]byte) error
+	closed  bool
+	mu      sync.RWMutex
+}
+
+// Open maps the table at path. On platforms without mmap support the file
+// is read into memory instead.
+func Open(path string) (*Reader, error) {
+	data, unmap, err := mapFile(path)
+	if err != nil {
+		return nil, err
+	}
+	t, err := parseTable(data)
+	if err != nil {
+		unmap(data)
+		return nil, err
+	}
+	return &Reader{table: t, mapping: data, unmap: unmap}, nil
+}
+
+// Get returns the value stored under key.
+func (r *Reader) Get(key []byte) ([]byte, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.closed {
+		return nil, false
+	}
+	i := r.search(key)
+	if i == r.count {
+		return nil, false
+	}
+	k, v := r.record(i)
+	if !bytes.Equal(k, key) {
+		return nil, false
+	}
+	return v, true
+}
+
+// Range calls fn for every record in key order until fn returns false.
+func (r *Reader) Range(fn func(key, value []byte) bool) {
+	r.RangeFrom(nil, fn)
+}
+
+// RangeFrom calls fn in key order for every key greater than or equal to
+// from, until fn returns false.
+func (r *Reader) RangeFrom(from []byte, fn func(key, value []byte) bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.closed {
+		return
+	}
+	for i := r.search(from); i < r.count; i++ {
+		if !fn(r.record(i)) {
+			return
+		}
+	}
+}
+
+// Len returns the number of records.
+func (r *Reader) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.count
+}
+
+// Close unmaps the file. Slices obtained from the reader must not be used
+// afterwards.
+func (r *Reader) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	data := r.mapping
+	r.mapping, r.table = nil, table{}
+	return r.unmap(data)
+}
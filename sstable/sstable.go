@@ -0,0 +1,159 @@
+// Package sstable writes and reads immutable sorted key-value files. A
+// table is built once with a Builder and then opened with Open, which maps
+// the file into memory so lookups and scans touch only the pages they need
+// and nothing is copied onto the Go heap.
+//
+// The file layout is
+//
+//	records | index | footer
+//
+// where each record is uvarint key length | key | uvarint value length |
+// value, the index holds the little-endian uint64 offset of every record in
+// key order, and the footer is the index offset, the record count and a
+// magic number, each a little-endian uint64.
+package sstable
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"sort"
+)
+
+const (
+	magic      = 0x6473676f73737401 // "dsgosst" v1
+	footerSize = 24
+)
+
+var (
+	ErrUnsorted = errors.New("sstable: keys must be added in strictly increasing order")
+	ErrCorrupt  = errors.New("sstable: corrupt table")
+	ErrFinished = errors.New("sstable: builder already finished")
+)
+
+// Builder writes a table to an io.Writer. Keys must be added in strictly
+// increasing bytes.Compare order.
+type Builder struct {
+	w        *bufio.Writer
+	offsets  []uint64
+	offset   uint64
+	lastKey  []byte
+	finished bool
+}
+
+func NewBuilder(w io.Writer) *Builder {
+	return &Builder{w: bufio.NewWriter(w)}
+}
+
+// Add appends a record. It returns ErrUnsorted if key is not greater than
+// the previous key.
+func (b *Builder) Add(key, value []byte) error {
+	if b.finished {
+		return ErrFinished
+	}
+	if len(b.offsets) > 0 && bytes.Compare(key, b.lastKey) <= 0 {
+		return ErrUnsorted
+	}
+	b.offsets = append(b.offsets, b.offset)
+	b.lastKey = append(b.lastKey[:0], key...)
+
+	var header [binary.MaxVarintLen64]byte
+	for _, field := range [][]byte{key, value} {
+		n := binary.PutUvarint(header[:], uint64(len(field)))
+		if _, err := b.w.Write(header[:n]); err != nil {
+			return err
+		}
+		if _, err := b.w.Write(field); err != nil {
+			return err
+		}
+		b.offset += uint64(n + len(field))
+	}
+	return nil
+}
+
+// Len returns the number of records added so far.
+func (b *Builder) Len() int {
+	return len(b.offsets)
+}
+
+// Finish writes the index and footer and flushes the writer. It does not
+// close the underlying writer.
+func (b *Builder) Finish() error {
+	if b.finished {
+		return ErrFinished
+	}
+	b.finished = true
+	var buf [8]byte
+	for _, off := range b.offsets {
+		binary.LittleEndian.PutUint64(buf[:], off)
+		if _, err := b.w.Write(buf[:]); err != nil {
+			return err
+		}
+	}
+	footer := binary.LittleEndian.AppendUint64(nil, b.offset)
+	footer = binary.LittleEndian.AppendUint64(footer, uint64(len(b.offsets)))
+	footer = binary.LittleEndian.AppendUint64(footer, magic)
+	if _, err := b.w.Write(footer); err != nil {
+		return err
+	}
+	return b.w.Flush()
+}
+
+// table answers queries over the raw bytes of a table file.
+type table struct {
+	data  []byte
+	index []byte
+	count int
+}
+
+func parseTable(data []byte) (table, error) {
+	if len(data) < footerSize {
+		return table{}, ErrCorrupt
+	}
+	footer := data[len(data)-footerSize:]
+	indexOffset := binary.LittleEndian.Uint64(footer)
+	count := binary.LittleEndian.Uint64(footer[8:])
+	if binary.LittleEndian.Uint64(footer[16:]) != magic {
+		return table{}, ErrCorrupt
+	}
+	indexEnd := uint64(len(data) - footerSize)
+	if indexOffset > indexEnd || (indexEnd-indexOffset)/8 != count || (indexEnd-indexOffset)%8 != 0 {
+		return table{}, ErrCorrupt
+	}
+	return table{
+		data:  data[:indexOffset],
+		index: data[indexOffset:indexEnd],
+		count: int(count),
+	}, nil
+}
+
+// record decodes the i-th record. Corrupt records decode as empty.
+func (t table) record(i int) (key, value []byte) {
+	off := binary.LittleEndian.Uint64(t.index[i*8:])
+	if off >= uint64(len(t.data)) {
+		return nil, nil
+	}
+	rest := t.data[off:]
+	key, rest = field(rest)
+	value, _ = field(rest)
+	return key, value
+}
+
+func field(b []byte) ([]byte, []byte) {
+	n, size := binary.Uvarint(b)
+	if size <= 0 || uint64(len(b)-size) < n {
+		return nil, nil
+	}
+	end := size + int(n)
+	return b[size:end:end], b[end:]
+}
+
+// search returns the index of the first record with key >= key.
+func (t table) search(key []byte) int {
+	return sort.Search(t.count, func(i int) bool {
+		k, _ := t.record(i)
+		return bytes.Compare(k, key) >= 0
+	})
+}
@@ -0,0 +1,123 @@
+package sstable
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func buildTable(t *testing.T, n int) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "table.sst")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	b := NewBuilder(f)
+	for i := 0; i < n; i++ {
+		key := []byte(fmt.Sprintf("key-%05d", i*2))
+		if err := b.Add(key, []byte(fmt.Sprint(i))); err != nil {
+			t.Fatalf("Add(%s) error = %v", key, err)
+		}
+	}
+	if err := b.Finish(); err != nil {
+		t.Fatalf("Finish() error = %v", err)
+	}
+	return path
+}
+
+func TestReaderGet(t *testing.T) {
+	r, err := Open(buildTable(t, 1000))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer r.Close()
+
+	if r.Len() != 1000 {
+		t.Errorf("Len() = %d, want 1000", r.Len())
+	}
+	for _, i := range []int{0, 1, 499, 999} {
+		key := []byte(fmt.Sprintf("key-%05d", i*2))
+		if v, ok := r.Get(key); !ok || string(v) != fmt.Sprint(i) {
+			t.Errorf("Get(%s) = %q, %v, want %d", key, v, ok, i)
+		}
+	}
+	for _, key := range []string{"key-00001", "a", "zzz", ""} {
+		if v, ok := r.Get([]byte(key)); ok {
+			t.Errorf("Get(%q) = %q, want a miss", key, v)
+		}
+	}
+}
+
+func TestReaderRange(t *testing.T) {
+	r, err := Open(buildTable(t, 100))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer r.Close()
+
+	var prev []byte
+	count := 0
+	r.Range(func(key, value []byte) bool {
+		if prev != nil && bytes.Compare(prev, key) >= 0 {
+			t.Fatalf("Range out of order: %s after %s", key, prev)
+		}
+		prev = key
+		count++
+		return true
+	})
+	if count != 100 {
+		t.Errorf("Range visited %d records, want 100", count)
+	}
+
+	var keys []string
+	r.RangeFrom([]byte("key-00091"), func(key, value []byte) bool {
+		keys = append(keys, string(key))
+		return len(keys) < 2
+	})
+	if len(keys) != 2 || keys[0] != "key-00092" || keys[1] != "key-00094" {
+		t.Errorf("RangeFrom() = %v, want [key-00092 key-00094]", keys)
+	}
+
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if _, ok := r.Get([]byte("key-00000")); ok {
+		t.Error("Get() after Close should miss")
+	}
+}
+
+func TestBuilderErrors(t *testing.T) {
+	var buf bytes.Buffer
+	b := NewBuilder(&buf)
+	b.Add([]byte("b"), nil)
+	if err := b.Add([]byte("a"), nil); !errors.Is(err, ErrUnsorted) {
+		t.Errorf("Add(smaller key) error = %v, want ErrUnsorted", err)
+	}
+	if err := b.Add([]byte("b"), nil); !errors.Is(err, ErrUnsorted) {
+		t.Errorf("Add(duplicate key) error = %v, want ErrUnsorted", err)
+	}
+	b.Finish()
+	if err := b.Add([]byte("c"), nil); !errors.Is(err, ErrFinished) {
+		t.Errorf("Add() after Finish error = %v, want ErrFinished", err)
+	}
+}
+
+func TestOpenCorrupt(t *testing.T) {
+	dir := t.TempDir()
+	for name, data := range map[string][]byte{
+		"empty":     nil,
+		"short":     []byte("abc"),
+		"bad-magic": make([]byte, 64),
+	} {
+		path := filepath.Join(dir, name)
+		os.WriteFile(path, data, 0o644)
+		if _, err := Open(path); !errors.Is(err, ErrCorrupt) {
+			t.Errorf("Open(%s) error = %v, want ErrCorrupt", name, err)
+		}
+	}
+}
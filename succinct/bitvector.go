@@ -0,0 +1,196 @@
+// Package succinct provides compact static structures that answer queries
+// directly on their compressed representation.
+package succinct
+
+import "math/bits"
+
+const (
+	wordsPerSuperblock = 8
+	bitsPerSuperblock  = wordsPerSuperblock * 64
+)
+
+// BitVector is an immutable bit vector with O(1) Rank and O(log n) Select.
+// Alongside the bits it keeps the number of ones before every 512-bit
+// superblock and, within each superblock, before every word, adding about
+// 25% to the size of the bits. A BitVector is safe for concurrent use.
+type BitVector struct {
+	words  []uint64
+	length int
+	ones   int
+	// super[s] counts the ones before superblock s; super has one extra
+	// entry holding the total so Select can binary search it
+	super []uint64
+	// local[w] counts the ones between the start of w's superblock and w
+	local []uint16
+}
+
+// NewBitVector builds a bit vector over the first length bits of words,
+// where bit i is words[i/64] >> (i%64) & 1. The words are copied.
+func NewBitVector(words []uint64, length int) *BitVector {
+	length = max(0, min(length, len(words)*64))
+	n := (length + 63) / 64
+	v := &BitVector{
+		words:  make([]uint64, n),
+		length: length,
+		super:  make([]uint64, (n+wordsPerSuperblock-1)/wordsPerSuperblock+1),
+		local:  make([]uint16, n),
+	}
+	copy(v.words, words)
+	if rem := length % 64; rem != 0 {
+		v.words[n-1] &= 1<<rem - 1
+	}
+
+	var total uint64
+	var inBlock uint16
+	for w, word := range v.words {
+		if w%wordsPerSuperblock == 0 {
+			v.super[w/wordsPerSuperblock] = total
+			inBlock = 0
+		}
+		v.local[w] = inBlock
+		count := bits.OnesCount64(word)
+		inBlock += uint16(count)
+		total += uint64(count)
+	}
+	v.super[len(v.super)-1] = total
+	v.ones = int(total)
+	return v
+}
+
+// Len returns the number of bits.
+func (v *BitVector) Len() int {
+	return v.length
+}
+
+// Ones returns the number of set bits.
+func (v *BitVector) Ones() int {
+	return v.ones
+}
+
+// Get reports whether bit i is set. It panics if i is out of range.
+func (v *BitVector) Get(i int) bool {
+	if i < 0 || i >= v.length {
+		panic("succinct: bit index out of range")
+	}
+	return v.words[i/64]>>(i%64)&1 == 1
+}
+
+// Rank1 returns the number of set bits in positions [0, i). i is clamped to
+// [0, Len()].
+func (v *BitVector) Rank1(i int) int {
+	if i <= 0 {
+		return 0
+	}
+	if i >= v.length {
+		return v.ones
+	}
+	w := i / 64
+	rank := int(v.super[w/wordsPerSuperblock]) + int(v.local[w])
+	if rem := i % 64; rem != 0 {
+		rank += bits.OnesCount64(v.words[w] & (1<<rem - 1))
+	}
+	return rank
+}
+
+// Rank0 returns the number of clear bits in positions [0, i).
+func (v *BitVector) Rank0(i int) int {
+	i = max(0, min(i, v.length))
+	return i - v.Rank1(i)
+}
+
+// Select1 returns the position of the k-th set bit, counting from zero, and
+// false if fewer than k+1 bits are set.
+func (v *BitVector) Select1(k int) (int, bool) {
+	if k < 0 || k >= v.ones {
+		return 0, false
+	}
+	return v.selectBit(k, func(s int) int { return int(v.super[s]) },
+		func(w int) int { return int(v.local[w]) },
+		func(w int) uint64 { return v.words[w] }), true
+}
+
+// Select0 returns the position of the k-th clear bit, counting from zero,
+// and false if fewer than k+1 bits are clear.
+func (v *BitVector) Select0(k int) (int, bool) {
+	if k < 0 || k >= v.length-v.ones {
+		return 0, false
+	}
+	return v.selectBit(k, func(s int) int { return s*bitsPerSuperblock - int(v.super[s]) },
+		func(w int) int { return (w%wordsPerSuperblock)*64 - int(v.local[w]) },
+		// Padding past length is zero in words; invert only real bits
+		func(w int) uint64 {
+			word := ^v.words[w]
+			if w == len(v.words)-1 && v.length%64 != 0 {
+				word &= 1<<(v.length%64) - 1
+			}
+			return word
+		}), true
+}
+
+// selectBit finds the k-th matching bit given counts of matching bits before
+// each superblock and before each word within its superblock.
+func (v *BitVector) selectBit(k int, superRank, localRank func(int) int, word func(int) uint64) int {
+	// Last superblock whose preceding count is <= k
+	lo, hi := 0, len(v.super)-2
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		if superRank(mid) <= k {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	k -= superRank(lo)
+
+	w := lo * wordsPerSuperblock
+	end := min(w+wordsPerSuperblock, len(v.words))
+	for w+1 < end && localRank(w+1) <= k {
+		w++
+	}
+	k -= localRank(w)
+	return w*64 + selectInWord(word(w), k)
+}
+
+// selectInWord returns the position of the k-th set bit of x.
+func selectInWord(x uint64, k int) int {
+	for ; k > 0; k-- {
+		x &= x - 1
+	}
+	return bits.TrailingZeros64(x)
+}
+
+// Builder accumulates bits for a BitVector.
+type Builder struct {
+	words  []uint64
+	length int
+}
+
+// Append adds bit to the end of the vector.
+func (b *Builder) Append(bit bool) {
+	if b.length%64 == 0 {
+		b.words = append(b.words, 0)
+	}
+	if bit {
+		b.words[b.length/64] |= 1 << (b.length % 64)
+	}
+	b.length++
+}
+
+// Set sets bit i, growing the vector with clear bits if needed.
+func (b *Builder) Set(i int) {
+	for b.length <= i {
+		b.Append(false)
+	}
+	b.words[i/64] |= 1 << (i % 64)
+}
+
+// Len returns the number of bits appended so far.
+func (b *Builder) Len() int {
+	return b.length
+}
+
+// Build returns a BitVector of the accumulated bits. The builder can keep
+// being used afterwards.
+func (b *Builder) Build() *BitVector {
+	return NewBitVector(b.words, b.length)
+}
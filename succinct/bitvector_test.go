@@ -0,0 +1,79 @@
+package succinct
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestBitVectorRankSelect(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, length := range []int{0, 1, 63, 64, 65, 511, 512, 513, 5000} {
+		for _, density := range []float64{0, 0.05, 0.5, 1} {
+			var b Builder
+			bits := make([]bool, length)
+			for i := range bits {
+				bits[i] = rng.Float64() < density
+				b.Append(bits[i])
+			}
+			v := b.Build()
+
+			var ones, zeros []int
+			for i, bit := range bits {
+				if v.Get(i) != bit {
+					t.Fatalf("len %d: Get(%d) = %v, want %v", length, i, v.Get(i), bit)
+				}
+				if v.Rank1(i) != len(ones) || v.Rank0(i) != len(zeros) {
+					t.Fatalf("len %d: Rank1(%d), Rank0(%d) = %d, %d, want %d, %d",
+						length, i, i, v.Rank1(i), v.Rank0(i), len(ones), len(zeros))
+				}
+				if bit {
+					ones = append(ones, i)
+				} else {
+					zeros = append(zeros, i)
+				}
+			}
+			if v.Rank1(length) != len(ones) || v.Ones() != len(ones) || v.Len() != length {
+				t.Fatalf("len %d: totals do not match", length)
+			}
+			for k, want := range ones {
+				if got, ok := v.Select1(k); !ok || got != want {
+					t.Fatalf("len %d: Select1(%d) = %d, %v, want %d", length, k, got, ok, want)
+				}
+			}
+			for k, want := range zeros {
+				if got, ok := v.Select0(k); !ok || got != want {
+					t.Fatalf("len %d: Select0(%d) = %d, %v, want %d", length, k, got, ok, want)
+				}
+			}
+			if _, ok := v.Select1(len(ones)); ok {
+				t.Errorf("len %d: Select1 past the last one should fail", length)
+			}
+			if _, ok := v.Select0(len(zeros)); ok {
+				t.Errorf("len %d: Select0 past the last zero should fail", length)
+			}
+		}
+	}
+}
+
+func TestNewBitVectorMasksTail(t *testing.T) {
+	v := NewBitVector([]uint64{^uint64(0)}, 10)
+	if v.Ones() != 10 {
+		t.Errorf("Ones() = %d, want 10", v.Ones())
+	}
+	if pos, ok := v.Select0(0); ok {
+		t.Errorf("Select0(0) = %d, want no clear bits", pos)
+	}
+}
+
+func TestBuilderSet(t *testing.T) {
+	var b Builder
+	b.Set(100)
+	b.Set(3)
+	v := b.Build()
+	if v.Len() != 101 || v.Ones() != 2 {
+		t.Errorf("Len(), Ones() = %d, %d, want 101, 2", v.Len(), v.Ones())
+	}
+	if pos, _ := v.Select1(1); pos != 100 {
+		t.Errorf("Select1(1) = %d, want 100", pos)
+	}
+}
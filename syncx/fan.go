@@ -0,0 +1,195 @@
+package syncx
+
+import (
+	"context"
+	"sync"
+
+	"dsgo/queues"
+)
+
+// DefaultFanBuffer is the number of items FanIn and FanOut buffer per
+// output channel.
+const DefaultFanBuffer = 64
+
+// pipe is a bounded FIFO between producers and a goroutine feeding an
+// output channel. put blocks while the ring buffer is full, which is what
+// pushes back on the producers. Cancelling the pipe's context wakes both
+// sides and makes them give up.
+type pipe[T any] struct {
+	buf     *queues.RingBuffer[T]
+	closed  bool
+	stopped bool
+	done    <-chan struct{}
+	unwatch func() bool
+	mu      sync.Mutex
+	cond    sync.Cond
+}
+
+func newPipe[T any](ctx context.Context, capacity int) *pipe[T] {
+	p := &pipe[T]{buf: queues.NewRingBuffer[T](capacity, false), done: ctx.Done()}
+	p.cond.L = &p.mu
+	p.unwatch = context.AfterFunc(ctx, p.stop)
+	return p
+}
+
+// put queues item, waiting for room, and reports false if the pipe was
+// stopped first.
+func (p *pipe[T]) put(item T) bool {
+	p.mu.Lock()
+	for !p.stopped && !p.buf.Push(item) {
+		p.cond.Wait()
+	}
+	stopped := p.stopped
+	p.mu.Unlock()
+	p.cond.Broadcast()
+	return !stopped
+}
+
+func (p *pipe[T]) stop() {
+	p.mu.Lock()
+	p.stopped = true
+	p.mu.Unlock()
+	p.cond.Broadcast()
+}
+
+func (p *pipe[T]) close() {
+	p.mu.Lock()
+	p.closed = true
+	p.mu.Unlock()
+	p.cond.Broadcast()
+}
+
+// drain sends every item to out, closing it once the pipe is closed and
+// empty or as soon as it is stopped.
+func (p *pipe[T]) drain(out chan<- T) {
+	defer close(out)
+	defer p.unwatch()
+	for {
+		p.mu.Lock()
+		for p.buf.IsEmpty() && !p.closed && !p.stopped {
+			p.cond.Wait()
+		}
+		var item T
+		ok := !p.stopped
+		if ok {
+			item, ok = p.buf.Pop()
+		}
+		p.mu.Unlock()
+		if !ok {
+			return
+		}
+		p.cond.Broadcast()
+		select {
+		case out <- item:
+		case <-p.done:
+			return
+		}
+	}
+}
+
+// receive reads the next item of ch, reporting false once ch is closed or
+// done is.
+func receive[T any](ch <-chan T, done <-chan struct{}) (T, bool) {
+	select {
+	case item, ok := <-ch:
+		return item, ok
+	case <-done:
+		var zero T
+		return zero, false
+	}
+}
+
+// FanIn merges chs into one channel with DefaultFanBuffer items of
+// buffering. See FanInBuffered.
+func FanIn[T any](chs ...<-chan T) <-chan T {
+	return FanInBuffered(DefaultFanBuffer, chs...)
+}
+
+// FanInBuffered merges chs into one channel, buffering up to capacity
+// items in a ring buffer so that bursts on the inputs do not wait on the
+// consumer. Items from one input keep their order. The output is closed
+// once every input is closed and the buffer has drained, so shutting down
+// is a matter of closing the inputs; the consumer must keep reading until
+// then. FanInCtx can also be stopped from the consumer's side.
+func FanInBuffered[T any](capacity int, chs ...<-chan T) <-chan T {
+	return FanInCtx(context.Background(), capacity, chs...)
+}
+
+// FanInCtx is FanInBuffered that also shuts down when ctx is cancelled: its
+// goroutines stop reading the inputs, buffered items are dropped and the
+// output is closed, so a consumer that stops early does not leak them.
+func FanInCtx[T any](ctx context.Context, capacity int, chs ...<-chan T) <-chan T {
+	p := newPipe[T](ctx, capacity)
+	out := make(chan T)
+	var readers sync.WaitGroup
+	readers.Add(len(chs))
+	for _, ch := range chs {
+		go func() {
+			defer readers.Done()
+			for {
+				item, ok := receive(ch, p.done)
+				if !ok || !p.put(item) {
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		readers.Wait()
+		p.close()
+	}()
+	go p.drain(out)
+	return out
+}
+
+// FanOut splits ch into n channels with DefaultFanBuffer items of
+// buffering each. See FanOutBuffered.
+func FanOut[T any](ch <-chan T, n int, route func(item T) int) []<-chan T {
+	return FanOutBuffered(DefaultFanBuffer, ch, n, route)
+}
+
+// FanOutBuffered sends each item of ch to output route(item), taken modulo
+// n, so that hashing a key routes all its items to the same consumer in
+// order. Each output buffers up to capacity items; once a slow consumer's
+// buffer is full, routing waits for it. Every output is closed after ch is
+// closed and its buffer has drained. It panics if n is not positive.
+// FanOutCtx can also be stopped from the consumers' side.
+func FanOutBuffered[T any](capacity int, ch <-chan T, n int, route func(item T) int) []<-chan T {
+	return FanOutCtx(context.Background(), capacity, ch, n, route)
+}
+
+// FanOutCtx is FanOutBuffered that also shuts down when ctx is cancelled:
+// routing stops, buffered items are dropped and every output is closed, so
+// consumers that stop early do not leak the goroutines.
+func FanOutCtx[T any](ctx context.Context, capacity int, ch <-chan T, n int, route func(item T) int) []<-chan T {
+	if n <= 0 {
+		panic("syncx: fan out needs at least one output")
+	}
+	pipes := make([]*pipe[T], n)
+	outs := make([]<-chan T, n)
+	for i := range pipes {
+		pipes[i] = newPipe[T](ctx, capacity)
+		out := make(chan T)
+		outs[i] = out
+		go pipes[i].drain(out)
+	}
+	go func() {
+		for {
+			item, ok := receive(ch, ctx.Done())
+			if !ok {
+				break
+			}
+			i := route(item) % n
+			if i < 0 {
+				i += n
+			}
+			if !pipes[i].put(item) {
+				break
+			}
+		}
+		for _, p := range pipes {
+			p.close()
+		}
+	}()
+	return outs
+}
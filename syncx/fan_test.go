@@ -0,0 +1,153 @@
+package syncx
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func produce(from, to int) <-chan int {
+	ch := make(chan int)
+	go func() {
+		defer close(ch)
+		for i := from; i < to; i++ {
+			ch <- i
+		}
+	}()
+	return ch
+}
+
+func TestFanIn(t *testing.T) {
+	out := FanInBuffered(4, produce(0, 500), produce(1000, 1500), produce(2000, 2500))
+	var got []int
+	last := map[int]int{0: -1, 1: 999, 2: 1999}
+	for v := range out {
+		src := v / 1000
+		if v <= last[src] {
+			t.Fatalf("FanIn() reordered input %d: %d after %d", src, v, last[src])
+		}
+		last[src] = v
+		got = append(got, v)
+	}
+	if len(got) != 1500 {
+		t.Errorf("FanIn() delivered %d items, want 1500", len(got))
+	}
+
+	if _, ok := <-FanIn[int](); ok {
+		t.Error("FanIn() of no inputs should close at once")
+	}
+}
+
+func TestFanOut(t *testing.T) {
+	outs := FanOut(produce(-50, 1000), 3, func(v int) int { return v })
+	if len(outs) != 3 {
+		t.Fatalf("FanOut() returned %d outputs, want 3", len(outs))
+	}
+	results := make([][]int, 3)
+	var wg sync.WaitGroup
+	for i, out := range outs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for v := range out {
+				results[i] = append(results[i], v)
+			}
+		}()
+	}
+	wg.Wait()
+
+	total := 0
+	for i, got := range results {
+		total += len(got)
+		if !slices.IsSorted(got) {
+			t.Errorf("output %d is out of order", i)
+		}
+		for _, v := range got {
+			if want := ((v % 3) + 3) % 3; want != i {
+				t.Errorf("item %d routed to output %d, want %d", v, i, want)
+				break
+			}
+		}
+	}
+	if total != 1050 {
+		t.Errorf("FanOut() delivered %d items, want 1050", total)
+	}
+}
+
+func TestFanOutBackpressure(t *testing.T) {
+	in := make(chan int)
+	outs := FanOutBuffered(2, in, 2, func(v int) int { return 0 })
+	// Output 0 holds two items in its buffer and one in flight, and the
+	// router holds a fourth, so a fifth send can only complete once the
+	// consumer reads.
+	for i := 0; i < 3; i++ {
+		in <- i
+	}
+	sent := make(chan struct{})
+	go func() {
+		in <- 3
+		in <- 4
+		close(sent)
+	}()
+	time.Sleep(20 * time.Millisecond)
+	select {
+	case <-sent:
+		t.Fatal("sends should block while output 0 is full")
+	default:
+	}
+	var got []int
+	for v := range outs[0] {
+		got = append(got, v)
+		if v == 1 {
+			<-sent
+			close(in)
+		}
+	}
+	if !slices.Equal(got, []int{0, 1, 2, 3, 4}) {
+		t.Errorf("output 0 = %v, want [0 1 2 3 4]", got)
+	}
+	if _, ok := <-outs[1]; ok {
+		t.Error("unused output should be closed after the input closes")
+	}
+}
+
+func TestFanCtxCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan int)
+	merged := FanInCtx(ctx, 1, (<-chan int)(in))
+	outs := FanOutCtx(ctx, 1, merged, 2, func(v int) int { return v })
+	// Nobody reads the outputs, so the pipelines fill up and block.
+	sent := make(chan struct{})
+	go func() {
+		defer close(sent)
+		for i := 0; ; i++ {
+			select {
+			case in <- i:
+			case <-time.After(20 * time.Millisecond):
+				return
+			}
+		}
+	}()
+	<-sent
+	cancel()
+	for i, out := range outs {
+		done := make(chan struct{})
+		go func() {
+			for range out {
+			}
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatalf("output %d not closed after cancel", i)
+		}
+	}
+	select {
+	case in <- 0:
+		t.Error("FanInCtx still reading its input after cancel")
+	case <-time.After(20 * time.Millisecond):
+	}
+}
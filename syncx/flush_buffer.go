@@ -0,0 +1,112 @@
+package syncx
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"dsgo/queues"
+)
+
+var ErrStopped = errors.New("buffer is stopped")
+
+// FlushBuffer accumulates items and hands them to a flush callback in
+// batches. A batch is flushed as soon as it reaches the buffer size, or once
+// the interval has elapsed since its first item was added, whichever comes
+// first. Batches are flushed one at a time and in the order they were
+// filled. The callback must not call back into the buffer.
+type FlushBuffer[T any] struct {
+	buf      *queues.RingBuffer[T]
+	flush    func(batch []T)
+	interval time.Duration
+	mu       sync.Mutex
+	flushMu  sync.Mutex
+	timer    *time.Timer
+	gen      uint64
+	stopped  bool
+}
+
+// NewFlushBuffer creates a buffer that flushes every size items or interval
+// after the first buffered item. An interval of zero disables time-based
+// flushing.
+func NewFlushBuffer[T any](size int, interval time.Duration, flush func(batch []T)) *FlushBuffer[T] {
+	return &FlushBuffer[T]{
+		buf:      queues.NewRingBuffer[T](size, false),
+		flush:    flush,
+		interval: interval,
+	}
+}
+
+// Add buffers item, flushing the batch from the calling goroutine if it is
+// now full. It returns ErrStopped after Stop.
+func (b *FlushBuffer[T]) Add(item T) error {
+	b.mu.Lock()
+	if b.stopped {
+		b.mu.Unlock()
+		return ErrStopped
+	}
+	b.buf.Push(item)
+	if b.buf.Len() == 1 && b.interval > 0 {
+		gen := b.gen
+		b.timer = time.AfterFunc(b.interval, func() { b.flushGen(gen) })
+	}
+	if !b.buf.IsFull() {
+		b.mu.Unlock()
+		return nil
+	}
+	b.flushLocked()
+	return nil
+}
+
+// Flush flushes the buffered items immediately, if there are any.
+func (b *FlushBuffer[T]) Flush() {
+	b.mu.Lock()
+	b.flushLocked()
+}
+
+// Stop flushes the remaining items and rejects further Adds. It is safe to
+// call more than once.
+func (b *FlushBuffer[T]) Stop() {
+	b.mu.Lock()
+	b.stopped = true
+	b.flushLocked()
+}
+
+// Len returns the number of items waiting to be flushed.
+func (b *FlushBuffer[T]) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Len()
+}
+
+// flushGen is run by the interval timer. It only flushes if no other flush
+// has happened since the timer was started for batch gen.
+func (b *FlushBuffer[T]) flushGen(gen uint64) {
+	b.mu.Lock()
+	if b.gen != gen {
+		b.mu.Unlock()
+		return
+	}
+	b.flushLocked()
+}
+
+// flushLocked takes the current batch and flushes it. It must be called with
+// b.mu held and releases it; flushMu is taken before b.mu is released so
+// batches reach the callback in order.
+func (b *FlushBuffer[T]) flushLocked() {
+	if b.buf.IsEmpty() {
+		b.mu.Unlock()
+		return
+	}
+	batch := b.buf.Drain()
+	b.gen++
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+
+	b.flushMu.Lock()
+	b.mu.Unlock()
+	defer b.flushMu.Unlock()
+	b.flush(batch)
+}
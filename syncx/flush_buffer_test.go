@@ -0,0 +1,101 @@
+package syncx
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+type batchRecorder struct {
+	mu      sync.Mutex
+	batches [][]int
+}
+
+func (r *batchRecorder) flush(batch []int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.batches = append(r.batches, batch)
+}
+
+func (r *batchRecorder) snapshot() [][]int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([][]int(nil), r.batches...)
+}
+
+func TestFlushBufferSize(t *testing.T) {
+	var rec batchRecorder
+	b := NewFlushBuffer(3, 0, rec.flush)
+
+	for i := 0; i < 7; i++ {
+		b.Add(i)
+	}
+	batches := rec.snapshot()
+	if len(batches) != 2 {
+		t.Fatalf("flushed %d batches, want 2", len(batches))
+	}
+	if batches[1][0] != 3 || len(batches[1]) != 3 {
+		t.Errorf("second batch = %v, want [3 4 5]", batches[1])
+	}
+	if b.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", b.Len())
+	}
+
+	b.Stop()
+	batches = rec.snapshot()
+	if len(batches) != 3 || batches[2][0] != 6 {
+		t.Errorf("Stop should flush the remaining item, got %v", batches)
+	}
+	if err := b.Add(7); err != ErrStopped {
+		t.Errorf("Add after Stop = %v, want ErrStopped", err)
+	}
+	b.Stop()
+}
+
+func TestFlushBufferInterval(t *testing.T) {
+	var rec batchRecorder
+	b := NewFlushBuffer(100, 20*time.Millisecond, rec.flush)
+	defer b.Stop()
+
+	b.Add(1)
+	b.Add(2)
+	deadline := time.Now().Add(time.Second)
+	for len(rec.snapshot()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("interval flush never happened")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if batch := rec.snapshot()[0]; len(batch) != 2 {
+		t.Errorf("interval batch = %v, want [1 2]", batch)
+	}
+}
+
+func TestFlushBufferConcurrent(t *testing.T) {
+	var rec batchRecorder
+	b := NewFlushBuffer(10, time.Millisecond, rec.flush)
+	var wg sync.WaitGroup
+
+	for i := 0; i < 1000; i++ {
+		wg.Add(1)
+		go func(val int) {
+			defer wg.Done()
+			b.Add(val)
+		}(i)
+	}
+	wg.Wait()
+	b.Stop()
+
+	seen := make(map[int]bool)
+	for _, batch := range rec.snapshot() {
+		if len(batch) > 10 {
+			t.Errorf("batch of %d items exceeds size 10", len(batch))
+		}
+		for _, item := range batch {
+			seen[item] = true
+		}
+	}
+	if len(seen) != 1000 {
+		t.Errorf("flushed %d distinct items, want 1000", len(seen))
+	}
+}
@@ -0,0 +1,91 @@
+package syncx
+
+import (
+	"sync"
+)
+
+type keyedEntry struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// KeyedMutex serializes work per key without a single global lock. Entries
+// are created on demand and dropped as soon as no goroutine holds or waits
+// for the key, so the registry only grows with the number of contended keys.
+// The zero value is ready to use.
+type KeyedMutex[K comparable] struct {
+	mu      sync.Mutex
+	entries map[K]*keyedEntry
+}
+
+func NewKeyedMutex[K comparable]() *KeyedMutex[K] {
+	return &KeyedMutex[K]{
+		entries: make(map[K]*keyedEntry),
+	}
+}
+
+// Lock locks key, blocking until it is available.
+func (m *KeyedMutex[K]) Lock(key K) {
+	m.acquire(key).mu.Lock()
+}
+
+// TryLock tries to lock key without blocking and reports whether it succeeded.
+func (m *KeyedMutex[K]) TryLock(key K) bool {
+	entry := m.acquire(key)
+	if entry.mu.TryLock() {
+		return true
+	}
+	m.release(key)
+	return false
+}
+
+// Unlock unlocks key. It panics if key is not locked.
+func (m *KeyedMutex[K]) Unlock(key K) {
+	m.mu.Lock()
+	entry, exists := m.entries[key]
+	m.mu.Unlock()
+	if !exists {
+		panic("syncx: unlock of unlocked key")
+	}
+	m.release(key)
+	entry.mu.Unlock()
+}
+
+// Do runs fn while holding the lock for key.
+func (m *KeyedMutex[K]) Do(key K, fn func()) {
+	m.Lock(key)
+	defer m.Unlock(key)
+	fn()
+}
+
+// Len returns the number of keys currently held or waited on.
+func (m *KeyedMutex[K]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.entries)
+}
+
+func (m *KeyedMutex[K]) acquire(key K) *keyedEntry {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.entries == nil {
+		m.entries = make(map[K]*keyedEntry)
+	}
+	entry, exists := m.entries[key]
+	if !exists {
+		entry = &keyedEntry{}
+		m.entries[key] = entry
+	}
+	entry.refs++
+	return entry
+}
+
+func (m *KeyedMutex[K]) release(key K) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry := m.entries[key]
+	entry.refs--
+	if entry.refs == 0 {
+		delete(m.entries, key)
+	}
+}
@@ -0,0 +1,33 @@
+package syncx_test
+
+import (
+	"sync"
+	"testing"
+
+	"dsgo/cache"
+	"dsgo/syncx"
+)
+
+// cache depends on syncx, so this test lives in the external test package
+func TestKeyedMutexWithCache(t *testing.T) {
+	c := cache.NewLRUCache[string, int](10)
+	km := syncx.NewKeyedMutex[string]()
+	var wg sync.WaitGroup
+
+	// Read-modify-write on a cache entry is only safe under a per-key lock
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			km.Do("hits", func() {
+				v, _ := c.Get("hits")
+				c.Put("hits", v+1)
+			})
+		}()
+	}
+	wg.Wait()
+
+	if v, _ := c.Get("hits"); v != 50 {
+		t.Errorf("Expected 50 hits, got %d", v)
+	}
+}
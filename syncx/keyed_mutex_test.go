@@ -0,0 +1,69 @@
+package syncx
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestKeyedMutexSerializesPerKey(t *testing.T) {
+	km := NewKeyedMutex[string]()
+	counters := map[string]int{"a": 0, "b": 0}
+	var mapMu sync.Mutex
+	var wg sync.WaitGroup
+
+	for i := 0; i < 100; i++ {
+		for _, key := range []string{"a", "b"} {
+			wg.Add(1)
+			go func(key string) {
+				defer wg.Done()
+				km.Do(key, func() {
+					mapMu.Lock()
+					v := counters[key]
+					mapMu.Unlock()
+
+					mapMu.Lock()
+					counters[key] = v + 1
+					mapMu.Unlock()
+				})
+			}(key)
+		}
+	}
+	wg.Wait()
+
+	if counters["a"] != 100 || counters["b"] != 100 {
+		t.Errorf("Expected 100 increments per key, got %v", counters)
+	}
+	if km.Len() != 0 {
+		t.Errorf("Expected registry to be cleaned up, got %d entries", km.Len())
+	}
+}
+
+func TestKeyedMutexTryLock(t *testing.T) {
+	var km KeyedMutex[int]
+
+	if !km.TryLock(1) {
+		t.Fatal("TryLock on a free key should succeed")
+	}
+	if km.TryLock(1) {
+		t.Error("TryLock on a held key should fail")
+	}
+	if !km.TryLock(2) {
+		t.Error("TryLock on a different key should succeed")
+	}
+	km.Unlock(1)
+	km.Unlock(2)
+
+	if km.Len() != 0 {
+		t.Errorf("Expected 0 entries, got %d", km.Len())
+	}
+}
+
+func TestKeyedMutexUnlockUnlockedPanics(t *testing.T) {
+	km := NewKeyedMutex[string]()
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic on unlock of unlocked key")
+		}
+	}()
+	km.Unlock("missing")
+}
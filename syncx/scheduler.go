@@ -0,0 +1,187 @@
+package syncx
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"dsgo/queues"
+)
+
+// ErrSchedulerStopped is returned when scheduling on a stopped Scheduler.
+var ErrSchedulerStopped = errors.New("scheduler is stopped")
+
+type firing[K comparable] struct {
+	key K
+	seq uint64
+}
+
+type scheduledJob struct {
+	seq   uint64
+	due   time.Time
+	every time.Duration
+	fn    func(ctx context.Context)
+}
+
+// Scheduler runs keyed jobs at a point in time or on a fixed interval, on a
+// pool of worker goroutines. Due times are kept in a DelayQueue and the live
+// job for each key in a map; scheduling a key again replaces its job, and
+// queue entries left behind by replaced or cancelled jobs are skipped when
+// they come due.
+type Scheduler[K comparable] struct {
+	queue   *queues.DelayQueue[firing[K]]
+	jobs    map[K]*scheduledJob
+	seq     uint64
+	stopped bool
+	mu      sync.Mutex
+
+	work    chan func(ctx context.Context)
+	workers sync.WaitGroup
+	// stop ends dispatching; abort cancels the context passed to running
+	// jobs when Stop gives up waiting for them
+	stop  context.CancelFunc
+	abort context.CancelFunc
+}
+
+// NewScheduler starts a scheduler that runs jobs on the given number of
+// worker goroutines. Call Stop to release them.
+func NewScheduler[K comparable](workers int) *Scheduler[K] {
+	if workers <= 0 {
+		panic("syncx: scheduler needs at least one worker")
+	}
+	dispatchCtx, stop := context.WithCancel(context.Background())
+	jobCtx, abort := context.WithCancel(context.Background())
+	s := &Scheduler[K]{
+		queue: queues.NewDelayQueue[firing[K]](),
+		jobs:  make(map[K]*scheduledJob),
+		work:  make(chan func(ctx context.Context)),
+		stop:  stop,
+		abort: abort,
+	}
+	s.workers.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer s.workers.Done()
+			for fn := range s.work {
+				fn(jobCtx)
+			}
+		}()
+	}
+	go s.dispatch(dispatchCtx)
+	return s
+}
+
+// ScheduleAt runs fn once at the given time, replacing any job scheduled
+// under key. A time in the past runs fn as soon as a worker is free.
+func (s *Scheduler[K]) ScheduleAt(key K, at time.Time, fn func(ctx context.Context)) error {
+	return s.schedule(key, &scheduledJob{due: at, fn: fn})
+}
+
+// ScheduleEvery runs fn every interval, starting one interval from now,
+// until the key is cancelled or replaced. Runs that fall due while the
+// scheduler is behind are skipped rather than run back to back.
+func (s *Scheduler[K]) ScheduleEvery(key K, interval time.Duration, fn func(ctx context.Context)) error {
+	if interval <= 0 {
+		panic("syncx: schedule interval must be positive")
+	}
+	return s.schedule(key, &scheduledJob{due: time.Now().Add(interval), every: interval, fn: fn})
+}
+
+func (s *Scheduler[K]) schedule(key K, job *scheduledJob) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return ErrSchedulerStopped
+	}
+	s.seq++
+	job.seq = s.seq
+	s.jobs[key] = job
+	s.queue.Push(firing[K]{key: key, seq: job.seq}, job.due)
+	return nil
+}
+
+// Cancel removes the job scheduled under key and reports whether there was
+// one. A run that has already been handed to a worker is not interrupted.
+func (s *Scheduler[K]) Cancel(key K) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, exists := s.jobs[key]
+	delete(s.jobs, key)
+	return exists
+}
+
+// Len returns the number of scheduled jobs.
+func (s *Scheduler[K]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.jobs)
+}
+
+// Stop stops dispatching jobs and waits for running ones to finish. If ctx
+// is done first, the context passed to the running jobs is cancelled and
+// ctx's error is returned. Jobs that have not started are dropped. Stop is
+// safe to call more than once.
+func (s *Scheduler[K]) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	s.stopped = true
+	s.jobs = make(map[K]*scheduledJob)
+	s.mu.Unlock()
+	s.stop()
+
+	done := make(chan struct{})
+	go func() {
+		s.workers.Wait()
+		close(done)
+	}()
+	defer s.abort()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// dispatch hands due jobs to the workers until ctx is cancelled, then
+// closes the work channel so the workers exit.
+func (s *Scheduler[K]) dispatch(ctx context.Context) {
+	defer close(s.work)
+	for {
+		f, err := s.queue.Take(ctx)
+		if err != nil {
+			return
+		}
+		fn, ok := s.fire(f)
+		if !ok {
+			continue
+		}
+		select {
+		case s.work <- fn:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// fire returns the job for a queue entry that has come due, rescheduling it
+// if it repeats. It reports false for entries of cancelled or replaced jobs.
+func (s *Scheduler[K]) fire(f firing[K]) (func(ctx context.Context), bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, exists := s.jobs[f.key]
+	if !exists || job.seq != f.seq {
+		return nil, false
+	}
+	if job.every == 0 {
+		delete(s.jobs, f.key)
+		return job.fn, true
+	}
+	job.due = job.due.Add(job.every)
+	if now := time.Now(); job.due.Before(now) {
+		missed := now.Sub(job.due)/job.every + 1
+		job.due = job.due.Add(missed * job.every)
+	}
+	s.queue.Push(f, job.due)
+	return job.fn, true
+}
@@ -0,0 +1,90 @@
+package syncx
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSchedulerScheduleAt(t *testing.T) {
+	s := NewScheduler[string](2)
+	defer s.Stop(context.Background())
+
+	ran := make(chan string, 3)
+	now := time.Now()
+	s.ScheduleAt("b", now.Add(20*time.Millisecond), func(context.Context) { ran <- "b" })
+	s.ScheduleAt("a", now.Add(10*time.Millisecond), func(context.Context) { ran <- "a" })
+	s.ScheduleAt("c", now.Add(15*time.Millisecond), func(context.Context) { ran <- "c" })
+	// Replacing a job moves it, and cancelling one drops it
+	s.ScheduleAt("b", now.Add(30*time.Millisecond), func(context.Context) { ran <- "b2" })
+	if !s.Cancel("c") || s.Cancel("c") {
+		t.Error("Cancel(c) should succeed once and then report false")
+	}
+
+	for _, want := range []string{"a", "b2"} {
+		select {
+		case got := <-ran:
+			if got != want {
+				t.Errorf("ran %q, want %q", got, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for %q", want)
+		}
+	}
+	select {
+	case got := <-ran:
+		t.Errorf("unexpected run of %q", got)
+	case <-time.After(30 * time.Millisecond):
+	}
+	if s.Len() != 0 {
+		t.Errorf("Len() = %d, want 0 after one-shot jobs ran", s.Len())
+	}
+}
+
+func TestSchedulerScheduleEvery(t *testing.T) {
+	s := NewScheduler[int](1)
+	var runs atomic.Int32
+	s.ScheduleEvery(1, 5*time.Millisecond, func(context.Context) { runs.Add(1) })
+
+	deadline := time.Now().Add(time.Second)
+	for runs.Load() < 3 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if runs.Load() < 3 {
+		t.Fatalf("periodic job ran %d times, want at least 3", runs.Load())
+	}
+	s.Cancel(1)
+	time.Sleep(10 * time.Millisecond)
+	after := runs.Load()
+	time.Sleep(20 * time.Millisecond)
+	if runs.Load() != after {
+		t.Errorf("job kept running after Cancel: %d runs, then %d", after, runs.Load())
+	}
+	if err := s.Stop(context.Background()); err != nil {
+		t.Errorf("Stop() error = %v", err)
+	}
+}
+
+func TestSchedulerStop(t *testing.T) {
+	s := NewScheduler[int](1)
+	started := make(chan struct{})
+	s.ScheduleAt(1, time.Now(), func(ctx context.Context) {
+		close(started)
+		<-ctx.Done()
+	})
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := s.Stop(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Stop() with a stuck job error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if err := s.ScheduleAt(2, time.Now(), func(context.Context) {}); err != ErrSchedulerStopped {
+		t.Errorf("ScheduleAt() after Stop error = %v, want %v", err, ErrSchedulerStopped)
+	}
+	// The stuck job sees its context cancelled, so a second Stop completes
+	if err := s.Stop(context.Background()); err != nil {
+		t.Errorf("second Stop() error = %v", err)
+	}
+}
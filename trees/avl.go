@@ -3,6 +3,7 @@ package trees
 import (
 	"dsgo/utils"
 	"sync"
+	"sync/atomic"
 )
 
 type AVLNode[K utils.Ordered, V any] struct {
@@ -11,12 +12,15 @@ type AVLNode[K utils.Ordered, V any] struct {
 	Left   *AVLNode[K, V]
 	Right  *AVLNode[K, V]
 	Height int
+	// Size is the number of nodes in the subtree rooted at this node.
+	Size int
 }
 
 type AVLTree[K utils.Ordered, V any] struct {
 	Root       *AVLNode[K, V]
 	threadSafe bool
 	mu         sync.RWMutex
+	sealed     atomic.Bool
 }
 
 func NewAVLTree[K utils.Ordered, V any](threadSafe ...bool) *AVLTree[K, V] {
@@ -36,11 +40,11 @@ func height[K utils.Ordered, V any](node *AVLNode[K, V]) int {
 	return node.Height
 }
 
-func max(a, b int) int {
-	if a > b {
-		return a
+func avlSize[K utils.Ordered, V any](node *AVLNode[K, V]) int {
+	if node == nil {
+		return 0
 	}
-	return b
+	return node.Size
 }
 
 func getBalance[K utils.Ordered, V any](node *AVLNode[K, V]) int {
@@ -58,9 +62,11 @@ func rightRotate[K utils.Ordered, V any](y *AVLNode[K, V]) *AVLNode[K, V] {
 	x.Right = y
 	y.Left = T2
 
-	// Update heights
+	// Update heights and sizes
 	y.Height = max(height(y.Left), height(y.Right)) + 1
 	x.Height = max(height(x.Left), height(x.Right)) + 1
+	y.Size = avlSize(y.Left) + avlSize(y.Right) + 1
+	x.Size = avlSize(x.Left) + avlSize(x.Right) + 1
 
 	return x
 }
@@ -73,9 +79,11 @@ func leftRotate[K utils.Ordered, V any](x *AVLNode[K, V]) *AVLNode[K, V] {
 	y.Left = x
 	x.Right = T2
 
-	// Update heights
+	// Update heights and sizes
 	x.Height = max(height(x.Left), height(x.Right)) + 1
 	y.Height = max(height(y.Left), height(y.Right)) + 1
+	x.Size = avlSize(x.Left) + avlSize(x.Right) + 1
+	y.Size = avlSize(y.Left) + avlSize(y.Right) + 1
 
 	return y
 }
@@ -85,12 +93,15 @@ func (t *AVLTree[K, V]) Insert(key K, value V) {
 		t.mu.Lock()
 		defer t.mu.Unlock()
 	}
+	if t.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
 	t.Root = t.insert(t.Root, key, value)
 }
 
 func (t *AVLTree[K, V]) insert(node *AVLNode[K, V], key K, value V) *AVLNode[K, V] {
 	if node == nil {
-		return &AVLNode[K, V]{Key: key, Value: value, Height: 1}
+		return &AVLNode[K, V]{Key: key, Value: value, Height: 1, Size: 1}
 	}
 
 	if key < node.Key {
@@ -103,8 +114,9 @@ func (t *AVLTree[K, V]) insert(node *AVLNode[K, V], key K, value V) *AVLNode[K,
 		return node
 	}
 
-	// Update height of current node
+	// Update height and size of current node
 	node.Height = 1 + max(height(node.Left), height(node.Right))
+	node.Size = 1 + avlSize(node.Left) + avlSize(node.Right)
 
 	// Get balance factor
 	balance := getBalance(node)
@@ -139,9 +151,35 @@ func (t *AVLTree[K, V]) Delete(key K) {
 		t.mu.Lock()
 		defer t.mu.Unlock()
 	}
+	if t.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
 	t.Root = t.delete(t.Root, key)
 }
 
+// Remove deletes key and returns the value it held, or false if the key was
+// not present.
+func (t *AVLTree[K, V]) Remove(key K) (V, bool) {
+	if t.threadSafe {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+	if t.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
+	value, found := t.search(t.Root, key)
+	if found {
+		t.Root = t.delete(t.Root, key)
+	}
+	return value, found
+}
+
+// TryDelete deletes key and reports whether it was present.
+func (t *AVLTree[K, V]) TryDelete(key K) bool {
+	_, ok := t.Remove(key)
+	return ok
+}
+
 func (t *AVLTree[K, V]) delete(node *AVLNode[K, V], key K) *AVLNode[K, V] {
 	if node == nil {
 		return nil
@@ -168,8 +206,9 @@ func (t *AVLTree[K, V]) delete(node *AVLNode[K, V], key K) *AVLNode[K, V] {
 		node.Right = t.delete(node.Right, temp.Key)
 	}
 
-	// Update height
+	// Update height and size
 	node.Height = 1 + max(height(node.Left), height(node.Right))
+	node.Size = 1 + avlSize(node.Left) + avlSize(node.Right)
 
 	// Get balance factor
 	balance := getBalance(node)
@@ -207,8 +246,141 @@ func (t *AVLTree[K, V]) minValueNode(node *AVLNode[K, V]) *AVLNode[K, V] {
 	return current
 }
 
-func (t *AVLTree[K, V]) Search(key K) (V, bool) {
+// Len returns the number of keys in the tree.
+func (t *AVLTree[K, V]) Len() int {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	return avlSize(t.Root)
+}
+
+// Min returns the entry with the smallest key, or false if the tree is empty.
+func (t *AVLTree[K, V]) Min() (K, V, bool) {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	return avlEntryOf(avlExtreme(t.Root, true))
+}
+
+// Max returns the entry with the largest key, or false if the tree is empty.
+func (t *AVLTree[K, V]) Max() (K, V, bool) {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	return avlEntryOf(avlExtreme(t.Root, false))
+}
+
+// DeleteMin removes and returns the entry with the smallest key, or false if
+// the tree is empty.
+func (t *AVLTree[K, V]) DeleteMin() (K, V, bool) {
+	return t.deleteExtreme(true)
+}
+
+// DeleteMax removes and returns the entry with the largest key, or false if
+// the tree is empty.
+func (t *AVLTree[K, V]) DeleteMax() (K, V, bool) {
+	return t.deleteExtreme(false)
+}
+
+func (t *AVLTree[K, V]) deleteExtreme(smallest bool) (K, V, bool) {
 	if t.threadSafe {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+	if t.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
+	key, value, ok := avlEntryOf(avlExtreme(t.Root, smallest))
+	if ok {
+		t.Root = t.delete(t.Root, key)
+	}
+	return key, value, ok
+}
+
+func avlExtreme[K utils.Ordered, V any](node *AVLNode[K, V], smallest bool) *AVLNode[K, V] {
+	for node != nil {
+		next := node.Right
+		if smallest {
+			next = node.Left
+		}
+		if next == nil {
+			break
+		}
+		node = next
+	}
+	return node
+}
+
+func avlEntryOf[K utils.Ordered, V any](node *AVLNode[K, V]) (K, V, bool) {
+	if node == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	return node.Key, node.Value, true
+}
+
+// Floor returns the entry with the largest key less than or equal to key,
+// or false if there is none.
+func (t *AVLTree[K, V]) Floor(key K) (K, V, bool) {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	return t.floor(key)
+}
+
+// Ceiling returns the entry with the smallest key greater than or equal to
+// key, or false if there is none.
+func (t *AVLTree[K, V]) Ceiling(key K) (K, V, bool) {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	return t.ceiling(key)
+}
+
+func (t *AVLTree[K, V]) floor(key K) (K, V, bool) {
+	var best *AVLNode[K, V]
+	for node := t.Root; node != nil; {
+		if node.Key <= key {
+			best = node
+			node = node.Right
+		} else {
+			node = node.Left
+		}
+	}
+	if best == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	return best.Key, best.Value, true
+}
+
+func (t *AVLTree[K, V]) ceiling(key K) (K, V, bool) {
+	var best *AVLNode[K, V]
+	for node := t.Root; node != nil; {
+		if node.Key >= key {
+			best = node
+			node = node.Left
+		} else {
+			node = node.Right
+		}
+	}
+	if best == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	return best.Key, best.Value, true
+}
+
+func (t *AVLTree[K, V]) Search(key K) (V, bool) {
+	if t.threadSafe && !t.sealed.Load() {
 		t.mu.RLock()
 		defer t.mu.RUnlock()
 	}
@@ -229,20 +401,196 @@ func (t *AVLTree[K, V]) search(node *AVLNode[K, V], key K) (V, bool) {
 	return node.Value, true
 }
 
+// Rank returns the number of keys less than key, which is the position key
+// has or would have in key order, in O(log n).
+func (t *AVLTree[K, V]) Rank(key K) int {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	return t.countBelow(key, false)
+}
+
+// Select returns the i-th smallest entry, counting from 0, in O(log n).
+func (t *AVLTree[K, V]) Select(i int) (K, V, bool) {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	node := t.Root
+	if i < 0 || i >= avlSize(node) {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	for {
+		ls := avlSize(node.Left)
+		switch {
+		case i < ls:
+			node = node.Left
+		case i > ls:
+			i -= ls + 1
+			node = node.Right
+		default:
+			return node.Key, node.Value, true
+		}
+	}
+}
+
+// CountRange returns the number of keys k with low <= k <= high in O(log n).
+func (t *AVLTree[K, V]) CountRange(low, high K) int {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	if high < low {
+		return 0
+	}
+	return t.countBelow(high, true) - t.countBelow(low, false)
+}
+
+func (t *AVLTree[K, V]) countBelow(key K, inclusive bool) int {
+	count := 0
+	for node := t.Root; node != nil; {
+		if node.Key < key || (inclusive && node.Key == key) {
+			count += avlSize(node.Left) + 1
+			node = node.Right
+		} else {
+			node = node.Left
+		}
+	}
+	return count
+}
+
 func (t *AVLTree[K, V]) InOrderTraversal() []V {
-	if t.threadSafe {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	return avlAppendValues(t.Root, nil)
+}
+
+func (t *AVLTree[K, V]) Keys() []K {
+	if t.threadSafe && !t.sealed.Load() {
 		t.mu.RLock()
 		defer t.mu.RUnlock()
 	}
-	var result []V
-	t.inOrderTraversal(t.Root, &result)
-	return result
+	return avlAppendKeys(t.Root, make([]K, 0, avlSize(t.Root)))
 }
 
-func (t *AVLTree[K, V]) inOrderTraversal(node *AVLNode[K, V], result *[]V) {
-	if node != nil {
-		t.inOrderTraversal(node.Left, result)
-		*result = append(*result, node.Value)
-		t.inOrderTraversal(node.Right, result)
+// AppendKeys appends the keys in sorted order to dst and returns the
+// extended slice, letting callers reuse a buffer across calls.
+func (t *AVLTree[K, V]) AppendKeys(dst []K) []K {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	return avlAppendKeys(t.Root, dst)
+}
+
+// Values returns all values in key order.
+func (t *AVLTree[K, V]) Values() []V {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	return avlAppendValues(t.Root, make([]V, 0, avlSize(t.Root)))
+}
+
+// AppendValues appends the values in key order to dst and returns the
+// extended slice.
+func (t *AVLTree[K, V]) AppendValues(dst []V) []V {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
 	}
+	return avlAppendValues(t.Root, dst)
+}
+
+// Page returns up to limit entries starting at offset in key order, along
+// with the offset of the next page or -1 if there are no more entries.
+func (t *AVLTree[K, V]) Page(offset, limit int) ([]utils.Entry[K, V], int) {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	return collectPage(func(fn func(K, V) bool) {
+		t.ascend(t.Root, nil, fn)
+	}, offset, limit)
+}
+
+// PageAfter returns up to limit entries whose keys are greater than key, and
+// whether more entries follow.
+func (t *AVLTree[K, V]) PageAfter(key K, limit int) ([]utils.Entry[K, V], bool) {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	entries, next := collectPage(func(fn func(K, V) bool) {
+		t.ascend(t.Root, &key, fn)
+	}, 0, limit)
+	return entries, next != -1
+}
+
+// RangeFrom calls fn in key order for every key greater than or equal to
+// from, until fn returns false.
+func (t *AVLTree[K, V]) RangeFrom(from K, fn func(key K, value V) bool) {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	if value, ok := t.search(t.Root, from); ok && !fn(from, value) {
+		return
+	}
+	t.ascend(t.Root, &from, fn)
+}
+
+// ascend walks the subtree in key order, starting after *after when after is
+// non-nil, until fn returns false.
+func (t *AVLTree[K, V]) ascend(node *AVLNode[K, V], after *K, fn func(K, V) bool) bool {
+	if node == nil {
+		return true
+	}
+	if after == nil || node.Key > *after {
+		if !t.ascend(node.Left, after, fn) {
+			return false
+		}
+		if !fn(node.Key, node.Value) {
+			return false
+		}
+	}
+	return t.ascend(node.Right, after, fn)
+}
+
+func avlAppendKeys[K utils.Ordered, V any](node *AVLNode[K, V], dst []K) []K {
+	for node != nil {
+		dst = avlAppendKeys(node.Left, dst)
+		dst = append(dst, node.Key)
+		node = node.Right
+	}
+	return dst
+}
+
+func avlAppendValues[K utils.Ordered, V any](node *AVLNode[K, V], dst []V) []V {
+	for node != nil {
+		dst = avlAppendValues(node.Left, dst)
+		dst = append(dst, node.Value)
+		node = node.Right
+	}
+	return dst
+}
+
+// Seal makes the tree read-only. Mutations after Seal panic with
+// utils.ErrSealed. Reads on a sealed tree skip locking.
+func (t *AVLTree[K, V]) Seal() {
+	if t.threadSafe {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+	t.sealed.Store(true)
+}
+
+// IsSealed reports whether Seal has been called.
+func (t *AVLTree[K, V]) IsSealed() bool {
+	return t.sealed.Load()
 }
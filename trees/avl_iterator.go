@@ -0,0 +1,68 @@
+package trees
+
+import (
+	"dsgo/utils"
+)
+
+// AVLIterator walks an AVLTree in key order using an explicit stack of the
+// ancestors still to be visited, so each step is amortized O(1) and a partial
+// scan costs only the entries it visits plus O(log n).
+//
+// An iterator does not lock the tree. The tree must not be modified while
+// an iterator is in use, unless it has been sealed.
+type AVLIterator[K utils.Ordered, V any] struct {
+	stack   []*AVLNode[K, V]
+	current *AVLNode[K, V]
+}
+
+// Begin returns an iterator positioned before the smallest key. Call Next to
+// advance to the first entry.
+func (t *AVLTree[K, V]) Begin() *AVLIterator[K, V] {
+	it := &AVLIterator[K, V]{}
+	it.pushLeft(t.Root)
+	return it
+}
+
+// Seek returns an iterator positioned before the smallest key greater than
+// or equal to key.
+func (t *AVLTree[K, V]) Seek(key K) *AVLIterator[K, V] {
+	it := &AVLIterator[K, V]{}
+	for node := t.Root; node != nil; {
+		if node.Key >= key {
+			it.stack = append(it.stack, node)
+			node = node.Left
+		} else {
+			node = node.Right
+		}
+	}
+	return it
+}
+
+func (it *AVLIterator[K, V]) pushLeft(node *AVLNode[K, V]) {
+	for ; node != nil; node = node.Left {
+		it.stack = append(it.stack, node)
+	}
+}
+
+// Next advances to the next entry and reports whether there was one.
+func (it *AVLIterator[K, V]) Next() bool {
+	if len(it.stack) == 0 {
+		it.current = nil
+		return false
+	}
+	last := len(it.stack) - 1
+	it.current = it.stack[last]
+	it.stack = it.stack[:last]
+	it.pushLeft(it.current.Right)
+	return true
+}
+
+// Key returns the key of the current entry.
+func (it *AVLIterator[K, V]) Key() K {
+	return it.current.Key
+}
+
+// Value returns the value of the current entry.
+func (it *AVLIterator[K, V]) Value() V {
+	return it.current.Value
+}
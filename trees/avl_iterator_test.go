@@ -0,0 +1,61 @@
+package trees
+
+import (
+	"testing"
+)
+
+func TestAVLIterator(t *testing.T) {
+	tree := NewAVLTree[int, int]()
+	if tree.Begin().Next() {
+		t.Error("Next on an empty tree should return false")
+	}
+
+	for _, key := range []int{50, 30, 70, 20, 40, 60, 80, 10} {
+		tree.Insert(key, key*10)
+	}
+
+	var keys []int
+	for it := tree.Begin(); it.Next(); {
+		if it.Value() != it.Key()*10 {
+			t.Errorf("Value() = %d for key %d", it.Value(), it.Key())
+		}
+		keys = append(keys, it.Key())
+	}
+	if !equalInts(keys, []int{10, 20, 30, 40, 50, 60, 70, 80}) {
+		t.Errorf("iteration order = %v", keys)
+	}
+
+	tests := []struct {
+		seek     int
+		expected []int
+	}{
+		{seek: 0, expected: []int{10, 20, 30}},
+		{seek: 40, expected: []int{40, 50, 60}},
+		{seek: 45, expected: []int{50, 60, 70}},
+		{seek: 75, expected: []int{80}},
+		{seek: 90, expected: nil},
+	}
+	for _, tt := range tests {
+		var got []int
+		for it := tree.Seek(tt.seek); len(got) < 3 && it.Next(); {
+			got = append(got, it.Key())
+		}
+		if !equalInts(got, tt.expected) {
+			t.Errorf("Seek(%d) = %v, want %v", tt.seek, got, tt.expected)
+		}
+	}
+}
+
+func BenchmarkAVLPartialScan(b *testing.B) {
+	tree := NewAVLTree[int, int](false)
+	for i := 0; i < 100000; i++ {
+		tree.Insert(i, i)
+	}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		it := tree.Begin()
+		for j := 0; j < 10 && it.Next(); j++ {
+		}
+	}
+}
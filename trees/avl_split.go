@@ -0,0 +1,116 @@
+package trees
+
+import (
+	"unsafe"
+
+	"dsgo/utils"
+)
+
+// Split moves the entries with keys less than key into one tree and the
+// rest into another, leaving t empty. It runs in O(log n): the nodes are
+// relinked rather than copied, with AVL joins rebalancing each half. Both
+// trees have t's thread-safety setting.
+func (t *AVLTree[K, V]) Split(key K) (*AVLTree[K, V], *AVLTree[K, V]) {
+	if t.threadSafe {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+	if t.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
+	left, right := NewAVLTree[K, V](t.threadSafe), NewAVLTree[K, V](t.threadSafe)
+	left.Root, right.Root = avlSplit(t.Root, key)
+	t.Root = nil
+	return left, right
+}
+
+// Concat moves every entry of other onto the end of t in O(log n), leaving
+// other empty. Every key of t must be less than every key of other,
+// otherwise Concat returns ErrOverlap and changes neither tree; Merge
+// handles trees whose keys interleave.
+func (t *AVLTree[K, V]) Concat(other *AVLTree[K, V]) error {
+	if other == t {
+		panic("trees: cannot concat a tree onto itself")
+	}
+	// Lock in address order so a.Concat(b) and b.Concat(a) cannot deadlock
+	lo, hi := t, other
+	if uintptr(unsafe.Pointer(other)) < uintptr(unsafe.Pointer(t)) {
+		lo, hi = other, t
+	}
+	if lo.threadSafe {
+		lo.mu.Lock()
+		defer lo.mu.Unlock()
+	}
+	if hi.threadSafe {
+		hi.mu.Lock()
+		defer hi.mu.Unlock()
+	}
+	if t.sealed.Load() || other.sealed.Load() {
+		return utils.ErrSealed
+	}
+	if other.Root == nil {
+		return nil
+	}
+	first := avlExtreme(other.Root, true)
+	if t.Root != nil && avlExtreme(t.Root, false).Key >= first.Key {
+		return ErrOverlap
+	}
+	mid := &AVLNode[K, V]{Key: first.Key, Value: first.Value}
+	rest := other.delete(other.Root, first.Key)
+	t.Root = avlJoin(t.Root, mid, rest)
+	other.Root = nil
+	return nil
+}
+
+// avlSplit cuts the subtree at node into the keys below key and the rest,
+// reusing each node on the search path as the middle of a join.
+func avlSplit[K utils.Ordered, V any](node *AVLNode[K, V], key K) (*AVLNode[K, V], *AVLNode[K, V]) {
+	if node == nil {
+		return nil, nil
+	}
+	if key <= node.Key {
+		left, right := avlSplit(node.Left, key)
+		return left, avlJoin(right, node, node.Right)
+	}
+	left, right := avlSplit(node.Right, key)
+	return avlJoin(node.Left, node, left), right
+}
+
+// avlJoin returns a balanced tree of left, mid and right, whose keys must
+// be in that order. It descends the taller side until the heights are
+// within one, hangs mid there, and rebalances on the way back up, so it
+// costs O(|height(left) - height(right)| + 1).
+func avlJoin[K utils.Ordered, V any](left, mid, right *AVLNode[K, V]) *AVLNode[K, V] {
+	switch {
+	case height(left) > height(right)+1:
+		left.Right = avlJoin(left.Right, mid, right)
+		return avlRebalance(left)
+	case height(right) > height(left)+1:
+		right.Left = avlJoin(left, mid, right.Left)
+		return avlRebalance(right)
+	}
+	mid.Left, mid.Right = left, right
+	mid.Height = 1 + max(height(left), height(right))
+	mid.Size = 1 + avlSize(left) + avlSize(right)
+	return mid
+}
+
+// avlRebalance updates node's height and size and applies the rotation, if
+// any, that brings its balance back within one.
+func avlRebalance[K utils.Ordered, V any](node *AVLNode[K, V]) *AVLNode[K, V] {
+	node.Height = 1 + max(height(node.Left), height(node.Right))
+	node.Size = 1 + avlSize(node.Left) + avlSize(node.Right)
+	switch balance := getBalance(node); {
+	case balance > 1:
+		if getBalance(node.Left) < 0 {
+			node.Left = leftRotate(node.Left)
+		}
+		return rightRotate(node)
+	case balance < -1:
+		if getBalance(node.Right) > 0 {
+			node.Right = rightRotate(node.Right)
+		}
+		return leftRotate(node)
+	}
+	return node
+}
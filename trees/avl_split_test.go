@@ -0,0 +1,114 @@
+package trees
+
+import (
+	"dsgo/utils"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+// verifyAVL validates the tree and returns its keys in order.
+func verifyAVL(t *testing.T, tree *AVLTree[int, int]) []int {
+	t.Helper()
+	if err := tree.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	return tree.Keys()
+}
+
+func TestAVLTreeSplitConcat(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for round := 0; round < 50; round++ {
+		tree := NewAVLTree[int, int](false)
+		n := rng.Intn(500)
+		for i := 0; i < n; i++ {
+			tree.Insert(rng.Intn(1000), i)
+		}
+		before := verifyAVL(t, tree)
+
+		key := rng.Intn(1000)
+		left, right := tree.Split(key)
+		leftKeys, rightKeys := verifyAVL(t, left), verifyAVL(t, right)
+		if len(leftKeys) > 0 && leftKeys[len(leftKeys)-1] >= key {
+			t.Fatalf("Split(%d) left tree holds %d", key, leftKeys[len(leftKeys)-1])
+		}
+		if len(rightKeys) > 0 && rightKeys[0] < key {
+			t.Fatalf("Split(%d) right tree holds %d", key, rightKeys[0])
+		}
+		if !equalInts(append(leftKeys, rightKeys...), before) {
+			t.Fatalf("Split(%d) produced %d and %d keys from %d", key, len(leftKeys), len(rightKeys), len(before))
+		}
+		if tree.Len() != 0 {
+			t.Fatalf("Len() after Split = %d, want 0", tree.Len())
+		}
+		if left.Len() != len(leftKeys) || left.Rank(key) != len(leftKeys) {
+			t.Fatalf("left Len() = %d, Rank(%d) = %d, want %d", left.Len(), key, left.Rank(key), len(leftKeys))
+		}
+
+		if err := left.Concat(right); err != nil {
+			t.Fatalf("Concat() error = %v", err)
+		}
+		if !equalInts(verifyAVL(t, left), before) {
+			t.Fatal("Concat did not restore the original keys")
+		}
+		if right.Len() != 0 {
+			t.Fatalf("Len() of the concatenated tree = %d, want 0", right.Len())
+		}
+	}
+}
+
+func TestAVLTreeConcatUneven(t *testing.T) {
+	small, large := NewAVLTree[int, int](), NewAVLTree[int, int]()
+	small.Insert(-1, 0)
+	for i := 0; i < 1000; i++ {
+		large.Insert(i, i)
+	}
+	if err := small.Concat(large); err != nil {
+		t.Fatalf("Concat() error = %v", err)
+	}
+	if keys := verifyAVL(t, small); len(keys) != 1001 || keys[0] != -1 {
+		t.Errorf("Concat gave %d keys starting at %d, want 1001 starting at -1", len(keys), keys[0])
+	}
+	if value, ok := small.Search(999); !ok || value != 999 {
+		t.Errorf("Search(999) = %d, %v, want 999, true", value, ok)
+	}
+}
+
+func TestAVLTreeConcatErrors(t *testing.T) {
+	a, b := NewAVLTree[int, int](), NewAVLTree[int, int]()
+	a.Insert(5, 5)
+	b.Insert(3, 3)
+	b.Insert(7, 7)
+	if err := a.Concat(b); err != ErrOverlap {
+		t.Errorf("Concat of overlapping trees error = %v, want ErrOverlap", err)
+	}
+	if a.Len() != 1 || b.Len() != 2 {
+		t.Errorf("failed Concat changed the trees: Len() = %d, %d, want 1, 2", a.Len(), b.Len())
+	}
+
+	c := NewAVLTree[int, int]()
+	c.Insert(10, 10)
+	c.Seal()
+	if err := a.Concat(c); err != utils.ErrSealed {
+		t.Errorf("Concat of a sealed tree error = %v, want ErrSealed", err)
+	}
+}
+
+func TestAVLTreeConcatBothWays(t *testing.T) {
+	a, b := NewAVLTree[int, int](), NewAVLTree[int, int]()
+	a.Insert(1, 1)
+	b.Insert(2, 2)
+
+	// One direction succeeds and the other reports ErrOverlap or finds an
+	// empty tree; neither may deadlock
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(2)
+		go func() { defer wg.Done(); a.Concat(b) }()
+		go func() { defer wg.Done(); b.Concat(a) }()
+	}
+	wg.Wait()
+	if n := a.Len() + b.Len(); n != 2 {
+		t.Errorf("Len() total = %d, want 2", n)
+	}
+}
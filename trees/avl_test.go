@@ -1,6 +1,7 @@
 package trees
 
 import (
+	"dsgo/utils"
 	"fmt"
 	"sync"
 	"testing"
@@ -419,3 +420,103 @@ func TestAVLTreeConcurrentModifications(t *testing.T) {
 		t.Fatal("Test timed out after 5 seconds")
 	}
 }
+
+func TestAVLTreeSeal(t *testing.T) {
+	tree := NewAVLTree[int, int]()
+	tree.Insert(1, 1)
+	tree.Seal()
+
+	if !tree.IsSealed() {
+		t.Error("IsSealed should be true after Seal")
+	}
+	if v, ok := tree.Search(1); !ok || v != 1 {
+		t.Error("Search should keep working on a sealed tree")
+	}
+	defer func() {
+		if r := recover(); r != utils.ErrSealed {
+			t.Errorf("Delete on sealed tree should panic with ErrSealed, got %v", r)
+		}
+	}()
+	tree.Delete(1)
+}
+
+func TestAVLTree_Remove(t *testing.T) {
+	tree := NewAVLTree[int, string](false)
+	tree.Insert(1, "one")
+	tree.Insert(2, "two")
+
+	if value, ok := tree.Remove(2); !ok || value != "two" {
+		t.Errorf("Remove(2) = %q, %v, want \"two\", true", value, ok)
+	}
+	if value, ok := tree.Remove(2); ok || value != "" {
+		t.Errorf("Remove(2) again = %q, %v, want \"\", false", value, ok)
+	}
+	if !tree.TryDelete(1) {
+		t.Error("TryDelete(1) = false, want true")
+	}
+	if tree.TryDelete(1) {
+		t.Error("TryDelete(1) on a missing key = true, want false")
+	}
+}
+
+func TestAVLTree_RankSelect(t *testing.T) {
+	tree := NewAVLTree[int, int](false)
+	// Insert in a scrambled order and delete a third of the keys so sizes are
+	// maintained through rotations on both paths
+	for i := 0; i < 101; i++ {
+		key := i * 37 % 101
+		tree.Insert(key, key*10)
+	}
+	for key := 0; key < 101; key += 3 {
+		tree.Delete(key)
+	}
+
+	var sorted []int
+	for key := 0; key < 101; key++ {
+		if key%3 != 0 {
+			sorted = append(sorted, key)
+		}
+	}
+	for i, key := range sorted {
+		if got := tree.Rank(key); got != i {
+			t.Errorf("Rank(%d) = %d, want %d", key, got, i)
+		}
+		if k, v, ok := tree.Select(i); !ok || k != key || v != key*10 {
+			t.Errorf("Select(%d) = %d, %d, %v, want %d, %d, true", i, k, v, ok, key, key*10)
+		}
+	}
+	if got := tree.Rank(3); got != 2 {
+		t.Errorf("Rank(3) of a missing key = %d, want 2", got)
+	}
+	if got := tree.Rank(1000); got != len(sorted) {
+		t.Errorf("Rank(1000) = %d, want %d", got, len(sorted))
+	}
+	for _, i := range []int{-1, len(sorted)} {
+		if _, _, ok := tree.Select(i); ok {
+			t.Errorf("Select(%d) out of range reported an entry", i)
+		}
+	}
+}
+
+func TestAVLTree_AppendKeys(t *testing.T) {
+	tree := NewAVLTree[int, int]()
+	for _, k := range []int{5, 3, 8} {
+		tree.Insert(k, k*10)
+	}
+	buf := make([]int, 0, 8)
+	if got := tree.AppendKeys(buf[:0]); fmt.Sprint(got) != "[3 5 8]" {
+		t.Errorf("AppendKeys() = %v, want [3 5 8]", got)
+	}
+	if got := tree.AppendValues([]int{0}); fmt.Sprint(got) != "[0 30 50 80]" {
+		t.Errorf("AppendValues() = %v, want [0 30 50 80]", got)
+	}
+	if got := tree.Keys(); fmt.Sprint(got) != "[3 5 8]" {
+		t.Errorf("Keys() = %v, want [3 5 8]", got)
+	}
+	if got := tree.Values(); fmt.Sprint(got) != "[30 50 80]" {
+		t.Errorf("Values() = %v, want [30 50 80]", got)
+	}
+	if allocs := testing.AllocsPerRun(100, func() { buf = tree.AppendKeys(buf[:0]) }); allocs != 0 {
+		t.Errorf("AppendKeys() into a large enough buffer allocated %v times", allocs)
+	}
+}
@@ -3,12 +3,14 @@ package trees
 import (
 	"dsgo/utils"
 	"sync"
+	"sync/atomic"
 )
 
 type BST[K utils.Ordered, V any] struct {
 	root       *Node[K, V]
 	threadSafe bool
 	mu         sync.RWMutex
+	sealed     atomic.Bool
 }
 
 type Node[K utils.Ordered, V any] struct {
@@ -16,6 +18,7 @@ type Node[K utils.Ordered, V any] struct {
 	value V
 	left  *Node[K, V]
 	right *Node[K, V]
+	size  int
 }
 
 func NewBST[K utils.Ordered, V any](threadSafe ...bool) *BST[K, V] {
@@ -30,8 +33,11 @@ func NewBST[K utils.Ordered, V any](threadSafe ...bool) *BST[K, V] {
 
 func (b *BST[K, V]) Insert(key K, value V) {
 	if !b.threadSafe {
+		if b.sealed.Load() {
+			panic(utils.ErrSealed)
+		}
 		if b.root == nil {
-			b.root = &Node[K, V]{key: key, value: value}
+			b.root = &Node[K, V]{key: key, value: value, size: 1}
 			return
 		}
 		b.root = insert(b.root, key, value)
@@ -41,15 +47,18 @@ func (b *BST[K, V]) Insert(key K, value V) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
+	if b.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
 	if b.root == nil {
-		b.root = &Node[K, V]{key: key, value: value}
+		b.root = &Node[K, V]{key: key, value: value, size: 1}
 		return
 	}
 	b.root = insert(b.root, key, value)
 }
 
 func (b *BST[K, V]) Search(key K) (V, bool) {
-	if !b.threadSafe {
+	if !b.threadSafe || b.sealed.Load() {
 		if b.root == nil {
 			var zero V
 			return zero, false
@@ -69,6 +78,9 @@ func (b *BST[K, V]) Search(key K) (V, bool) {
 
 func (b *BST[K, V]) Delete(key K) {
 	if !b.threadSafe {
+		if b.sealed.Load() {
+			panic(utils.ErrSealed)
+		}
 		if b.root == nil {
 			return
 		}
@@ -79,15 +91,233 @@ func (b *BST[K, V]) Delete(key K) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
+	if b.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
 	if b.root == nil {
 		return
 	}
 	b.root = delete(b.root, key)
 }
 
+// Remove deletes key and returns the value it held, or false if the key was
+// not present.
+func (b *BST[K, V]) Remove(key K) (V, bool) {
+	if b.threadSafe {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+	}
+	if b.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
+	value, found := search(b.root, key)
+	if found {
+		b.root = delete(b.root, key)
+	}
+	return value, found
+}
+
+// TryDelete deletes key and reports whether it was present.
+func (b *BST[K, V]) TryDelete(key K) bool {
+	_, ok := b.Remove(key)
+	return ok
+}
+
+// Len returns the number of keys in the tree.
+func (b *BST[K, V]) Len() int {
+	if b.threadSafe && !b.sealed.Load() {
+		b.mu.RLock()
+		defer b.mu.RUnlock()
+	}
+	return size(b.root)
+}
+
+// Min returns the entry with the smallest key, or false if the tree is empty.
+func (b *BST[K, V]) Min() (K, V, bool) {
+	if b.threadSafe && !b.sealed.Load() {
+		b.mu.RLock()
+		defer b.mu.RUnlock()
+	}
+	return entryOf(extreme(b.root, true))
+}
+
+// Max returns the entry with the largest key, or false if the tree is empty.
+func (b *BST[K, V]) Max() (K, V, bool) {
+	if b.threadSafe && !b.sealed.Load() {
+		b.mu.RLock()
+		defer b.mu.RUnlock()
+	}
+	return entryOf(extreme(b.root, false))
+}
+
+// DeleteMin removes and returns the entry with the smallest key, or false if
+// the tree is empty.
+func (b *BST[K, V]) DeleteMin() (K, V, bool) {
+	return b.deleteExtreme(true)
+}
+
+// DeleteMax removes and returns the entry with the largest key, or false if
+// the tree is empty.
+func (b *BST[K, V]) DeleteMax() (K, V, bool) {
+	return b.deleteExtreme(false)
+}
+
+func (b *BST[K, V]) deleteExtreme(smallest bool) (K, V, bool) {
+	if b.threadSafe {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+	}
+	if b.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
+	node := extreme(b.root, smallest)
+	if node != nil {
+		b.root = delete(b.root, node.key)
+	}
+	return entryOf(node)
+}
+
+// extreme returns the leftmost node of the subtree if smallest is set, and
+// the rightmost otherwise.
+func extreme[K utils.Ordered, V any](node *Node[K, V], smallest bool) *Node[K, V] {
+	for node != nil {
+		next := node.right
+		if smallest {
+			next = node.left
+		}
+		if next == nil {
+			break
+		}
+		node = next
+	}
+	return node
+}
+
+func entryOf[K utils.Ordered, V any](node *Node[K, V]) (K, V, bool) {
+	if node == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	return node.key, node.value, true
+}
+
+// Page returns up to limit entries starting at offset in key order, along
+// with the offset of the next page or -1 if there are no more entries.
+func (b *BST[K, V]) Page(offset, limit int) ([]utils.Entry[K, V], int) {
+	if b.threadSafe && !b.sealed.Load() {
+		b.mu.RLock()
+		defer b.mu.RUnlock()
+	}
+	return collectPage(func(fn func(K, V) bool) {
+		ascend(b.root, nil, fn)
+	}, offset, limit)
+}
+
+// PageAfter returns up to limit entries whose keys are greater than key, and
+// whether more entries follow. Using the last key of the previous page as
+// the cursor keeps pagination stable while the tree is modified.
+func (b *BST[K, V]) PageAfter(key K, limit int) ([]utils.Entry[K, V], bool) {
+	if b.threadSafe && !b.sealed.Load() {
+		b.mu.RLock()
+		defer b.mu.RUnlock()
+	}
+	entries, next := collectPage(func(fn func(K, V) bool) {
+		ascend(b.root, &key, fn)
+	}, 0, limit)
+	return entries, next != -1
+}
+
+// CountRange returns the number of keys k with low <= k <= high in O(h).
+func (b *BST[K, V]) CountRange(low, high K) int {
+	if b.threadSafe && !b.sealed.Load() {
+		b.mu.RLock()
+		defer b.mu.RUnlock()
+	}
+	if high < low {
+		return 0
+	}
+	return countBelow(b.root, high, true) - countBelow(b.root, low, false)
+}
+
+// RangeFrom calls fn in key order for every key greater than or equal to
+// from, until fn returns false.
+func (b *BST[K, V]) RangeFrom(from K, fn func(key K, value V) bool) {
+	if b.threadSafe && !b.sealed.Load() {
+		b.mu.RLock()
+		defer b.mu.RUnlock()
+	}
+	if value, ok := search(b.root, from); ok && !fn(from, value) {
+		return
+	}
+	ascend(b.root, &from, fn)
+}
+
+// InOrder calls fn for every entry in key order until fn returns false.
+func (b *BST[K, V]) InOrder(fn func(key K, value V) bool) {
+	if b.threadSafe && !b.sealed.Load() {
+		b.mu.RLock()
+		defer b.mu.RUnlock()
+	}
+	ascend(b.root, nil, fn)
+}
+
+// Keys returns all keys in sorted order.
+func (b *BST[K, V]) Keys() []K {
+	if b.threadSafe && !b.sealed.Load() {
+		b.mu.RLock()
+		defer b.mu.RUnlock()
+	}
+	return appendKeys(b.root, make([]K, 0, size(b.root)))
+}
+
+// AppendKeys appends the keys in sorted order to dst and returns the
+// extended slice, letting callers reuse a buffer across calls.
+func (b *BST[K, V]) AppendKeys(dst []K) []K {
+	if b.threadSafe && !b.sealed.Load() {
+		b.mu.RLock()
+		defer b.mu.RUnlock()
+	}
+	return appendKeys(b.root, dst)
+}
+
+// Values returns all values in key order.
+func (b *BST[K, V]) Values() []V {
+	if b.threadSafe && !b.sealed.Load() {
+		b.mu.RLock()
+		defer b.mu.RUnlock()
+	}
+	return appendValues(b.root, make([]V, 0, size(b.root)))
+}
+
+// AppendValues appends the values in key order to dst and returns the
+// extended slice.
+func (b *BST[K, V]) AppendValues(dst []V) []V {
+	if b.threadSafe && !b.sealed.Load() {
+		b.mu.RLock()
+		defer b.mu.RUnlock()
+	}
+	return appendValues(b.root, dst)
+}
+
+// Seal makes the tree read-only. Mutations after Seal panic with
+// utils.ErrSealed. Reads on a sealed tree skip locking.
+func (b *BST[K, V]) Seal() {
+	if b.threadSafe {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+	}
+	b.sealed.Store(true)
+}
+
+// IsSealed reports whether Seal has been called.
+func (b *BST[K, V]) IsSealed() bool {
+	return b.sealed.Load()
+}
+
 func insert[K utils.Ordered, V any](node *Node[K, V], key K, value V) *Node[K, V] {
 	if node == nil {
-		return &Node[K, V]{key: key, value: value}
+		return &Node[K, V]{key: key, value: value, size: 1}
 	}
 
 	switch {
@@ -98,6 +328,7 @@ func insert[K utils.Ordered, V any](node *Node[K, V], key K, value V) *Node[K, V
 	default:
 		node.value = value
 	}
+	node.size = 1 + size(node.left) + size(node.right)
 	return node
 }
 
@@ -145,9 +376,32 @@ func delete[K utils.Ordered, V any](node *Node[K, V], key K) *Node[K, V] {
 		node.value = successor.value
 		node.right = delete(node.right, successor.key)
 	}
+	node.size = 1 + size(node.left) + size(node.right)
 	return node
 }
 
+func size[K utils.Ordered, V any](node *Node[K, V]) int {
+	if node == nil {
+		return 0
+	}
+	return node.size
+}
+
+// countBelow returns the number of keys less than key, or less than or equal
+// to key if inclusive is set, using subtree sizes.
+func countBelow[K utils.Ordered, V any](node *Node[K, V], key K, inclusive bool) int {
+	count := 0
+	for node != nil {
+		if node.key < key || (inclusive && node.key == key) {
+			count += size(node.left) + 1
+			node = node.right
+		} else {
+			node = node.left
+		}
+	}
+	return count
+}
+
 func findMin[K utils.Ordered, V any](node *Node[K, V]) *Node[K, V] {
 	current := node
 	for current.left != nil {
@@ -155,3 +409,41 @@ func findMin[K utils.Ordered, V any](node *Node[K, V]) *Node[K, V] {
 	}
 	return current
 }
+
+// ascend calls fn for every node in key order, starting after *after when
+// after is non-nil, until fn returns false. It reports whether the walk ran
+// to completion.
+func ascend[K utils.Ordered, V any](node *Node[K, V], after *K, fn func(K, V) bool) bool {
+	if node == nil {
+		return true
+	}
+	if after == nil || node.key > *after {
+		if !ascend(node.left, after, fn) {
+			return false
+		}
+		if !fn(node.key, node.value) {
+			return false
+		}
+	}
+	return ascend(node.right, after, fn)
+}
+
+// appendKeys appends the subtree's keys in order without allocating beyond
+// growing dst.
+func appendKeys[K utils.Ordered, V any](node *Node[K, V], dst []K) []K {
+	for node != nil {
+		dst = appendKeys(node.left, dst)
+		dst = append(dst, node.key)
+		node = node.right
+	}
+	return dst
+}
+
+func appendValues[K utils.Ordered, V any](node *Node[K, V], dst []V) []V {
+	for node != nil {
+		dst = appendValues(node.left, dst)
+		dst = append(dst, node.value)
+		node = node.right
+	}
+	return dst
+}
@@ -1,6 +1,7 @@
 package trees
 
 import (
+	"dsgo/utils"
 	"fmt"
 	"sync"
 	"testing"
@@ -217,6 +218,33 @@ func TestBST_Delete(t *testing.T) {
 	}
 }
 
+func TestBST_InOrder(t *testing.T) {
+	bst := NewBST[int, string](false)
+	for _, k := range []int{5, 3, 8, 1, 4, 9} {
+		bst.Insert(k, fmt.Sprint(k))
+	}
+
+	var visited []int
+	bst.InOrder(func(key int, value string) bool {
+		visited = append(visited, key)
+		return key < 5
+	})
+	if fmt.Sprint(visited) != "[1 3 4 5]" {
+		t.Errorf("InOrder() stopping after 5 visited %v, want [1 3 4 5]", visited)
+	}
+	if keys := bst.Keys(); fmt.Sprint(keys) != "[1 3 4 5 8 9]" {
+		t.Errorf("Keys() = %v, want [1 3 4 5 8 9]", keys)
+	}
+	if values := bst.Values(); fmt.Sprint(values) != "[1 3 4 5 8 9]" {
+		t.Errorf("Values() = %v, want [1 3 4 5 8 9]", values)
+	}
+
+	empty := NewBST[int, int]()
+	if len(empty.Keys()) != 0 || len(empty.Values()) != 0 {
+		t.Error("Keys() and Values() on an empty tree should be empty")
+	}
+}
+
 func TestBSTConcurrent(t *testing.T) {
 	tree := NewBST[int, string](true)
 	var wg sync.WaitGroup
@@ -353,3 +381,77 @@ func TestBSTConcurrentModifications(t *testing.T) {
 		t.Fatal("Test timed out after 5 seconds")
 	}
 }
+
+func TestBSTSeal(t *testing.T) {
+	for _, threadSafe := range []bool{false, true} {
+		bst := NewBST[int, int](threadSafe)
+		bst.Insert(1, 1)
+		bst.Seal()
+
+		if v, ok := bst.Search(1); !ok || v != 1 {
+			t.Error("Search should keep working on a sealed tree")
+		}
+		func() {
+			defer func() {
+				if r := recover(); r != utils.ErrSealed {
+					t.Errorf("Insert on sealed tree should panic with ErrSealed, got %v", r)
+				}
+			}()
+			bst.Insert(2, 2)
+		}()
+		func() {
+			defer func() {
+				if r := recover(); r != utils.ErrSealed {
+					t.Errorf("Delete on sealed tree should panic with ErrSealed, got %v", r)
+				}
+			}()
+			bst.Delete(1)
+		}()
+	}
+}
+
+func TestBST_AppendKeys(t *testing.T) {
+	bst := NewBST[int, int]()
+	for _, k := range []int{5, 3, 8} {
+		bst.Insert(k, k*10)
+	}
+	buf := make([]int, 0, 8)
+	if got := bst.AppendKeys(buf[:0]); fmt.Sprint(got) != "[3 5 8]" {
+		t.Errorf("AppendKeys() = %v, want [3 5 8]", got)
+	}
+	if got := bst.AppendValues([]int{0}); fmt.Sprint(got) != "[0 30 50 80]" {
+		t.Errorf("AppendValues() = %v, want [0 30 50 80]", got)
+	}
+	if allocs := testing.AllocsPerRun(100, func() { buf = bst.AppendKeys(buf[:0]) }); allocs != 0 {
+		t.Errorf("AppendKeys() into a large enough buffer allocated %v times", allocs)
+	}
+
+	m := NewTreeMap[int, int](AVL)
+	m.Set(2, 20)
+	m.Set(1, 10)
+	if got := m.AppendKeys(nil); fmt.Sprint(got) != "[1 2]" {
+		t.Errorf("TreeMap.AppendKeys() = %v, want [1 2]", got)
+	}
+	if got := m.AppendValues(nil); fmt.Sprint(got) != "[10 20]" {
+		t.Errorf("TreeMap.AppendValues() = %v, want [10 20]", got)
+	}
+}
+
+func TestBST_Remove(t *testing.T) {
+	tree := NewBST[int, string](false)
+	tree.Insert(1, "one")
+	tree.Insert(2, "two")
+
+	if value, ok := tree.Remove(2); !ok || value != "two" {
+		t.Errorf("Remove(2) = %q, %v, want \"two\", true", value, ok)
+	}
+	if value, ok := tree.Remove(2); ok || value != "" {
+		t.Errorf("Remove(2) again = %q, %v, want \"\", false", value, ok)
+	}
+	if !tree.TryDelete(1) {
+		t.Error("TryDelete(1) = false, want true")
+	}
+	if tree.TryDelete(1) {
+		t.Error("TryDelete(1) on a missing key = true, want false")
+	}
+}
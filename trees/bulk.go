@@ -0,0 +1,87 @@
+package trees
+
+import (
+	"errors"
+	"math/bits"
+
+	"dsgo/utils"
+)
+
+var (
+	ErrLengthMismatch = errors.New("keys and values have different lengths")
+	ErrUnsorted       = errors.New("keys are not strictly increasing")
+)
+
+func checkSorted[K utils.Ordered, V any](keys []K, values []V) error {
+	if len(keys) != len(values) {
+		return ErrLengthMismatch
+	}
+	for i := 1; i < len(keys); i++ {
+		if keys[i-1] >= keys[i] {
+			return ErrUnsorted
+		}
+	}
+	return nil
+}
+
+// NewAVLFromSorted builds a perfectly balanced AVL tree from strictly
+// increasing keys and their values in O(n).
+func NewAVLFromSorted[K utils.Ordered, V any](keys []K, values []V, threadSafe ...bool) (*AVLTree[K, V], error) {
+	if err := checkSorted(keys, values); err != nil {
+		return nil, err
+	}
+	t := NewAVLTree[K, V](threadSafe...)
+	t.Root = buildAVL(keys, values)
+	return t, nil
+}
+
+func buildAVL[K utils.Ordered, V any](keys []K, values []V) *AVLNode[K, V] {
+	if len(keys) == 0 {
+		return nil
+	}
+	mid := len(keys) / 2
+	node := &AVLNode[K, V]{
+		Key:   keys[mid],
+		Value: values[mid],
+		Left:  buildAVL(keys[:mid], values[:mid]),
+		Right: buildAVL(keys[mid+1:], values[mid+1:]),
+		Size:  len(keys),
+	}
+	node.Height = 1 + max(height(node.Left), height(node.Right))
+	return node
+}
+
+// NewRBTreeFromSorted builds a balanced red-black tree from strictly
+// increasing keys and their values in O(n).
+func NewRBTreeFromSorted[K utils.Ordered, V any](keys []K, values []V, threadSafe ...bool) (*RBTree[K, V], error) {
+	if err := checkSorted(keys, values); err != nil {
+		return nil, err
+	}
+	t := NewRBTree[K, V](threadSafe...)
+	// Splitting at the midpoint fills every level but the last, so
+	// coloring the nodes of a partial last level red keeps the black
+	// height equal on every path
+	fullLevels := bits.Len(uint(len(keys)+1)) - 1
+	t.root = buildRB[K, V](keys, values, nil, 0, fullLevels)
+	return t, nil
+}
+
+func buildRB[K utils.Ordered, V any](keys []K, values []V, parent *RBNode[K, V], depth, fullLevels int) *RBNode[K, V] {
+	if len(keys) == 0 {
+		return nil
+	}
+	mid := len(keys) / 2
+	node := &RBNode[K, V]{
+		key:    keys[mid],
+		value:  values[mid],
+		color:  Black,
+		parent: parent,
+		size:   len(keys),
+	}
+	if depth >= fullLevels {
+		node.color = Red
+	}
+	node.left = buildRB(keys[:mid], values[:mid], node, depth+1, fullLevels)
+	node.right = buildRB(keys[mid+1:], values[mid+1:], node, depth+1, fullLevels)
+	return node
+}
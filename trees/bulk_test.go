@@ -0,0 +1,106 @@
+package trees
+
+import (
+	"testing"
+)
+
+func sortedInput(n int) ([]int, []int) {
+	keys := make([]int, n)
+	values := make([]int, n)
+	for i := range keys {
+		keys[i] = i * 2
+		values[i] = i * 20
+	}
+	return keys, values
+}
+
+// rbBlackHeight returns the black height of the subtree, or -1 if it breaks
+// a red-black invariant.
+func rbBlackHeight(node *RBNode[int, int]) int {
+	if node == nil {
+		return 1
+	}
+	if node.color == Red {
+		if (node.left != nil && node.left.color == Red) || (node.right != nil && node.right.color == Red) {
+			return -1
+		}
+	}
+	left, right := rbBlackHeight(node.left), rbBlackHeight(node.right)
+	if left == -1 || left != right || node.size != 1+rbSize(node.left)+rbSize(node.right) {
+		return -1
+	}
+	if node.color == Black {
+		left++
+	}
+	return left
+}
+
+func TestNewFromSorted(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 3, 7, 8, 100, 1023} {
+		keys, values := sortedInput(n)
+
+		avl, err := NewAVLFromSorted(keys, values)
+		if err != nil {
+			t.Fatalf("NewAVLFromSorted(%d) error = %v", n, err)
+		}
+		rb, err := NewRBTreeFromSorted(keys, values)
+		if err != nil {
+			t.Fatalf("NewRBTreeFromSorted(%d) error = %v", n, err)
+		}
+
+		if balance := getBalance(avl.Root); balance > 1 || balance < -1 {
+			t.Errorf("AVL tree of %d keys is unbalanced", n)
+		}
+		if rb.root != nil && rb.root.color != Black {
+			t.Errorf("RB root of %d keys is red", n)
+		}
+		if rbBlackHeight(rb.root) == -1 {
+			t.Errorf("RB tree of %d keys breaks an invariant", n)
+		}
+
+		for i, key := range keys {
+			if val, ok := avl.Search(key); !ok || val != values[i] {
+				t.Errorf("AVL Search(%d) = %d, %v", key, val, ok)
+			}
+			if node, ok := rb.Search(key); !ok || node.value != values[i] {
+				t.Errorf("RB Search(%d) failed", key)
+			}
+		}
+		if got := avl.CountRange(0, 2*n); got != n {
+			t.Errorf("AVL CountRange = %d, want %d", got, n)
+		}
+
+		// The trees must stay valid under further mutation
+		rb.Insert(-1, 0)
+		rb.Delete(0)
+		if rbBlackHeight(rb.root) == -1 {
+			t.Errorf("RB tree of %d keys breaks an invariant after mutation", n)
+		}
+	}
+}
+
+func TestNewFromSortedErrors(t *testing.T) {
+	if _, err := NewAVLFromSorted([]int{1, 2}, []int{1}); err != ErrLengthMismatch {
+		t.Errorf("error = %v, want ErrLengthMismatch", err)
+	}
+	if _, err := NewRBTreeFromSorted([]int{1, 1}, []int{1, 2}); err != ErrUnsorted {
+		t.Errorf("error = %v, want ErrUnsorted", err)
+	}
+}
+
+func BenchmarkAVLFromSorted(b *testing.B) {
+	keys, values := sortedInput(100000)
+	b.Run("Bulk", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			NewAVLFromSorted(keys, values, false)
+		}
+	})
+	b.Run("Insert", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			t := NewAVLTree[int, int](false)
+			for j, key := range keys {
+				t.Insert(key, values[j])
+			}
+		}
+	})
+}
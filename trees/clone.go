@@ -0,0 +1,71 @@
+package trees
+
+import "dsgo/utils"
+
+// Clone returns an independent copy of the tree in O(n), with the same
+// shape and thread-safety setting. The copy is not sealed. Keys and values
+// are copied by assignment, so values that hold pointers share what they
+// point to.
+func (b *BST[K, V]) Clone() *BST[K, V] {
+	if b.threadSafe && !b.sealed.Load() {
+		b.mu.RLock()
+		defer b.mu.RUnlock()
+	}
+	clone := NewBST[K, V](b.threadSafe)
+	clone.root = cloneNode(b.root)
+	return clone
+}
+
+func cloneNode[K utils.Ordered, V any](node *Node[K, V]) *Node[K, V] {
+	if node == nil {
+		return nil
+	}
+	copied := *node
+	copied.left = cloneNode(node.left)
+	copied.right = cloneNode(node.right)
+	return &copied
+}
+
+// Clone returns an independent copy of the tree in O(n), as BST.Clone does.
+func (t *AVLTree[K, V]) Clone() *AVLTree[K, V] {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	clone := NewAVLTree[K, V](t.threadSafe)
+	clone.Root = cloneAVLNode(t.Root)
+	return clone
+}
+
+func cloneAVLNode[K utils.Ordered, V any](node *AVLNode[K, V]) *AVLNode[K, V] {
+	if node == nil {
+		return nil
+	}
+	copied := *node
+	copied.Left = cloneAVLNode(node.Left)
+	copied.Right = cloneAVLNode(node.Right)
+	return &copied
+}
+
+// Clone returns an independent copy of the tree in O(n), as BST.Clone does,
+// keeping node colors and relinking parent pointers within the copy.
+func (t *RBTree[K, V]) Clone() *RBTree[K, V] {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	clone := NewRBTree[K, V](t.threadSafe)
+	clone.root = cloneRBNode(t.root, nil)
+	return clone
+}
+
+func cloneRBNode[K utils.Ordered, V any](node, parent *RBNode[K, V]) *RBNode[K, V] {
+	if node == nil {
+		return nil
+	}
+	copied := *node
+	copied.parent = parent
+	copied.left = cloneRBNode(node.left, &copied)
+	copied.right = cloneRBNode(node.right, &copied)
+	return &copied
+}
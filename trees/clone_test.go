@@ -0,0 +1,63 @@
+package trees
+
+import (
+	"dsgo/utils"
+	"slices"
+	"testing"
+)
+
+type cloneable interface {
+	Insert(key int, value int)
+	Delete(key int)
+	Page(offset, limit int) ([]utils.Entry[int, int], int)
+	Len() int
+}
+
+func TestClone(t *testing.T) {
+	bst, avl, rb := NewBST[int, int](), NewAVLTree[int, int](), NewRBTree[int, int]()
+	tests := map[string]struct {
+		tree  cloneable
+		clone func() cloneable
+	}{
+		"BST":     {bst, func() cloneable { return bst.Clone() }},
+		"AVLTree": {avl, func() cloneable { return avl.Clone() }},
+		"RBTree":  {rb, func() cloneable { return rb.Clone() }},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			for _, k := range []int{50, 30, 70, 20, 40, 60, 80, 10} {
+				tt.tree.Insert(k, k)
+			}
+			before, _ := tt.tree.Page(0, 100)
+			clone := tt.clone()
+			if got, _ := clone.Page(0, 100); !slices.Equal(got, before) {
+				t.Errorf("Clone() entries = %v, want %v", got, before)
+			}
+
+			// Mutating either side must not show through to the other
+			tt.tree.Insert(90, 90)
+			tt.tree.Delete(30)
+			clone.Insert(25, 25)
+			clone.Insert(50, -50)
+			clone.Delete(80)
+			if got, _ := tt.tree.Page(0, 100); slices.Contains(got, utils.Entry[int, int]{Key: 25, Value: 25}) || len(got) != 8 {
+				t.Errorf("original after clone mutations = %v", got)
+			}
+			got, _ := clone.Page(0, 100)
+			var want []utils.Entry[int, int]
+			for _, kv := range [][2]int{{10, 10}, {20, 20}, {25, 25}, {30, 30}, {40, 40}, {50, -50}, {60, 60}, {70, 70}} {
+				want = append(want, utils.Entry[int, int]{Key: kv[0], Value: kv[1]})
+			}
+			if !slices.Equal(got, want) {
+				t.Errorf("clone after mutations = %v, want %v", got, want)
+			}
+			if clone.Len() != len(want) {
+				t.Errorf("clone Len() = %d, want %d", clone.Len(), len(want))
+			}
+		})
+	}
+
+	if NewRBTree[int, int]().Clone().Len() != 0 {
+		t.Error("Clone() of an empty tree should be empty")
+	}
+}
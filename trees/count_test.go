@@ -0,0 +1,65 @@
+package trees
+
+import (
+	"math/rand"
+	"testing"
+)
+
+type rangeCounter interface {
+	Insert(key int, value int)
+	Delete(key int)
+	CountRange(low, high int) int
+	Len() int
+}
+
+func TestCountRangeAndLen(t *testing.T) {
+	trees := map[string]rangeCounter{
+		"BST":     NewBST[int, int](false),
+		"AVLTree": NewAVLTree[int, int](false),
+		"RBTree":  NewRBTree[int, int](false),
+	}
+	for name, tree := range trees {
+		t.Run(name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(1))
+			// The package's delete helper shadows the builtin, so track
+			// membership in a slice rather than a map
+			present := make([]bool, 500)
+			for i := 0; i < 2000; i++ {
+				key := rng.Intn(500)
+				if rng.Intn(3) == 0 {
+					tree.Delete(key)
+					present[key] = false
+				} else {
+					tree.Insert(key, key)
+					present[key] = true
+				}
+
+				if i%50 != 0 {
+					continue
+				}
+				low, high := rng.Intn(520)-10, rng.Intn(520)-10
+				expected := 0
+				for k, ok := range present {
+					if ok && low <= k && k <= high {
+						expected++
+					}
+				}
+				if got := tree.CountRange(low, high); got != expected {
+					t.Fatalf("CountRange(%d, %d) = %d, want %d", low, high, got, expected)
+				}
+			}
+			total := 0
+			for _, ok := range present {
+				if ok {
+					total++
+				}
+			}
+			if got := tree.CountRange(-1, 500); got != total {
+				t.Errorf("CountRange over all keys = %d, want %d", got, total)
+			}
+			if got := tree.Len(); got != total {
+				t.Errorf("Len() = %d, want %d", got, total)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package trees
+
+import (
+	"reflect"
+
+	"dsgo/utils"
+)
+
+// Diff lists the keys that differ between two trees, each in key order.
+type Diff[K utils.Ordered] struct {
+	// Added holds keys present only in the other tree.
+	Added []K
+	// Removed holds keys present only in the receiver.
+	Removed []K
+	// Changed holds keys present in both with different values.
+	Changed []K
+}
+
+// IsEmpty reports whether the two trees held the same entries.
+func (d Diff[K]) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// diffEntries merges two sorted entry lists in O(n+m). A nil equal falls
+// back to reflect.DeepEqual.
+func diffEntries[K utils.Ordered, V any](a, b []utils.Entry[K, V], equal func(a, b V) bool) Diff[K] {
+	if equal == nil {
+		equal = func(a, b V) bool { return reflect.DeepEqual(a, b) }
+	}
+	var d Diff[K]
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i].Key < b[j].Key:
+			d.Removed = append(d.Removed, a[i].Key)
+			i++
+		case a[i].Key > b[j].Key:
+			d.Added = append(d.Added, b[j].Key)
+			j++
+		default:
+			if !equal(a[i].Value, b[j].Value) {
+				d.Changed = append(d.Changed, a[i].Key)
+			}
+			i++
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		d.Removed = append(d.Removed, a[i].Key)
+	}
+	for ; j < len(b); j++ {
+		d.Added = append(d.Added, b[j].Key)
+	}
+	return d
+}
+
+// resolved returns the value to store when merging incoming into a tree
+// that may already hold existing for the same key. A nil resolve lets the
+// incoming value win.
+func resolved[V any](existing V, exists bool, incoming V, resolve func(a, b V) V) V {
+	if !exists || resolve == nil {
+		return incoming
+	}
+	return resolve(existing, incoming)
+}
+
+func collectEntries[K utils.Ordered, V any](walk func(fn func(K, V) bool)) []utils.Entry[K, V] {
+	var entries []utils.Entry[K, V]
+	walk(func(key K, value V) bool {
+		entries = append(entries, utils.Entry[K, V]{Key: key, Value: value})
+		return true
+	})
+	return entries
+}
+
+// Each tree is snapshotted under its own lock in turn rather than locking
+// both at once, so concurrent a.Diff(b) and b.Diff(a) cannot deadlock.
+
+func (b *BST[K, V]) entries() []utils.Entry[K, V] {
+	if b.threadSafe && !b.sealed.Load() {
+		b.mu.RLock()
+		defer b.mu.RUnlock()
+	}
+	return collectEntries(func(fn func(K, V) bool) { ascend(b.root, nil, fn) })
+}
+
+// Diff compares b with other. Values are compared with equal, or with
+// reflect.DeepEqual if equal is nil.
+func (b *BST[K, V]) Diff(other *BST[K, V], equal func(a, b V) bool) Diff[K] {
+	return diffEntries(b.entries(), other.entries(), equal)
+}
+
+// Merge inserts every entry of other into b. For keys present in both,
+// resolve picks the value from b's and other's; if resolve is nil other's
+// value wins.
+func (b *BST[K, V]) Merge(other *BST[K, V], resolve func(a, b V) V) {
+	incoming := other.entries()
+	if b.threadSafe {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+	}
+	if b.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
+	for _, e := range incoming {
+		existing, exists := search(b.root, e.Key)
+		b.root = insert(b.root, e.Key, resolved(existing, exists, e.Value, resolve))
+	}
+}
+
+func (t *AVLTree[K, V]) entries() []utils.Entry[K, V] {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	return collectEntries(func(fn func(K, V) bool) { t.ascend(t.Root, nil, fn) })
+}
+
+// Diff compares t with other. Values are compared with equal, or with
+// reflect.DeepEqual if equal is nil.
+func (t *AVLTree[K, V]) Diff(other *AVLTree[K, V], equal func(a, b V) bool) Diff[K] {
+	return diffEntries(t.entries(), other.entries(), equal)
+}
+
+// Merge inserts every entry of other into t. For keys present in both,
+// resolve picks the value from t's and other's; if resolve is nil other's
+// value wins.
+func (t *AVLTree[K, V]) Merge(other *AVLTree[K, V], resolve func(a, b V) V) {
+	incoming := other.entries()
+	if t.threadSafe {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+	if t.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
+	for _, e := range incoming {
+		existing, exists := t.search(t.Root, e.Key)
+		t.Root = t.insert(t.Root, e.Key, resolved(existing, exists, e.Value, resolve))
+	}
+}
+
+func (t *RBTree[K, V]) entries() []utils.Entry[K, V] {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	return collectEntries(func(fn func(K, V) bool) { t.ascend(t.root, nil, fn) })
+}
+
+// Diff compares t with other. Values are compared with equal, or with
+// reflect.DeepEqual if equal is nil.
+func (t *RBTree[K, V]) Diff(other *RBTree[K, V], equal func(a, b V) bool) Diff[K] {
+	return diffEntries(t.entries(), other.entries(), equal)
+}
+
+// Merge inserts every entry of other into t. For keys present in both,
+// resolve picks the value from t's and other's; if resolve is nil other's
+// value wins.
+func (t *RBTree[K, V]) Merge(other *RBTree[K, V], resolve func(a, b V) V) {
+	incoming := other.entries()
+	if t.threadSafe {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+	if t.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
+	for _, e := range incoming {
+		var existing V
+		node, exists := t.searchNoLock(e.Key)
+		if exists {
+			existing = node.value
+		}
+		t.insertNoLock(e.Key, resolved(existing, exists, e.Value, resolve))
+	}
+}
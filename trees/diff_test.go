@@ -0,0 +1,58 @@
+package trees
+
+import (
+	"dsgo/utils"
+	"testing"
+)
+
+type mergeable[T any] interface {
+	Insert(key int, value int)
+	Diff(other T, equal func(a, b int) bool) Diff[int]
+	Merge(other T, resolve func(a, b int) int)
+	Page(offset, limit int) ([]utils.Entry[int, int], int)
+}
+
+func testDiffMerge[T mergeable[T]](t *testing.T, newTree func() T) {
+	a, b := newTree(), newTree()
+	for _, key := range []int{1, 2, 3, 4} {
+		a.Insert(key, key)
+	}
+	for _, key := range []int{2, 3, 4, 5, 6} {
+		b.Insert(key, key)
+	}
+	b.Insert(3, 30)
+
+	d := a.Diff(b, nil)
+	if !equalInts(d.Added, []int{5, 6}) || !equalInts(d.Removed, []int{1}) || !equalInts(d.Changed, []int{3}) {
+		t.Errorf("Diff() = %+v", d)
+	}
+	if !a.Diff(a, nil).IsEmpty() {
+		t.Error("Diff with itself should be empty")
+	}
+
+	// Keep the larger value on conflict
+	a.Merge(b, func(x, y int) int { return max(x, y) })
+	entries, _ := a.Page(0, 10)
+	keys := entryKeys(entries)
+	if !equalInts(keys, []int{1, 2, 3, 4, 5, 6}) {
+		t.Errorf("keys after Merge = %v", keys)
+	}
+	if entries[2].Value != 30 {
+		t.Errorf("merged value for 3 = %d, want 30", entries[2].Value)
+	}
+	if d := a.Diff(b, nil); len(d.Added) != 0 || len(d.Changed) != 0 {
+		t.Errorf("Diff after Merge = %+v, want only removals", d)
+	}
+}
+
+func TestDiffMerge(t *testing.T) {
+	t.Run("BST", func(t *testing.T) {
+		testDiffMerge(t, func() *BST[int, int] { return NewBST[int, int]() })
+	})
+	t.Run("AVLTree", func(t *testing.T) {
+		testDiffMerge(t, func() *AVLTree[int, int] { return NewAVLTree[int, int]() })
+	})
+	t.Run("RBTree", func(t *testing.T) {
+		testDiffMerge(t, func() *RBTree[int, int] { return NewRBTree[int, int]() })
+	})
+}
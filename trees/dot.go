@@ -0,0 +1,104 @@
+package trees
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+
+	"dsgo/utils"
+)
+
+// writeDOT writes the tree under root as a Graphviz digraph. Nodes are
+// numbered in pre-order. A node with a single child gets a point standing
+// in for the missing one, so left and right children stay distinguishable
+// in the layout. attrs, if not nil, adds attributes to a node's statement.
+func writeDOT[N any, K utils.Ordered, V any](w io.Writer, name string, root *N, walker binaryWalker[N, K, V], attrs func(n *N) string) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "digraph %s {\n\tnode [shape=circle];\n", name)
+	next := 0
+	var visit func(n *N) int
+	visit = func(n *N) int {
+		id := next
+		next++
+		key, _ := walker.entry(n)
+		extra := ""
+		if attrs != nil {
+			extra = ", " + attrs(n)
+		}
+		fmt.Fprintf(bw, "\tn%d [label=%s%s];\n", id, strconv.Quote(fmt.Sprint(key)), extra)
+		left, right := walker.children(n)
+		if left == nil && right == nil {
+			return id
+		}
+		for _, child := range []*N{left, right} {
+			if child == nil {
+				fmt.Fprintf(bw, "\tnil%d [shape=point];\n\tn%d -> nil%d;\n", next, id, next)
+				next++
+				continue
+			}
+			fmt.Fprintf(bw, "\tn%d -> n%d;\n", id, visit(child))
+		}
+		return id
+	}
+	if root != nil {
+		visit(root)
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// ExportDOT writes the tree to w in Graphviz DOT format, one node per key,
+// for rendering with e.g. `dot -Tsvg`. It is meant for debugging the shape
+// of the tree.
+func (b *BST[K, V]) ExportDOT(w io.Writer) error {
+	if b.threadSafe && !b.sealed.Load() {
+		b.mu.RLock()
+		defer b.mu.RUnlock()
+	}
+	return writeDOT(w, "BST", b.root, bstWalker[K, V](), nil)
+}
+
+// ExportDOT writes the tree in DOT format as BST.ExportDOT does, labelling
+// each node with its height so rotations can be followed.
+func (t *AVLTree[K, V]) ExportDOT(w io.Writer) error {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	return writeDOT(w, "AVLTree", t.Root, avlWalker[K, V](), func(n *AVLNode[K, V]) string {
+		return fmt.Sprintf("xlabel=\"h=%d\"", n.Height)
+	})
+}
+
+// ExportDOT writes the tree in DOT format as BST.ExportDOT does, filling
+// each node with its red-black color.
+func (t *RBTree[K, V]) ExportDOT(w io.Writer) error {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	return writeDOT(w, "RBTree", t.root, rbWalker[K, V](), func(n *RBNode[K, V]) string {
+		if n.color == Red {
+			return "style=filled, fillcolor=red, fontcolor=white"
+		}
+		return "style=filled, fillcolor=black, fontcolor=white"
+	})
+}
+
+// ExportDOT writes the tree in DOT format as BST.ExportDOT does.
+func (t *ScapegoatTree[K, V]) ExportDOT(w io.Writer) error {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	return writeDOT(w, "ScapegoatTree", t.root, sgWalker[K, V](), nil)
+}
+
+// ExportDOT writes a snapshot of the tree in DOT format as BST.ExportDOT
+// does, labelling each node with its subtree size.
+func (t *WBTree[K, V]) ExportDOT(w io.Writer) error {
+	return writeDOT(w, "WBTree", t.snapshot(), wbWalker[K, V](), func(n *wbNode[K, V]) string {
+		return fmt.Sprintf("xlabel=\"size=%d\"", n.size)
+	})
+}
@@ -0,0 +1,76 @@
+package trees
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBSTExportDOT(t *testing.T) {
+	b := NewBST[int, string]()
+	for _, key := range []int{5, 3, 8, 4} {
+		b.Insert(key, "v")
+	}
+	var sb strings.Builder
+	if err := b.ExportDOT(&sb); err != nil {
+		t.Fatalf("ExportDOT() error = %v", err)
+	}
+	got := sb.String()
+	for _, line := range []string{
+		"digraph BST {",
+		`n0 [label="5"];`,
+		`n1 [label="3"];`,
+		"n0 -> n1;",
+		"n1 -> nil2;",
+		"nil2 [shape=point];",
+		`n3 [label="4"];`,
+		"n1 -> n3;",
+		`n4 [label="8"];`,
+		"n0 -> n4;",
+	} {
+		if !strings.Contains(got, line) {
+			t.Errorf("ExportDOT() output is missing %q:\n%s", line, got)
+		}
+	}
+	if !strings.HasSuffix(got, "}\n") {
+		t.Errorf("ExportDOT() output does not close the graph:\n%s", got)
+	}
+}
+
+func TestRBTreeExportDOTColors(t *testing.T) {
+	rb := NewRBTree[string, int]()
+	for _, key := range []string{"b", "a", "c", "d"} {
+		rb.Insert(key, 0)
+	}
+	var sb strings.Builder
+	if err := rb.ExportDOT(&sb); err != nil {
+		t.Fatalf("ExportDOT() error = %v", err)
+	}
+	got := sb.String()
+	// b is the black root; d is the red child of black c
+	for _, line := range []string{
+		`n0 [label="b", style=filled, fillcolor=black, fontcolor=white];`,
+		`[label="d", style=filled, fillcolor=red, fontcolor=white];`,
+	} {
+		if !strings.Contains(got, line) {
+			t.Errorf("ExportDOT() output is missing %q:\n%s", line, got)
+		}
+	}
+}
+
+func TestExportDOTEmptyAndQuoting(t *testing.T) {
+	var sb strings.Builder
+	if err := NewAVLTree[int, int]().ExportDOT(&sb); err != nil {
+		t.Fatalf("ExportDOT() error = %v", err)
+	}
+	if got, want := sb.String(), "digraph AVLTree {\n\tnode [shape=circle];\n}\n"; got != want {
+		t.Errorf("ExportDOT() on empty tree = %q, want %q", got, want)
+	}
+
+	sg := NewScapegoatTree[string, int]()
+	sg.Insert(`say "hi"`, 1)
+	sb.Reset()
+	sg.ExportDOT(&sb)
+	if want := `n0 [label="say \"hi\""];`; !strings.Contains(sb.String(), want) {
+		t.Errorf("ExportDOT() output is missing %q:\n%s", want, sb.String())
+	}
+}
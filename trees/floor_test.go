@@ -0,0 +1,49 @@
+package trees
+
+import (
+	"testing"
+)
+
+type floorCeiler interface {
+	Insert(key int, value int)
+	Floor(key int) (int, int, bool)
+	Ceiling(key int) (int, int, bool)
+}
+
+func TestFloorCeiling(t *testing.T) {
+	trees := map[string]floorCeiler{
+		"AVLTree": NewAVLTree[int, int](false),
+		"RBTree":  NewRBTree[int, int](false),
+	}
+	tests := []struct {
+		key         int
+		floor, ceil int
+		hasF, hasC  bool
+	}{
+		{key: 5, ceil: 10, hasC: true},
+		{key: 10, floor: 10, ceil: 10, hasF: true, hasC: true},
+		{key: 35, floor: 30, ceil: 40, hasF: true, hasC: true},
+		{key: 50, floor: 50, ceil: 50, hasF: true, hasC: true},
+		{key: 55, floor: 50, hasF: true},
+	}
+	for name, tree := range trees {
+		t.Run(name, func(t *testing.T) {
+			if _, _, ok := tree.Floor(10); ok {
+				t.Error("Floor() on an empty tree reported an entry")
+			}
+			for key := 10; key <= 50; key += 10 {
+				tree.Insert(key, key*2)
+			}
+			for _, tt := range tests {
+				key, value, ok := tree.Floor(tt.key)
+				if ok != tt.hasF || (ok && (key != tt.floor || value != key*2)) {
+					t.Errorf("Floor(%d) = %d, %d, %v, want %d, %v", tt.key, key, value, ok, tt.floor, tt.hasF)
+				}
+				key, value, ok = tree.Ceiling(tt.key)
+				if ok != tt.hasC || (ok && (key != tt.ceil || value != key*2)) {
+					t.Errorf("Ceiling(%d) = %d, %d, %v, want %d, %v", tt.key, key, value, ok, tt.ceil, tt.hasC)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package trees
+
+import (
+	"errors"
+	"sync"
+)
+
+var (
+	ErrDuplicateNode = errors.New("trees: node already exists")
+	ErrUnknownParent = errors.New("trees: parent does not exist")
+)
+
+// Forest is a grow-only rooted forest over arbitrary keys, for hierarchies
+// such as org charts and taxonomies. Every node stores its ancestors at
+// power-of-two distances (binary lifting), built when the node is added, so
+// Depth is O(1) and LCA is O(log d) for depth d.
+type Forest[K comparable] struct {
+	keys  []K
+	pos   map[K]int
+	depth []int
+	// jumps[i][j] is the index of the 2^j-th ancestor of node i
+	jumps      [][]int
+	threadSafe bool
+	mu         sync.RWMutex
+}
+
+func NewForest[K comparable](threadSafe ...bool) *Forest[K] {
+	isThreadSafe := true
+	if len(threadSafe) > 0 {
+		isThreadSafe = threadSafe[0]
+	}
+	return &Forest[K]{
+		pos:        make(map[K]int),
+		threadSafe: isThreadSafe,
+	}
+}
+
+// AddRoot adds key as the root of a new tree.
+func (f *Forest[K]) AddRoot(key K) error {
+	if f.threadSafe {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+	}
+	if _, exists := f.pos[key]; exists {
+		return ErrDuplicateNode
+	}
+	f.add(key, 0, nil)
+	return nil
+}
+
+// AddChild adds child under parent, which must already be in the forest.
+func (f *Forest[K]) AddChild(parent, child K) error {
+	if f.threadSafe {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+	}
+	p, exists := f.pos[parent]
+	if !exists {
+		return ErrUnknownParent
+	}
+	if _, exists := f.pos[child]; exists {
+		return ErrDuplicateNode
+	}
+	jumps := []int{p}
+	for j := 0; j < len(f.jumps[jumps[j]]); j++ {
+		jumps = append(jumps, f.jumps[jumps[j]][j])
+	}
+	f.add(child, f.depth[p]+1, jumps)
+	return nil
+}
+
+func (f *Forest[K]) add(key K, depth int, jumps []int) {
+	f.pos[key] = len(f.keys)
+	f.keys = append(f.keys, key)
+	f.depth = append(f.depth, depth)
+	f.jumps = append(f.jumps, jumps)
+}
+
+// Parent returns the parent of key, or false if key is a root or not in the
+// forest.
+func (f *Forest[K]) Parent(key K) (K, bool) {
+	if f.threadSafe {
+		f.mu.RLock()
+		defer f.mu.RUnlock()
+	}
+	i, exists := f.pos[key]
+	if !exists || len(f.jumps[i]) == 0 {
+		var zero K
+		return zero, false
+	}
+	return f.keys[f.jumps[i][0]], true
+}
+
+// Depth returns the number of edges between key and its root, or false if
+// key is not in the forest.
+func (f *Forest[K]) Depth(key K) (int, bool) {
+	if f.threadSafe {
+		f.mu.RLock()
+		defer f.mu.RUnlock()
+	}
+	i, exists := f.pos[key]
+	if !exists {
+		return 0, false
+	}
+	return f.depth[i], true
+}
+
+// LCA returns the lowest common ancestor of a and b, which is a itself if a
+// is an ancestor of b. It returns false if either key is missing or the two
+// are in different trees.
+func (f *Forest[K]) LCA(a, b K) (K, bool) {
+	if f.threadSafe {
+		f.mu.RLock()
+		defer f.mu.RUnlock()
+	}
+	var zero K
+	i, iok := f.pos[a]
+	j, jok := f.pos[b]
+	if !iok || !jok {
+		return zero, false
+	}
+	if f.depth[i] < f.depth[j] {
+		i, j = j, i
+	}
+	i = f.ancestor(i, f.depth[i]-f.depth[j])
+	if i == j {
+		return f.keys[i], true
+	}
+	// Both nodes are at the same depth, so they have the same jump tables
+	for k := len(f.jumps[i]) - 1; k >= 0; k-- {
+		if k < len(f.jumps[i]) && f.jumps[i][k] != f.jumps[j][k] {
+			i, j = f.jumps[i][k], f.jumps[j][k]
+		}
+	}
+	if len(f.jumps[i]) == 0 || f.jumps[i][0] != f.jumps[j][0] {
+		return zero, false
+	}
+	return f.keys[f.jumps[i][0]], true
+}
+
+// ancestor returns the index of the ancestor d levels above node i.
+func (f *Forest[K]) ancestor(i, d int) int {
+	for k := 0; d > 0; k, d = k+1, d>>1 {
+		if d&1 == 1 {
+			i = f.jumps[i][k]
+		}
+	}
+	return i
+}
+
+// Len returns the number of nodes in the forest.
+func (f *Forest[K]) Len() int {
+	if f.threadSafe {
+		f.mu.RLock()
+		defer f.mu.RUnlock()
+	}
+	return len(f.keys)
+}
@@ -0,0 +1,84 @@
+package trees
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestForestLCA(t *testing.T) {
+	f := NewForest[string](false)
+	f.AddRoot("ceo")
+	f.AddChild("ceo", "cto")
+	f.AddChild("ceo", "cfo")
+	f.AddChild("cto", "eng1")
+	f.AddChild("cto", "eng2")
+	f.AddChild("eng2", "intern")
+	f.AddRoot("other")
+
+	tests := []struct {
+		a, b, want string
+		ok         bool
+	}{
+		{"eng1", "intern", "cto", true},
+		{"intern", "cfo", "ceo", true},
+		{"cto", "intern", "cto", true},
+		{"ceo", "ceo", "ceo", true},
+		{"intern", "other", "", false},
+		{"intern", "missing", "", false},
+	}
+	for _, tt := range tests {
+		if got, ok := f.LCA(tt.a, tt.b); ok != tt.ok || got != tt.want {
+			t.Errorf("LCA(%s, %s) = %q, %v, want %q, %v", tt.a, tt.b, got, ok, tt.want, tt.ok)
+		}
+	}
+	if d, ok := f.Depth("intern"); !ok || d != 3 {
+		t.Errorf("Depth(intern) = %d, %v, want 3, true", d, ok)
+	}
+	if p, ok := f.Parent("intern"); !ok || p != "eng2" {
+		t.Errorf("Parent(intern) = %q, %v, want eng2, true", p, ok)
+	}
+	if err := f.AddChild("nobody", "x"); err != ErrUnknownParent {
+		t.Errorf("AddChild() under a missing parent error = %v, want %v", err, ErrUnknownParent)
+	}
+	if err := f.AddChild("ceo", "cto"); err != ErrDuplicateNode {
+		t.Errorf("AddChild() of an existing node error = %v, want %v", err, ErrDuplicateNode)
+	}
+}
+
+func TestForestLCARandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	f := NewForest[int](false)
+	parent := []int{-1}
+	f.AddRoot(0)
+	for i := 1; i < 2000; i++ {
+		// Bias towards recent nodes so the trees get deep
+		p := i - 1 - rng.Intn(min(i, 5))
+		if rng.Intn(100) == 0 {
+			p = -1
+			f.AddRoot(i)
+		} else {
+			f.AddChild(p, i)
+		}
+		parent = append(parent, p)
+	}
+
+	naive := func(a, b int) (int, bool) {
+		seen := make(map[int]bool)
+		for ; a != -1; a = parent[a] {
+			seen[a] = true
+		}
+		for ; b != -1; b = parent[b] {
+			if seen[b] {
+				return b, true
+			}
+		}
+		return 0, false
+	}
+	for i := 0; i < 1000; i++ {
+		a, b := rng.Intn(len(parent)), rng.Intn(len(parent))
+		want, wantOK := naive(a, b)
+		if got, ok := f.LCA(a, b); ok != wantOK || got != want {
+			t.Fatalf("LCA(%d, %d) = %d, %v, want %d, %v", a, b, got, ok, want, wantOK)
+		}
+	}
+}
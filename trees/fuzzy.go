@@ -0,0 +1,68 @@
+package trees
+
+import "sort"
+
+// FuzzyMatch is a key returned by Trie.SearchFuzzy with its edit distance
+// from the search term and its weight.
+type FuzzyMatch struct {
+	Key      string
+	Distance int
+	Weight   int
+}
+
+// SearchFuzzy returns the keys within maxDistance insertions, deletions or
+// substitutions of term, closest first and then in key order. It walks the
+// trie computing one row of the Levenshtein table per rune, shared by every
+// key below that node, and abandons a subtree once every entry in its row
+// exceeds maxDistance, so only keys near term are visited.
+func (t *Trie) SearchFuzzy(term string, maxDistance int) []FuzzyMatch {
+	if maxDistance < 0 {
+		return nil
+	}
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+
+	target := []rune(term)
+	first := make([]int, len(target)+1)
+	for i := range first {
+		first[i] = i
+	}
+	var matches []FuzzyMatch
+	if t.root.terminal && first[len(target)] <= maxDistance {
+		matches = append(matches, FuzzyMatch{Distance: first[len(target)], Weight: t.root.weight})
+	}
+
+	var visit func(n *trieNode, key []rune, prev []int)
+	visit = func(n *trieNode, key []rune, prev []int) {
+		row := make([]int, len(prev))
+		row[0] = prev[0] + 1
+		best := row[0]
+		for i := 1; i < len(row); i++ {
+			cost := 1
+			if target[i-1] == n.r {
+				cost = 0
+			}
+			row[i] = min(row[i-1]+1, prev[i]+1, prev[i-1]+cost)
+			best = min(best, row[i])
+		}
+		if n.terminal && row[len(target)] <= maxDistance {
+			matches = append(matches, FuzzyMatch{Key: string(key), Distance: row[len(target)], Weight: n.weight})
+		}
+		if best > maxDistance {
+			return
+		}
+		for _, child := range n.children {
+			visit(child, append(key, child.r), row)
+		}
+	}
+	for _, child := range t.root.children {
+		visit(child, []rune{child.r}, first)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Distance < matches[j].Distance
+	})
+	return matches
+}
@@ -0,0 +1,96 @@
+package trees
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		row := make([]int, len(b)+1)
+		row[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			row[j] = min(row[j-1]+1, prev[j]+1, prev[j-1]+cost)
+		}
+		prev = row
+	}
+	return prev[len(b)]
+}
+
+func TestTrie_SearchFuzzy(t *testing.T) {
+	trie := NewTrie()
+	for i, key := range []string{"kitten", "sitting", "mitten", "kitchen", "bitten", "knit", "fuß"} {
+		trie.Insert(key, i)
+	}
+
+	if got := trie.SearchFuzzy("kiten", 1); len(got) != 1 || got[0] != (FuzzyMatch{"kitten", 1, 0}) {
+		t.Errorf("SearchFuzzy(kiten, 1) = %v, want [{kitten 1 0}]", got)
+	}
+	got := trie.SearchFuzzy("kiten", 2)
+	want := []FuzzyMatch{{"kitten", 1, 0}, {"bitten", 2, 4}, {"kitchen", 2, 3}, {"mitten", 2, 2}}
+	if len(got) != len(want) {
+		t.Fatalf("SearchFuzzy(kiten, 2) = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("SearchFuzzy(kiten, 2)[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	got = trie.SearchFuzzy("kitten", 2)
+	if len(got) == 0 || got[0].Key != "kitten" || got[0].Distance != 0 {
+		t.Errorf("SearchFuzzy(kitten, 2) should start with the exact match, got %v", got)
+	}
+	for i := 1; i < len(got); i++ {
+		if got[i-1].Distance > got[i].Distance {
+			t.Errorf("SearchFuzzy() results are not ordered by distance: %v", got)
+		}
+	}
+	if got := trie.SearchFuzzy("fus", 1); len(got) != 1 || got[0].Key != "fuß" {
+		t.Errorf("SearchFuzzy(fus, 1) = %v, want [fuß]: distance is counted in runes", got)
+	}
+	if got := trie.SearchFuzzy("zzz", 1); len(got) != 0 {
+		t.Errorf("SearchFuzzy(zzz, 1) = %v, want none", got)
+	}
+}
+
+func TestTrie_SearchFuzzyRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	trie := NewTrie(false)
+	keys := make(map[string]bool)
+	for i := 0; i < 300; i++ {
+		b := make([]rune, rng.Intn(7))
+		for j := range b {
+			b[j] = 'a' + rune(rng.Intn(4))
+		}
+		trie.Insert(string(b), 0)
+		keys[string(b)] = true
+	}
+	for _, term := range []string{"", "abc", "dddd", "abcabc"} {
+		for k := 0; k <= 2; k++ {
+			want := 0
+			for key := range keys {
+				if levenshtein([]rune(key), []rune(term)) <= k {
+					want++
+				}
+			}
+			got := trie.SearchFuzzy(term, k)
+			if len(got) != want {
+				t.Errorf("SearchFuzzy(%q, %d) returned %d keys, want %d", term, k, len(got), want)
+			}
+			for _, m := range got {
+				if d := levenshtein([]rune(m.Key), []rune(term)); d != m.Distance {
+					t.Errorf("SearchFuzzy(%q, %d) reported %q at distance %d, want %d", term, k, m.Key, m.Distance, d)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package trees
+
+import (
+	"errors"
+	"slices"
+
+	"dsgo/heaps"
+	"dsgo/utils"
+)
+
+var (
+	ErrNoSymbols        = errors.New("trees: no symbols to code")
+	ErrInvalidFrequency = errors.New("trees: symbol frequency must be positive")
+	ErrUnknownSymbol    = errors.New("trees: symbol has no code")
+	ErrTruncatedCode    = errors.New("trees: bits end part way through a code")
+)
+
+type huffmanNode[T utils.Ordered] struct {
+	symbol T
+	weight int
+	// seq orders nodes of equal weight so the same frequencies always give
+	// the same codes
+	seq   int
+	left  *huffmanNode[T]
+	right *huffmanNode[T]
+}
+
+// HuffmanTree is an optimal prefix code for a set of symbols, built by
+// BuildHuffman. Left edges are 0 bits and right edges 1 bits. It is
+// immutable and safe for concurrent use.
+type HuffmanTree[T utils.Ordered] struct {
+	root  *huffmanNode[T]
+	codes map[T][]bool
+}
+
+// BuildHuffman builds the Huffman code for the given symbol frequencies by
+// repeatedly merging the two lightest subtrees off a min-heap. Ties are
+// broken by symbol order, so a decoder given the same frequency table
+// rebuilds the same code. A lone symbol gets the one-bit code 0.
+func BuildHuffman[T utils.Ordered](freq map[T]int) (*HuffmanTree[T], error) {
+	if len(freq) == 0 {
+		return nil, ErrNoSymbols
+	}
+	symbols := make([]T, 0, len(freq))
+	for symbol, weight := range freq {
+		if weight <= 0 {
+			return nil, ErrInvalidFrequency
+		}
+		symbols = append(symbols, symbol)
+	}
+	slices.Sort(symbols)
+
+	h := heaps.NewMinHeap(func(a, b *huffmanNode[T]) bool {
+		if a.weight == b.weight {
+			return a.seq < b.seq
+		}
+		return a.weight < b.weight
+	}, false)
+	seq := 0
+	for _, symbol := range symbols {
+		h.Push(&huffmanNode[T]{symbol: symbol, weight: freq[symbol], seq: seq})
+		seq++
+	}
+	for h.Size() > 1 {
+		left, _ := h.Pop()
+		right, _ := h.Pop()
+		h.Push(&huffmanNode[T]{weight: left.weight + right.weight, seq: seq, left: left, right: right})
+		seq++
+	}
+	root, _ := h.Pop()
+	if root.left == nil {
+		// Give a lone symbol a real edge so its code is not empty
+		root = &huffmanNode[T]{weight: root.weight, left: root}
+	}
+
+	t := &HuffmanTree[T]{root: root, codes: make(map[T][]bool, len(symbols))}
+	var assign func(n *huffmanNode[T], code []bool)
+	assign = func(n *huffmanNode[T], code []bool) {
+		if n == nil {
+			return
+		}
+		if n.left == nil && n.right == nil {
+			t.codes[n.symbol] = slices.Clone(code)
+			return
+		}
+		assign(n.left, append(code, false))
+		assign(n.right, append(code, true))
+	}
+	assign(root, nil)
+	return t, nil
+}
+
+// Code returns the bits for symbol, or false if it was not in the
+// frequency table.
+func (t *HuffmanTree[T]) Code(symbol T) ([]bool, bool) {
+	code, ok := t.codes[symbol]
+	return slices.Clone(code), ok
+}
+
+// Encode packs the codes for symbols into bytes, most significant bit
+// first, and returns them with the number of bits used. It returns
+// ErrUnknownSymbol if a symbol has no code.
+func (t *HuffmanTree[T]) Encode(symbols []T) ([]byte, int, error) {
+	var data []byte
+	n := 0
+	for _, symbol := range symbols {
+		code, ok := t.codes[symbol]
+		if !ok {
+			return nil, 0, ErrUnknownSymbol
+		}
+		for _, bit := range code {
+			if n%8 == 0 {
+				data = append(data, 0)
+			}
+			if bit {
+				data[n/8] |= 0x80 >> (n % 8)
+			}
+			n++
+		}
+	}
+	return data, n, nil
+}
+
+// Decode reads nbits bits of data as written by Encode and returns the
+// symbols they code. It returns ErrTruncatedCode if the bits stop part way
+// through a code or nbits is longer than data.
+func (t *HuffmanTree[T]) Decode(data []byte, nbits int) ([]T, error) {
+	if nbits < 0 || nbits > len(data)*8 {
+		return nil, ErrTruncatedCode
+	}
+	var symbols []T
+	n := t.root
+	for i := 0; i < nbits; i++ {
+		if data[i/8]&(0x80>>(i%8)) != 0 {
+			n = n.right
+		} else {
+			n = n.left
+		}
+		if n == nil {
+			// Only the lone-symbol tree has a missing branch
+			return nil, ErrTruncatedCode
+		}
+		if n.left == nil && n.right == nil {
+			symbols = append(symbols, n.symbol)
+			n = t.root
+		}
+	}
+	if n != t.root {
+		return nil, ErrTruncatedCode
+	}
+	return symbols, nil
+}
@@ -0,0 +1,85 @@
+package trees
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestHuffman(t *testing.T) {
+	text := []rune("abracadabra alakazam")
+	freq := make(map[rune]int)
+	for _, r := range text {
+		freq[r]++
+	}
+	h, err := BuildHuffman(freq)
+	if err != nil {
+		t.Fatalf("BuildHuffman() error = %v", err)
+	}
+
+	// The most frequent symbol must get the shortest code, and the encoded
+	// length must match the weighted code lengths.
+	codeA, _ := h.Code('a')
+	want := 0
+	for r, n := range freq {
+		code, ok := h.Code(r)
+		if !ok {
+			t.Fatalf("Code(%q) missing", r)
+		}
+		if len(code) < len(codeA) {
+			t.Errorf("Code(%q) is shorter than the code for the most frequent symbol", r)
+		}
+		want += n * len(code)
+	}
+
+	data, nbits, err := h.Encode(text)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if nbits != want || len(data) != (nbits+7)/8 {
+		t.Errorf("Encode() = %d bits in %d bytes, want %d bits", nbits, len(data), want)
+	}
+	decoded, err := h.Decode(data, nbits)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if !slices.Equal(decoded, text) {
+		t.Errorf("Decode() = %q, want %q", string(decoded), string(text))
+	}
+
+	again, _ := BuildHuffman(freq)
+	for r := range freq {
+		c1, _ := h.Code(r)
+		c2, _ := again.Code(r)
+		if !slices.Equal(c1, c2) {
+			t.Errorf("Code(%q) differs between builds: %v and %v", r, c1, c2)
+		}
+	}
+
+	if _, _, err := h.Encode([]rune("xyz")); err != ErrUnknownSymbol {
+		t.Errorf("Encode() of an unknown symbol error = %v, want %v", err, ErrUnknownSymbol)
+	}
+	if _, err := h.Decode(data, nbits-1); err != ErrTruncatedCode {
+		t.Errorf("Decode() of truncated bits error = %v, want %v", err, ErrTruncatedCode)
+	}
+}
+
+func TestHuffman_EdgeCases(t *testing.T) {
+	if _, err := BuildHuffman(map[string]int{}); err != ErrNoSymbols {
+		t.Errorf("BuildHuffman(empty) error = %v, want %v", err, ErrNoSymbols)
+	}
+	if _, err := BuildHuffman(map[string]int{"a": 1, "b": 0}); err != ErrInvalidFrequency {
+		t.Errorf("BuildHuffman(zero weight) error = %v, want %v", err, ErrInvalidFrequency)
+	}
+
+	h, err := BuildHuffman(map[string]int{"only": 3})
+	if err != nil {
+		t.Fatalf("BuildHuffman(one symbol) error = %v", err)
+	}
+	data, nbits, _ := h.Encode([]string{"only", "only", "only"})
+	if nbits != 3 {
+		t.Errorf("Encode() of a lone symbol used %d bits, want 3", nbits)
+	}
+	if got, err := h.Decode(data, nbits); err != nil || len(got) != 3 {
+		t.Errorf("Decode() = %v, %v, want three symbols", got, err)
+	}
+}
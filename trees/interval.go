@@ -0,0 +1,263 @@
+package trees
+
+import (
+	"dsgo/utils"
+	"sync"
+	"sync/atomic"
+)
+
+// Interval is a half-open range [Start, End) and the value stored with it.
+type Interval[K utils.Ordered, V any] struct {
+	Start K
+	End   K
+	Value V
+}
+
+type intervalNode[K utils.Ordered, V any] struct {
+	interval Interval[K, V]
+	// maxEnd is the largest End in the subtree rooted at this node
+	maxEnd K
+	height int
+	left   *intervalNode[K, V]
+	right  *intervalNode[K, V]
+}
+
+// IntervalTree stores half-open intervals in an AVL tree ordered by start
+// then end, with each node augmented by the largest end below it so that
+// subtrees ending before a query are skipped. Stab and Overlaps run in
+// O(log n + m) for m results.
+type IntervalTree[K utils.Ordered, V any] struct {
+	root       *intervalNode[K, V]
+	size       int
+	threadSafe bool
+	mu         sync.RWMutex
+	sealed     atomic.Bool
+}
+
+func NewIntervalTree[K utils.Ordered, V any](threadSafe ...bool) *IntervalTree[K, V] {
+	isThreadSafe := true
+	if len(threadSafe) > 0 {
+		isThreadSafe = threadSafe[0]
+	}
+	return &IntervalTree[K, V]{
+		threadSafe: isThreadSafe,
+	}
+}
+
+func ivHeight[K utils.Ordered, V any](n *intervalNode[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+// ivUpdate recomputes the height and maxEnd of n from its children.
+func ivUpdate[K utils.Ordered, V any](n *intervalNode[K, V]) {
+	n.height = 1 + max(ivHeight(n.left), ivHeight(n.right))
+	n.maxEnd = n.interval.End
+	if n.left != nil {
+		n.maxEnd = max(n.maxEnd, n.left.maxEnd)
+	}
+	if n.right != nil {
+		n.maxEnd = max(n.maxEnd, n.right.maxEnd)
+	}
+}
+
+func ivRotateRight[K utils.Ordered, V any](y *intervalNode[K, V]) *intervalNode[K, V] {
+	x := y.left
+	y.left = x.right
+	x.right = y
+	ivUpdate(y)
+	ivUpdate(x)
+	return x
+}
+
+func ivRotateLeft[K utils.Ordered, V any](x *intervalNode[K, V]) *intervalNode[K, V] {
+	y := x.right
+	x.right = y.left
+	y.left = x
+	ivUpdate(x)
+	ivUpdate(y)
+	return y
+}
+
+func ivBalance[K utils.Ordered, V any](n *intervalNode[K, V]) *intervalNode[K, V] {
+	ivUpdate(n)
+	switch balance := ivHeight(n.left) - ivHeight(n.right); {
+	case balance > 1:
+		if ivHeight(n.left.left) < ivHeight(n.left.right) {
+			n.left = ivRotateLeft(n.left)
+		}
+		return ivRotateRight(n)
+	case balance < -1:
+		if ivHeight(n.right.right) < ivHeight(n.right.left) {
+			n.right = ivRotateRight(n.right)
+		}
+		return ivRotateLeft(n)
+	}
+	return n
+}
+
+// ivCompare orders intervals by start, then end.
+func ivCompare[K utils.Ordered](start, end, otherStart, otherEnd K) int {
+	switch {
+	case start < otherStart:
+		return -1
+	case start > otherStart:
+		return 1
+	case end < otherEnd:
+		return -1
+	case end > otherEnd:
+		return 1
+	}
+	return 0
+}
+
+// Insert stores value for [start, end), replacing the value of an identical
+// interval. It panics if start is not before end.
+func (t *IntervalTree[K, V]) Insert(start, end K, value V) {
+	if !(start < end) {
+		panic("trees: interval start must be before end")
+	}
+	if t.threadSafe {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+	if t.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
+	t.root = t.insert(t.root, Interval[K, V]{Start: start, End: end, Value: value})
+}
+
+func (t *IntervalTree[K, V]) insert(n *intervalNode[K, V], iv Interval[K, V]) *intervalNode[K, V] {
+	if n == nil {
+		t.size++
+		return &intervalNode[K, V]{interval: iv, maxEnd: iv.End, height: 1}
+	}
+	switch ivCompare(iv.Start, iv.End, n.interval.Start, n.interval.End) {
+	case -1:
+		n.left = t.insert(n.left, iv)
+	case 1:
+		n.right = t.insert(n.right, iv)
+	default:
+		n.interval.Value = iv.Value
+		return n
+	}
+	return ivBalance(n)
+}
+
+// Delete removes the interval [start, end) and reports whether it was
+// present.
+func (t *IntervalTree[K, V]) Delete(start, end K) bool {
+	if t.threadSafe {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+	if t.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
+	size := t.size
+	t.root = t.delete(t.root, start, end)
+	return t.size < size
+}
+
+func (t *IntervalTree[K, V]) delete(n *intervalNode[K, V], start, end K) *intervalNode[K, V] {
+	if n == nil {
+		return nil
+	}
+	switch ivCompare(start, end, n.interval.Start, n.interval.End) {
+	case -1:
+		n.left = t.delete(n.left, start, end)
+	case 1:
+		n.right = t.delete(n.right, start, end)
+	default:
+		t.size--
+		if n.left == nil {
+			return n.right
+		}
+		if n.right == nil {
+			return n.left
+		}
+		succ, right := ivDeleteMin(n.right)
+		succ.left, succ.right = n.left, right
+		return ivBalance(succ)
+	}
+	return ivBalance(n)
+}
+
+// ivDeleteMin unlinks the leftmost node of n and returns it along with the
+// rebalanced remainder.
+func ivDeleteMin[K utils.Ordered, V any](n *intervalNode[K, V]) (*intervalNode[K, V], *intervalNode[K, V]) {
+	if n.left == nil {
+		return n, n.right
+	}
+	first, left := ivDeleteMin(n.left)
+	n.left = left
+	return first, ivBalance(n)
+}
+
+// Stab returns the intervals containing point, in start order.
+func (t *IntervalTree[K, V]) Stab(point K) []Interval[K, V] {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	var out []Interval[K, V]
+	ivQuery(t.root, point, point, true, &out)
+	return out
+}
+
+// Overlaps returns the intervals sharing at least one point with
+// [start, end), in start order. An empty query range overlaps nothing.
+func (t *IntervalTree[K, V]) Overlaps(start, end K) []Interval[K, V] {
+	if !(start < end) {
+		return nil
+	}
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	var out []Interval[K, V]
+	ivQuery(t.root, start, end, false, &out)
+	return out
+}
+
+// ivQuery appends, in order, the intervals that end after lo and start
+// before hi, or at hi when inclusive.
+func ivQuery[K utils.Ordered, V any](n *intervalNode[K, V], lo, hi K, inclusive bool, out *[]Interval[K, V]) {
+	if n == nil || n.maxEnd <= lo {
+		return
+	}
+	ivQuery(n.left, lo, hi, inclusive, out)
+	if n.interval.Start > hi || (!inclusive && n.interval.Start == hi) {
+		// Everything to the right starts later still
+		return
+	}
+	if n.interval.End > lo {
+		*out = append(*out, n.interval)
+	}
+	ivQuery(n.right, lo, hi, inclusive, out)
+}
+
+func (t *IntervalTree[K, V]) Len() int {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	return t.size
+}
+
+// Seal makes the tree read-only. Mutations after Seal panic with
+// utils.ErrSealed. Reads on a sealed tree skip locking.
+func (t *IntervalTree[K, V]) Seal() {
+	if t.threadSafe {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+	t.sealed.Store(true)
+}
+
+// IsSealed reports whether Seal has been called.
+func (t *IntervalTree[K, V]) IsSealed() bool {
+	return t.sealed.Load()
+}
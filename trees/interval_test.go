@@ -0,0 +1,118 @@
+package trees
+
+import (
+	"dsgo/utils"
+	"math/rand"
+	"testing"
+)
+
+func intervalStarts(ivs []Interval[int, string]) []int {
+	starts := make([]int, len(ivs))
+	for i, iv := range ivs {
+		starts[i] = iv.Start
+	}
+	return starts
+}
+
+func TestIntervalTree_Queries(t *testing.T) {
+	tree := NewIntervalTree[int, string]()
+	tree.Insert(9, 12, "standup")
+	tree.Insert(10, 11, "review")
+	tree.Insert(13, 15, "lunch")
+	tree.Insert(14, 17, "planning")
+	tree.Insert(9, 12, "standup (moved)")
+
+	if tree.Len() != 4 {
+		t.Errorf("Len() = %d, want 4", tree.Len())
+	}
+	stab := tree.Stab(10)
+	if !equalInts(intervalStarts(stab), []int{9, 10}) || stab[0].Value != "standup (moved)" {
+		t.Errorf("Stab(10) = %v", stab)
+	}
+	if got := tree.Stab(12); len(got) != 0 {
+		t.Errorf("Stab(12) = %v, want none: ends are exclusive", got)
+	}
+	if got := intervalStarts(tree.Stab(14)); !equalInts(got, []int{13, 14}) {
+		t.Errorf("Stab(14) starts = %v, want [13 14]", got)
+	}
+	if got := intervalStarts(tree.Overlaps(11, 14)); !equalInts(got, []int{9, 13}) {
+		t.Errorf("Overlaps(11, 14) starts = %v, want [9 13]", got)
+	}
+	if got := tree.Overlaps(12, 13); len(got) != 0 {
+		t.Errorf("Overlaps(12, 13) = %v, want none", got)
+	}
+	if got := tree.Overlaps(10, 10); len(got) != 0 {
+		t.Errorf("Overlaps() of an empty range = %v, want none", got)
+	}
+
+	if tree.Delete(9, 11) {
+		t.Error("Delete() of a missing interval succeeded")
+	}
+	if !tree.Delete(9, 12) {
+		t.Error("Delete(9, 12) failed")
+	}
+	if got := intervalStarts(tree.Stab(10)); !equalInts(got, []int{10}) {
+		t.Errorf("Stab(10) after Delete starts = %v, want [10]", got)
+	}
+}
+
+func TestIntervalTree_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tree := NewIntervalTree[int, int](false)
+	var live []Interval[int, int]
+	for i := 0; i < 2000; i++ {
+		start := rng.Intn(500)
+		end := start + 1 + rng.Intn(30)
+		if len(live) > 0 && rng.Intn(3) == 0 {
+			j := rng.Intn(len(live))
+			if !tree.Delete(live[j].Start, live[j].End) {
+				t.Fatalf("Delete(%d, %d) failed", live[j].Start, live[j].End)
+			}
+			live = append(live[:j], live[j+1:]...)
+			continue
+		}
+		exists := false
+		for _, iv := range live {
+			exists = exists || (iv.Start == start && iv.End == end)
+		}
+		tree.Insert(start, end, i)
+		if !exists {
+			live = append(live, Interval[int, int]{Start: start, End: end})
+		}
+	}
+	if tree.Len() != len(live) {
+		t.Fatalf("Len() = %d, want %d", tree.Len(), len(live))
+	}
+	for q := 0; q < 200; q++ {
+		lo := rng.Intn(520)
+		hi := lo + 1 + rng.Intn(20)
+		want := 0
+		for _, iv := range live {
+			if iv.Start < hi && lo < iv.End {
+				want++
+			}
+		}
+		if got := tree.Overlaps(lo, hi); len(got) != want {
+			t.Errorf("Overlaps(%d, %d) returned %d intervals, want %d", lo, hi, len(got), want)
+		}
+	}
+}
+
+func TestIntervalTree_Panics(t *testing.T) {
+	tree := NewIntervalTree[int, string]()
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Insert() of an empty interval did not panic")
+			}
+		}()
+		tree.Insert(5, 5, "empty")
+	}()
+	tree.Seal()
+	defer func() {
+		if r := recover(); r != utils.ErrSealed {
+			t.Errorf("Insert() after Seal panicked with %v, want %v", r, utils.ErrSealed)
+		}
+	}()
+	tree.Insert(1, 2, "x")
+}
@@ -0,0 +1,85 @@
+package trees
+
+// LCA returns the lowest common ancestor of key1 and key2: the deepest node
+// whose subtree holds both, which is the first node on the search path whose
+// key lies between them. It returns false unless both keys are present.
+func (b *BST[K, V]) LCA(key1, key2 K) (K, bool) {
+	if b.threadSafe && !b.sealed.Load() {
+		b.mu.RLock()
+		defer b.mu.RUnlock()
+	}
+	var zero K
+	if _, ok := search(b.root, key1); !ok {
+		return zero, false
+	}
+	if _, ok := search(b.root, key2); !ok {
+		return zero, false
+	}
+	node := b.root
+	for {
+		switch {
+		case key1 < node.key && key2 < node.key:
+			node = node.left
+		case key1 > node.key && key2 > node.key:
+			node = node.right
+		default:
+			return node.key, true
+		}
+	}
+}
+
+// LCA returns the lowest common ancestor of key1 and key2 in the tree's
+// current shape, or false unless both keys are present. Rotations move
+// ancestors around, so the answer can change as the tree is modified.
+func (t *AVLTree[K, V]) LCA(key1, key2 K) (K, bool) {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	var zero K
+	if _, ok := t.search(t.Root, key1); !ok {
+		return zero, false
+	}
+	if _, ok := t.search(t.Root, key2); !ok {
+		return zero, false
+	}
+	node := t.Root
+	for {
+		switch {
+		case key1 < node.Key && key2 < node.Key:
+			node = node.Left
+		case key1 > node.Key && key2 > node.Key:
+			node = node.Right
+		default:
+			return node.Key, true
+		}
+	}
+}
+
+// LCA returns the lowest common ancestor of key1 and key2 in the tree's
+// current shape, or false unless both keys are present. Rotations move
+// ancestors around, so the answer can change as the tree is modified.
+func (t *RBTree[K, V]) LCA(key1, key2 K) (K, bool) {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	var zero K
+	if _, ok := t.searchNoLock(key1); !ok {
+		return zero, false
+	}
+	if _, ok := t.searchNoLock(key2); !ok {
+		return zero, false
+	}
+	node := t.root
+	for {
+		switch {
+		case key1 < node.key && key2 < node.key:
+			node = node.left
+		case key1 > node.key && key2 > node.key:
+			node = node.right
+		default:
+			return node.key, true
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package trees
+
+import (
+	"testing"
+)
+
+type lcaTree interface {
+	Insert(key int, value int)
+	LCA(key1, key2 int) (int, bool)
+}
+
+func TestSearchTreeLCA(t *testing.T) {
+	trees := map[string]lcaTree{
+		"BST":     NewBST[int, int](false),
+		"AVLTree": NewAVLTree[int, int](false),
+		"RBTree":  NewRBTree[int, int](false),
+	}
+	for name, tree := range trees {
+		t.Run(name, func(t *testing.T) {
+			// Inserted in this order, every tree ends up with 50 at the root,
+			// 30 and 70 below it, and the other keys as leaves
+			for _, key := range []int{50, 30, 70, 20, 40, 60, 80} {
+				tree.Insert(key, key)
+			}
+			tests := []struct {
+				a, b, want int
+			}{
+				{20, 40, 30},
+				{60, 80, 70},
+				{20, 80, 50},
+				{30, 40, 30},
+				{40, 40, 40},
+				{40, 60, 50},
+			}
+			for _, tt := range tests {
+				if got, ok := tree.LCA(tt.a, tt.b); !ok || got != tt.want {
+					t.Errorf("LCA(%d, %d) = %d, %v, want %d, true", tt.a, tt.b, got, ok, tt.want)
+				}
+			}
+			if _, ok := tree.LCA(20, 25); ok {
+				t.Error("LCA() with a missing key reported an ancestor")
+			}
+		})
+	}
+}
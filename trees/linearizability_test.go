@@ -0,0 +1,144 @@
+package trees
+
+import (
+	"dsgo/concurrencytest"
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// searchTree is the surface shared by the thread-safe search trees. Lookup
+// is passed separately because RBTree.Search returns a node.
+type searchTree interface {
+	Insert(key, value int)
+	Delete(key int)
+	Len() int
+	LevelOrder(fn func(key, value int) bool)
+}
+
+// treeModel holds the value of each of the four keys the scripts use. It
+// is an array rather than a map because delete is shadowed in this package.
+type treeModel = *[4]treeSlot
+
+type treeSlot struct {
+	value   int
+	present bool
+}
+
+func cloneTreeModel(m treeModel) treeModel {
+	c := *m
+	return &c
+}
+
+func modelLen(m treeModel) int {
+	n := 0
+	for _, slot := range m {
+		if slot.present {
+			n++
+		}
+	}
+	return n
+}
+
+// treeOps generates Insert, Delete, lookups, Len and a full traversal over
+// a small key space so goroutines collide.
+func treeOps[S searchTree](lookup func(t S, key int) (int, bool)) func(r *rand.Rand) concurrencytest.Op[S, treeModel] {
+	return func(r *rand.Rand) concurrencytest.Op[S, treeModel] {
+		key, value := r.Intn(4), r.Intn(100)
+		switch r.Intn(5) {
+		case 0:
+			return concurrencytest.Op[S, treeModel]{
+				Name:  fmt.Sprintf("Insert(%d, %d)", key, value),
+				Run:   func(t S) any { t.Insert(key, value); return nil },
+				Model: func(m treeModel) any { m[key].value, m[key].present = value, true; return nil },
+			}
+		case 1:
+			return concurrencytest.Op[S, treeModel]{
+				Name:  fmt.Sprintf("Delete(%d)", key),
+				Run:   func(t S) any { t.Delete(key); return nil },
+				Model: func(m treeModel) any { m[key].value, m[key].present = 0, false; return nil },
+			}
+		case 2:
+			return concurrencytest.Op[S, treeModel]{
+				Name: fmt.Sprintf("Search(%d)", key),
+				Run: func(t S) any {
+					v, ok := lookup(t, key)
+					return [2]any{v, ok}
+				},
+				Model: func(m treeModel) any {
+					return [2]any{m[key].value, m[key].present}
+				},
+			}
+		case 3:
+			return concurrencytest.Op[S, treeModel]{
+				Name:  "Len()",
+				Run:   func(t S) any { return t.Len() },
+				Model: func(m treeModel) any { return modelLen(m) },
+			}
+		default:
+			return concurrencytest.Op[S, treeModel]{
+				Name: "LevelOrder()",
+				Run: func(t S) any {
+					entries := [][2]int{}
+					t.LevelOrder(func(k, v int) bool {
+						entries = append(entries, [2]int{k, v})
+						return true
+					})
+					slices.SortFunc(entries, func(a, b [2]int) int { return a[0] - b[0] })
+					return entries
+				},
+				Model: func(m treeModel) any {
+					entries := [][2]int{}
+					for k, slot := range m {
+						if slot.present {
+							entries = append(entries, [2]int{k, slot.value})
+						}
+					}
+					return entries
+				},
+			}
+		}
+	}
+}
+
+func runTreeScript[S searchTree](t *testing.T, newTree func() S, lookup func(t S, key int) (int, bool)) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		concurrencytest.Run(t, concurrencytest.Script[S, treeModel]{
+			New:      newTree,
+			NewModel: func() treeModel { return new([4]treeSlot) },
+			Clone:    cloneTreeModel,
+			Threads:  concurrencytest.RandomThreads(r, 4, 6, treeOps(lookup)),
+			Runs:     10,
+		})
+	}
+}
+
+func TestAVLTreeLinearizable(t *testing.T) {
+	runTreeScript(t, func() *AVLTree[int, int] { return NewAVLTree[int, int]() }, (*AVLTree[int, int]).Search)
+}
+
+// RBTree.Search hands back a node that a concurrent Insert may update, so
+// the lookup goes through Floor, which copies the value under the lock.
+func TestRBTreeLinearizable(t *testing.T) {
+	runTreeScript(t, func() *RBTree[int, int] { return NewRBTree[int, int]() }, func(t *RBTree[int, int], key int) (int, bool) {
+		k, v, ok := t.Floor(key)
+		if !ok || k != key {
+			return 0, false
+		}
+		return v, true
+	})
+}
+
+func TestBSTLinearizable(t *testing.T) {
+	runTreeScript(t, func() *BST[int, int] { return NewBST[int, int]() }, (*BST[int, int]).Search)
+}
+
+func TestScapegoatTreeLinearizable(t *testing.T) {
+	runTreeScript(t, func() *ScapegoatTree[int, int] { return NewScapegoatTree[int, int]() }, (*ScapegoatTree[int, int]).Search)
+}
+
+func TestWBTreeLinearizable(t *testing.T) {
+	runTreeScript(t, func() *WBTree[int, int] { return NewWBTree[int, int]() }, (*WBTree[int, int]).Search)
+}
@@ -0,0 +1,63 @@
+package trees
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+type extremeTree interface {
+	Insert(key int, value int)
+	Min() (int, int, bool)
+	Max() (int, int, bool)
+	DeleteMin() (int, int, bool)
+	DeleteMax() (int, int, bool)
+}
+
+func TestMinMax(t *testing.T) {
+	trees := map[string]extremeTree{
+		"BST":     NewBST[int, int](false),
+		"AVLTree": NewAVLTree[int, int](false),
+		"RBTree":  NewRBTree[int, int](false),
+	}
+	for name, tree := range trees {
+		t.Run(name, func(t *testing.T) {
+			if _, _, ok := tree.Min(); ok {
+				t.Error("Min() on an empty tree reported an entry")
+			}
+			if _, _, ok := tree.DeleteMax(); ok {
+				t.Error("DeleteMax() on an empty tree reported an entry")
+			}
+
+			rng := rand.New(rand.NewSource(1))
+			keys := rng.Perm(200)
+			for _, key := range keys {
+				tree.Insert(key, -key)
+			}
+			slices.Sort(keys)
+
+			if key, value, ok := tree.Min(); !ok || key != 0 || value != 0 {
+				t.Errorf("Min() = %d, %d, %v, want 0, 0, true", key, value, ok)
+			}
+			if key, value, ok := tree.Max(); !ok || key != 199 || value != -199 {
+				t.Errorf("Max() = %d, %d, %v, want 199, -199, true", key, value, ok)
+			}
+
+			// Drain from both ends; the keys must come out in sorted order
+			for lo, hi := 0, len(keys)-1; lo <= hi; lo, hi = lo+1, hi-1 {
+				if key, value, ok := tree.DeleteMin(); !ok || key != keys[lo] || value != -keys[lo] {
+					t.Fatalf("DeleteMin() = %d, %d, %v, want %d", key, value, ok, keys[lo])
+				}
+				if lo == hi {
+					break
+				}
+				if key, _, ok := tree.DeleteMax(); !ok || key != keys[hi] {
+					t.Fatalf("DeleteMax() = %d, %v, want %d", key, ok, keys[hi])
+				}
+			}
+			if _, _, ok := tree.Max(); ok {
+				t.Error("Max() on a drained tree reported an entry")
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package trees
+
+import "encoding/json"
+
+// WalkOrder selects the traversal used by Tree.Walk.
+type WalkOrder int
+
+const (
+	// DepthFirst visits a node before its children, children in order.
+	DepthFirst WalkOrder = iota
+	// BreadthFirst visits nodes level by level, left to right.
+	BreadthFirst
+)
+
+// TreeNode is a node of a Tree. Value may be read and written freely; the
+// structure is changed only through AddChild and Tree.Remove.
+type TreeNode[T any] struct {
+	Value    T
+	parent   *TreeNode[T]
+	children []*TreeNode[T]
+	tree     *Tree[T]
+}
+
+// Tree is a rooted tree in which every node holds a value and any number of
+// ordered children, for hierarchies such as file systems and menus. Unlike
+// the search trees in this package it imposes no ordering on values. A Tree
+// hands out node pointers and is not safe for concurrent mutation.
+type Tree[T any] struct {
+	root *TreeNode[T]
+	size int
+}
+
+// NewTree creates a tree holding only a root with the given value.
+func NewTree[T any](rootValue T) *Tree[T] {
+	t := &Tree[T]{size: 1}
+	t.root = &TreeNode[T]{Value: rootValue, tree: t}
+	return t
+}
+
+// Root returns the root node, or nil for an empty tree.
+func (t *Tree[T]) Root() *TreeNode[T] {
+	return t.root
+}
+
+// Len returns the number of nodes in the tree.
+func (t *Tree[T]) Len() int {
+	return t.size
+}
+
+// AddChild appends a new child holding value to n and returns it.
+func (n *TreeNode[T]) AddChild(value T) *TreeNode[T] {
+	child := &TreeNode[T]{Value: value, parent: n, tree: n.tree}
+	n.children = append(n.children, child)
+	if n.tree != nil {
+		n.tree.size++
+	}
+	return child
+}
+
+// Parent returns the parent of n, or nil for a root.
+func (n *TreeNode[T]) Parent() *TreeNode[T] {
+	return n.parent
+}
+
+// Children returns the children of n in order.
+func (n *TreeNode[T]) Children() []*TreeNode[T] {
+	return append([]*TreeNode[T](nil), n.children...)
+}
+
+// Depth returns the number of edges between n and the root.
+func (n *TreeNode[T]) Depth() int {
+	depth := 0
+	for p := n.parent; p != nil; p = p.parent {
+		depth++
+	}
+	return depth
+}
+
+// PathToRoot returns n followed by each of its ancestors, ending at the root.
+func (n *TreeNode[T]) PathToRoot() []*TreeNode[T] {
+	var path []*TreeNode[T]
+	for p := n; p != nil; p = p.parent {
+		path = append(path, p)
+	}
+	return path
+}
+
+// Size returns the number of nodes in the subtree rooted at n, n included.
+func (n *TreeNode[T]) Size() int {
+	size := 0
+	n.walk(DepthFirst, func(*TreeNode[T]) bool {
+		size++
+		return true
+	})
+	return size
+}
+
+// Remove detaches n and its subtree from the tree and reports whether it
+// did. The root and nodes of other trees cannot be removed. The detached
+// subtree stays intact and can still be walked from n.
+func (t *Tree[T]) Remove(n *TreeNode[T]) bool {
+	if n == nil || n.tree != t || n.parent == nil {
+		return false
+	}
+	siblings := n.parent.children
+	for i, child := range siblings {
+		if child == n {
+			n.parent.children = append(siblings[:i:i], siblings[i+1:]...)
+			break
+		}
+	}
+	n.parent = nil
+	n.walk(DepthFirst, func(node *TreeNode[T]) bool {
+		node.tree = nil
+		t.size--
+		return true
+	})
+	return true
+}
+
+// Walk calls fn for every node in the given order until fn returns false.
+func (t *Tree[T]) Walk(order WalkOrder, fn func(node *TreeNode[T]) bool) {
+	if t.root != nil {
+		t.root.walk(order, fn)
+	}
+}
+
+func (n *TreeNode[T]) walk(order WalkOrder, fn func(node *TreeNode[T]) bool) {
+	if order == BreadthFirst {
+		queue := []*TreeNode[T]{n}
+		for len(queue) > 0 {
+			node := queue[0]
+			queue = queue[1:]
+			if !fn(node) {
+				return
+			}
+			queue = append(queue, node.children...)
+		}
+		return
+	}
+	stack := []*TreeNode[T]{n}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !fn(node) {
+			return
+		}
+		for i := len(node.children) - 1; i >= 0; i-- {
+			stack = append(stack, node.children[i])
+		}
+	}
+}
+
+// jsonTreeNode is the serialized form of a TreeNode.
+type jsonTreeNode[T any] struct {
+	Value    T                  `json:"value"`
+	Children []*jsonTreeNode[T] `json:"children,omitempty"`
+}
+
+// MarshalJSON encodes the tree as nested {"value", "children"} objects, or
+// null for an empty tree.
+func (t *Tree[T]) MarshalJSON() ([]byte, error) {
+	if t.root == nil {
+		return []byte("null"), nil
+	}
+	var encode func(n *TreeNode[T]) *jsonTreeNode[T]
+	encode = func(n *TreeNode[T]) *jsonTreeNode[T] {
+		out := &jsonTreeNode[T]{Value: n.Value}
+		for _, child := range n.children {
+			out.Children = append(out.Children, encode(child))
+		}
+		return out
+	}
+	return json.Marshal(encode(t.root))
+}
+
+// UnmarshalJSON replaces the tree with one decoded from the MarshalJSON
+// format.
+func (t *Tree[T]) UnmarshalJSON(data []byte) error {
+	var root *jsonTreeNode[T]
+	if err := json.Unmarshal(data, &root); err != nil {
+		return err
+	}
+	t.root, t.size = nil, 0
+	if root == nil {
+		return nil
+	}
+	var decode func(in *jsonTreeNode[T], parent *TreeNode[T]) *TreeNode[T]
+	decode = func(in *jsonTreeNode[T], parent *TreeNode[T]) *TreeNode[T] {
+		n := &TreeNode[T]{Value: in.Value, parent: parent, tree: t}
+		t.size++
+		for _, child := range in.Children {
+			if child != nil {
+				n.children = append(n.children, decode(child, n))
+			}
+		}
+		return n
+	}
+	t.root = decode(root, nil)
+	return nil
+}
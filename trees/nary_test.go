@@ -0,0 +1,111 @@
+package trees
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+// buildMenu returns the tree
+//
+//	root
+//	├── file
+//	│   ├── open
+//	│   └── save
+//	└── edit
+//	    └── undo
+func buildMenu() (*Tree[string], map[string]*TreeNode[string]) {
+	t := NewTree("root")
+	nodes := map[string]*TreeNode[string]{"root": t.Root()}
+	nodes["file"] = t.Root().AddChild("file")
+	nodes["edit"] = t.Root().AddChild("edit")
+	nodes["open"] = nodes["file"].AddChild("open")
+	nodes["save"] = nodes["file"].AddChild("save")
+	nodes["undo"] = nodes["edit"].AddChild("undo")
+	return t, nodes
+}
+
+func walkValues(t *Tree[string], order WalkOrder) []string {
+	var values []string
+	t.Walk(order, func(n *TreeNode[string]) bool {
+		values = append(values, n.Value)
+		return true
+	})
+	return values
+}
+
+func TestTreeWalk(t *testing.T) {
+	tree, nodes := buildMenu()
+	if tree.Len() != 6 {
+		t.Errorf("Len() = %d, want 6", tree.Len())
+	}
+	if got := walkValues(tree, DepthFirst); !slices.Equal(got, []string{"root", "file", "open", "save", "edit", "undo"}) {
+		t.Errorf("DepthFirst walk = %v", got)
+	}
+	if got := walkValues(tree, BreadthFirst); !slices.Equal(got, []string{"root", "file", "edit", "open", "save", "undo"}) {
+		t.Errorf("BreadthFirst walk = %v", got)
+	}
+
+	var path []string
+	for _, n := range nodes["save"].PathToRoot() {
+		path = append(path, n.Value)
+	}
+	if !slices.Equal(path, []string{"save", "file", "root"}) {
+		t.Errorf("PathToRoot() = %v, want [save file root]", path)
+	}
+	if d := nodes["undo"].Depth(); d != 2 {
+		t.Errorf("Depth() = %d, want 2", d)
+	}
+	if s := nodes["file"].Size(); s != 3 {
+		t.Errorf("Size() = %d, want 3", s)
+	}
+}
+
+func TestTreeRemove(t *testing.T) {
+	tree, nodes := buildMenu()
+	if tree.Remove(tree.Root()) {
+		t.Error("Remove() of the root succeeded")
+	}
+	if !tree.Remove(nodes["file"]) {
+		t.Fatal("Remove(file) failed")
+	}
+	if tree.Remove(nodes["open"]) {
+		t.Error("Remove() of a node in a detached subtree succeeded")
+	}
+	if tree.Len() != 3 {
+		t.Errorf("Len() after Remove = %d, want 3", tree.Len())
+	}
+	if got := walkValues(tree, DepthFirst); !slices.Equal(got, []string{"root", "edit", "undo"}) {
+		t.Errorf("walk after Remove = %v", got)
+	}
+	if nodes["file"].Parent() != nil || nodes["file"].Size() != 3 {
+		t.Error("removed subtree should be detached but intact")
+	}
+}
+
+func TestTreeJSON(t *testing.T) {
+	tree, _ := buildMenu()
+	data, err := json.Marshal(tree)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want := `{"value":"root","children":[{"value":"file","children":[{"value":"open"},{"value":"save"}]},{"value":"edit","children":[{"value":"undo"}]}]}`
+	if string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+
+	var decoded Tree[string]
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded.Len() != 6 {
+		t.Errorf("decoded Len() = %d, want 6", decoded.Len())
+	}
+	if got := walkValues(&decoded, BreadthFirst); !slices.Equal(got, walkValues(tree, BreadthFirst)) {
+		t.Errorf("decoded walk = %v", got)
+	}
+	undo := decoded.Root().Children()[1].Children()[0]
+	if undo.Parent().Value != "edit" || !decoded.Remove(undo) {
+		t.Error("decoded nodes should be linked to their parent and tree")
+	}
+}
@@ -0,0 +1,35 @@
+package trees
+
+import (
+	"dsgo/utils"
+)
+
+// collectPage runs an in-order walk, skipping offset entries and collecting
+// up to limit. It returns the offset of the following page, or -1 if the
+// walk ran out of entries.
+func collectPage[K utils.Ordered, V any](walk func(fn func(K, V) bool), offset, limit int) ([]utils.Entry[K, V], int) {
+	offset = max(offset, 0)
+	if limit <= 0 {
+		return nil, -1
+	}
+
+	var entries []utils.Entry[K, V]
+	seen := 0
+	more := false
+	walk(func(key K, value V) bool {
+		if seen < offset {
+			seen++
+			return true
+		}
+		if len(entries) == limit {
+			more = true
+			return false
+		}
+		entries = append(entries, utils.Entry[K, V]{Key: key, Value: value})
+		return true
+	})
+	if !more {
+		return entries, -1
+	}
+	return entries, offset + limit
+}
@@ -0,0 +1,104 @@
+package trees
+
+import (
+	"dsgo/utils"
+	"testing"
+)
+
+type pager interface {
+	Insert(key int, value int)
+	Delete(key int)
+	Page(offset, limit int) ([]utils.Entry[int, int], int)
+	PageAfter(key int, limit int) ([]utils.Entry[int, int], bool)
+}
+
+func pagers() map[string]pager {
+	return map[string]pager{
+		"BST":     NewBST[int, int](false),
+		"AVLTree": NewAVLTree[int, int](false),
+		"RBTree":  NewRBTree[int, int](false),
+	}
+}
+
+func entryKeys(entries []utils.Entry[int, int]) []int {
+	keys := make([]int, len(entries))
+	for i, e := range entries {
+		keys[i] = e.Key
+	}
+	return keys
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestTreePage(t *testing.T) {
+	for name, tree := range pagers() {
+		t.Run(name, func(t *testing.T) {
+			for _, k := range []int{5, 3, 8, 1, 4, 7, 9, 2, 6} {
+				tree.Insert(k, k*10)
+			}
+
+			tests := []struct {
+				offset, limit int
+				wantKeys      []int
+				wantNext      int
+			}{
+				{0, 4, []int{1, 2, 3, 4}, 4},
+				{4, 4, []int{5, 6, 7, 8}, 8},
+				{8, 4, []int{9}, -1},
+				{6, 3, []int{7, 8, 9}, -1},
+				{20, 4, nil, -1},
+				{0, 0, nil, -1},
+			}
+			for _, tt := range tests {
+				entries, next := tree.Page(tt.offset, tt.limit)
+				if !equalInts(entryKeys(entries), tt.wantKeys) || next != tt.wantNext {
+					t.Errorf("Page(%d, %d) = %v, %d; want %v, %d",
+						tt.offset, tt.limit, entryKeys(entries), next, tt.wantKeys, tt.wantNext)
+				}
+			}
+
+			entries, _ := tree.Page(0, 1)
+			if entries[0].Value != 10 {
+				t.Errorf("Page(0, 1) value = %d, want 10", entries[0].Value)
+			}
+		})
+	}
+}
+
+func TestTreePageAfterIsStable(t *testing.T) {
+	for name, tree := range pagers() {
+		t.Run(name, func(t *testing.T) {
+			for k := 1; k <= 6; k++ {
+				tree.Insert(k*10, k)
+			}
+
+			first, more := tree.PageAfter(0, 3)
+			if !equalInts(entryKeys(first), []int{10, 20, 30}) || !more {
+				t.Fatalf("PageAfter(0, 3) = %v, %v", entryKeys(first), more)
+			}
+
+			// Mutations before the cursor must not shift the next page
+			tree.Delete(10)
+			tree.Insert(15, 0)
+
+			second, more := tree.PageAfter(first[len(first)-1].Key, 3)
+			if !equalInts(entryKeys(second), []int{40, 50, 60}) || more {
+				t.Errorf("PageAfter(30, 3) = %v, %v; want [40 50 60], false", entryKeys(second), more)
+			}
+
+			if entries, more := tree.PageAfter(35, 10); !equalInts(entryKeys(entries), []int{40, 50, 60}) || more {
+				t.Errorf("PageAfter(35, 10) = %v, %v", entryKeys(entries), more)
+			}
+		})
+	}
+}
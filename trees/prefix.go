@@ -0,0 +1,22 @@
+package trees
+
+import (
+	"strings"
+)
+
+type rangeFromer[V any] interface {
+	RangeFrom(from string, fn func(key string, value V) bool)
+}
+
+// RangePrefix calls f, in key order, for every entry of a string-keyed BST,
+// AVLTree, RBTree or TreeMap whose key starts with prefix, until f returns
+// false. It seeks to the first key at or after prefix and stops at the first
+// key that no longer matches.
+func RangePrefix[V any](t rangeFromer[V], prefix string, f func(key string, value V) bool) {
+	t.RangeFrom(prefix, func(key string, value V) bool {
+		if !strings.HasPrefix(key, prefix) {
+			return false
+		}
+		return f(key, value)
+	})
+}
@@ -0,0 +1,54 @@
+package trees
+
+import (
+	"testing"
+)
+
+func TestRangePrefix(t *testing.T) {
+	trees := map[string]interface {
+		rangeFromer[int]
+		Insert(key string, value int)
+	}{
+		"BST":     NewBST[string, int](),
+		"AVLTree": NewAVLTree[string, int](),
+		"RBTree":  NewRBTree[string, int](),
+	}
+	treeMap := NewTreeMap[string, int](RedBlack)
+
+	for i, key := range []string{"user/", "user/1", "user/2", "users", "admin", "user/10", "v"} {
+		for _, tree := range trees {
+			tree.Insert(key, i)
+		}
+		treeMap.Set(key, i)
+	}
+
+	all := map[string]rangeFromer[int]{"TreeMap": treeMap}
+	for name, tree := range trees {
+		all[name] = tree
+	}
+	for name, tree := range all {
+		var got []string
+		RangePrefix(tree, "user/", func(key string, value int) bool {
+			got = append(got, key)
+			return true
+		})
+		expected := []string{"user/", "user/1", "user/10", "user/2"}
+		if len(got) != len(expected) {
+			t.Fatalf("%s: RangePrefix(user/) = %v, want %v", name, got, expected)
+		}
+		for i := range expected {
+			if got[i] != expected[i] {
+				t.Errorf("%s: RangePrefix(user/)[%d] = %s, want %s", name, i, got[i], expected[i])
+			}
+		}
+
+		visited := 0
+		RangePrefix(tree, "user/", func(key string, value int) bool {
+			visited++
+			return false
+		})
+		if visited != 1 {
+			t.Errorf("%s: RangePrefix visited %d keys after f returned false", name, visited)
+		}
+	}
+}
@@ -0,0 +1,270 @@
+package trees
+
+import (
+	"dsgo/utils"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+type radixNode[V any] struct {
+	// prefix is the label on the edge into this node
+	prefix string
+	value  V
+	leaf   bool
+	// edges are ordered by the first byte of their prefix, which is unique
+	// among siblings
+	edges []*radixNode[V]
+}
+
+// edge returns the index of the child whose prefix starts with b, or where
+// it would be inserted, and the child if there is one.
+func (n *radixNode[V]) edge(b byte) (int, *radixNode[V]) {
+	i := sort.Search(len(n.edges), func(i int) bool { return n.edges[i].prefix[0] >= b })
+	if i < len(n.edges) && n.edges[i].prefix[0] == b {
+		return i, n.edges[i]
+	}
+	return i, nil
+}
+
+// RadixTree is a compressed trie over string keys: chains of single-child
+// nodes are merged into one edge labelled with the whole substring, so long
+// shared prefixes such as URL routes and file paths cost one node rather
+// than one per byte. Keys are walked in lexicographic byte order.
+type RadixTree[V any] struct {
+	root       *radixNode[V]
+	size       int
+	threadSafe bool
+	mu         sync.RWMutex
+	sealed     atomic.Bool
+}
+
+func NewRadixTree[V any](threadSafe ...bool) *RadixTree[V] {
+	isThreadSafe := true
+	if len(threadSafe) > 0 {
+		isThreadSafe = threadSafe[0]
+	}
+	return &RadixTree[V]{
+		root:       &radixNode[V]{},
+		threadSafe: isThreadSafe,
+	}
+}
+
+// commonPrefix returns the length of the longest common prefix of a and b.
+func commonPrefix(a, b string) int {
+	n := min(len(a), len(b))
+	for i := 0; i < n; i++ {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return n
+}
+
+// Insert sets the value for key, splitting an edge if key diverges from it
+// part way along.
+func (t *RadixTree[V]) Insert(key string, value V) {
+	if t.threadSafe {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+	if t.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
+
+	n := t.root
+	for key != "" {
+		i, child := n.edge(key[0])
+		if child == nil {
+			n.edges = append(n.edges, nil)
+			copy(n.edges[i+1:], n.edges[i:])
+			n.edges[i] = &radixNode[V]{prefix: key, value: value, leaf: true}
+			t.size++
+			return
+		}
+		common := commonPrefix(key, child.prefix)
+		if common < len(child.prefix) {
+			split := &radixNode[V]{prefix: child.prefix[:common], edges: []*radixNode[V]{child}}
+			child.prefix = child.prefix[common:]
+			n.edges[i] = split
+			child = split
+		}
+		key = key[common:]
+		n = child
+	}
+	if !n.leaf {
+		t.size++
+	}
+	n.value = value
+	n.leaf = true
+}
+
+// find returns the node holding exactly key, or nil.
+func (t *RadixTree[V]) find(key string) *radixNode[V] {
+	n := t.root
+	for key != "" {
+		_, child := n.edge(key[0])
+		if child == nil || !strings.HasPrefix(key, child.prefix) {
+			return nil
+		}
+		key = key[len(child.prefix):]
+		n = child
+	}
+	return n
+}
+
+func (t *RadixTree[V]) Get(key string) (V, bool) {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	if n := t.find(key); n != nil && n.leaf {
+		return n.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Delete removes key and reports whether it was present. Nodes left without
+// a value are pruned or merged into their only child, keeping the tree
+// compressed.
+func (t *RadixTree[V]) Delete(key string) bool {
+	if t.threadSafe {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+	if t.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
+
+	path := []*radixNode[V]{t.root}
+	n := t.root
+	for rest := key; rest != ""; {
+		_, child := n.edge(rest[0])
+		if child == nil || !strings.HasPrefix(rest, child.prefix) {
+			return false
+		}
+		rest = rest[len(child.prefix):]
+		n = child
+		path = append(path, n)
+	}
+	if !n.leaf {
+		return false
+	}
+	var zero V
+	n.value, n.leaf = zero, false
+	t.size--
+
+	if len(path) > 1 && len(n.edges) == 0 {
+		parent := path[len(path)-2]
+		i, _ := parent.edge(n.prefix[0])
+		parent.edges = append(parent.edges[:i], parent.edges[i+1:]...)
+		path = path[:len(path)-1]
+		n = parent
+	}
+	if len(path) > 1 && !n.leaf && len(n.edges) == 1 {
+		parent := path[len(path)-2]
+		i, _ := parent.edge(n.prefix[0])
+		child := n.edges[0]
+		child.prefix = n.prefix + child.prefix
+		parent.edges[i] = child
+	}
+	return true
+}
+
+// LongestPrefixMatch returns the longest key in the tree that is a prefix
+// of s, and its value. This is the lookup a router makes to pick the most
+// specific route for a path.
+func (t *RadixTree[V]) LongestPrefixMatch(s string) (string, V, bool) {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+
+	n := t.root
+	best, bestValue := -1, n.value
+	if n.leaf {
+		best = 0
+	}
+	for consumed := 0; consumed < len(s); {
+		_, child := n.edge(s[consumed])
+		if child == nil || !strings.HasPrefix(s[consumed:], child.prefix) {
+			break
+		}
+		consumed += len(child.prefix)
+		n = child
+		if n.leaf {
+			best, bestValue = consumed, n.value
+		}
+	}
+	if best < 0 {
+		var zero V
+		return "", zero, false
+	}
+	return s[:best], bestValue, true
+}
+
+// WalkPrefix calls fn, in key order, for every key that starts with prefix
+// until fn returns false.
+func (t *RadixTree[V]) WalkPrefix(prefix string, fn func(key string, value V) bool) {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+
+	n, key := t.root, ""
+	for rest := prefix; rest != ""; {
+		_, child := n.edge(rest[0])
+		if child == nil {
+			return
+		}
+		if strings.HasPrefix(child.prefix, rest) {
+			// prefix ends part way along this edge
+			radixWalk(child, key+child.prefix, fn)
+			return
+		}
+		if !strings.HasPrefix(rest, child.prefix) {
+			return
+		}
+		rest = rest[len(child.prefix):]
+		key += child.prefix
+		n = child
+	}
+	radixWalk(n, key, fn)
+}
+
+func radixWalk[V any](n *radixNode[V], key string, fn func(key string, value V) bool) bool {
+	if n.leaf && !fn(key, n.value) {
+		return false
+	}
+	for _, child := range n.edges {
+		if !radixWalk(child, key+child.prefix, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *RadixTree[V]) Len() int {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	return t.size
+}
+
+// Seal makes the tree read-only. Mutations after Seal panic with
+// utils.ErrSealed. Reads on a sealed tree skip locking.
+func (t *RadixTree[V]) Seal() {
+	if t.threadSafe {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+	t.sealed.Store(true)
+}
+
+// IsSealed reports whether Seal has been called.
+func (t *RadixTree[V]) IsSealed() bool {
+	return t.sealed.Load()
+}
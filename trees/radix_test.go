@@ -0,0 +1,134 @@
+package trees
+
+import (
+	"dsgo/utils"
+	"slices"
+	"testing"
+)
+
+func radixKeys[V any](t *RadixTree[V], prefix string) []string {
+	var keys []string
+	t.WalkPrefix(prefix, func(key string, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+func TestRadixTree_InsertGetDelete(t *testing.T) {
+	tree := NewRadixTree[int]()
+	keys := []string{"romane", "romanus", "romulus", "rubens", "ruber", "rubicon", "rubicundus", "rom", ""}
+	for i, key := range keys {
+		tree.Insert(key, i)
+	}
+	tree.Insert("ruber", 100)
+	if tree.Len() != len(keys) {
+		t.Errorf("Len() = %d, want %d", tree.Len(), len(keys))
+	}
+	for i, key := range keys {
+		want := i
+		if key == "ruber" {
+			want = 100
+		}
+		if v, ok := tree.Get(key); !ok || v != want {
+			t.Errorf("Get(%q) = %v, %v, want %v, true", key, v, ok, want)
+		}
+	}
+	for _, key := range []string{"r", "ro", "roma", "rubicons", "x"} {
+		if _, ok := tree.Get(key); ok {
+			t.Errorf("Get(%q) found a key that was never inserted", key)
+		}
+	}
+
+	sorted := slices.Clone(keys)
+	slices.Sort(sorted)
+	if got := radixKeys(tree, ""); !slices.Equal(got, sorted) {
+		t.Errorf("WalkPrefix(\"\") = %v, want %v", got, sorted)
+	}
+
+	if tree.Delete("roma") {
+		t.Error("Delete() of a missing key succeeded")
+	}
+	for _, key := range []string{"rom", "romane", "rubicon", ""} {
+		if !tree.Delete(key) {
+			t.Errorf("Delete(%q) failed", key)
+		}
+	}
+	if got, want := radixKeys(tree, ""), []string{"romanus", "romulus", "rubens", "ruber", "rubicundus"}; !slices.Equal(got, want) {
+		t.Errorf("keys after Delete = %v, want %v", got, want)
+	}
+	if v, ok := tree.Get("romanus"); !ok || v != 1 {
+		t.Errorf("Get(romanus) after merge = %v, %v, want 1, true", v, ok)
+	}
+	if tree.Len() != 5 {
+		t.Errorf("Len() after Delete = %d, want 5", tree.Len())
+	}
+}
+
+func TestRadixTree_LongestPrefixMatch(t *testing.T) {
+	tree := NewRadixTree[string]()
+	tree.Insert("/", "root")
+	tree.Insert("/api/", "api")
+	tree.Insert("/api/users", "users")
+	tree.Insert("/static/", "static")
+
+	tests := []struct {
+		path    string
+		key     string
+		value   string
+		matched bool
+	}{
+		{"/api/users/42", "/api/users", "users", true},
+		{"/api/orders", "/api/", "api", true},
+		{"/api", "/", "root", true},
+		{"/static/css/site.css", "/static/", "static", true},
+		{"/", "/", "root", true},
+		{"index.html", "", "", false},
+	}
+	for _, tt := range tests {
+		key, value, ok := tree.LongestPrefixMatch(tt.path)
+		if key != tt.key || value != tt.value || ok != tt.matched {
+			t.Errorf("LongestPrefixMatch(%q) = %q, %q, %v, want %q, %q, %v", tt.path, key, value, ok, tt.key, tt.value, tt.matched)
+		}
+	}
+}
+
+func TestRadixTree_WalkPrefix(t *testing.T) {
+	tree := NewRadixTree[int]()
+	for i, key := range []string{"team", "tea", "ten", "to", "toast"} {
+		tree.Insert(key, i)
+	}
+	tests := map[string][]string{
+		"te":  {"tea", "team", "ten"},
+		"tea": {"tea", "team"},
+		"tom": nil,
+		"t":   {"tea", "team", "ten", "to", "toast"},
+		"toa": {"toast"},
+	}
+	for prefix, want := range tests {
+		if got := radixKeys(tree, prefix); !slices.Equal(got, want) {
+			t.Errorf("WalkPrefix(%q) = %v, want %v", prefix, got, want)
+		}
+	}
+
+	var first []string
+	tree.WalkPrefix("t", func(key string, _ int) bool {
+		first = append(first, key)
+		return len(first) < 2
+	})
+	if len(first) != 2 {
+		t.Errorf("WalkPrefix() did not stop early, visited %v", first)
+	}
+}
+
+func TestRadixTree_Seal(t *testing.T) {
+	tree := NewRadixTree[int]()
+	tree.Insert("a", 1)
+	tree.Seal()
+	defer func() {
+		if r := recover(); r != utils.ErrSealed {
+			t.Errorf("Insert() after Seal panicked with %v, want %v", r, utils.ErrSealed)
+		}
+	}()
+	tree.Insert("b", 2)
+}
@@ -3,6 +3,7 @@ package trees
 import (
 	"dsgo/utils"
 	"sync"
+	"sync/atomic"
 )
 
 type Color bool
@@ -19,12 +20,14 @@ type RBNode[K utils.Ordered, V any] struct {
 	left   *RBNode[K, V]
 	right  *RBNode[K, V]
 	parent *RBNode[K, V]
+	size   int
 }
 
 type RBTree[K utils.Ordered, V any] struct {
 	root       *RBNode[K, V]
 	threadSafe bool
 	mu         sync.RWMutex
+	sealed     atomic.Bool
 }
 
 func NewRBTree[K utils.Ordered, V any](threadSafe ...bool) *RBTree[K, V] {
@@ -42,7 +45,15 @@ func (t *RBTree[K, V]) Insert(key K, value V) {
 		t.mu.Lock()
 		defer t.mu.Unlock()
 	}
-	node := &RBNode[K, V]{key: key, value: value, color: Red}
+	if t.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
+	t.insertNoLock(key, value)
+}
+
+// insertNoLock does not acquire any locks. For internal use only.
+func (t *RBTree[K, V]) insertNoLock(key K, value V) {
+	node := &RBNode[K, V]{key: key, value: value, color: Red, size: 1}
 	if t.root == nil {
 		node.color = Black
 		t.root = node
@@ -71,6 +82,9 @@ func (t *RBTree[K, V]) Insert(key K, value V) {
 	} else {
 		parent.right = node
 	}
+	for p := parent; p != nil; p = p.parent {
+		p.size++
+	}
 
 	t.fixInsert(node)
 }
@@ -141,6 +155,9 @@ func (t *RBTree[K, V]) rotateLeft(node *RBNode[K, V]) {
 
 	rightChild.left = node
 	node.parent = rightChild
+
+	rightChild.size = node.size
+	node.size = 1 + rbSize(node.left) + rbSize(node.right)
 }
 
 func (t *RBTree[K, V]) rotateRight(node *RBNode[K, V]) {
@@ -162,10 +179,84 @@ func (t *RBTree[K, V]) rotateRight(node *RBNode[K, V]) {
 
 	leftChild.right = node
 	node.parent = leftChild
+
+	leftChild.size = node.size
+	node.size = 1 + rbSize(node.left) + rbSize(node.right)
+}
+
+func rbSize[K utils.Ordered, V any](node *RBNode[K, V]) int {
+	if node == nil {
+		return 0
+	}
+	return node.size
+}
+
+// Len returns the number of keys in the tree.
+func (t *RBTree[K, V]) Len() int {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	return rbSize(t.root)
+}
+
+func (t *RBTree[K, V]) Keys() []K {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	return rbAppendKeys(t.root, make([]K, 0, rbSize(t.root)))
+}
+
+// AppendKeys appends the keys in sorted order to dst and returns the
+// extended slice, letting callers reuse a buffer across calls.
+func (t *RBTree[K, V]) AppendKeys(dst []K) []K {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	return rbAppendKeys(t.root, dst)
+}
+
+// Values returns all values in key order.
+func (t *RBTree[K, V]) Values() []V {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	return rbAppendValues(t.root, make([]V, 0, rbSize(t.root)))
+}
+
+// AppendValues appends the values in key order to dst and returns the
+// extended slice.
+func (t *RBTree[K, V]) AppendValues(dst []V) []V {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	return rbAppendValues(t.root, dst)
+}
+
+func rbAppendKeys[K utils.Ordered, V any](node *RBNode[K, V], dst []K) []K {
+	for node != nil {
+		dst = rbAppendKeys(node.left, dst)
+		dst = append(dst, node.key)
+		node = node.right
+	}
+	return dst
+}
+
+func rbAppendValues[K utils.Ordered, V any](node *RBNode[K, V], dst []V) []V {
+	for node != nil {
+		dst = rbAppendValues(node.left, dst)
+		dst = append(dst, node.value)
+		node = node.right
+	}
+	return dst
 }
 
 func (t *RBTree[K, V]) Search(key K) (*RBNode[K, V], bool) {
-	if t.threadSafe {
+	if t.threadSafe && !t.sealed.Load() {
 		t.mu.RLock()
 		defer t.mu.RUnlock()
 	}
@@ -187,11 +278,214 @@ func (t *RBTree[K, V]) searchNoLock(key K) (*RBNode[K, V], bool) {
 	return nil, false
 }
 
+// Floor returns the entry with the largest key less than or equal to key,
+// or false if there is none.
+func (t *RBTree[K, V]) Floor(key K) (K, V, bool) {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	return t.floor(key)
+}
+
+// Ceiling returns the entry with the smallest key greater than or equal to
+// key, or false if there is none.
+func (t *RBTree[K, V]) Ceiling(key K) (K, V, bool) {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	return t.ceiling(key)
+}
+
+func (t *RBTree[K, V]) floor(key K) (K, V, bool) {
+	var best *RBNode[K, V]
+	for node := t.root; node != nil; {
+		if node.key <= key {
+			best = node
+			node = node.right
+		} else {
+			node = node.left
+		}
+	}
+	return rbEntryOf(best)
+}
+
+func (t *RBTree[K, V]) ceiling(key K) (K, V, bool) {
+	var best *RBNode[K, V]
+	for node := t.root; node != nil; {
+		if node.key >= key {
+			best = node
+			node = node.left
+		} else {
+			node = node.right
+		}
+	}
+	return rbEntryOf(best)
+}
+
+// Successor returns the entry with the smallest key greater than key, or
+// false if there is none. key itself need not be in the tree.
+func (t *RBTree[K, V]) Successor(key K) (K, V, bool) {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	var best *RBNode[K, V]
+	for node := t.root; node != nil; {
+		if node.key > key {
+			best = node
+			node = node.left
+		} else {
+			node = node.right
+		}
+	}
+	return rbEntryOf(best)
+}
+
+// Predecessor returns the entry with the largest key less than key, or false
+// if there is none. key itself need not be in the tree.
+func (t *RBTree[K, V]) Predecessor(key K) (K, V, bool) {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	var best *RBNode[K, V]
+	for node := t.root; node != nil; {
+		if node.key < key {
+			best = node
+			node = node.right
+		} else {
+			node = node.left
+		}
+	}
+	return rbEntryOf(best)
+}
+
+func rbEntryOf[K utils.Ordered, V any](node *RBNode[K, V]) (K, V, bool) {
+	if node == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	return node.key, node.value, true
+}
+
+// CountRange returns the number of keys k with low <= k <= high in O(log n).
+func (t *RBTree[K, V]) CountRange(low, high K) int {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	if high < low {
+		return 0
+	}
+	return t.countBelow(high, true) - t.countBelow(low, false)
+}
+
+func (t *RBTree[K, V]) countBelow(key K, inclusive bool) int {
+	count := 0
+	for node := t.root; node != nil; {
+		if node.key < key || (inclusive && node.key == key) {
+			count += rbSize(node.left) + 1
+			node = node.right
+		} else {
+			node = node.left
+		}
+	}
+	return count
+}
+
+// Page returns up to limit entries starting at offset in key order, along
+// with the offset of the next page or -1 if there are no more entries.
+func (t *RBTree[K, V]) Page(offset, limit int) ([]utils.Entry[K, V], int) {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	return collectPage(func(fn func(K, V) bool) {
+		t.ascend(t.root, nil, fn)
+	}, offset, limit)
+}
+
+// PageAfter returns up to limit entries whose keys are greater than key, and
+// whether more entries follow.
+func (t *RBTree[K, V]) PageAfter(key K, limit int) ([]utils.Entry[K, V], bool) {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	entries, next := collectPage(func(fn func(K, V) bool) {
+		t.ascend(t.root, &key, fn)
+	}, 0, limit)
+	return entries, next != -1
+}
+
+// RangeFrom calls fn in key order for every key greater than or equal to
+// from, until fn returns false.
+func (t *RBTree[K, V]) RangeFrom(from K, fn func(key K, value V) bool) {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	if node, ok := t.searchNoLock(from); ok && !fn(from, node.value) {
+		return
+	}
+	t.ascend(t.root, &from, fn)
+}
+
+// Range calls fn in key order for every key k with lo <= k <= hi, until fn
+// returns false. Only the subtrees that can hold such keys are visited, so
+// the cost is O(log n + m) for m matching keys.
+func (t *RBTree[K, V]) Range(lo, hi K, fn func(key K, value V) bool) {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	t.rangeBetween(t.root, lo, hi, fn)
+}
+
+func (t *RBTree[K, V]) rangeBetween(node *RBNode[K, V], lo, hi K, fn func(K, V) bool) bool {
+	if node == nil {
+		return true
+	}
+	if lo < node.key && !t.rangeBetween(node.left, lo, hi, fn) {
+		return false
+	}
+	if lo <= node.key && node.key <= hi && !fn(node.key, node.value) {
+		return false
+	}
+	if node.key < hi {
+		return t.rangeBetween(node.right, lo, hi, fn)
+	}
+	return true
+}
+
+// ascend walks the subtree in key order, starting after *after when after is
+// non-nil, until fn returns false.
+func (t *RBTree[K, V]) ascend(node *RBNode[K, V], after *K, fn func(K, V) bool) bool {
+	if node == nil {
+		return true
+	}
+	if after == nil || node.key > *after {
+		if !t.ascend(node.left, after, fn) {
+			return false
+		}
+		if !fn(node.key, node.value) {
+			return false
+		}
+	}
+	return t.ascend(node.right, after, fn)
+}
+
 func (t *RBTree[K, V]) Delete(key K) {
 	if t.threadSafe {
 		t.mu.Lock()
 		defer t.mu.Unlock()
 	}
+	if t.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
 	node, found := t.searchNoLock(key)
 	if !found {
 		return
@@ -199,11 +493,47 @@ func (t *RBTree[K, V]) Delete(key K) {
 	t.deleteNode(node)
 }
 
+// Remove deletes key and returns the value it held, or false if the key was
+// not present.
+func (t *RBTree[K, V]) Remove(key K) (V, bool) {
+	if t.threadSafe {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+	if t.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
+	node, found := t.searchNoLock(key)
+	if !found {
+		var zero V
+		return zero, false
+	}
+	value := node.value
+	t.deleteNode(node)
+	return value, true
+}
+
+// TryDelete deletes key and reports whether it was present.
+func (t *RBTree[K, V]) TryDelete(key K) bool {
+	_, ok := t.Remove(key)
+	return ok
+}
+
 func (t *RBTree[K, V]) deleteNode(node *RBNode[K, V]) {
 	var child *RBNode[K, V]
 	var childParent *RBNode[K, V]
 	originalColor := node.color
 
+	// Every ancestor of the node that is physically unlinked loses one
+	// descendant: node itself, or its successor if it has two children
+	removed := node
+	if node.left != nil && node.right != nil {
+		removed = t.minimum(node.right)
+	}
+	for p := removed.parent; p != nil; p = p.parent {
+		p.size--
+	}
+
 	if node.left == nil {
 		child = node.right
 		childParent = node.parent
@@ -219,6 +549,9 @@ func (t *RBTree[K, V]) deleteNode(node *RBNode[K, V]) {
 		childParent = successor.parent
 
 		if successor.parent == node {
+			// The successor takes node's place, so it becomes the parent
+			// of the fixup position rather than the removed node
+			childParent = successor
 			if child != nil {
 				child.parent = successor
 			}
@@ -232,6 +565,7 @@ func (t *RBTree[K, V]) deleteNode(node *RBNode[K, V]) {
 		successor.left = node.left
 		successor.left.parent = successor
 		successor.color = node.color
+		successor.size = node.size
 	}
 
 	if originalColor == Black {
@@ -252,94 +586,64 @@ func (t *RBTree[K, V]) transplant(u, v *RBNode[K, V]) {
 	}
 }
 
+// fixDelete restores the red-black properties after a black node was
+// unlinked, leaving node (possibly nil) one black short. parent is tracked
+// separately because node may be nil. In a valid tree a node that is one
+// black short always has a non-nil sibling, and when that sibling is black
+// with a red child the child is non-nil, so no nil checks are needed beyond
+// isRed/isBlack.
 func (t *RBTree[K, V]) fixDelete(node, parent *RBNode[K, V]) {
-	for (node != t.root) && (node == nil || node.color == Black) {
-		var sibling *RBNode[K, V]
-		if parent == nil {
-			break
-		}
-		if node == nil {
-			if parent.left == nil || parent.left == node {
+	for node != t.root && isBlack(node) {
+		if node == parent.left {
+			sibling := parent.right
+			if isRed(sibling) {
+				sibling.color = Black
+				parent.color = Red
+				t.rotateLeft(parent)
 				sibling = parent.right
-			} else {
-				sibling = parent.left
 			}
-		} else {
-			if node.parent == nil {
-				break
+			if isBlack(sibling.left) && isBlack(sibling.right) {
+				sibling.color = Red
+				node = parent
+				parent = node.parent
+				continue
 			}
-			if node == node.parent.left {
-				sibling = node.parent.right
-			} else {
-				sibling = node.parent.left
-			}
-			parent = node.parent
-		}
-
-		if sibling == nil {
-			break
-		}
-
-		if sibling.color == Red {
-			sibling.color = Black
-			parent.color = Red
-			if sibling == parent.right {
-				t.rotateLeft(parent)
+			if isBlack(sibling.right) {
+				sibling.left.color = Black
+				sibling.color = Red
+				t.rotateRight(sibling)
 				sibling = parent.right
-			} else {
+			}
+			sibling.color = parent.color
+			parent.color = Black
+			sibling.right.color = Black
+			t.rotateLeft(parent)
+			node = t.root
+		} else {
+			sibling := parent.left
+			if isRed(sibling) {
+				sibling.color = Black
+				parent.color = Red
 				t.rotateRight(parent)
 				sibling = parent.left
 			}
-			if sibling == nil {
-				break
+			if isBlack(sibling.left) && isBlack(sibling.right) {
+				sibling.color = Red
+				node = parent
+				parent = node.parent
+				continue
 			}
-		}
-
-		if (sibling.left == nil || sibling.left.color == Black) &&
-			(sibling.right == nil || sibling.right.color == Black) {
-			sibling.color = Red
-			node = parent
-			parent = node.parent
-		} else {
-			if sibling == parent.right {
-				if sibling.right == nil || sibling.right.color == Black {
-					if sibling.left != nil {
-						sibling.left.color = Black
-					}
-					sibling.color = Red
-					t.rotateRight(sibling)
-					sibling = parent.right
-					if sibling == nil {
-						break
-					}
-				}
-				sibling.color = parent.color
-				parent.color = Black
-				if sibling.right != nil {
-					sibling.right.color = Black
-				}
-				t.rotateLeft(parent)
-				node = t.root
-			} else {
-				if sibling.left == nil || sibling.left.color == Black {
-					if sibling.right != nil {
-						sibling.right.color = Black
-					}
-					sibling.color = Red
-					t.rotateLeft(sibling)
-					sibling = parent.left
-					if sibling == nil {
-						break
-					}
-				}
-				sibling.color = parent.color
-				parent.color = Black
-				if sibling.left != nil {
-					sibling.left.color = Black
-				}
-				t.rotateRight(parent)
-				node = t.root
+			if isBlack(sibling.left) {
+				sibling.right.color = Black
+				sibling.color = Red
+				t.rotateLeft(sibling)
+				sibling = parent.left
 			}
+			sibling.color = parent.color
+			parent.color = Black
+			sibling.left.color = Black
+			t.rotateRight(parent)
+			node = t.root
 		}
 	}
 	if node != nil {
@@ -347,9 +651,102 @@ func (t *RBTree[K, V]) fixDelete(node, parent *RBNode[K, V]) {
 	}
 }
 
+func isRed[K utils.Ordered, V any](node *RBNode[K, V]) bool {
+	return node != nil && node.color == Red
+}
+
+// isBlack treats nil leaves as black.
+func isBlack[K utils.Ordered, V any](node *RBNode[K, V]) bool {
+	return node == nil || node.color == Black
+}
+
 func (t *RBTree[K, V]) minimum(node *RBNode[K, V]) *RBNode[K, V] {
 	for node.left != nil {
 		node = node.left
 	}
 	return node
 }
+
+func (t *RBTree[K, V]) maximum(node *RBNode[K, V]) *RBNode[K, V] {
+	for node.right != nil {
+		node = node.right
+	}
+	return node
+}
+
+// Min returns the entry with the smallest key, or false if the tree is empty.
+func (t *RBTree[K, V]) Min() (K, V, bool) {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	return t.extreme(true)
+}
+
+// Max returns the entry with the largest key, or false if the tree is empty.
+func (t *RBTree[K, V]) Max() (K, V, bool) {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	return t.extreme(false)
+}
+
+// DeleteMin removes and returns the entry with the smallest key, or false if
+// the tree is empty.
+func (t *RBTree[K, V]) DeleteMin() (K, V, bool) {
+	return t.deleteExtreme(true)
+}
+
+// DeleteMax removes and returns the entry with the largest key, or false if
+// the tree is empty.
+func (t *RBTree[K, V]) DeleteMax() (K, V, bool) {
+	return t.deleteExtreme(false)
+}
+
+func (t *RBTree[K, V]) deleteExtreme(smallest bool) (K, V, bool) {
+	if t.threadSafe {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+	if t.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
+	if t.root == nil {
+		return t.extreme(smallest)
+	}
+	node := t.maximum(t.root)
+	if smallest {
+		node = t.minimum(t.root)
+	}
+	t.deleteNode(node)
+	return node.key, node.value, true
+}
+
+// extreme returns the smallest entry if smallest is set, and the largest
+// otherwise. The caller must hold the lock.
+func (t *RBTree[K, V]) extreme(smallest bool) (K, V, bool) {
+	if t.root == nil {
+		return rbEntryOf[K, V](nil)
+	}
+	node := t.maximum(t.root)
+	if smallest {
+		node = t.minimum(t.root)
+	}
+	return rbEntryOf(node)
+}
+
+// Seal makes the tree read-only. Mutations after Seal panic with
+// utils.ErrSealed. Reads on a sealed tree skip locking.
+func (t *RBTree[K, V]) Seal() {
+	if t.threadSafe {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+	t.sealed.Store(true)
+}
+
+// IsSealed reports whether Seal has been called.
+func (t *RBTree[K, V]) IsSealed() bool {
+	return t.sealed.Load()
+}
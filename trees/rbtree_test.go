@@ -3,6 +3,8 @@ package trees
 import (
 	"dsgo/utils"
 	"fmt"
+	"math/rand"
+	"runtime"
 	"sync"
 	"testing"
 	"time"
@@ -217,6 +219,46 @@ func TestRBTree_Delete(t *testing.T) {
 	}
 }
 
+func TestRBTree_Range(t *testing.T) {
+	tree := NewRBTree[int, string](false)
+	for i := 0; i < 100; i += 10 {
+		tree.Insert(i, fmt.Sprint(i))
+	}
+
+	tests := []struct {
+		lo, hi int
+		want   string
+	}{
+		{20, 50, "[20 30 40 50]"},
+		{15, 35, "[20 30]"},
+		{-5, 0, "[0]"},
+		{95, 200, "[]"},
+		{50, 20, "[]"},
+	}
+	for _, tt := range tests {
+		var keys []int
+		tree.Range(tt.lo, tt.hi, func(key int, value string) bool {
+			if value != fmt.Sprint(key) {
+				t.Errorf("Range() passed value %q for key %d", value, key)
+			}
+			keys = append(keys, key)
+			return true
+		})
+		if got := fmt.Sprint(keys); got != tt.want && !(tt.want == "[]" && keys == nil) {
+			t.Errorf("Range(%d, %d) = %v, want %v", tt.lo, tt.hi, got, tt.want)
+		}
+	}
+
+	var keys []int
+	tree.Range(0, 90, func(key int, _ string) bool {
+		keys = append(keys, key)
+		return len(keys) < 3
+	})
+	if fmt.Sprint(keys) != "[0 10 20]" {
+		t.Errorf("Range() stopping after 3 keys = %v, want [0 10 20]", keys)
+	}
+}
+
 func TestRBTreeConcurrent(t *testing.T) {
 	tree := NewRBTree[int, string](true)
 	var wg sync.WaitGroup
@@ -372,45 +414,217 @@ func TestRBTreeConcurrentModifications(t *testing.T) {
 
 // Helper function to verify Red-Black tree properties
 func verifyRBProperties[K utils.Ordered, V any](t *testing.T, rb *RBTree[K, V]) {
-	if rb.root == nil {
-		return
+	t.Helper()
+	if err := rb.Validate(); err != nil {
+		t.Error(err)
 	}
+}
 
-	// Property 1: Root is black
-	if rb.root.color != Black {
-		t.Error("Root node is not black")
-	}
+func TestRBTreeRandomizedDifferential(t *testing.T) {
+	const keySpace = 200
+	for seed := int64(0); seed < 20; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		tree := NewRBTree[int, int](false)
+		// Model map as slices: the package's delete helper shadows the builtin
+		values := make([]int, keySpace)
+		present := make([]bool, keySpace)
+		count := 0
+
+		for i := 0; i < 2000; i++ {
+			key := rng.Intn(keySpace)
+			if rng.Intn(3) == 0 {
+				tree.Delete(key)
+				if present[key] {
+					count--
+				}
+				present[key] = false
+			} else {
+				tree.Insert(key, i)
+				if !present[key] {
+					count++
+				}
+				values[key], present[key] = i, true
+			}
 
-	// Property 2: Red nodes have black children
-	// Property 3: All paths from root to leaves have same number of black nodes
-	var verifyNode func(*RBNode[K, V], int, *int) bool
-	verifyNode = func(node *RBNode[K, V], blackCount int, pathBlackCount *int) bool {
-		if node == nil {
-			if *pathBlackCount == -1 {
-				*pathBlackCount = blackCount
-			} else if blackCount != *pathBlackCount {
-				t.Error("Different number of black nodes in paths from root to leaves")
-				return false
+			verifyRBProperties(t, tree)
+			if t.Failed() {
+				t.Fatalf("seed %d: invariants broken after op %d on key %d", seed, i, key)
+			}
+			node, found := tree.Search(key)
+			if found != present[key] || (found && node.value != values[key]) {
+				t.Fatalf("seed %d: Search(%d) disagrees with model after op %d", seed, key, i)
+			}
+			if size := rbSize(tree.root); size != count {
+				t.Fatalf("seed %d: tree has %d keys, model has %d", seed, size, count)
 			}
-			return true
 		}
+	}
+}
 
-		if node.color == Red {
-			if (node.left != nil && node.left.color == Red) || (node.right != nil && node.right.color == Red) {
-				t.Error("Red node has red child")
-				return false
-			}
+func TestRBTreeSeal(t *testing.T) {
+	tree := NewRBTree[int, int]()
+	tree.Insert(1, 1)
+	tree.Seal()
+
+	if _, ok := tree.Search(1); !ok {
+		t.Error("Search should keep working on a sealed tree")
+	}
+	defer func() {
+		if r := recover(); r != utils.ErrSealed {
+			t.Errorf("Insert on sealed tree should panic with ErrSealed, got %v", r)
 		}
+	}()
+	tree.Insert(2, 2)
+}
 
-		newBlackCount := blackCount
-		if node.color == Black {
-			newBlackCount++
+// BenchmarkRBTreeInlineValues compares int values, which generics store
+// inline in each node, with the same values boxed behind an interface as a
+// pre-generics tree would hold them. Boxing costs an extra allocation per
+// value outside the small-integer cache, a pointer chase on every read and
+// an extra object for the collector to mark.
+func BenchmarkRBTreeInlineValues(b *testing.B) {
+	const n = 100000
+	buildInline := func() *RBTree[int, int] {
+		t := NewRBTree[int, int](false)
+		for k := 0; k < n; k++ {
+			t.Insert(k, k*1000)
 		}
+		return t
+	}
+	buildBoxed := func() *RBTree[int, any] {
+		t := NewRBTree[int, any](false)
+		for k := 0; k < n; k++ {
+			t.Insert(k, k*1000)
+		}
+		return t
+	}
 
-		return verifyNode(node.left, newBlackCount, pathBlackCount) &&
-			verifyNode(node.right, newBlackCount, pathBlackCount)
+	b.Run("inline/insert", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			buildInline()
+		}
+	})
+	b.Run("boxed/insert", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			buildBoxed()
+		}
+	})
+	b.Run("inline/scan", func(b *testing.B) {
+		t := buildInline()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			sum := 0
+			t.ascend(t.root, nil, func(_ int, v int) bool { sum += v; return true })
+		}
+	})
+	b.Run("boxed/scan", func(b *testing.B) {
+		t := buildBoxed()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			sum := 0
+			t.ascend(t.root, nil, func(_ int, v any) bool { sum += v.(int); return true })
+		}
+	})
+	b.Run("inline/gc", func(b *testing.B) {
+		t := buildInline()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			runtime.GC()
+		}
+		runtime.KeepAlive(t)
+	})
+	b.Run("boxed/gc", func(b *testing.B) {
+		t := buildBoxed()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			runtime.GC()
+		}
+		runtime.KeepAlive(t)
+	})
+}
+
+func TestRBTree_Remove(t *testing.T) {
+	tree := NewRBTree[int, string](false)
+	tree.Insert(1, "one")
+	tree.Insert(2, "two")
+
+	if value, ok := tree.Remove(2); !ok || value != "two" {
+		t.Errorf("Remove(2) = %q, %v, want \"two\", true", value, ok)
+	}
+	if value, ok := tree.Remove(2); ok || value != "" {
+		t.Errorf("Remove(2) again = %q, %v, want \"\", false", value, ok)
+	}
+	if !tree.TryDelete(1) {
+		t.Error("TryDelete(1) = false, want true")
+	}
+	if tree.TryDelete(1) {
+		t.Error("TryDelete(1) on a missing key = true, want false")
+	}
+}
+
+func TestRBTree_SuccessorPredecessor(t *testing.T) {
+	tree := NewRBTree[int, string](false)
+	if _, _, ok := tree.Successor(0); ok {
+		t.Error("Successor() on an empty tree reported an entry")
+	}
+	for i := 10; i <= 50; i += 10 {
+		tree.Insert(i, fmt.Sprint(i))
 	}
 
-	pathBlackCount := -1
-	verifyNode(rb.root, 0, &pathBlackCount)
+	tests := []struct {
+		key        int
+		succ, pred int
+		hasS, hasP bool
+	}{
+		{key: 5, succ: 10, hasS: true},
+		{key: 10, succ: 20, hasS: true},
+		{key: 30, succ: 40, pred: 20, hasS: true, hasP: true},
+		{key: 35, succ: 40, pred: 30, hasS: true, hasP: true},
+		{key: 50, pred: 40, hasP: true},
+		{key: 60, pred: 50, hasP: true},
+	}
+	for _, tt := range tests {
+		key, value, ok := tree.Successor(tt.key)
+		if ok != tt.hasS || (ok && (key != tt.succ || value != fmt.Sprint(key))) {
+			t.Errorf("Successor(%d) = %d, %q, %v, want %d, %v", tt.key, key, value, ok, tt.succ, tt.hasS)
+		}
+		key, value, ok = tree.Predecessor(tt.key)
+		if ok != tt.hasP || (ok && (key != tt.pred || value != fmt.Sprint(key))) {
+			t.Errorf("Predecessor(%d) = %d, %q, %v, want %d, %v", tt.key, key, value, ok, tt.pred, tt.hasP)
+		}
+	}
+
+	// Walking by Successor visits every key in order
+	var keys []int
+	for key, _, ok := tree.Successor(0); ok; key, _, ok = tree.Successor(key) {
+		keys = append(keys, key)
+	}
+	if fmt.Sprint(keys) != "[10 20 30 40 50]" {
+		t.Errorf("walk by Successor = %v, want [10 20 30 40 50]", keys)
+	}
+}
+
+func TestRBTree_AppendKeys(t *testing.T) {
+	tree := NewRBTree[int, int]()
+	for _, k := range []int{5, 3, 8} {
+		tree.Insert(k, k*10)
+	}
+	buf := make([]int, 0, 8)
+	if got := tree.AppendKeys(buf[:0]); fmt.Sprint(got) != "[3 5 8]" {
+		t.Errorf("AppendKeys() = %v, want [3 5 8]", got)
+	}
+	if got := tree.AppendValues([]int{0}); fmt.Sprint(got) != "[0 30 50 80]" {
+		t.Errorf("AppendValues() = %v, want [0 30 50 80]", got)
+	}
+	if got := tree.Keys(); fmt.Sprint(got) != "[3 5 8]" {
+		t.Errorf("Keys() = %v, want [3 5 8]", got)
+	}
+	if got := tree.Values(); fmt.Sprint(got) != "[30 50 80]" {
+		t.Errorf("Values() = %v, want [30 50 80]", got)
+	}
+	if allocs := testing.AllocsPerRun(100, func() { buf = tree.AppendKeys(buf[:0]) }); allocs != 0 {
+		t.Errorf("AppendKeys() into a large enough buffer allocated %v times", allocs)
+	}
 }
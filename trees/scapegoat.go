@@ -0,0 +1,242 @@
+package trees
+
+import (
+	"dsgo/utils"
+	"math"
+	"sync"
+	"sync/atomic"
+)
+
+// scapegoatAlpha bounds how lopsided a subtree may get before it is rebuilt.
+// Higher values rebuild less often but allow deeper trees.
+const scapegoatAlpha = 0.7
+
+type sgNode[K utils.Ordered, V any] struct {
+	key   K
+	value V
+	left  *sgNode[K, V]
+	right *sgNode[K, V]
+}
+
+// ScapegoatTree is a balanced binary search tree that stores no balance
+// information in its nodes. Instead, an insert that lands too deep finds an
+// unbalanced ancestor, the scapegoat, and rebuilds its subtree perfectly
+// balanced; deletes rebuild the whole tree once enough keys are gone.
+// Lookups are O(log n) worst case and updates O(log n) amortized.
+type ScapegoatTree[K utils.Ordered, V any] struct {
+	root       *sgNode[K, V]
+	size       int
+	maxSize    int
+	threadSafe bool
+	mu         sync.RWMutex
+	sealed     atomic.Bool
+}
+
+func NewScapegoatTree[K utils.Ordered, V any](threadSafe ...bool) *ScapegoatTree[K, V] {
+	isThreadSafe := true
+	if len(threadSafe) > 0 {
+		isThreadSafe = threadSafe[0]
+	}
+	return &ScapegoatTree[K, V]{
+		threadSafe: isThreadSafe,
+	}
+}
+
+// depthLimit is the deepest an insert may land in a tree of n nodes.
+func depthLimit(n int) int {
+	return int(math.Floor(math.Log(float64(n)) / math.Log(1/scapegoatAlpha)))
+}
+
+func (t *ScapegoatTree[K, V]) Insert(key K, value V) {
+	if t.threadSafe {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+	if t.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
+
+	var path []*sgNode[K, V]
+	link := &t.root
+	for *link != nil {
+		node := *link
+		path = append(path, node)
+		switch {
+		case key < node.key:
+			link = &node.left
+		case key > node.key:
+			link = &node.right
+		default:
+			node.value = value
+			return
+		}
+	}
+	node := &sgNode[K, V]{key: key, value: value}
+	*link = node
+	t.size++
+	t.maxSize = max(t.maxSize, t.size)
+
+	if len(path) <= depthLimit(t.size) {
+		return
+	}
+	// Walk back up to the first ancestor with a child too heavy for it
+	childSize := 1
+	for i := len(path) - 1; i >= 0; i-- {
+		parent := path[i]
+		sibling := parent.left
+		if sibling == node {
+			sibling = parent.right
+		}
+		parentSize := childSize + sgSize(sibling) + 1
+		if float64(childSize) > scapegoatAlpha*float64(parentSize) {
+			rebuilt := sgRebuild(parent, parentSize)
+			switch {
+			case i == 0:
+				t.root = rebuilt
+			case path[i-1].left == parent:
+				path[i-1].left = rebuilt
+			default:
+				path[i-1].right = rebuilt
+			}
+			return
+		}
+		node, childSize = parent, parentSize
+	}
+}
+
+func (t *ScapegoatTree[K, V]) Delete(key K) {
+	if t.threadSafe {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+	if t.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
+
+	link := &t.root
+	for *link != nil && (*link).key != key {
+		if key < (*link).key {
+			link = &(*link).left
+		} else {
+			link = &(*link).right
+		}
+	}
+	node := *link
+	if node == nil {
+		return
+	}
+	switch {
+	case node.left == nil:
+		*link = node.right
+	case node.right == nil:
+		*link = node.left
+	default:
+		// Replace with the in-order successor
+		succLink := &node.right
+		for (*succLink).left != nil {
+			succLink = &(*succLink).left
+		}
+		succ := *succLink
+		*succLink = succ.right
+		node.key, node.value = succ.key, succ.value
+	}
+	t.size--
+
+	if float64(t.size) < scapegoatAlpha*float64(t.maxSize) {
+		t.root = sgRebuild(t.root, t.size)
+		t.maxSize = t.size
+	}
+}
+
+func (t *ScapegoatTree[K, V]) Search(key K) (V, bool) {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	for node := t.root; node != nil; {
+		switch {
+		case key < node.key:
+			node = node.left
+		case key > node.key:
+			node = node.right
+		default:
+			return node.value, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+func (t *ScapegoatTree[K, V]) Len() int {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	return t.size
+}
+
+// Range calls fn for every entry in key order until fn returns false.
+func (t *ScapegoatTree[K, V]) Range(fn func(key K, value V) bool) {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	sgAscend(t.root, fn)
+}
+
+func sgAscend[K utils.Ordered, V any](node *sgNode[K, V], fn func(K, V) bool) bool {
+	if node == nil {
+		return true
+	}
+	return sgAscend(node.left, fn) && fn(node.key, node.value) && sgAscend(node.right, fn)
+}
+
+// sgSize counts the subtree in O(size); only rebuilds pay for it.
+func sgSize[K utils.Ordered, V any](node *sgNode[K, V]) int {
+	if node == nil {
+		return 0
+	}
+	return sgSize(node.left) + sgSize(node.right) + 1
+}
+
+// sgRebuild rebalances a subtree of n nodes perfectly, reusing its nodes.
+func sgRebuild[K utils.Ordered, V any](root *sgNode[K, V], n int) *sgNode[K, V] {
+	nodes := make([]*sgNode[K, V], 0, n)
+	var flatten func(node *sgNode[K, V])
+	flatten = func(node *sgNode[K, V]) {
+		if node == nil {
+			return
+		}
+		flatten(node.left)
+		nodes = append(nodes, node)
+		flatten(node.right)
+	}
+	flatten(root)
+	return sgBuild(nodes)
+}
+
+func sgBuild[K utils.Ordered, V any](nodes []*sgNode[K, V]) *sgNode[K, V] {
+	if len(nodes) == 0 {
+		return nil
+	}
+	mid := len(nodes) / 2
+	node := nodes[mid]
+	node.left = sgBuild(nodes[:mid])
+	node.right = sgBuild(nodes[mid+1:])
+	return node
+}
+
+// Seal makes the tree read-only. Mutations after Seal panic with
+// utils.ErrSealed. Reads on a sealed tree skip locking.
+func (t *ScapegoatTree[K, V]) Seal() {
+	if t.threadSafe {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+	t.sealed.Store(true)
+}
+
+// IsSealed reports whether Seal has been called.
+func (t *ScapegoatTree[K, V]) IsSealed() bool {
+	return t.sealed.Load()
+}
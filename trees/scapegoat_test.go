@@ -0,0 +1,128 @@
+package trees
+
+import (
+	"math/rand"
+	"runtime"
+	"testing"
+)
+
+func sgHeight(node *sgNode[int, int]) int {
+	if node == nil {
+		return 0
+	}
+	return 1 + max(sgHeight(node.left), sgHeight(node.right))
+}
+
+func TestScapegoatTree(t *testing.T) {
+	tree := NewScapegoatTree[int, int]()
+	// Sorted inserts are the worst case for an unbalanced BST
+	for i := 0; i < 10000; i++ {
+		tree.Insert(i, i)
+	}
+	if tree.Len() != 10000 {
+		t.Errorf("Len() = %d, want 10000", tree.Len())
+	}
+	if h := sgHeight(tree.root); h > depthLimit(tree.Len())+1 {
+		t.Errorf("height %d exceeds the scapegoat bound %d", h, depthLimit(tree.Len())+1)
+	}
+
+	for i := 0; i < 10000; i += 2 {
+		tree.Delete(i)
+	}
+	tree.Delete(-1)
+	if tree.Len() != 5000 {
+		t.Errorf("Len() = %d, want 5000", tree.Len())
+	}
+	if _, ok := tree.Search(4); ok {
+		t.Error("Search(4) should miss after Delete")
+	}
+	if val, ok := tree.Search(5); !ok || val != 5 {
+		t.Errorf("Search(5) = %d, %v, want 5, true", val, ok)
+	}
+
+	prev := -1
+	tree.Range(func(key, value int) bool {
+		if key <= prev {
+			t.Fatalf("Range out of order: %d after %d", key, prev)
+		}
+		prev = key
+		return true
+	})
+}
+
+func TestScapegoatTreeRandomized(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tree := NewScapegoatTree[int, int](false)
+	present := make([]bool, 1000)
+	count := 0
+
+	for i := 0; i < 20000; i++ {
+		key := rng.Intn(len(present))
+		if rng.Intn(3) == 0 {
+			tree.Delete(key)
+			if present[key] {
+				count--
+			}
+			present[key] = false
+		} else {
+			tree.Insert(key, key)
+			if !present[key] {
+				count++
+			}
+			present[key] = true
+		}
+		if _, found := tree.Search(key); found != present[key] {
+			t.Fatalf("Search(%d) = %v after op %d, want %v", key, found, i, present[key])
+		}
+	}
+	if tree.Len() != count || sgSize(tree.root) != count {
+		t.Errorf("Len() = %d, counted %d nodes, want %d", tree.Len(), sgSize(tree.root), count)
+	}
+}
+
+// BenchmarkTreeMemoryPerNode reports the heap bytes each tree type uses per
+// stored int/int entry.
+func BenchmarkTreeMemoryPerNode(b *testing.B) {
+	const n = 100000
+	measure := func(b *testing.B, build func() any) {
+		var keep any
+		for i := 0; i < b.N; i++ {
+			var before, after runtime.MemStats
+			runtime.GC()
+			runtime.ReadMemStats(&before)
+			keep = build()
+			runtime.GC()
+			runtime.ReadMemStats(&after)
+			b.ReportMetric(float64(after.HeapAlloc-before.HeapAlloc)/n, "bytes/node")
+		}
+		runtime.KeepAlive(keep)
+	}
+
+	b.Run("Scapegoat", func(b *testing.B) {
+		measure(b, func() any {
+			t := NewScapegoatTree[int, int](false)
+			for i := 0; i < n; i++ {
+				t.Insert(i, i)
+			}
+			return t
+		})
+	})
+	b.Run("AVL", func(b *testing.B) {
+		measure(b, func() any {
+			t := NewAVLTree[int, int](false)
+			for i := 0; i < n; i++ {
+				t.Insert(i, i)
+			}
+			return t
+		})
+	})
+	b.Run("RB", func(b *testing.B) {
+		measure(b, func() any {
+			t := NewRBTree[int, int](false)
+			for i := 0; i < n; i++ {
+				t.Insert(i, i)
+			}
+			return t
+		})
+	})
+}
@@ -0,0 +1,290 @@
+package trees
+
+import "dsgo/utils"
+
+type traversalOrder int
+
+const (
+	preOrder traversalOrder = iota
+	inOrder
+	postOrder
+	levelOrder
+)
+
+// binaryWalker visits the nodes of one of the binary tree types. Each type
+// supplies how to reach a node's children and entry, so the orders are
+// written once for all of them.
+type binaryWalker[N any, K utils.Ordered, V any] struct {
+	children func(n *N) (*N, *N)
+	entry    func(n *N) (K, V)
+}
+
+// walk calls fn for every entry under root in the given order until fn
+// returns false, and reports whether it ran to the end.
+func (w binaryWalker[N, K, V]) walk(root *N, order traversalOrder, fn func(K, V) bool) bool {
+	if order == levelOrder {
+		return w.levels(root, fn)
+	}
+	if root == nil {
+		return true
+	}
+	left, right := w.children(root)
+	visit := func() bool { return fn(w.entry(root)) }
+	switch order {
+	case preOrder:
+		return visit() && w.walk(left, order, fn) && w.walk(right, order, fn)
+	case inOrder:
+		return w.walk(left, order, fn) && visit() && w.walk(right, order, fn)
+	default:
+		return w.walk(left, order, fn) && w.walk(right, order, fn) && visit()
+	}
+}
+
+// levels visits breadth first, left to right within each depth.
+func (w binaryWalker[N, K, V]) levels(root *N, fn func(K, V) bool) bool {
+	if root == nil {
+		return true
+	}
+	queue := []*N{root}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		if !fn(w.entry(n)) {
+			return false
+		}
+		left, right := w.children(n)
+		if left != nil {
+			queue = append(queue, left)
+		}
+		if right != nil {
+			queue = append(queue, right)
+		}
+	}
+	return true
+}
+
+func bstWalker[K utils.Ordered, V any]() binaryWalker[Node[K, V], K, V] {
+	return binaryWalker[Node[K, V], K, V]{
+		children: func(n *Node[K, V]) (*Node[K, V], *Node[K, V]) { return n.left, n.right },
+		entry:    func(n *Node[K, V]) (K, V) { return n.key, n.value },
+	}
+}
+
+func (b *BST[K, V]) traverse(order traversalOrder, fn func(K, V) bool) {
+	if b.threadSafe && !b.sealed.Load() {
+		b.mu.RLock()
+		defer b.mu.RUnlock()
+	}
+	bstWalker[K, V]().walk(b.root, order, fn)
+}
+
+// PreOrder calls fn for every entry, each node before its subtrees, until
+// fn returns false. Reinserting the entries in this order rebuilds a tree
+// of the same shape.
+func (b *BST[K, V]) PreOrder(fn func(key K, value V) bool) {
+	b.traverse(preOrder, fn)
+}
+
+// PostOrder calls fn for every entry, each node after its subtrees, until
+// fn returns false.
+func (b *BST[K, V]) PostOrder(fn func(key K, value V) bool) {
+	b.traverse(postOrder, fn)
+}
+
+// LevelOrder calls fn for every entry breadth first, root first and left to
+// right within a depth, until fn returns false.
+func (b *BST[K, V]) LevelOrder(fn func(key K, value V) bool) {
+	b.traverse(levelOrder, fn)
+}
+
+// PreOrderEntries returns the entries in the order PreOrder visits them.
+func (b *BST[K, V]) PreOrderEntries() []utils.Entry[K, V] {
+	return collectEntries(func(fn func(K, V) bool) { b.traverse(preOrder, fn) })
+}
+
+// PostOrderEntries returns the entries in the order PostOrder visits them.
+func (b *BST[K, V]) PostOrderEntries() []utils.Entry[K, V] {
+	return collectEntries(func(fn func(K, V) bool) { b.traverse(postOrder, fn) })
+}
+
+// LevelOrderEntries returns the entries in the order LevelOrder visits them.
+func (b *BST[K, V]) LevelOrderEntries() []utils.Entry[K, V] {
+	return collectEntries(func(fn func(K, V) bool) { b.traverse(levelOrder, fn) })
+}
+
+func avlWalker[K utils.Ordered, V any]() binaryWalker[AVLNode[K, V], K, V] {
+	return binaryWalker[AVLNode[K, V], K, V]{
+		children: func(n *AVLNode[K, V]) (*AVLNode[K, V], *AVLNode[K, V]) { return n.Left, n.Right },
+		entry:    func(n *AVLNode[K, V]) (K, V) { return n.Key, n.Value },
+	}
+}
+
+func (t *AVLTree[K, V]) traverse(order traversalOrder, fn func(K, V) bool) {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	avlWalker[K, V]().walk(t.Root, order, fn)
+}
+
+// InOrder calls fn for every entry in key order until fn returns false.
+// Unlike InOrderTraversal it passes keys as well as values.
+func (t *AVLTree[K, V]) InOrder(fn func(key K, value V) bool) {
+	t.traverse(inOrder, fn)
+}
+
+// PreOrder visits each node before its subtrees, as BST.PreOrder does.
+func (t *AVLTree[K, V]) PreOrder(fn func(key K, value V) bool) {
+	t.traverse(preOrder, fn)
+}
+
+// PostOrder visits each node after its subtrees.
+func (t *AVLTree[K, V]) PostOrder(fn func(key K, value V) bool) {
+	t.traverse(postOrder, fn)
+}
+
+// LevelOrder visits breadth first, as BST.LevelOrder does.
+func (t *AVLTree[K, V]) LevelOrder(fn func(key K, value V) bool) {
+	t.traverse(levelOrder, fn)
+}
+
+func (t *AVLTree[K, V]) PreOrderEntries() []utils.Entry[K, V] {
+	return collectEntries(func(fn func(K, V) bool) { t.traverse(preOrder, fn) })
+}
+
+func (t *AVLTree[K, V]) PostOrderEntries() []utils.Entry[K, V] {
+	return collectEntries(func(fn func(K, V) bool) { t.traverse(postOrder, fn) })
+}
+
+func (t *AVLTree[K, V]) LevelOrderEntries() []utils.Entry[K, V] {
+	return collectEntries(func(fn func(K, V) bool) { t.traverse(levelOrder, fn) })
+}
+
+func rbWalker[K utils.Ordered, V any]() binaryWalker[RBNode[K, V], K, V] {
+	return binaryWalker[RBNode[K, V], K, V]{
+		children: func(n *RBNode[K, V]) (*RBNode[K, V], *RBNode[K, V]) { return n.left, n.right },
+		entry:    func(n *RBNode[K, V]) (K, V) { return n.key, n.value },
+	}
+}
+
+func (t *RBTree[K, V]) traverse(order traversalOrder, fn func(K, V) bool) {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	rbWalker[K, V]().walk(t.root, order, fn)
+}
+
+// InOrder calls fn for every entry in key order until fn returns false.
+func (t *RBTree[K, V]) InOrder(fn func(key K, value V) bool) {
+	t.traverse(inOrder, fn)
+}
+
+// PreOrder visits each node before its subtrees, as BST.PreOrder does.
+func (t *RBTree[K, V]) PreOrder(fn func(key K, value V) bool) {
+	t.traverse(preOrder, fn)
+}
+
+// PostOrder visits each node after its subtrees.
+func (t *RBTree[K, V]) PostOrder(fn func(key K, value V) bool) {
+	t.traverse(postOrder, fn)
+}
+
+// LevelOrder visits breadth first, as BST.LevelOrder does.
+func (t *RBTree[K, V]) LevelOrder(fn func(key K, value V) bool) {
+	t.traverse(levelOrder, fn)
+}
+
+func (t *RBTree[K, V]) PreOrderEntries() []utils.Entry[K, V] {
+	return collectEntries(func(fn func(K, V) bool) { t.traverse(preOrder, fn) })
+}
+
+func (t *RBTree[K, V]) PostOrderEntries() []utils.Entry[K, V] {
+	return collectEntries(func(fn func(K, V) bool) { t.traverse(postOrder, fn) })
+}
+
+func (t *RBTree[K, V]) LevelOrderEntries() []utils.Entry[K, V] {
+	return collectEntries(func(fn func(K, V) bool) { t.traverse(levelOrder, fn) })
+}
+
+func sgWalker[K utils.Ordered, V any]() binaryWalker[sgNode[K, V], K, V] {
+	return binaryWalker[sgNode[K, V], K, V]{
+		children: func(n *sgNode[K, V]) (*sgNode[K, V], *sgNode[K, V]) { return n.left, n.right },
+		entry:    func(n *sgNode[K, V]) (K, V) { return n.key, n.value },
+	}
+}
+
+func (t *ScapegoatTree[K, V]) traverse(order traversalOrder, fn func(K, V) bool) {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	sgWalker[K, V]().walk(t.root, order, fn)
+}
+
+// PreOrder visits each node before its subtrees, as BST.PreOrder does.
+func (t *ScapegoatTree[K, V]) PreOrder(fn func(key K, value V) bool) {
+	t.traverse(preOrder, fn)
+}
+
+// PostOrder visits each node after its subtrees.
+func (t *ScapegoatTree[K, V]) PostOrder(fn func(key K, value V) bool) {
+	t.traverse(postOrder, fn)
+}
+
+// LevelOrder visits breadth first, as BST.LevelOrder does.
+func (t *ScapegoatTree[K, V]) LevelOrder(fn func(key K, value V) bool) {
+	t.traverse(levelOrder, fn)
+}
+
+func (t *ScapegoatTree[K, V]) PreOrderEntries() []utils.Entry[K, V] {
+	return collectEntries(func(fn func(K, V) bool) { t.traverse(preOrder, fn) })
+}
+
+func (t *ScapegoatTree[K, V]) PostOrderEntries() []utils.Entry[K, V] {
+	return collectEntries(func(fn func(K, V) bool) { t.traverse(postOrder, fn) })
+}
+
+func (t *ScapegoatTree[K, V]) LevelOrderEntries() []utils.Entry[K, V] {
+	return collectEntries(func(fn func(K, V) bool) { t.traverse(levelOrder, fn) })
+}
+
+func wbWalker[K utils.Ordered, V any]() binaryWalker[wbNode[K, V], K, V] {
+	return binaryWalker[wbNode[K, V], K, V]{
+		children: func(n *wbNode[K, V]) (*wbNode[K, V], *wbNode[K, V]) { return n.left, n.right },
+		entry:    func(n *wbNode[K, V]) (K, V) { return n.key, n.value },
+	}
+}
+
+// traverse walks a snapshot of the tree, so fn may modify t without
+// affecting the walk.
+func (t *WBTree[K, V]) traverse(order traversalOrder, fn func(K, V) bool) {
+	wbWalker[K, V]().walk(t.snapshot(), order, fn)
+}
+
+// PreOrder visits each node before its subtrees, as BST.PreOrder does.
+func (t *WBTree[K, V]) PreOrder(fn func(key K, value V) bool) {
+	t.traverse(preOrder, fn)
+}
+
+// PostOrder visits each node after its subtrees.
+func (t *WBTree[K, V]) PostOrder(fn func(key K, value V) bool) {
+	t.traverse(postOrder, fn)
+}
+
+// LevelOrder visits breadth first, as BST.LevelOrder does.
+func (t *WBTree[K, V]) LevelOrder(fn func(key K, value V) bool) {
+	t.traverse(levelOrder, fn)
+}
+
+func (t *WBTree[K, V]) PreOrderEntries() []utils.Entry[K, V] {
+	return collectEntries(func(fn func(K, V) bool) { t.traverse(preOrder, fn) })
+}
+
+func (t *WBTree[K, V]) PostOrderEntries() []utils.Entry[K, V] {
+	return collectEntries(func(fn func(K, V) bool) { t.traverse(postOrder, fn) })
+}
+
+func (t *WBTree[K, V]) LevelOrderEntries() []utils.Entry[K, V] {
+	return collectEntries(func(fn func(K, V) bool) { t.traverse(levelOrder, fn) })
+}
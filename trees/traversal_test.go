@@ -0,0 +1,87 @@
+package trees
+
+import (
+	"dsgo/utils"
+	"slices"
+	"testing"
+)
+
+type traversable interface {
+	Insert(key int, value int)
+	PreOrder(fn func(key, value int) bool)
+	LevelOrder(fn func(key, value int) bool)
+	PreOrderEntries() []utils.Entry[int, int]
+	PostOrderEntries() []utils.Entry[int, int]
+	LevelOrderEntries() []utils.Entry[int, int]
+}
+
+func TestTraversalOrders(t *testing.T) {
+	trees := map[string]traversable{
+		"BST":       NewBST[int, int](),
+		"AVL":       NewAVLTree[int, int](),
+		"RB":        NewRBTree[int, int](),
+		"Scapegoat": NewScapegoatTree[int, int](),
+		"WB":        NewWBTree[int, int](),
+	}
+	for name, tree := range trees {
+		t.Run(name, func(t *testing.T) {
+			// This order builds the same perfect tree in every type:
+			//       4
+			//     2   6
+			//    1 3 5 7
+			for _, key := range []int{4, 2, 6, 1, 3, 5, 7} {
+				tree.Insert(key, key*10)
+			}
+
+			if got, want := entryKeys(tree.PreOrderEntries()), []int{4, 2, 1, 3, 6, 5, 7}; !slices.Equal(got, want) {
+				t.Errorf("PreOrderEntries() = %v, want %v", got, want)
+			}
+			if got, want := entryKeys(tree.PostOrderEntries()), []int{1, 3, 2, 5, 7, 6, 4}; !slices.Equal(got, want) {
+				t.Errorf("PostOrderEntries() = %v, want %v", got, want)
+			}
+			if got, want := entryKeys(tree.LevelOrderEntries()), []int{4, 2, 6, 1, 3, 5, 7}; !slices.Equal(got, want) {
+				t.Errorf("LevelOrderEntries() = %v, want %v", got, want)
+			}
+			if e := tree.LevelOrderEntries()[1]; e.Value != 20 {
+				t.Errorf("LevelOrderEntries()[1].Value = %d, want 20", e.Value)
+			}
+
+			var visited []int
+			tree.LevelOrder(func(key, _ int) bool {
+				visited = append(visited, key)
+				return len(visited) < 3
+			})
+			if want := []int{4, 2, 6}; !slices.Equal(visited, want) {
+				t.Errorf("LevelOrder stopped early visited %v, want %v", visited, want)
+			}
+			visited = nil
+			tree.PreOrder(func(key, _ int) bool {
+				visited = append(visited, key)
+				return key != 1
+			})
+			if want := []int{4, 2, 1}; !slices.Equal(visited, want) {
+				t.Errorf("PreOrder stopped early visited %v, want %v", visited, want)
+			}
+		})
+	}
+}
+
+func TestInOrderKeys(t *testing.T) {
+	avl, rb := NewAVLTree[int, string](), NewRBTree[int, string]()
+	for _, key := range []int{5, 3, 8, 1} {
+		avl.Insert(key, "v")
+		rb.Insert(key, "v")
+	}
+	var avlKeys, rbKeys []int
+	avl.InOrder(func(key int, _ string) bool { avlKeys = append(avlKeys, key); return true })
+	rb.InOrder(func(key int, _ string) bool { rbKeys = append(rbKeys, key); return true })
+	want := []int{1, 3, 5, 8}
+	if !slices.Equal(avlKeys, want) || !slices.Equal(rbKeys, want) {
+		t.Errorf("InOrder keys = %v (AVL), %v (RB), want %v", avlKeys, rbKeys, want)
+	}
+
+	empty := NewBST[int, int]()
+	if got := empty.LevelOrderEntries(); len(got) != 0 {
+		t.Errorf("LevelOrderEntries() on empty tree = %v, want empty", got)
+	}
+}
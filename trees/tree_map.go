@@ -0,0 +1,218 @@
+package trees
+
+import (
+	"sync"
+
+	"dsgo/utils"
+)
+
+// TreeKind selects the balanced tree backing a TreeMap.
+type TreeKind int
+
+const (
+	RedBlack TreeKind = iota
+	AVL
+)
+
+// sortedTree is the lock-free core of a balanced tree used by TreeMap. The
+// trees are created non-thread-safe; TreeMap does its own locking.
+type sortedTree[K utils.Ordered, V any] interface {
+	Insert(key K, value V)
+	Delete(key K)
+	get(key K) (V, bool)
+	len() int
+	floor(key K) (K, V, bool)
+	ceiling(key K) (K, V, bool)
+	ascend(after *K, fn func(K, V) bool)
+}
+
+// TreeMap is a sorted map with O(log n) Get, Set and Delete, backed by a
+// red-black or AVL tree.
+type TreeMap[K utils.Ordered, V any] struct {
+	tree       sortedTree[K, V]
+	threadSafe bool
+	mu         sync.RWMutex
+}
+
+func NewTreeMap[K utils.Ordered, V any](kind TreeKind, threadSafe ...bool) *TreeMap[K, V] {
+	isThreadSafe := true
+	if len(threadSafe) > 0 {
+		isThreadSafe = threadSafe[0]
+	}
+	var tree sortedTree[K, V]
+	switch kind {
+	case AVL:
+		tree = &avlCore[K, V]{NewAVLTree[K, V](false)}
+	default:
+		tree = &rbCore[K, V]{NewRBTree[K, V](false)}
+	}
+	return &TreeMap[K, V]{
+		tree:       tree,
+		threadSafe: isThreadSafe,
+	}
+}
+
+func (m *TreeMap[K, V]) Get(key K) (V, bool) {
+	if m.threadSafe {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
+	return m.tree.get(key)
+}
+
+func (m *TreeMap[K, V]) Set(key K, value V) {
+	if m.threadSafe {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+	}
+	m.tree.Insert(key, value)
+}
+
+func (m *TreeMap[K, V]) Delete(key K) {
+	if m.threadSafe {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+	}
+	m.tree.Delete(key)
+}
+
+func (m *TreeMap[K, V]) Len() int {
+	if m.threadSafe {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
+	return m.tree.len()
+}
+
+func (m *TreeMap[K, V]) IsEmpty() bool {
+	return m.Len() == 0
+}
+
+// Floor returns the entry with the greatest key less than or equal to key.
+func (m *TreeMap[K, V]) Floor(key K) (K, V, bool) {
+	if m.threadSafe {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
+	return m.tree.floor(key)
+}
+
+// Ceiling returns the entry with the least key greater than or equal to key.
+func (m *TreeMap[K, V]) Ceiling(key K) (K, V, bool) {
+	if m.threadSafe {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
+	return m.tree.ceiling(key)
+}
+
+// Range calls f for each entry in key order until f returns false. The map
+// must not be modified from f.
+func (m *TreeMap[K, V]) Range(f func(key K, value V) bool) {
+	if m.threadSafe {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
+	m.tree.ascend(nil, f)
+}
+
+// RangeFrom calls f in key order for every key greater than or equal to
+// from, until f returns false.
+func (m *TreeMap[K, V]) RangeFrom(from K, f func(key K, value V) bool) {
+	if m.threadSafe {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
+	if value, ok := m.tree.get(from); ok && !f(from, value) {
+		return
+	}
+	m.tree.ascend(&from, f)
+}
+
+func (m *TreeMap[K, V]) Keys() []K {
+	if m.threadSafe {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
+	return m.appendKeys(make([]K, 0, m.tree.len()))
+}
+
+// AppendKeys appends the keys in sorted order to dst and returns the
+// extended slice, letting callers reuse a buffer across calls.
+func (m *TreeMap[K, V]) AppendKeys(dst []K) []K {
+	if m.threadSafe {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
+	return m.appendKeys(dst)
+}
+
+func (m *TreeMap[K, V]) appendKeys(dst []K) []K {
+	m.tree.ascend(nil, func(key K, _ V) bool {
+		dst = append(dst, key)
+		return true
+	})
+	return dst
+}
+
+func (m *TreeMap[K, V]) Values() []V {
+	if m.threadSafe {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
+	return m.appendValues(make([]V, 0, m.tree.len()))
+}
+
+// AppendValues appends the values in key order to dst and returns the
+// extended slice.
+func (m *TreeMap[K, V]) AppendValues(dst []V) []V {
+	if m.threadSafe {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+	}
+	return m.appendValues(dst)
+}
+
+func (m *TreeMap[K, V]) appendValues(dst []V) []V {
+	m.tree.ascend(nil, func(_ K, value V) bool {
+		dst = append(dst, value)
+		return true
+	})
+	return dst
+}
+
+type avlCore[K utils.Ordered, V any] struct {
+	*AVLTree[K, V]
+}
+
+func (c *avlCore[K, V]) get(key K) (V, bool) {
+	return c.search(c.Root, key)
+}
+
+func (c *avlCore[K, V]) len() int {
+	return avlSize(c.Root)
+}
+
+func (c *avlCore[K, V]) ascend(after *K, fn func(K, V) bool) {
+	c.AVLTree.ascend(c.Root, after, fn)
+}
+
+type rbCore[K utils.Ordered, V any] struct {
+	*RBTree[K, V]
+}
+
+func (c *rbCore[K, V]) get(key K) (V, bool) {
+	if node, ok := c.searchNoLock(key); ok {
+		return node.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+func (c *rbCore[K, V]) len() int {
+	return rbSize(c.root)
+}
+
+func (c *rbCore[K, V]) ascend(after *K, fn func(K, V) bool) {
+	c.RBTree.ascend(c.root, after, fn)
+}
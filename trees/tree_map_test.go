@@ -0,0 +1,88 @@
+package trees
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestTreeMap(t *testing.T) {
+	for name, kind := range map[string]TreeKind{"RedBlack": RedBlack, "AVL": AVL} {
+		t.Run(name, func(t *testing.T) {
+			m := NewTreeMap[int, string](kind)
+			if !m.IsEmpty() {
+				t.Error("New map should be empty")
+			}
+
+			for _, key := range []int{50, 20, 80, 10, 30, 70, 90} {
+				m.Set(key, "v")
+			}
+			m.Set(30, "updated")
+			m.Delete(80)
+			m.Delete(1000)
+
+			if m.Len() != 6 {
+				t.Errorf("Len() = %d, want 6", m.Len())
+			}
+			if val, ok := m.Get(30); !ok || val != "updated" {
+				t.Errorf("Get(30) = %q, %v, want updated, true", val, ok)
+			}
+			if _, ok := m.Get(80); ok {
+				t.Error("Get(80) should miss after Delete")
+			}
+
+			expected := []int{10, 20, 30, 50, 70, 90}
+			keys := m.Keys()
+			if !equalInts(keys, expected) {
+				t.Errorf("Keys() = %v, want %v", keys, expected)
+			}
+
+			tests := []struct {
+				key         int
+				floor, ceil int
+				hasF, hasC  bool
+			}{
+				{key: 5, ceil: 10, hasC: true},
+				{key: 10, floor: 10, ceil: 10, hasF: true, hasC: true},
+				{key: 75, floor: 70, ceil: 90, hasF: true, hasC: true},
+				{key: 80, floor: 70, ceil: 90, hasF: true, hasC: true},
+				{key: 95, floor: 90, hasF: true},
+			}
+			for _, tt := range tests {
+				if key, _, ok := m.Floor(tt.key); ok != tt.hasF || (ok && key != tt.floor) {
+					t.Errorf("Floor(%d) = %d, %v, want %d, %v", tt.key, key, ok, tt.floor, tt.hasF)
+				}
+				if key, _, ok := m.Ceiling(tt.key); ok != tt.hasC || (ok && key != tt.ceil) {
+					t.Errorf("Ceiling(%d) = %d, %v, want %d, %v", tt.key, key, ok, tt.ceil, tt.hasC)
+				}
+			}
+
+			var visited []int
+			m.Range(func(key int, value string) bool {
+				visited = append(visited, key)
+				return len(visited) < 3
+			})
+			if !equalInts(visited, []int{10, 20, 30}) {
+				t.Errorf("Range stopped after %v, want [10 20 30]", visited)
+			}
+		})
+	}
+}
+
+func TestTreeMapConcurrent(t *testing.T) {
+	m := NewTreeMap[int, int](AVL)
+	var wg sync.WaitGroup
+
+	for i := 0; i < 1000; i++ {
+		wg.Add(1)
+		go func(val int) {
+			defer wg.Done()
+			m.Set(val, val)
+			m.Get(val)
+		}(i)
+	}
+	wg.Wait()
+
+	if m.Len() != 1000 {
+		t.Errorf("Expected length 1000 after concurrent sets, got %d", m.Len())
+	}
+}
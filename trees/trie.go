@@ -0,0 +1,254 @@
+package trees
+
+import (
+	"dsgo/heaps"
+	"dsgo/utils"
+	"slices"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+type trieNode struct {
+	r rune
+	// children are ordered by rune
+	children []*trieNode
+	weight   int
+	terminal bool
+	// best is the highest weight of any key in this subtree, used to skip
+	// subtrees that cannot make the top N
+	best int
+}
+
+// Suggestion is a key returned by Trie.Suggest with its weight.
+type Suggestion struct {
+	Key    string
+	Weight int
+}
+
+// Trie is a rune-level prefix tree of weighted string keys, for
+// autocomplete: Suggest returns the heaviest completions of a prefix.
+// Unlike RadixTree it keeps one node per rune, which is what rune-by-rune
+// walks such as fuzzy matching need.
+type Trie struct {
+	root       *trieNode
+	size       int
+	threadSafe bool
+	mu         sync.RWMutex
+	sealed     atomic.Bool
+}
+
+func NewTrie(threadSafe ...bool) *Trie {
+	isThreadSafe := true
+	if len(threadSafe) > 0 {
+		isThreadSafe = threadSafe[0]
+	}
+	return &Trie{
+		root:       &trieNode{},
+		threadSafe: isThreadSafe,
+	}
+}
+
+// child returns the index of the child for r, or where it would be
+// inserted, and the child if there is one.
+func (n *trieNode) child(r rune) (int, *trieNode) {
+	i := sort.Search(len(n.children), func(i int) bool { return n.children[i].r >= r })
+	if i < len(n.children) && n.children[i].r == r {
+		return i, n.children[i]
+	}
+	return i, nil
+}
+
+// refresh recomputes best for n from its own weight and its children.
+func (n *trieNode) refresh() {
+	found := n.terminal
+	n.best = n.weight
+	for _, child := range n.children {
+		if !found || child.best > n.best {
+			n.best = child.best
+			found = true
+		}
+	}
+}
+
+// Insert adds key with the given weight, or sets the weight of an existing
+// key.
+func (t *Trie) Insert(key string, weight int) {
+	if t.threadSafe {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+	if t.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
+
+	path := []*trieNode{t.root}
+	n := t.root
+	for _, r := range key {
+		i, child := n.child(r)
+		if child == nil {
+			child = &trieNode{r: r}
+			n.children = slices.Insert(n.children, i, child)
+		}
+		n = child
+		path = append(path, n)
+	}
+	if !n.terminal {
+		t.size++
+	}
+	n.terminal, n.weight = true, weight
+	for i := len(path) - 1; i >= 0; i-- {
+		path[i].refresh()
+	}
+}
+
+// Weight returns the weight of key, or false if it is not in the trie.
+func (t *Trie) Weight(key string) (int, bool) {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	if n := t.find(key); n != nil && n.terminal {
+		return n.weight, true
+	}
+	return 0, false
+}
+
+func (t *Trie) find(key string) *trieNode {
+	n := t.root
+	for _, r := range key {
+		if _, n = n.child(r); n == nil {
+			return nil
+		}
+	}
+	return n
+}
+
+// Delete removes key and reports whether it was present, pruning nodes
+// that no longer lead to a key.
+func (t *Trie) Delete(key string) bool {
+	if t.threadSafe {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+	if t.sealed.Load() {
+		panic(utils.ErrSealed)
+	}
+
+	path := []*trieNode{t.root}
+	n := t.root
+	for _, r := range key {
+		if _, n = n.child(r); n == nil {
+			return false
+		}
+		path = append(path, n)
+	}
+	if !n.terminal {
+		return false
+	}
+	n.terminal, n.weight = false, 0
+	t.size--
+	for i := len(path) - 1; i >= 0; i-- {
+		node := path[i]
+		if i > 0 && !node.terminal && len(node.children) == 0 {
+			parent := path[i-1]
+			j, _ := parent.child(node.r)
+			parent.children = slices.Delete(parent.children, j, j+1)
+			continue
+		}
+		node.refresh()
+	}
+	return true
+}
+
+// Suggest returns up to limit keys starting with prefix, heaviest first,
+// with equal weights in key order. The candidates are kept in a min-heap
+// bounded to limit entries, and subtrees whose best weight cannot beat the
+// lightest of a full heap are skipped.
+func (t *Trie) Suggest(prefix string, limit int) []Suggestion {
+	if limit <= 0 {
+		return nil
+	}
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	start := t.find(prefix)
+	if start == nil {
+		return nil
+	}
+
+	// worse reports whether a ranks below b
+	worse := func(a, b Suggestion) bool {
+		if a.Weight != b.Weight {
+			return a.Weight < b.Weight
+		}
+		return a.Key > b.Key
+	}
+	top := heaps.NewMinHeap(worse, false)
+	var visit func(n *trieNode, key []rune)
+	visit = func(n *trieNode, key []rune) {
+		if lightest, ok := top.Peek(); ok && top.Size() == limit && n.best < lightest.Weight {
+			return
+		}
+		if n.terminal {
+			top.Push(Suggestion{Key: string(key), Weight: n.weight})
+			if top.Size() > limit {
+				top.Pop()
+			}
+		}
+		for _, child := range n.children {
+			visit(child, append(key, child.r))
+		}
+	}
+	visit(start, []rune(prefix))
+
+	out := make([]Suggestion, top.Size())
+	for i := len(out) - 1; i >= 0; i-- {
+		out[i], _ = top.Pop()
+	}
+	return out
+}
+
+// Keys returns every key in the trie in lexicographic order.
+func (t *Trie) Keys() []string {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	keys := make([]string, 0, t.size)
+	var visit func(n *trieNode, key []rune)
+	visit = func(n *trieNode, key []rune) {
+		if n.terminal {
+			keys = append(keys, string(key))
+		}
+		for _, child := range n.children {
+			visit(child, append(key, child.r))
+		}
+	}
+	visit(t.root, nil)
+	return keys
+}
+
+func (t *Trie) Len() int {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	return t.size
+}
+
+// Seal makes the trie read-only. Mutations after Seal panic with
+// utils.ErrSealed. Reads on a sealed trie skip locking.
+func (t *Trie) Seal() {
+	if t.threadSafe {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+	t.sealed.Store(true)
+}
+
+// IsSealed reports whether Seal has been called.
+func (t *Trie) IsSealed() bool {
+	return t.sealed.Load()
+}
@@ -0,0 +1,136 @@
+package trees
+
+import (
+	"dsgo/utils"
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func suggestionKeys(s []Suggestion) []string {
+	keys := make([]string, len(s))
+	for i, sg := range s {
+		keys[i] = sg.Key
+	}
+	return keys
+}
+
+func TestTrie_InsertDelete(t *testing.T) {
+	trie := NewTrie()
+	for _, key := range []string{"tea", "ten", "team", "to", "café", ""} {
+		trie.Insert(key, 1)
+	}
+	trie.Insert("tea", 5)
+	if trie.Len() != 6 {
+		t.Errorf("Len() = %d, want 6", trie.Len())
+	}
+	if w, ok := trie.Weight("tea"); !ok || w != 5 {
+		t.Errorf("Weight(tea) = %d, %v, want 5, true", w, ok)
+	}
+	if _, ok := trie.Weight("te"); ok {
+		t.Error("Weight() found a prefix that was never inserted")
+	}
+	if got, want := trie.Keys(), []string{"", "café", "tea", "team", "ten", "to"}; !slices.Equal(got, want) {
+		t.Errorf("Keys() = %v, want %v", got, want)
+	}
+
+	if trie.Delete("te") {
+		t.Error("Delete() of a prefix succeeded")
+	}
+	for _, key := range []string{"team", "café", ""} {
+		if !trie.Delete(key) {
+			t.Errorf("Delete(%q) failed", key)
+		}
+	}
+	if got, want := trie.Keys(), []string{"tea", "ten", "to"}; !slices.Equal(got, want) {
+		t.Errorf("Keys() after Delete = %v, want %v", got, want)
+	}
+	if trie.find("caf") != nil {
+		t.Error("Delete() left nodes that lead to no key")
+	}
+}
+
+func TestTrie_Suggest(t *testing.T) {
+	trie := NewTrie()
+	weights := map[string]int{
+		"go": 50, "golang": 90, "gopher": 70, "goroutine": 70, "google": 100,
+		"gob": 10, "graph": 80, "gorm": 5,
+	}
+	for key, w := range weights {
+		trie.Insert(key, w)
+	}
+
+	got := trie.Suggest("go", 4)
+	if want := []string{"google", "golang", "gopher", "goroutine"}; !slices.Equal(suggestionKeys(got), want) {
+		t.Errorf("Suggest(go, 4) = %v, want %v", suggestionKeys(got), want)
+	}
+	if got[0].Weight != 100 {
+		t.Errorf("Suggest()[0].Weight = %d, want 100", got[0].Weight)
+	}
+	if got := trie.Suggest("gr", 10); !slices.Equal(suggestionKeys(got), []string{"graph"}) {
+		t.Errorf("Suggest(gr, 10) = %v, want [graph]", suggestionKeys(got))
+	}
+	if got := trie.Suggest("x", 3); got != nil {
+		t.Errorf("Suggest(x, 3) = %v, want nil", got)
+	}
+	if got := trie.Suggest("go", 0); got != nil {
+		t.Errorf("Suggest(go, 0) = %v, want nil", got)
+	}
+
+	trie.Delete("google")
+	trie.Insert("gob", 95)
+	if got := trie.Suggest("go", 2); !slices.Equal(suggestionKeys(got), []string{"gob", "golang"}) {
+		t.Errorf("Suggest(go, 2) after updates = %v, want [gob golang]", suggestionKeys(got))
+	}
+}
+
+func TestTrie_SuggestRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	trie := NewTrie(false)
+	weights := make(map[string]int)
+	for i := 0; i < 500; i++ {
+		b := make([]byte, 1+rng.Intn(6))
+		for j := range b {
+			b[j] = 'a' + byte(rng.Intn(3))
+		}
+		key := string(b)
+		if rng.Intn(4) == 0 {
+			trie.Delete(key)
+			weights[key] = 0
+			continue
+		}
+		w := rng.Intn(50)
+		trie.Insert(key, w)
+		weights[key] = w + 1
+	}
+	for _, prefix := range []string{"", "a", "ab", "cab"} {
+		var want []Suggestion
+		for key, w := range weights {
+			if w > 0 && len(key) >= len(prefix) && key[:len(prefix)] == prefix {
+				want = append(want, Suggestion{key, w - 1})
+			}
+		}
+		sort.Slice(want, func(i, j int) bool {
+			if want[i].Weight != want[j].Weight {
+				return want[i].Weight > want[j].Weight
+			}
+			return want[i].Key < want[j].Key
+		})
+		want = want[:min(len(want), 5)]
+		if got := trie.Suggest(prefix, 5); !slices.Equal(got, want) {
+			t.Errorf("Suggest(%q, 5) = %v, want %v", prefix, got, want)
+		}
+	}
+}
+
+func TestTrie_Seal(t *testing.T) {
+	trie := NewTrie()
+	trie.Seal()
+	defer func() {
+		if r := recover(); r != utils.ErrSealed {
+			t.Errorf("Insert() after Seal panicked with %v, want %v", r, utils.ErrSealed)
+		}
+	}()
+	trie.Insert("a", 1)
+}
@@ -0,0 +1,155 @@
+package trees
+
+import (
+	"errors"
+	"fmt"
+
+	"dsgo/utils"
+)
+
+// ErrInvalidTree is wrapped by the errors Validate returns, which name the
+// node and the invariant it breaks.
+var ErrInvalidTree = errors.New("trees: invariant violated")
+
+// checkOrder reports a key that falls outside the open range (lo, hi) set
+// by its ancestors. A nil bound is unbounded.
+func checkOrder[K utils.Ordered](key K, lo, hi *K) error {
+	if (lo != nil && key <= *lo) || (hi != nil && key >= *hi) {
+		return fmt.Errorf("%w: key %v is out of order", ErrInvalidTree, key)
+	}
+	return nil
+}
+
+// Validate checks that every key sorts between its ancestors and that
+// every subtree size is up to date, and returns an error wrapping
+// ErrInvalidTree for the first node that does not. It runs in O(n) and is
+// meant for tests and fuzzing rather than production paths.
+func (b *BST[K, V]) Validate() error {
+	if b.threadSafe && !b.sealed.Load() {
+		b.mu.RLock()
+		defer b.mu.RUnlock()
+	}
+	_, err := validateBST(b.root, nil, nil)
+	return err
+}
+
+func validateBST[K utils.Ordered, V any](node *Node[K, V], lo, hi *K) (int, error) {
+	if node == nil {
+		return 0, nil
+	}
+	if err := checkOrder(node.key, lo, hi); err != nil {
+		return 0, err
+	}
+	left, err := validateBST(node.left, lo, &node.key)
+	if err != nil {
+		return 0, err
+	}
+	right, err := validateBST(node.right, &node.key, hi)
+	if err != nil {
+		return 0, err
+	}
+	if node.size != 1+left+right {
+		return 0, fmt.Errorf("%w: key %v has size %d, want %d", ErrInvalidTree, node.key, node.size, 1+left+right)
+	}
+	return node.size, nil
+}
+
+// Validate checks ordering, the stored heights and sizes, and that no
+// node's subtrees differ in height by more than one. It reports the first
+// violation as BST.Validate does.
+func (t *AVLTree[K, V]) Validate() error {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	_, _, err := validateAVL(t.Root, nil, nil)
+	return err
+}
+
+// validateAVL returns the true height and size of the subtree at node.
+func validateAVL[K utils.Ordered, V any](node *AVLNode[K, V], lo, hi *K) (int, int, error) {
+	if node == nil {
+		return 0, 0, nil
+	}
+	if err := checkOrder(node.Key, lo, hi); err != nil {
+		return 0, 0, err
+	}
+	lh, ls, err := validateAVL(node.Left, lo, &node.Key)
+	if err != nil {
+		return 0, 0, err
+	}
+	rh, rs, err := validateAVL(node.Right, &node.Key, hi)
+	if err != nil {
+		return 0, 0, err
+	}
+	switch {
+	case lh-rh > 1 || rh-lh > 1:
+		return 0, 0, fmt.Errorf("%w: key %v has balance factor %d", ErrInvalidTree, node.Key, lh-rh)
+	case node.Height != 1+max(lh, rh):
+		return 0, 0, fmt.Errorf("%w: key %v has height %d, want %d", ErrInvalidTree, node.Key, node.Height, 1+max(lh, rh))
+	case node.Size != 1+ls+rs:
+		return 0, 0, fmt.Errorf("%w: key %v has size %d, want %d", ErrInvalidTree, node.Key, node.Size, 1+ls+rs)
+	}
+	return node.Height, node.Size, nil
+}
+
+// Validate checks ordering, sizes and parent links along with the
+// red-black rules: the root is black, no red node has a red child, and
+// every path from a node down to a leaf crosses the same number of black
+// nodes. It reports the first violation as BST.Validate does.
+func (t *RBTree[K, V]) Validate() error {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	if t.root == nil {
+		return nil
+	}
+	if t.root.color != Black {
+		return fmt.Errorf("%w: root %v is red", ErrInvalidTree, t.root.key)
+	}
+	if t.root.parent != nil {
+		return fmt.Errorf("%w: root %v has a parent", ErrInvalidTree, t.root.key)
+	}
+	_, err := validateRB(t.root, nil, nil)
+	return err
+}
+
+// validateRB returns the black height of the subtree at node.
+func validateRB[K utils.Ordered, V any](node *RBNode[K, V], lo, hi *K) (int, error) {
+	if node == nil {
+		return 1, nil
+	}
+	if err := checkOrder(node.key, lo, hi); err != nil {
+		return 0, err
+	}
+	for _, child := range []*RBNode[K, V]{node.left, node.right} {
+		if child == nil {
+			continue
+		}
+		if child.parent != node {
+			return 0, fmt.Errorf("%w: key %v does not link back to its parent %v", ErrInvalidTree, child.key, node.key)
+		}
+		if node.color == Red && child.color == Red {
+			return 0, fmt.Errorf("%w: red key %v has a red child %v", ErrInvalidTree, node.key, child.key)
+		}
+	}
+	left, err := validateRB(node.left, lo, &node.key)
+	if err != nil {
+		return 0, err
+	}
+	right, err := validateRB(node.right, &node.key, hi)
+	if err != nil {
+		return 0, err
+	}
+	if left != right {
+		return 0, fmt.Errorf("%w: key %v has black heights %d and %d below it", ErrInvalidTree, node.key, left, right)
+	}
+	if want := 1 + rbSize(node.left) + rbSize(node.right); node.size != want {
+		return 0, fmt.Errorf("%w: key %v has size %d, want %d", ErrInvalidTree, node.key, node.size, want)
+	}
+	if node.color == Black {
+		left++
+	}
+	return left, nil
+}
@@ -0,0 +1,109 @@
+package trees
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+type validatable interface {
+	Insert(key int, value int)
+	Delete(key int)
+	Validate() error
+}
+
+func TestValidateHealthyTrees(t *testing.T) {
+	trees := map[string]validatable{
+		"BST": NewBST[int, int](),
+		"AVL": NewAVLTree[int, int](),
+		"RB":  NewRBTree[int, int](),
+	}
+	for name, tree := range trees {
+		t.Run(name, func(t *testing.T) {
+			if err := tree.Validate(); err != nil {
+				t.Fatalf("Validate() on empty tree = %v", err)
+			}
+			rng := rand.New(rand.NewSource(7))
+			for i := 0; i < 500; i++ {
+				if rng.Intn(3) == 0 {
+					tree.Delete(rng.Intn(200))
+				} else {
+					tree.Insert(rng.Intn(200), i)
+				}
+				if err := tree.Validate(); err != nil {
+					t.Fatalf("Validate() after step %d = %v", i, err)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateBSTViolations(t *testing.T) {
+	b := NewBST[int, int]()
+	for _, key := range []int{5, 3, 8} {
+		b.Insert(key, key)
+	}
+	b.root.left.key = 9
+	if err := b.Validate(); !errors.Is(err, ErrInvalidTree) {
+		t.Errorf("Validate() with misplaced key = %v, want ErrInvalidTree", err)
+	}
+	b.root.left.key = 3
+	b.root.size = 7
+	if err := b.Validate(); !errors.Is(err, ErrInvalidTree) {
+		t.Errorf("Validate() with stale size = %v, want ErrInvalidTree", err)
+	}
+}
+
+func TestValidateAVLViolations(t *testing.T) {
+	a := NewAVLTree[int, int]()
+	a.Insert(2, 2)
+	// Hang a chain off the root that insert would have rotated away
+	a.Root.Right = &AVLNode[int, int]{Key: 3, Height: 2, Size: 2,
+		Right: &AVLNode[int, int]{Key: 4, Height: 1, Size: 1}}
+	a.Root.Height, a.Root.Size = 3, 3
+	if err := a.Validate(); !errors.Is(err, ErrInvalidTree) {
+		t.Errorf("Validate() on unbalanced tree = %v, want ErrInvalidTree", err)
+	}
+
+	a = NewAVLTree[int, int]()
+	a.Insert(1, 1)
+	a.Root.Height = 4
+	if err := a.Validate(); !errors.Is(err, ErrInvalidTree) {
+		t.Errorf("Validate() with stale height = %v, want ErrInvalidTree", err)
+	}
+}
+
+func TestValidateRBViolations(t *testing.T) {
+	build := func() *RBTree[int, int] {
+		rb := NewRBTree[int, int]()
+		for _, key := range []int{4, 2, 6, 1} {
+			rb.Insert(key, key)
+		}
+		return rb
+	}
+
+	rb := build()
+	rb.root.color = Red
+	if err := rb.Validate(); !errors.Is(err, ErrInvalidTree) {
+		t.Errorf("Validate() with red root = %v, want ErrInvalidTree", err)
+	}
+
+	rb = build()
+	rb.root.left.color = Red // its child 1 is red too
+	if err := rb.Validate(); !errors.Is(err, ErrInvalidTree) {
+		t.Errorf("Validate() with red-red edge = %v, want ErrInvalidTree", err)
+	}
+
+	rb = build()
+	rb.root.right.color = Red // one side loses a black node
+	rb.root.left.left.color = Black
+	if err := rb.Validate(); !errors.Is(err, ErrInvalidTree) {
+		t.Errorf("Validate() with uneven black heights = %v, want ErrInvalidTree", err)
+	}
+
+	rb = build()
+	rb.root.left.left.parent = rb.root
+	if err := rb.Validate(); !errors.Is(err, ErrInvalidTree) {
+		t.Errorf("Validate() with broken parent link = %v, want ErrInvalidTree", err)
+	}
+}
@@ -0,0 +1,358 @@
+package trees
+
+import (
+	"errors"
+	"sync"
+
+	"dsgo/utils"
+)
+
+var ErrOverlap = errors.New("key ranges overlap")
+
+// Balance parameters from Hirai and Yamamoto, "Balancing weight-balanced
+// trees": a subtree may be at most wbDelta times heavier than its sibling,
+// and wbRatio picks between single and double rotations.
+const (
+	wbDelta = 3
+	wbRatio = 2
+)
+
+type wbNode[K utils.Ordered, V any] struct {
+	key   K
+	value V
+	left  *wbNode[K, V]
+	right *wbNode[K, V]
+	size  int
+}
+
+// WBTree is a weight-balanced (BB[α]) tree. Besides the usual sorted-map
+// operations it supports selecting, splitting and concatenating by rank in
+// O(log n). Nodes are immutable and shared between versions, so SplitAt and
+// Concat leave their inputs untouched and cost no copying beyond O(log n)
+// new nodes.
+type WBTree[K utils.Ordered, V any] struct {
+	root       *wbNode[K, V]
+	threadSafe bool
+	mu         sync.RWMutex
+}
+
+func NewWBTree[K utils.Ordered, V any](threadSafe ...bool) *WBTree[K, V] {
+	isThreadSafe := true
+	if len(threadSafe) > 0 {
+		isThreadSafe = threadSafe[0]
+	}
+	return &WBTree[K, V]{
+		threadSafe: isThreadSafe,
+	}
+}
+
+func (t *WBTree[K, V]) withRoot(root *wbNode[K, V]) *WBTree[K, V] {
+	return &WBTree[K, V]{root: root, threadSafe: t.threadSafe}
+}
+
+func (t *WBTree[K, V]) snapshot() *wbNode[K, V] {
+	if t.threadSafe {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	return t.root
+}
+
+func (t *WBTree[K, V]) Insert(key K, value V) {
+	if t.threadSafe {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+	t.root = wbInsert(t.root, key, value)
+}
+
+func (t *WBTree[K, V]) Delete(key K) {
+	if t.threadSafe {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+	}
+	t.root = wbDelete(t.root, key)
+}
+
+func (t *WBTree[K, V]) Search(key K) (V, bool) {
+	for node := t.snapshot(); node != nil; {
+		switch {
+		case key < node.key:
+			node = node.left
+		case key > node.key:
+			node = node.right
+		default:
+			return node.value, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+func (t *WBTree[K, V]) Len() int {
+	return wbSize(t.snapshot())
+}
+
+// At returns the entry with the given rank, counting from 0 in key order.
+func (t *WBTree[K, V]) At(rank int) (K, V, bool) {
+	node := t.snapshot()
+	if rank < 0 || rank >= wbSize(node) {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	for {
+		ls := wbSize(node.left)
+		switch {
+		case rank < ls:
+			node = node.left
+		case rank > ls:
+			rank -= ls + 1
+			node = node.right
+		default:
+			return node.key, node.value, true
+		}
+	}
+}
+
+// Rank returns the number of keys less than key.
+func (t *WBTree[K, V]) Rank(key K) int {
+	rank := 0
+	for node := t.snapshot(); node != nil; {
+		if node.key < key {
+			rank += wbSize(node.left) + 1
+			node = node.right
+		} else {
+			node = node.left
+		}
+	}
+	return rank
+}
+
+// SplitAt returns a tree holding the first rank entries and a tree holding
+// the rest. t is not modified.
+func (t *WBTree[K, V]) SplitAt(rank int) (*WBTree[K, V], *WBTree[K, V]) {
+	left, right := wbSplitAt(t.snapshot(), rank)
+	return t.withRoot(left), t.withRoot(right)
+}
+
+// Concat returns a tree holding the entries of t followed by those of other.
+// Every key of t must be less than every key of other, otherwise Concat
+// returns ErrOverlap. Neither tree is modified.
+func (t *WBTree[K, V]) Concat(other *WBTree[K, V]) (*WBTree[K, V], error) {
+	left, right := t.snapshot(), other.snapshot()
+	if left != nil && right != nil && wbMax(left).key >= wbMin(right).key {
+		return nil, ErrOverlap
+	}
+	return t.withRoot(wbMerge(left, right)), nil
+}
+
+// Range calls fn for every entry in key order until fn returns false.
+func (t *WBTree[K, V]) Range(fn func(key K, value V) bool) {
+	wbAscend(t.snapshot(), fn)
+}
+
+func (t *WBTree[K, V]) Keys() []K {
+	root := t.snapshot()
+	return wbAppendKeys(root, make([]K, 0, wbSize(root)))
+}
+
+// AppendKeys appends the keys in sorted order to dst and returns the
+// extended slice, letting callers reuse a buffer across calls.
+func (t *WBTree[K, V]) AppendKeys(dst []K) []K {
+	return wbAppendKeys(t.snapshot(), dst)
+}
+
+func wbAppendKeys[K utils.Ordered, V any](node *wbNode[K, V], dst []K) []K {
+	for node != nil {
+		dst = wbAppendKeys(node.left, dst)
+		dst = append(dst, node.key)
+		node = node.right
+	}
+	return dst
+}
+
+func wbAscend[K utils.Ordered, V any](node *wbNode[K, V], fn func(K, V) bool) bool {
+	if node == nil {
+		return true
+	}
+	return wbAscend(node.left, fn) && fn(node.key, node.value) && wbAscend(node.right, fn)
+}
+
+func wbSize[K utils.Ordered, V any](node *wbNode[K, V]) int {
+	if node == nil {
+		return 0
+	}
+	return node.size
+}
+
+func wbMake[K utils.Ordered, V any](key K, value V, left, right *wbNode[K, V]) *wbNode[K, V] {
+	return &wbNode[K, V]{key: key, value: value, left: left, right: right, size: wbSize(left) + wbSize(right) + 1}
+}
+
+// wbBalance builds a node from subtrees whose weights are at most slightly
+// out of balance, rotating once or twice to restore the invariant.
+func wbBalance[K utils.Ordered, V any](key K, value V, left, right *wbNode[K, V]) *wbNode[K, V] {
+	ls, rs := wbSize(left), wbSize(right)
+	switch {
+	case ls+rs <= 1:
+		return wbMake(key, value, left, right)
+	case rs > wbDelta*ls:
+		if wbSize(right.left) < wbRatio*wbSize(right.right) {
+			// Single left rotation
+			return wbMake(right.key, right.value, wbMake(key, value, left, right.left), right.right)
+		}
+		// Double left rotation
+		rl := right.left
+		return wbMake(rl.key, rl.value, wbMake(key, value, left, rl.left), wbMake(right.key, right.value, rl.right, right.right))
+	case ls > wbDelta*rs:
+		if wbSize(left.right) < wbRatio*wbSize(left.left) {
+			// Single right rotation
+			return wbMake(left.key, left.value, left.left, wbMake(key, value, left.right, right))
+		}
+		// Double right rotation
+		lr := left.right
+		return wbMake(lr.key, lr.value, wbMake(left.key, left.value, left.left, lr.left), wbMake(key, value, lr.right, right))
+	default:
+		return wbMake(key, value, left, right)
+	}
+}
+
+func wbInsert[K utils.Ordered, V any](node *wbNode[K, V], key K, value V) *wbNode[K, V] {
+	if node == nil {
+		return wbMake(key, value, nil, nil)
+	}
+	switch {
+	case key < node.key:
+		return wbBalance(node.key, node.value, wbInsert(node.left, key, value), node.right)
+	case key > node.key:
+		return wbBalance(node.key, node.value, node.left, wbInsert(node.right, key, value))
+	default:
+		return wbMake(key, value, node.left, node.right)
+	}
+}
+
+func wbDelete[K utils.Ordered, V any](node *wbNode[K, V], key K) *wbNode[K, V] {
+	if node == nil {
+		return nil
+	}
+	switch {
+	case key < node.key:
+		return wbBalance(node.key, node.value, wbDelete(node.left, key), node.right)
+	case key > node.key:
+		return wbBalance(node.key, node.value, node.left, wbDelete(node.right, key))
+	default:
+		return wbGlue(node.left, node.right)
+	}
+}
+
+func wbMin[K utils.Ordered, V any](node *wbNode[K, V]) *wbNode[K, V] {
+	for node.left != nil {
+		node = node.left
+	}
+	return node
+}
+
+func wbMax[K utils.Ordered, V any](node *wbNode[K, V]) *wbNode[K, V] {
+	for node.right != nil {
+		node = node.right
+	}
+	return node
+}
+
+func wbDeleteMin[K utils.Ordered, V any](node *wbNode[K, V]) *wbNode[K, V] {
+	if node.left == nil {
+		return node.right
+	}
+	return wbBalance(node.key, node.value, wbDeleteMin(node.left), node.right)
+}
+
+func wbDeleteMax[K utils.Ordered, V any](node *wbNode[K, V]) *wbNode[K, V] {
+	if node.right == nil {
+		return node.left
+	}
+	return wbBalance(node.key, node.value, node.left, wbDeleteMax(node.right))
+}
+
+// wbGlue joins two balanced siblings whose keys are ordered, taking the new
+// root from the heavier side.
+func wbGlue[K utils.Ordered, V any](left, right *wbNode[K, V]) *wbNode[K, V] {
+	switch {
+	case left == nil:
+		return right
+	case right == nil:
+		return left
+	case wbSize(left) > wbSize(right):
+		m := wbMax(left)
+		return wbBalance(m.key, m.value, wbDeleteMax(left), right)
+	default:
+		m := wbMin(right)
+		return wbBalance(m.key, m.value, left, wbDeleteMin(right))
+	}
+}
+
+// wbLink joins left, key and right, whose keys are ordered but whose sizes
+// may differ arbitrarily, descending the heavier side until they balance.
+func wbLink[K utils.Ordered, V any](key K, value V, left, right *wbNode[K, V]) *wbNode[K, V] {
+	switch {
+	case left == nil:
+		return wbInsertMin(right, key, value)
+	case right == nil:
+		return wbInsertMax(left, key, value)
+	case wbDelta*wbSize(left) < wbSize(right):
+		return wbBalance(right.key, right.value, wbLink(key, value, left, right.left), right.right)
+	case wbDelta*wbSize(right) < wbSize(left):
+		return wbBalance(left.key, left.value, left.left, wbLink(key, value, left.right, right))
+	default:
+		return wbMake(key, value, left, right)
+	}
+}
+
+func wbInsertMin[K utils.Ordered, V any](node *wbNode[K, V], key K, value V) *wbNode[K, V] {
+	if node == nil {
+		return wbMake(key, value, nil, nil)
+	}
+	return wbBalance(node.key, node.value, wbInsertMin(node.left, key, value), node.right)
+}
+
+func wbInsertMax[K utils.Ordered, V any](node *wbNode[K, V], key K, value V) *wbNode[K, V] {
+	if node == nil {
+		return wbMake(key, value, nil, nil)
+	}
+	return wbBalance(node.key, node.value, node.left, wbInsertMax(node.right, key, value))
+}
+
+// wbMerge concatenates two trees whose keys are ordered.
+func wbMerge[K utils.Ordered, V any](left, right *wbNode[K, V]) *wbNode[K, V] {
+	switch {
+	case left == nil:
+		return right
+	case right == nil:
+		return left
+	case wbDelta*wbSize(left) < wbSize(right):
+		return wbBalance(right.key, right.value, wbMerge(left, right.left), right.right)
+	case wbDelta*wbSize(right) < wbSize(left):
+		return wbBalance(left.key, left.value, left.left, wbMerge(left.right, right))
+	default:
+		return wbGlue(left, right)
+	}
+}
+
+func wbSplitAt[K utils.Ordered, V any](node *wbNode[K, V], rank int) (*wbNode[K, V], *wbNode[K, V]) {
+	if node == nil {
+		return nil, nil
+	}
+	if rank <= 0 {
+		return nil, node
+	}
+	if rank >= node.size {
+		return node, nil
+	}
+	ls := wbSize(node.left)
+	if rank <= ls {
+		left, right := wbSplitAt(node.left, rank)
+		return left, wbLink(node.key, node.value, right, node.right)
+	}
+	left, right := wbSplitAt(node.right, rank-ls-1)
+	return wbLink(node.key, node.value, node.left, left), right
+}
@@ -0,0 +1,121 @@
+package trees
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// verifyWB checks ordering, sizes and the weight-balance invariant and
+// returns the keys in order.
+func verifyWB(t *testing.T, tree *WBTree[int, int]) []int {
+	t.Helper()
+	var walk func(node *wbNode[int, int]) int
+	walk = func(node *wbNode[int, int]) int {
+		if node == nil {
+			return 0
+		}
+		ls, rs := walk(node.left), walk(node.right)
+		if node.size != ls+rs+1 {
+			t.Fatalf("node %d has size %d, want %d", node.key, node.size, ls+rs+1)
+		}
+		if ls+rs > 1 && (ls > wbDelta*rs || rs > wbDelta*ls) {
+			t.Fatalf("node %d is unbalanced: %d vs %d", node.key, ls, rs)
+		}
+		return node.size
+	}
+	walk(tree.root)
+
+	keys := tree.Keys()
+	for i := 1; i < len(keys); i++ {
+		if keys[i-1] >= keys[i] {
+			t.Fatalf("keys out of order: %v", keys)
+		}
+	}
+	return keys
+}
+
+func TestWBTreeBasicOperations(t *testing.T) {
+	tree := NewWBTree[int, int]()
+	for i := 0; i < 100; i++ {
+		tree.Insert(i, i*10)
+	}
+	tree.Insert(5, 500)
+	for i := 0; i < 100; i += 3 {
+		tree.Delete(i)
+	}
+	verifyWB(t, tree)
+
+	if tree.Len() != 66 {
+		t.Errorf("Len() = %d, want 66", tree.Len())
+	}
+	if val, ok := tree.Search(5); !ok || val != 500 {
+		t.Errorf("Search(5) = %d, %v, want 500, true", val, ok)
+	}
+	if _, ok := tree.Search(3); ok {
+		t.Error("Search(3) should miss after Delete")
+	}
+	if key, _, ok := tree.At(0); !ok || key != 1 {
+		t.Errorf("At(0) = %d, %v, want 1, true", key, ok)
+	}
+	if key, _, ok := tree.At(2); !ok || key != 4 {
+		t.Errorf("At(2) = %d, %v, want 4, true", key, ok)
+	}
+	if _, _, ok := tree.At(66); ok {
+		t.Error("At(Len()) should return false")
+	}
+	if rank := tree.Rank(4); rank != 2 {
+		t.Errorf("Rank(4) = %d, want 2", rank)
+	}
+}
+
+func TestWBTreeSplitConcat(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for round := 0; round < 50; round++ {
+		tree := NewWBTree[int, int](false)
+		n := rng.Intn(500)
+		for i := 0; i < n; i++ {
+			tree.Insert(rng.Intn(1000), i)
+		}
+		before := verifyWB(t, tree)
+
+		rank := rng.Intn(len(before) + 1)
+		left, right := tree.SplitAt(rank)
+		leftKeys, rightKeys := verifyWB(t, left), verifyWB(t, right)
+		if len(leftKeys) != rank || !equalInts(append(leftKeys, rightKeys...), before) {
+			t.Fatalf("SplitAt(%d) produced %d and %d keys from %d", rank, len(leftKeys), len(rightKeys), len(before))
+		}
+		if !equalInts(verifyWB(t, tree), before) {
+			t.Fatal("SplitAt modified the original tree")
+		}
+
+		joined, err := left.Concat(right)
+		if err != nil {
+			t.Fatalf("Concat() error = %v", err)
+		}
+		if !equalInts(verifyWB(t, joined), before) {
+			t.Fatal("Concat did not restore the original keys")
+		}
+	}
+
+	a, b := NewWBTree[int, int](), NewWBTree[int, int]()
+	a.Insert(5, 5)
+	b.Insert(3, 3)
+	if _, err := a.Concat(b); err != ErrOverlap {
+		t.Errorf("Concat of overlapping trees error = %v, want ErrOverlap", err)
+	}
+}
+
+func TestWBTreeConcatUneven(t *testing.T) {
+	small, large := NewWBTree[int, int](), NewWBTree[int, int]()
+	small.Insert(-1, 0)
+	for i := 0; i < 1000; i++ {
+		large.Insert(i, i)
+	}
+	for _, pair := range [][2]*WBTree[int, int]{{small, large}, {large, NewWBTree[int, int]()}} {
+		joined, err := pair[0].Concat(pair[1])
+		if err != nil {
+			t.Fatalf("Concat() error = %v", err)
+		}
+		verifyWB(t, joined)
+	}
+}
@@ -0,0 +1,400 @@
+package trees
+
+import "dsgo/utils"
+
+// A zipper is a cursor into an immutable snapshot of a tree. It remembers
+// the path it took down from the root, so moving back up rebuilds only the
+// nodes on that path; everything else is shared between the snapshot and
+// its edited versions. Every move and edit returns a new zipper and leaves
+// the receiver, the snapshot and the original tree untouched.
+
+type zipNode[T any] struct {
+	value    T
+	children []*zipNode[T]
+}
+
+// zipCrumb records a step down into child index of parent. The step above
+// it is up.
+type zipCrumb[T any] struct {
+	parent *zipNode[T]
+	index  int
+	up     *zipCrumb[T]
+}
+
+// Zipper is a cursor over a snapshot of a Tree, created by Tree.Zipper.
+// Left and Right move between siblings, Down and Up between levels, and
+// Tree builds a new Tree with any edits applied.
+type Zipper[T any] struct {
+	focus *zipNode[T]
+	path  *zipCrumb[T]
+}
+
+// Zipper snapshots the tree and returns a cursor focused on its root. The
+// cursor of an empty tree has no focus and cannot move.
+func (t *Tree[T]) Zipper() *Zipper[T] {
+	var snapshot func(n *TreeNode[T]) *zipNode[T]
+	snapshot = func(n *TreeNode[T]) *zipNode[T] {
+		out := &zipNode[T]{value: n.Value, children: make([]*zipNode[T], len(n.children))}
+		for i, child := range n.children {
+			out.children[i] = snapshot(child)
+		}
+		return out
+	}
+	if t.root == nil {
+		return &Zipper[T]{}
+	}
+	return &Zipper[T]{focus: snapshot(t.root)}
+}
+
+// Value returns the value at the focus.
+func (z *Zipper[T]) Value() T {
+	if z.focus == nil {
+		var zero T
+		return zero
+	}
+	return z.focus.value
+}
+
+// Edit returns a zipper whose focus holds fn applied to the current value.
+func (z *Zipper[T]) Edit(fn func(value T) T) *Zipper[T] {
+	if z.focus == nil {
+		return z
+	}
+	return &Zipper[T]{
+		focus: &zipNode[T]{value: fn(z.focus.value), children: z.focus.children},
+		path:  z.path,
+	}
+}
+
+// Up moves to the parent of the focus. It reports false at the root.
+func (z *Zipper[T]) Up() (*Zipper[T], bool) {
+	if z.path == nil {
+		return z, false
+	}
+	parent := z.path.parent
+	if parent.children[z.path.index] != z.focus {
+		children := append([]*zipNode[T](nil), parent.children...)
+		children[z.path.index] = z.focus
+		parent = &zipNode[T]{value: parent.value, children: children}
+	}
+	return &Zipper[T]{focus: parent, path: z.path.up}, true
+}
+
+// Down moves to the i'th child of the focus. It reports false if there is
+// no such child.
+func (z *Zipper[T]) Down(i int) (*Zipper[T], bool) {
+	if z.focus == nil || i < 0 || i >= len(z.focus.children) {
+		return z, false
+	}
+	return &Zipper[T]{
+		focus: z.focus.children[i],
+		path:  &zipCrumb[T]{parent: z.focus, index: i, up: z.path},
+	}, true
+}
+
+// Left moves to the previous sibling of the focus. It reports false if the
+// focus is the root or a first child.
+func (z *Zipper[T]) Left() (*Zipper[T], bool) {
+	if z.path == nil || z.path.index == 0 {
+		return z, false
+	}
+	index := z.path.index
+	up, _ := z.Up()
+	return up.Down(index - 1)
+}
+
+// Right moves to the next sibling of the focus. It reports false if the
+// focus is the root or a last child.
+func (z *Zipper[T]) Right() (*Zipper[T], bool) {
+	if z.path == nil || z.path.index+1 >= len(z.path.parent.children) {
+		return z, false
+	}
+	index := z.path.index
+	up, _ := z.Up()
+	return up.Down(index + 1)
+}
+
+// Top moves to the root.
+func (z *Zipper[T]) Top() *Zipper[T] {
+	for ok := true; ok; {
+		z, ok = z.Up()
+	}
+	return z
+}
+
+// Focus moves to the first node, in depth-first order from the root, whose
+// value satisfies match. It reports false if there is none.
+func (z *Zipper[T]) Focus(match func(value T) bool) (*Zipper[T], bool) {
+	var find func(z *Zipper[T]) (*Zipper[T], bool)
+	find = func(z *Zipper[T]) (*Zipper[T], bool) {
+		if match(z.focus.value) {
+			return z, true
+		}
+		for i := range z.focus.children {
+			child, _ := z.Down(i)
+			if found, ok := find(child); ok {
+				return found, true
+			}
+		}
+		return z, false
+	}
+	top := z.Top()
+	if top.focus == nil {
+		return z, false
+	}
+	if found, ok := find(top); ok {
+		return found, true
+	}
+	return z, false
+}
+
+// Tree builds a new Tree from the snapshot with every edit applied.
+func (z *Zipper[T]) Tree() *Tree[T] {
+	root := z.Top().focus
+	t := &Tree[T]{}
+	if root == nil {
+		return t
+	}
+	var build func(in *zipNode[T], parent *TreeNode[T]) *TreeNode[T]
+	build = func(in *zipNode[T], parent *TreeNode[T]) *TreeNode[T] {
+		n := &TreeNode[T]{Value: in.value, parent: parent, tree: t}
+		t.size++
+		for _, child := range in.children {
+			n.children = append(n.children, build(child, n))
+		}
+		return n
+	}
+	t.root = build(root, nil)
+	return t
+}
+
+type zipBinNode[K utils.Ordered, V any] struct {
+	key   K
+	value V
+	left  *zipBinNode[K, V]
+	right *zipBinNode[K, V]
+	// color is kept so a red-black tree can be rebuilt in the same shape
+	color Color
+}
+
+type zipBinCrumb[K utils.Ordered, V any] struct {
+	parent *zipBinNode[K, V]
+	left   bool
+	up     *zipBinCrumb[K, V]
+}
+
+// SearchZipper is a cursor over a snapshot of a BST, AVLTree or RBTree,
+// created by their Zipper methods. Left and Right move to the children of
+// the focus. Edits change values only, so the snapshot keeps its shape and
+// ordering, and Tree rebuilds a tree of the original type T in that shape.
+type SearchZipper[K utils.Ordered, V any, T any] struct {
+	focus *zipBinNode[K, V]
+	path  *zipBinCrumb[K, V]
+	build func(root *zipBinNode[K, V]) T
+}
+
+func (b *BST[K, V]) Zipper() *SearchZipper[K, V, *BST[K, V]] {
+	if b.threadSafe && !b.sealed.Load() {
+		b.mu.RLock()
+		defer b.mu.RUnlock()
+	}
+	var snapshot func(n *Node[K, V]) *zipBinNode[K, V]
+	snapshot = func(n *Node[K, V]) *zipBinNode[K, V] {
+		if n == nil {
+			return nil
+		}
+		return &zipBinNode[K, V]{key: n.key, value: n.value, left: snapshot(n.left), right: snapshot(n.right)}
+	}
+	threadSafe := b.threadSafe
+	var build func(n *zipBinNode[K, V]) *Node[K, V]
+	build = func(n *zipBinNode[K, V]) *Node[K, V] {
+		if n == nil {
+			return nil
+		}
+		node := &Node[K, V]{key: n.key, value: n.value, left: build(n.left), right: build(n.right)}
+		node.size = 1 + size(node.left) + size(node.right)
+		return node
+	}
+	return &SearchZipper[K, V, *BST[K, V]]{
+		focus: snapshot(b.root),
+		build: func(root *zipBinNode[K, V]) *BST[K, V] {
+			t := NewBST[K, V](threadSafe)
+			t.root = build(root)
+			return t
+		},
+	}
+}
+
+func (t *AVLTree[K, V]) Zipper() *SearchZipper[K, V, *AVLTree[K, V]] {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	var snapshot func(n *AVLNode[K, V]) *zipBinNode[K, V]
+	snapshot = func(n *AVLNode[K, V]) *zipBinNode[K, V] {
+		if n == nil {
+			return nil
+		}
+		return &zipBinNode[K, V]{key: n.Key, value: n.Value, left: snapshot(n.Left), right: snapshot(n.Right)}
+	}
+	threadSafe := t.threadSafe
+	var build func(n *zipBinNode[K, V]) *AVLNode[K, V]
+	build = func(n *zipBinNode[K, V]) *AVLNode[K, V] {
+		if n == nil {
+			return nil
+		}
+		node := &AVLNode[K, V]{Key: n.key, Value: n.value, Left: build(n.left), Right: build(n.right)}
+		node.Height = 1 + max(height(node.Left), height(node.Right))
+		node.Size = 1 + avlSize(node.Left) + avlSize(node.Right)
+		return node
+	}
+	return &SearchZipper[K, V, *AVLTree[K, V]]{
+		focus: snapshot(t.Root),
+		build: func(root *zipBinNode[K, V]) *AVLTree[K, V] {
+			out := NewAVLTree[K, V](threadSafe)
+			out.Root = build(root)
+			return out
+		},
+	}
+}
+
+func (t *RBTree[K, V]) Zipper() *SearchZipper[K, V, *RBTree[K, V]] {
+	if t.threadSafe && !t.sealed.Load() {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+	}
+	var snapshot func(n *RBNode[K, V]) *zipBinNode[K, V]
+	snapshot = func(n *RBNode[K, V]) *zipBinNode[K, V] {
+		if n == nil {
+			return nil
+		}
+		return &zipBinNode[K, V]{key: n.key, value: n.value, left: snapshot(n.left), right: snapshot(n.right), color: n.color}
+	}
+	threadSafe := t.threadSafe
+	var build func(n *zipBinNode[K, V], parent *RBNode[K, V]) *RBNode[K, V]
+	build = func(n *zipBinNode[K, V], parent *RBNode[K, V]) *RBNode[K, V] {
+		if n == nil {
+			return nil
+		}
+		node := &RBNode[K, V]{key: n.key, value: n.value, color: n.color, parent: parent}
+		node.left = build(n.left, node)
+		node.right = build(n.right, node)
+		node.size = 1 + rbSize(node.left) + rbSize(node.right)
+		return node
+	}
+	return &SearchZipper[K, V, *RBTree[K, V]]{
+		focus: snapshot(t.root),
+		build: func(root *zipBinNode[K, V]) *RBTree[K, V] {
+			out := NewRBTree[K, V](threadSafe)
+			out.root = build(root, nil)
+			return out
+		},
+	}
+}
+
+// Key returns the key at the focus.
+func (z *SearchZipper[K, V, T]) Key() K {
+	if z.focus == nil {
+		var zero K
+		return zero
+	}
+	return z.focus.key
+}
+
+// Value returns the value at the focus.
+func (z *SearchZipper[K, V, T]) Value() V {
+	if z.focus == nil {
+		var zero V
+		return zero
+	}
+	return z.focus.value
+}
+
+// Edit returns a zipper whose focus holds fn applied to the current value.
+func (z *SearchZipper[K, V, T]) Edit(fn func(value V) V) *SearchZipper[K, V, T] {
+	if z.focus == nil {
+		return z
+	}
+	focus := *z.focus
+	focus.value = fn(focus.value)
+	return &SearchZipper[K, V, T]{focus: &focus, path: z.path, build: z.build}
+}
+
+// Up moves to the parent of the focus. It reports false at the root.
+func (z *SearchZipper[K, V, T]) Up() (*SearchZipper[K, V, T], bool) {
+	if z.path == nil {
+		return z, false
+	}
+	parent := z.path.parent
+	if z.path.left && parent.left != z.focus {
+		copied := *parent
+		copied.left = z.focus
+		parent = &copied
+	} else if !z.path.left && parent.right != z.focus {
+		copied := *parent
+		copied.right = z.focus
+		parent = &copied
+	}
+	return &SearchZipper[K, V, T]{focus: parent, path: z.path.up, build: z.build}, true
+}
+
+// Left moves to the left child of the focus. It reports false if there is
+// none.
+func (z *SearchZipper[K, V, T]) Left() (*SearchZipper[K, V, T], bool) {
+	if z.focus == nil || z.focus.left == nil {
+		return z, false
+	}
+	return &SearchZipper[K, V, T]{
+		focus: z.focus.left,
+		path:  &zipBinCrumb[K, V]{parent: z.focus, left: true, up: z.path},
+		build: z.build,
+	}, true
+}
+
+// Right moves to the right child of the focus. It reports false if there
+// is none.
+func (z *SearchZipper[K, V, T]) Right() (*SearchZipper[K, V, T], bool) {
+	if z.focus == nil || z.focus.right == nil {
+		return z, false
+	}
+	return &SearchZipper[K, V, T]{
+		focus: z.focus.right,
+		path:  &zipBinCrumb[K, V]{parent: z.focus, up: z.path},
+		build: z.build,
+	}, true
+}
+
+// Top moves to the root.
+func (z *SearchZipper[K, V, T]) Top() *SearchZipper[K, V, T] {
+	for ok := true; ok; {
+		z, ok = z.Up()
+	}
+	return z
+}
+
+// Focus moves to the node holding key, searching down from the root. It
+// reports false if the key is not in the tree.
+func (z *SearchZipper[K, V, T]) Focus(key K) (*SearchZipper[K, V, T], bool) {
+	cur := z.Top()
+	for cur.focus != nil {
+		var ok bool
+		switch {
+		case key < cur.focus.key:
+			cur, ok = cur.Left()
+		case key > cur.focus.key:
+			cur, ok = cur.Right()
+		default:
+			return cur, true
+		}
+		if !ok {
+			break
+		}
+	}
+	return z, false
+}
+
+// Tree builds a new tree of the original type from the snapshot with every
+// edit applied.
+func (z *SearchZipper[K, V, T]) Tree() T {
+	return z.build(z.Top().focus)
+}
@@ -0,0 +1,146 @@
+package trees
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestZipper_Tree(t *testing.T) {
+	tree, _ := buildMenu()
+	z := tree.Zipper()
+
+	file, ok := z.Down(0)
+	if !ok || file.Value() != "file" {
+		t.Fatalf("Down(0) = %q, %v, want file, true", file.Value(), ok)
+	}
+	if _, ok := file.Left(); ok {
+		t.Error("Left() of a first child succeeded")
+	}
+	edit, ok := file.Right()
+	if !ok || edit.Value() != "edit" {
+		t.Fatalf("Right() = %q, %v, want edit, true", edit.Value(), ok)
+	}
+	if _, ok := edit.Right(); ok {
+		t.Error("Right() of a last child succeeded")
+	}
+
+	save, ok := z.Focus(func(v string) bool { return v == "save" })
+	if !ok {
+		t.Fatal("Focus(save) failed")
+	}
+	save = save.Edit(strings.ToUpper)
+	open, ok := save.Left()
+	if !ok || open.Value() != "open" {
+		t.Fatalf("Left() = %q, %v, want open, true", open.Value(), ok)
+	}
+	up, ok := open.Up()
+	if !ok || up.Value() != "file" {
+		t.Fatalf("Up() = %q, %v, want file, true", up.Value(), ok)
+	}
+	edited := up.Edit(func(string) string { return "File" }).Tree()
+
+	if got, want := walkValues(edited, DepthFirst), []string{"root", "File", "open", "SAVE", "edit", "undo"}; !slices.Equal(got, want) {
+		t.Errorf("edited tree = %v, want %v", got, want)
+	}
+	if edited.Len() != 6 {
+		t.Errorf("edited Len() = %d, want 6", edited.Len())
+	}
+	if got, want := walkValues(tree, DepthFirst), []string{"root", "file", "open", "save", "edit", "undo"}; !slices.Equal(got, want) {
+		t.Errorf("original tree = %v, want %v", got, want)
+	}
+	if got := walkValues(z.Tree(), DepthFirst); !slices.Equal(got, walkValues(tree, DepthFirst)) {
+		t.Errorf("unedited zipper tree = %v", got)
+	}
+	if _, ok := z.Focus(func(v string) bool { return v == "redo" }); ok {
+		t.Error("Focus() of a missing value succeeded")
+	}
+}
+
+type zipperTree interface {
+	Insert(key int, value string)
+	RangeFrom(from int, fn func(key int, value string) bool)
+	Len() int
+}
+
+// zipperGet returns the value stored under key, or "" if there is none.
+func zipperGet(tr zipperTree, key int) string {
+	value := ""
+	tr.RangeFrom(key, func(k int, v string) bool {
+		if k == key {
+			value = v
+		}
+		return false
+	})
+	return value
+}
+
+func TestSearchZipper(t *testing.T) {
+	bst, avl, rb := NewBST[int, string](), NewAVLTree[int, string](), NewRBTree[int, string]()
+	for _, tr := range []zipperTree{bst, avl, rb} {
+		for _, k := range []int{50, 30, 70, 20, 40, 60, 80} {
+			tr.Insert(k, "v")
+		}
+	}
+	bump := func(v string) string { return v + "!" }
+
+	tests := map[string]struct {
+		original zipperTree
+		edited   func() zipperTree
+	}{
+		"BST": {bst, func() zipperTree {
+			z, _ := bst.Zipper().Focus(20)
+			z = z.Edit(bump)
+			z, _ = z.Up()
+			z, _ = z.Up()
+			z, _ = z.Right()
+			return z.Edit(bump).Tree()
+		}},
+		"AVL": {avl, func() zipperTree {
+			z, _ := avl.Zipper().Focus(20)
+			z = z.Edit(bump)
+			z, _ = z.Focus(70)
+			return z.Edit(bump).Tree()
+		}},
+		"RB": {rb, func() zipperTree {
+			z, _ := rb.Zipper().Focus(20)
+			z = z.Edit(bump)
+			z, _ = z.Focus(70)
+			return z.Edit(bump).Tree()
+		}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			edited := tt.edited()
+			for _, k := range []int{20, 70} {
+				if v := zipperGet(edited, k); v != "v!" {
+					t.Errorf("edited Search(%d) = %q, want v!", k, v)
+				}
+				if v := zipperGet(tt.original, k); v != "v" {
+					t.Errorf("original Search(%d) = %q, want v", k, v)
+				}
+			}
+			if v := zipperGet(edited, 40); v != "v" {
+				t.Errorf("edited Search(40) = %q, want v", v)
+			}
+			if edited.Len() != tt.original.Len() {
+				t.Errorf("edited Len() = %d, want %d", edited.Len(), tt.original.Len())
+			}
+			edited.Insert(65, "new")
+			if v := zipperGet(edited, 65); v != "new" {
+				t.Errorf("Insert() into edited tree lost the key")
+			}
+		})
+	}
+
+	z := rb.Zipper()
+	if _, ok := z.Focus(55); ok {
+		t.Error("Focus() of a missing key succeeded")
+	}
+	if _, ok := z.Up(); ok {
+		t.Error("Up() at the root succeeded")
+	}
+	if empty := NewAVLTree[int, string]().Zipper().Tree(); empty.Len() != 0 {
+		t.Errorf("empty zipper Tree().Len() = %d, want 0", empty.Len())
+	}
+}
@@ -0,0 +1,69 @@
+// Package dsgo holds helpers that span the structure packages.
+package dsgo
+
+import "slices"
+
+// Lockable is implemented by thread-safe structures that can take part in
+// an Atomic transaction: maps.SafeSortedMap and the policy (LRU, LFU) and
+// expiry caches.
+type Lockable interface {
+	// LockID returns an identifier that is unique and stable for the
+	// lifetime of the structure.
+	LockID() uint64
+	Lock()
+	Unlock()
+}
+
+// Tx is the transaction passed to an Atomic callback. Structures expose
+// their unlocked inner API through an InTx(tx) method, which checks that the
+// transaction holds their lock.
+type Tx struct {
+	held   map[uint64]struct{}
+	active bool
+}
+
+// Holds reports whether the transaction currently holds s's lock.
+func (tx *Tx) Holds(s any) bool {
+	l, ok := s.(Lockable)
+	if !ok || !tx.active {
+		return false
+	}
+	_, held := tx.held[l.LockID()]
+	return held
+}
+
+// Atomic locks every member, runs fn, and unlocks them again, so fn can
+// update several structures without another goroutine observing a partial
+// change. Locks are always taken in LockID order, so concurrent Atomic calls
+// over overlapping members cannot deadlock. Members may repeat.
+//
+// Inside fn, use each member's InTx(tx) view rather than its ordinary
+// methods, which would try to take the lock again and deadlock.
+func Atomic(fn func(tx *Tx), members ...Lockable) {
+	ordered := slices.Clone(members)
+	slices.SortFunc(ordered, func(a, b Lockable) int {
+		switch ida, idb := a.LockID(), b.LockID(); {
+		case ida < idb:
+			return -1
+		case ida > idb:
+			return 1
+		}
+		return 0
+	})
+	ordered = slices.CompactFunc(ordered, func(a, b Lockable) bool {
+		return a.LockID() == b.LockID()
+	})
+
+	tx := &Tx{held: make(map[uint64]struct{}, len(ordered)), active: true}
+	for _, m := range ordered {
+		m.Lock()
+		tx.held[m.LockID()] = struct{}{}
+	}
+	defer func() {
+		tx.active = false
+		for i := len(ordered) - 1; i >= 0; i-- {
+			ordered[i].Unlock()
+		}
+	}()
+	fn(tx)
+}
@@ -0,0 +1,136 @@
+package dsgo
+
+import (
+	"sync"
+	"testing"
+
+	"dsgo/cache"
+	"dsgo/maps"
+	"dsgo/utils"
+)
+
+func TestAtomic(t *testing.T) {
+	byID := maps.NewSafeSortedMap[int, string]()
+	byName := maps.NewSafeSortedMap[string, int]()
+
+	Atomic(func(tx *Tx) {
+		ids, names := byID.InTx(tx), byName.InTx(tx)
+		ids.Set(1, "alice")
+		names.Set("alice", 1)
+	}, byID, byName, byID)
+
+	if name, _ := byID.Get(1); name != "alice" {
+		t.Errorf("byID.Get(1) = %q, want alice", name)
+	}
+	if id, _ := byName.Get("alice"); id != 1 {
+		t.Errorf("byName.Get(alice) = %d, want 1", id)
+	}
+}
+
+func TestAtomicNoDeadlock(t *testing.T) {
+	a := maps.NewSafeSortedMap[int, int]()
+	b := maps.NewSafeSortedMap[int, int]()
+	a.Set(0, 0)
+	b.Set(0, 0)
+
+	// Opposite argument orders would deadlock without canonical ordering
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 500; j++ {
+				members := []Lockable{a, b}
+				if i%2 == 1 {
+					members = []Lockable{b, a}
+				}
+				Atomic(func(tx *Tx) {
+					am, bm := a.InTx(tx), b.InTx(tx)
+					av, _ := am.Get(0)
+					bv, _ := bm.Get(0)
+					am.Set(0, av+1)
+					bm.Set(0, bv+1)
+				}, members...)
+			}
+		}(i)
+	}
+
+	// Readers must never see the two counters disagree
+	for j := 0; j < 500; j++ {
+		Atomic(func(tx *Tx) {
+			av, _ := a.InTx(tx).Get(0)
+			bv, _ := b.InTx(tx).Get(0)
+			if av != bv {
+				t.Errorf("observed a=%d b=%d mid-transaction", av, bv)
+			}
+		}, a, b)
+	}
+	wg.Wait()
+
+	if av, _ := a.Get(0); av != 4000 {
+		t.Errorf("a = %d, want 4000", av)
+	}
+}
+
+func TestInTxOutsideTransaction(t *testing.T) {
+	held := maps.NewSafeSortedMap[int, int]()
+	other := maps.NewSafeSortedMap[int, int]()
+
+	var escaped *Tx
+	Atomic(func(tx *Tx) {
+		escaped = tx
+		defer func() {
+			if r := recover(); r != utils.ErrNotInTx {
+				t.Errorf("InTx on an unlocked map panicked with %v, want ErrNotInTx", r)
+			}
+		}()
+		other.InTx(tx)
+	}, held)
+
+	defer func() {
+		if r := recover(); r != utils.ErrNotInTx {
+			t.Errorf("InTx after Atomic returned panicked with %v, want ErrNotInTx", r)
+		}
+	}()
+	held.InTx(escaped)
+}
+
+func TestAtomicCacheAndIndex(t *testing.T) {
+	users := cache.NewLRUCache[int, string](10)
+	byName := maps.NewSafeSortedMap[string, int]()
+
+	Atomic(func(tx *Tx) {
+		c, index := users.InTx(tx), byName.InTx(tx)
+		c.Put(1, "alice")
+		index.Set("alice", 1)
+	}, users, byName)
+
+	if name, _ := users.Get(1); name != "alice" {
+		t.Errorf("users.Get(1) = %q, want alice", name)
+	}
+	if id, _ := byName.Get("alice"); id != 1 {
+		t.Errorf("byName.Get(alice) = %d, want 1", id)
+	}
+
+	// Evicting through the cache view and dropping the index entry happen
+	// together
+	sessions := cache.NewExpiryCache[string, int](10, 0)
+	sessions.Put("alice", 1)
+	Atomic(func(tx *Tx) {
+		if id, ok := byName.InTx(tx).Get("alice"); ok {
+			sessions.InTx(tx).Remove("alice")
+			users.InTx(tx).Remove(id)
+			byName.InTx(tx).Delete("alice")
+		}
+	}, byName, sessions, users)
+	if users.Len() != 0 || sessions.Len() != 0 || byName.Len() != 0 {
+		t.Errorf("Len() = %d, %d, %d after removal, want 0, 0, 0", users.Len(), sessions.Len(), byName.Len())
+	}
+
+	defer func() {
+		if r := recover(); r != utils.ErrNotInTx {
+			t.Errorf("InTx outside Atomic panic = %v, want %v", r, utils.ErrNotInTx)
+		}
+	}()
+	Atomic(func(tx *Tx) { users.InTx(tx) }, byName)
+}
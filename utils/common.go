@@ -6,3 +6,15 @@ type Ordered interface {
 		~float32 | ~float64 |
 		~string
 }
+
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Entry is a key-value pair returned by the collection APIs that hand out
+// more than one entry at a time.
+type Entry[K any, V any] struct {
+	Key   K
+	Value V
+}
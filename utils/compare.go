@@ -0,0 +1,52 @@
+package utils
+
+import "cmp"
+
+// CompareFunc reports the ordering of a and b: a negative number when a < b,
+// zero when they are equal and a positive number when a > b.
+type CompareFunc[T any] func(a, b T) int
+
+// Natural returns a CompareFunc that orders values by their natural ordering.
+func Natural[T Ordered]() CompareFunc[T] {
+	return func(a, b T) int {
+		return cmp.Compare(a, b)
+	}
+}
+
+// Reversed returns a CompareFunc that inverts the ordering of c.
+func Reversed[T any](c CompareFunc[T]) CompareFunc[T] {
+	return func(a, b T) int {
+		return c(b, a)
+	}
+}
+
+// Then returns a CompareFunc that orders by first and breaks ties using each
+// of the following comparators in turn.
+func Then[T any](first CompareFunc[T], rest ...CompareFunc[T]) CompareFunc[T] {
+	return func(a, b T) int {
+		if c := first(a, b); c != 0 {
+			return c
+		}
+		for _, next := range rest {
+			if c := next(a, b); c != 0 {
+				return c
+			}
+		}
+		return 0
+	}
+}
+
+// ComparingField returns a CompareFunc that orders values by the key
+// extracted with field, e.g. ComparingField(func(p Person) string { return p.Name }).
+func ComparingField[T any, F Ordered](field func(T) F) CompareFunc[T] {
+	return func(a, b T) int {
+		return cmp.Compare(field(a), field(b))
+	}
+}
+
+// Less adapts c into a less function, as accepted by heaps.NewMinHeap.
+func (c CompareFunc[T]) Less() func(a, b T) bool {
+	return func(a, b T) bool {
+		return c(a, b) < 0
+	}
+}
@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+)
+
+type person struct {
+	name string
+	age  int
+}
+
+func TestNatural(t *testing.T) {
+	c := Natural[int]()
+	if c(1, 2) >= 0 || c(2, 1) <= 0 || c(3, 3) != 0 {
+		t.Error("Natural should follow the < ordering")
+	}
+}
+
+func TestReversed(t *testing.T) {
+	c := Reversed(Natural[string]())
+	if c("a", "b") <= 0 {
+		t.Error("Reversed(a, b) should be positive")
+	}
+	if c("b", "b") != 0 {
+		t.Error("Reversed(b, b) should be zero")
+	}
+}
+
+func TestThenAndComparingField(t *testing.T) {
+	people := []person{
+		{"carol", 30},
+		{"alice", 30},
+		{"bob", 25},
+		{"dave", 40},
+	}
+
+	byAgeDescThenName := Then(
+		Reversed(ComparingField(func(p person) int { return p.age })),
+		ComparingField(func(p person) string { return p.name }),
+	)
+	slices.SortFunc(people, byAgeDescThenName)
+
+	want := []string{"dave", "alice", "carol", "bob"}
+	for i, p := range people {
+		if p.name != want[i] {
+			t.Errorf("people[%d] = %s, want %s", i, p.name, want[i])
+		}
+	}
+
+	if Then(Natural[int]())(1, 1) != 0 {
+		t.Error("Then with equal values should return zero")
+	}
+}
+
+func TestCompareFuncLess(t *testing.T) {
+	less := Natural[int]().Less()
+	if !less(1, 2) || less(2, 1) || less(2, 2) {
+		t.Error("Less should report strict ordering")
+	}
+}
@@ -0,0 +1,11 @@
+package utils
+
+import "errors"
+
+// ErrSealed is returned (or used as the panic value) when a sealed
+// structure is mutated.
+var ErrSealed = errors.New("structure is sealed")
+
+// ErrNotInTx is the panic value when a structure's transactional view is
+// requested by a transaction that does not hold its lock.
+var ErrNotInTx = errors.New("structure is not locked by this transaction")
@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/maphash"
+	"math"
+	"reflect"
+)
+
+// Hasher maps a key to a 64-bit hash. Hash-based structures accept one so
+// callers can supply their own key hashing.
+type Hasher[K any] func(key K) uint64
+
+// NewHasher returns a Hasher backed by hash/maphash with a random seed drawn
+// for this Hasher alone, so hash values differ between instances and
+// processes and cannot be predicted to mount hash-flooding attacks. Strings,
+// booleans and numeric kinds are hashed directly; other keys are hashed via
+// their %#v formatting, so struct-keyed hot paths should supply a custom
+// Hasher.
+func NewHasher[K comparable]() Hasher[K] {
+	seed := maphash.MakeSeed()
+	return func(key K) uint64 {
+		switch k := any(key).(type) {
+		case string:
+			return maphash.String(seed, k)
+		case int:
+			return hashUint64(seed, uint64(k))
+		case int64:
+			return hashUint64(seed, uint64(k))
+		case uint64:
+			return hashUint64(seed, k)
+		}
+		v := reflect.ValueOf(key)
+		switch v.Kind() {
+		case reflect.String:
+			return maphash.String(seed, v.String())
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return hashUint64(seed, uint64(v.Int()))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return hashUint64(seed, v.Uint())
+		case reflect.Float32, reflect.Float64:
+			// Equal keys must hash equally, and -0 == +0
+			f := v.Float()
+			if f == 0 {
+				f = 0
+			}
+			return hashUint64(seed, math.Float64bits(f))
+		case reflect.Bool:
+			if v.Bool() {
+				return hashUint64(seed, 1)
+			}
+			return hashUint64(seed, 0)
+		}
+		return maphash.String(seed, fmt.Sprintf("%#v", key))
+	}
+}
+
+func hashUint64(seed maphash.Seed, x uint64) uint64 {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], x)
+	return maphash.Bytes(seed, b[:])
+}
@@ -0,0 +1,41 @@
+package utils
+
+import "testing"
+
+type celsius float64
+
+type point struct{ X, Y int }
+
+func TestNewHasher(t *testing.T) {
+	h := NewHasher[string]()
+	if h("a") != h("a") {
+		t.Error("Hasher is not deterministic within an instance")
+	}
+	if h("a") == h("b") {
+		t.Error("Hasher collides on distinct short strings")
+	}
+	other := NewHasher[string]()
+	same := 0
+	for _, key := range []string{"a", "b", "c", "d"} {
+		if h(key) == other(key) {
+			same++
+		}
+	}
+	if same == 4 {
+		t.Error("separate Hashers share a seed")
+	}
+
+	temps := NewHasher[celsius]()
+	if temps(0) != temps(celsius(negativeZero())) {
+		t.Error("Hasher distinguishes -0 from +0")
+	}
+	points := NewHasher[point]()
+	if points(point{1, 2}) != points(point{1, 2}) || points(point{1, 2}) == points(point{2, 1}) {
+		t.Error("Hasher mishandles struct keys")
+	}
+}
+
+func negativeZero() float64 {
+	zero := 0.0
+	return -zero
+}
@@ -0,0 +1,21 @@
+package utils
+
+import "sync/atomic"
+
+var lastID atomic.Uint64
+
+// NextID returns a process-unique identifier. IDs increase monotonically
+// and are never zero, so zero can mean "not assigned yet".
+func NextID() uint64 {
+	return lastID.Add(1)
+}
+
+// LazyID returns the identifier stored in id, assigning one with NextID on
+// first use. Concurrent first calls agree on the same identifier.
+func LazyID(id *atomic.Uint64) uint64 {
+	if v := id.Load(); v != 0 {
+		return v
+	}
+	id.CompareAndSwap(0, NextID())
+	return id.Load()
+}
@@ -0,0 +1,76 @@
+package utils
+
+import "iter"
+
+// Signed is satisfied by the signed integer and floating-point types.
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~float32 | ~float64
+}
+
+// Number is satisfied by every integer and floating-point type.
+type Number interface {
+	Integer | ~float32 | ~float64
+}
+
+// Min returns the smallest value produced by seq, or false if seq is empty.
+// For a fixed set of arguments use the builtin min.
+func Min[T Ordered](seq iter.Seq[T]) (T, bool) {
+	var result T
+	found := false
+	for v := range seq {
+		if !found || v < result {
+			result, found = v, true
+		}
+	}
+	return result, found
+}
+
+// Max returns the largest value produced by seq, or false if seq is empty.
+// For a fixed set of arguments use the builtin max.
+func Max[T Ordered](seq iter.Seq[T]) (T, bool) {
+	var result T
+	found := false
+	for v := range seq {
+		if !found || v > result {
+			result, found = v, true
+		}
+	}
+	return result, found
+}
+
+// Clamp limits v to the range [lo, hi].
+func Clamp[T Ordered](v, lo, hi T) T {
+	return min(max(v, lo), hi)
+}
+
+// Abs returns the absolute value of v. For the most negative integer of a
+// type, which has no positive counterpart, it returns v unchanged.
+func Abs[T Signed](v T) T {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// Sum returns the sum of the values produced by seq.
+func Sum[T Number](seq iter.Seq[T]) T {
+	var total T
+	for v := range seq {
+		total += v
+	}
+	return total
+}
+
+// Mean returns the arithmetic mean of the values produced by seq, or false
+// if seq is empty.
+func Mean[T Number](seq iter.Seq[T]) (float64, bool) {
+	total, n := 0.0, 0
+	for v := range seq {
+		total += float64(v)
+		n++
+	}
+	if n == 0 {
+		return 0, false
+	}
+	return total / float64(n), true
+}
@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestMinMax(t *testing.T) {
+	values := []int{4, -2, 9, 0}
+	if got, ok := Min(slices.Values(values)); !ok || got != -2 {
+		t.Errorf("Min() = %v, %v, want -2, true", got, ok)
+	}
+	if got, ok := Max(slices.Values(values)); !ok || got != 9 {
+		t.Errorf("Max() = %v, %v, want 9, true", got, ok)
+	}
+	if _, ok := Min(slices.Values([]string(nil))); ok {
+		t.Error("Min() of an empty sequence should report false")
+	}
+	if got, _ := Max(slices.Values([]string{"pear", "apple", "zoo"})); got != "zoo" {
+		t.Errorf("Max() = %q, want zoo", got)
+	}
+}
+
+func TestClampAbs(t *testing.T) {
+	tests := []struct{ v, want int }{{-5, 0}, {5, 5}, {15, 10}}
+	for _, tt := range tests {
+		if got := Clamp(tt.v, 0, 10); got != tt.want {
+			t.Errorf("Clamp(%d, 0, 10) = %d, want %d", tt.v, got, tt.want)
+		}
+	}
+	if Abs(-3) != 3 || Abs(3) != 3 || Abs(-2.5) != 2.5 {
+		t.Error("Abs() returned the wrong magnitude")
+	}
+	if Abs(int8(math.MinInt8)) != math.MinInt8 {
+		t.Error("Abs(MinInt8) should be returned unchanged")
+	}
+}
+
+func TestSumMean(t *testing.T) {
+	if got := Sum(slices.Values([]int{1, 2, 3, 4})); got != 10 {
+		t.Errorf("Sum() = %d, want 10", got)
+	}
+	if got, ok := Mean(slices.Values([]uint8{200, 100})); !ok || got != 150 {
+		t.Errorf("Mean() = %v, %v, want 150 without overflow", got, ok)
+	}
+	if _, ok := Mean(slices.Values([]float64{})); ok {
+		t.Error("Mean() of an empty sequence should report false")
+	}
+}